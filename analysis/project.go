@@ -0,0 +1,360 @@
+package analysis
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Project holds the result of analyzing a project directory
+type Project struct {
+	// Lowercase programming language
+	Language string
+	// Language version declared by the build system as a wildcard e.g.
+	// 1.11.x.  Empty if not declared
+	LanguageVersion string
+	// Build systems found e.g. go, npm, maven
+	BuildSystems []string
+	// Frameworks found in the dependencies
+	Frameworks []string
+	// Datastores inferred from client libraries
+	Datastores []string
+	// Probable listening ports in ascending order
+	Ports []int
+}
+
+// buildFile describes a build system manifest file and how to read its
+// dependencies
+type buildFile struct {
+	name     string
+	system   string
+	language string
+	// parses the dependency names and declared language version
+	parse func([]byte) (deps []string, langver string)
+}
+
+// Ordered by precedence when determining the language
+var buildFiles = []buildFile{
+	{"go.mod", "go", "go", parseGoMod},
+	{"Gopkg.toml", "dep", "go", parseGopkg},
+	{"package.json", "npm", "javascript", parsePackageJSON},
+	{"pom.xml", "maven", "java", parsePomXML},
+	{"build.gradle", "gradle", "java", parseGradle},
+	{"requirements.txt", "pip", "python", parseRequirements},
+	{"Gemfile", "bundler", "ruby", parseGemfile},
+}
+
+// Dependency name prefixes to framework
+var frameworkDeps = map[string]string{
+	"github.com/gin-gonic/gin":            "gin",
+	"github.com/labstack/echo":            "echo",
+	"github.com/gorilla/mux":              "gorilla",
+	"express":                             "express",
+	"koa":                                 "koa",
+	"next":                                "next",
+	"spring-boot":                         "spring-boot",
+	"flask":                               "flask",
+	"django":                              "django",
+	"rails":                               "rails",
+	"sinatra":                             "sinatra",
+	"github.com/grpc/grpc-go":             "grpc",
+	"google.golang.org/grpc":              "grpc",
+	"github.com/urfave/negroni":           "negroni",
+	"github.com/go-chi/chi":               "chi",
+	"github.com/julienschmidt/httprouter": "httprouter",
+}
+
+// Default ports of frameworks used when none are found in the source
+var frameworkPorts = map[string]int{
+	"express":     3000,
+	"next":        3000,
+	"rails":       3000,
+	"spring-boot": 8080,
+	"flask":       5000,
+	"django":      8000,
+	"sinatra":     4567,
+}
+
+// Dependency name prefixes to datastore
+var datastoreDeps = map[string]string{
+	"github.com/lib/pq":                  "postgres",
+	"github.com/jackc/pgx":               "postgres",
+	"pg":                                 "postgres",
+	"psycopg2":                           "postgres",
+	"postgresql":                         "postgres",
+	"github.com/go-sql-driver/mysql":     "mysql",
+	"mysql":                              "mysql",
+	"mysql2":                             "mysql",
+	"pymysql":                            "mysql",
+	"mysqlclient":                        "mysql",
+	"mysql-connector-java":               "mysql",
+	"github.com/go-redis/redis":          "redis",
+	"github.com/gomodule/redigo":         "redis",
+	"github.com/garyburd/redigo":         "redis",
+	"redis":                              "redis",
+	"ioredis":                            "redis",
+	"jedis":                              "redis",
+	"gopkg.in/mgo":                       "mongo",
+	"github.com/mongodb/mongo-go-driver": "mongo",
+	"go.mongodb.org/mongo-driver":        "mongo",
+	"mongodb":                            "mongo",
+	"mongoose":                           "mongo",
+	"pymongo":                            "mongo",
+	"mongo":                              "mongo",
+}
+
+var portPatterns = []*regexp.Regexp{
+	// Dockerfile
+	regexp.MustCompile(`(?mi)^\s*EXPOSE\s+(\d+)`),
+	// go net/http and most servers taking an address
+	regexp.MustCompile(`(?:Listen|ListenAndServe|ListenAndServeTLS|Run)\(\s*"[^"]*:(\d+)"`),
+	// node
+	regexp.MustCompile(`\.listen\(\s*(\d+)`),
+	// spring
+	regexp.MustCompile(`server\.port\s*[=:]\s*(\d+)`),
+	// flask and friends
+	regexp.MustCompile(`\bport\s*=\s*(\d{2,5})\b`),
+}
+
+// Source files scanned for ports
+var portFileExts = map[string]bool{
+	".go": true, ".js": true, ".java": true, ".py": true, ".rb": true,
+	".properties": true, ".yml": true, ".yaml": true,
+}
+
+// Skip files larger than this when scanning for ports
+const maxScanFileSize = 1 << 20
+
+// AnalyzeProject analyzes the project in dir detecting the language, build
+// systems, frameworks, datastores and ports.  Build system manifests take
+// precedence over file type estimation for the language
+func AnalyzeProject(dir string) *Project {
+	proj := &Project{}
+
+	var (
+		deps       []string
+		frameworks = make(map[string]struct{})
+		datastores = make(map[string]struct{})
+	)
+
+	for _, bf := range buildFiles {
+		data, err := ioutil.ReadFile(filepath.Join(dir, bf.name))
+		if err != nil {
+			continue
+		}
+
+		proj.BuildSystems = append(proj.BuildSystems, bf.system)
+		d, langver := bf.parse(data)
+		deps = append(deps, d...)
+
+		if proj.Language == "" {
+			proj.Language = bf.language
+			proj.LanguageVersion = langver
+		}
+	}
+
+	if proj.Language == "" {
+		proj.Language = EstimateLanguage(dir)
+	}
+
+	for _, dep := range deps {
+		if fw := matchDep(frameworkDeps, dep); fw != "" {
+			frameworks[fw] = struct{}{}
+		}
+		if ds := matchDep(datastoreDeps, dep); ds != "" {
+			datastores[ds] = struct{}{}
+		}
+	}
+
+	proj.Frameworks = sortedKeys(frameworks)
+	proj.Datastores = sortedKeys(datastores)
+	proj.Ports = scanPorts(dir)
+
+	if len(proj.Ports) == 0 {
+		for _, fw := range proj.Frameworks {
+			if p, ok := frameworkPorts[fw]; ok {
+				proj.Ports = append(proj.Ports, p)
+				break
+			}
+		}
+	}
+
+	return proj
+}
+
+// matchDep returns the value of the longest key the dependency equals or is
+// prefixed by followed by a path, package or version separator
+func matchDep(m map[string]string, dep string) string {
+	dep = strings.ToLower(dep)
+
+	var key, val string
+	for k, v := range m {
+		if len(k) <= len(key) {
+			continue
+		}
+		if dep == k || strings.HasPrefix(dep, k+"/") || strings.HasPrefix(dep, k+"-") ||
+			strings.HasPrefix(dep, k+".") {
+			key, val = k, v
+		}
+	}
+	return val
+}
+
+func scanPorts(dir string) []int {
+	found := make(map[int]struct{})
+
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", ".svn", "vendor", "node_modules":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Size() > maxScanFileSize {
+			return nil
+		}
+		if info.Name() != "Dockerfile" && !portFileExts[filepath.Ext(info.Name())] {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		for _, re := range portPatterns {
+			for _, m := range re.FindAllSubmatch(data, -1) {
+				if p, err := strconv.Atoi(string(m[1])); err == nil && p > 0 && p < 65536 {
+					found[p] = struct{}{}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	ports := make([]int, 0, len(found))
+	for p := range found {
+		ports = append(ports, p)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+var (
+	goModVersion  = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+)`)
+	goModRequire  = regexp.MustCompile(`(?m)^\s*(?:require\s+)?([a-zA-Z0-9][^\s]*\.[^\s]+/[^\s]+)\s+v`)
+	gopkgName     = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+	pomArtifact   = regexp.MustCompile(`<artifactId>\s*([^<\s]+)\s*</artifactId>`)
+	pomJava       = regexp.MustCompile(`<(?:java\.version|maven\.compiler\.source|maven\.compiler\.release)>\s*(?:1\.)?(\d+)\s*<`)
+	gradleDep     = regexp.MustCompile(`['"][\w.\-]+:([\w.\-]+)(?::[^'"]*)?['"]`)
+	gradleJava    = regexp.MustCompile(`sourceCompatibility\s*=\s*['"]?(?:1\.)?(\d+)`)
+	requirement   = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._\-]*)`)
+	gemName       = regexp.MustCompile(`(?m)^\s*gem\s+['"]([^'"]+)['"]`)
+	gemRuby       = regexp.MustCompile(`(?m)^\s*ruby\s+['"](\d+\.\d+)`)
+	semverVersion = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+)
+
+func parseGoMod(data []byte) ([]string, string) {
+	var langver string
+	if m := goModVersion.FindSubmatch(data); m != nil {
+		langver = string(m[1]) + ".x"
+	}
+	return submatches(goModRequire, data), langver
+}
+
+func parseGopkg(data []byte) ([]string, string) {
+	return submatches(gopkgName, data), ""
+}
+
+func parsePackageJSON(data []byte) ([]string, string) {
+	var pkg struct {
+		Engines         map[string]string `json:"engines"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, ""
+	}
+
+	deps := make([]string, 0, len(pkg.Dependencies))
+	for k := range pkg.Dependencies {
+		deps = append(deps, k)
+	}
+	sort.Strings(deps)
+
+	var langver string
+	if m := semverVersion.FindStringSubmatch(pkg.Engines["node"]); m != nil {
+		langver = m[1] + ".x"
+	}
+
+	return deps, langver
+}
+
+func parsePomXML(data []byte) ([]string, string) {
+	var langver string
+	if m := pomJava.FindSubmatch(data); m != nil {
+		langver = string(m[1]) + ".x"
+	}
+	return submatches(pomArtifact, data), langver
+}
+
+func parseGradle(data []byte) ([]string, string) {
+	var langver string
+	if m := gradleJava.FindSubmatch(data); m != nil {
+		langver = string(m[1]) + ".x"
+	}
+	return submatches(gradleDep, data), langver
+}
+
+func parseRequirements(data []byte) ([]string, string) {
+	var deps []string
+	s := bufio.NewScanner(strings.NewReader(string(data)))
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if m := requirement.FindStringSubmatch(line); m != nil {
+			deps = append(deps, m[1])
+		}
+	}
+	return deps, ""
+}
+
+func parseGemfile(data []byte) ([]string, string) {
+	var langver string
+	if m := gemRuby.FindSubmatch(data); m != nil {
+		langver = string(m[1]) + ".x"
+	}
+	return submatches(gemName, data), langver
+}
+
+func submatches(re *regexp.Regexp, data []byte) []string {
+	matches := re.FindAllSubmatch(data, -1)
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, string(m[1]))
+	}
+	return out
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "analysis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		fpath := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(fpath), 0755)
+		if err = ioutil.WriteFile(fpath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_AnalyzeProject_go(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"go.mod": `module example.com/app
+
+go 1.11
+
+require (
+	github.com/gin-gonic/gin v1.3.0
+	github.com/lib/pq v1.0.0
+	github.com/go-redis/redis v6.14.2+incompatible
+)
+`,
+		"main.go":               `func main() { http.ListenAndServe(":8080", nil) }`,
+		"vendor/x/x.go":         `http.ListenAndServe(":9999", nil)`,
+		"cmd/admin/admin.go":    `srv.ListenAndServe("127.0.0.1:9090")`,
+		"deploy/Dockerfile":     "FROM golang\nEXPOSE 8080\n",
+		"README.md":             "port = 1234",
+		"config/app.properties": "",
+	})
+	defer os.RemoveAll(dir)
+
+	proj := AnalyzeProject(dir)
+	assert.Equal(t, "go", proj.Language)
+	assert.Equal(t, "1.11.x", proj.LanguageVersion)
+	assert.Equal(t, []string{"go"}, proj.BuildSystems)
+	assert.Equal(t, []string{"gin"}, proj.Frameworks)
+	assert.Equal(t, []string{"postgres", "redis"}, proj.Datastores)
+	assert.Equal(t, []int{8080, 9090}, proj.Ports)
+}
+
+func Test_AnalyzeProject_node(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": `{
+  "engines": {"node": ">=8.9"},
+  "dependencies": {"express": "^4.16.0", "mongoose": "^5.0.0", "pg-promise": "^8.0.0"}
+}`,
+		"index.js": "const app = express()\n",
+	})
+	defer os.RemoveAll(dir)
+
+	proj := AnalyzeProject(dir)
+	assert.Equal(t, "javascript", proj.Language)
+	assert.Equal(t, "8.x", proj.LanguageVersion)
+	assert.Equal(t, []string{"express"}, proj.Frameworks)
+	assert.Equal(t, []string{"mongo", "postgres"}, proj.Datastores)
+	// Framework default when none found in source
+	assert.Equal(t, []int{3000}, proj.Ports)
+}
+
+func Test_AnalyzeProject_python(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"requirements.txt": "# deps\nFlask==1.0.2\nPyMySQL>=0.9\n",
+		"app.py":           "app.run(host='0.0.0.0', port=5001)\n",
+	})
+	defer os.RemoveAll(dir)
+
+	proj := AnalyzeProject(dir)
+	assert.Equal(t, "python", proj.Language)
+	assert.Equal(t, "", proj.LanguageVersion)
+	assert.Equal(t, []string{"flask"}, proj.Frameworks)
+	assert.Equal(t, []string{"mysql"}, proj.Datastores)
+	assert.Equal(t, []int{5001}, proj.Ports)
+}
+
+func Test_matchDep(t *testing.T) {
+	assert.Equal(t, "mysql", matchDep(datastoreDeps, "mysql-connector-java"))
+	assert.Equal(t, "mongo", matchDep(datastoreDeps, "gopkg.in/mgo.v2"))
+	assert.Equal(t, "redis", matchDep(datastoreDeps, "github.com/go-redis/redis/v8"))
+	assert.Equal(t, "", matchDep(datastoreDeps, "pgx"))
+	assert.Equal(t, "", matchDep(datastoreDeps, "redistore"))
+}
@@ -16,6 +16,8 @@ type BasicStackConfig struct {
 	Language  thrapb.LanguageID
 	DataStore string
 	WebServer string
+	// Ports the dev component listens on
+	Ports []int32
 }
 
 // NewBasicStack builds a skeleton stack inferring as much information as
@@ -29,6 +31,7 @@ func NewBasicStack(c *BasicStackConfig, pks *packs.Packs) (*thrapb.Stack, error)
 	}
 
 	devComp := makeDevComp(consts.DefaultAPICompID, c.Language)
+	devComp.Ports = thrapb.NamePorts(c.Ports)
 	comps := map[string]*thrapb.Component{
 		consts.DefaultAPICompID: devComp,
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/euforia/thrap/utils"
 	"github.com/euforia/thrap/vars"
 	"github.com/euforia/thrap/vcs"
+	"github.com/hashicorp/hil/ast"
 	"github.com/pkg/errors"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -27,6 +28,8 @@ type StackAsm struct {
 	vars scope.Variables
 	// available packs
 	packs *packs.Packs
+	// image tag lister used to resolve language version constraints
+	tags packs.TagLister
 
 	// source code
 	vcs      vcs.VCS
@@ -40,6 +43,9 @@ type StackAsm struct {
 
 	// assemblers for each component
 	casms map[string]ComponentAssembler
+
+	// additional files to materialize e.g. from a stack pack
+	files map[string][]byte
 }
 
 // NewStackAsm returns a new stack assembler
@@ -48,6 +54,11 @@ func NewStackAsm(stack *thrapb.Stack,
 	vcsp vcs.VCS, gitrepo *git.Repository,
 	globalVars scope.Variables, packs *packs.Packs) (*StackAsm, error) {
 
+	// Refuse to assemble from packs that differ from those pinned
+	if err := packs.Check(stack.Packs); err != nil {
+		return nil, err
+	}
+
 	asm := &StackAsm{
 		vcs:     vcsp,
 		gitrepo: gitrepo,
@@ -56,6 +67,7 @@ func NewStackAsm(stack *thrapb.Stack,
 		stack:   stack,
 		cwd:     cwd,
 		casms:   make(map[string]ComponentAssembler),
+		files:   make(map[string][]byte),
 	}
 
 	// Add stack scope vars
@@ -72,6 +84,20 @@ func NewStackAsm(stack *thrapb.Stack,
 	return asm, nil
 }
 
+// SetTagLister sets the lister used to resolve language version constraints
+// against the available image tags
+func (asm *StackAsm) SetTagLister(tags packs.TagLister) {
+	asm.tags = tags
+}
+
+// AddFiles adds files to be written to the project on materialize.  Files
+// already in the project are not overwritten
+func (asm *StackAsm) AddFiles(files map[string][]byte) {
+	for k, v := range files {
+		asm.files[k] = v
+	}
+}
+
 // AssembleMaterialize is helper function to assemble and materialize in
 // a single call
 func (asm *StackAsm) AssembleMaterialize() error {
@@ -123,8 +149,13 @@ func (asm *StackAsm) Assemble() error {
 	return nil
 }
 
-// Materialize materialized all files and resources
+// Materialize materialized all files and resources.  Added files are
+// written first taking precedence over those from dev packs
 func (asm *StackAsm) Materialize() (err error) {
+	if err = asm.writeFiles(asm.files); err != nil {
+		return
+	}
+
 	for _, casm := range asm.casms {
 		if dcasm, ok := casm.(*DevCompAsm); ok {
 			if err = asm.materializeDevComp(dcasm); err != nil {
@@ -154,7 +185,13 @@ func (asm *StackAsm) assembleDevComponent(cmpt *thrapb.Component) (*DevCompAsm,
 		return nil, errors.Wrap(err, "Failed to load language pack")
 	}
 
+	langver, err := langpack.ResolveVersion(cmpt.Language.Version(), asm.tags)
+	if err != nil {
+		return nil, err
+	}
+
 	casm := NewDevCompAsm(cmpt, langpack)
+	casm.vars[vars.LangVersion] = ast.Variable{Value: langver, Type: ast.TypeString}
 	if err = casm.Assemble(asm.vars); err != nil {
 		return nil, err
 	}
@@ -201,6 +238,8 @@ func (asm *StackAsm) writeFiles(filemaps ...map[string][]byte) error {
 	return err
 }
 
+// writeFile writes the file relative to the worktree root and adds it.
+// Paths leaving the worktree are rejected as they may come from remote packs
 func (asm *StackAsm) writeFile(basename string, contents []byte, force bool) error {
 	var (
 		fs     = asm.worktree.Filesystem
@@ -209,6 +248,12 @@ func (asm *StackAsm) writeFile(basename string, contents []byte, force bool) err
 		err    error
 	)
 
+	rel, err := filepath.Rel(fsroot, path)
+	if filepath.IsAbs(basename) || err != nil || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return errors.Errorf("file outside the project: %s", basename)
+	}
+
 	if !utils.FileExists(path) || force {
 
 		bk := filepath.Base(basename)
@@ -0,0 +1,52 @@
+package asm
+
+import (
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/packs"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/vars"
+	"github.com/hashicorp/hil/ast"
+)
+
+// TemplateStackConfig holds the configuration to build a stack from a stack
+// pack
+type TemplateStackConfig struct {
+	Name string
+	// Stack pack id
+	Template string
+	// Parameter values.  Missing ones use the pack defaults
+	Params map[string]string
+}
+
+// NewTemplateStack renders the stack pack returning the stack and the
+// additional files to be written to the project.  The pack is rendered with
+// the parameters, stack id and name along with the given variables
+func NewTemplateStack(c *TemplateStackConfig, pks *packs.Packs, globalVars scope.Variables) (*thrapb.Stack, map[string][]byte, error) {
+	sp, err := pks.Stack().Load(c.Template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svars, err := sp.ScopeVars(c.Params)
+	if err != nil {
+		return nil, nil, err
+	}
+	svars = vars.MergeScopeVars(svars, scope.Variables{
+		vars.StackID:   ast.Variable{Type: ast.TypeString, Value: c.Name},
+		vars.StackName: ast.Variable{Type: ast.TypeString, Value: c.Name},
+	})
+	svars = vars.MergeScopeVars(svars, globalVars)
+
+	stack, err := sp.Stack(svars)
+	if err != nil {
+		return nil, nil, err
+	}
+	stack.ID = c.Name
+	stack.Name = c.Name
+	if stack.Description == "" {
+		stack.Description = sp.Description
+	}
+
+	files, err := sp.Files(svars)
+	return stack, files, err
+}
@@ -0,0 +1,157 @@
+package thrap
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc"
+)
+
+// Read-only method prefixes that are not audited.  All other calls are
+// considered mutations
+var unauditedPrefixes = []string{"Get", "Iter"}
+
+// Storage writes forwarded by followers.  They are the result of calls
+// already audited
+const forwardedMethod = "/Cluster/Apply"
+
+// resourceID is implemented by requests acting on a single resource
+type resourceID interface {
+	GetID() string
+}
+
+// Auditor records every mutating call to the audit log along with the
+// authenticated identity and outcome
+type Auditor struct {
+	aud *core.Audit
+}
+
+// NewAuditor returns an Auditor recording to the audit log
+func NewAuditor(aud *core.Audit) *Auditor {
+	return &Auditor{aud: aud}
+}
+
+// audited returns true if the full method is a mutation
+func audited(method string) bool {
+	if method == forwardedMethod {
+		return false
+	}
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, p := range unauditedPrefixes {
+		if strings.HasPrefix(name, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnaryInterceptor returns the server interceptor for unary calls.  It must
+// run after authentication for the identity to be recorded
+func (a *Auditor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !audited(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		entry := &thrapb.AuditEntry{
+			Timestamp: time.Now().UnixNano(),
+			Method:    info.FullMethod,
+		}
+		if ident := IdentityFromContext(ctx); ident != nil {
+			entry.Identity = ident.ID
+		}
+		if r, ok := req.(resourceID); ok {
+			entry.Resource = r.GetID()
+		}
+		entry.RequestHash = RequestHash(req)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			entry.Outcome = thrapb.Outcome_FAILED
+			entry.Error = err.Error()
+		} else {
+			entry.Outcome = thrapb.Outcome_SUCCEEDED
+		}
+
+		// The call has been made so a failure to record is only logged
+		a.aud.Record(entry)
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor returns the server interceptor for streaming calls.
+// Mutating streams such as snapshot restores are recorded once they end.  It
+// must run after authentication for the identity to be recorded
+func (a *Auditor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !audited(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		entry := &thrapb.AuditEntry{
+			Timestamp: time.Now().UnixNano(),
+			Method:    info.FullMethod,
+		}
+		if ident := IdentityFromContext(ss.Context()); ident != nil {
+			entry.Identity = ident.ID
+		}
+
+		err := handler(srv, ss)
+		if err != nil {
+			entry.Outcome = thrapb.Outcome_FAILED
+			entry.Error = err.Error()
+		} else {
+			entry.Outcome = thrapb.Outcome_SUCCEEDED
+		}
+		a.aud.Record(entry)
+
+		return err
+	}
+}
+
+// ChainUnaryInterceptors returns an interceptor calling each in order
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// ChainStreamInterceptors returns a stream interceptor calling each in order
+func ChainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, h)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+// ChainUnaryClientInterceptors returns a client interceptor calling each in
+// order
+func ChainUnaryClientInterceptors(interceptors ...grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		next := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inv := interceptors[i], next
+			next = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inv, opts...)
+			}
+		}
+		return next(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,461 @@
+package thrap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Request signature metadata keys
+const (
+	MetaIdentity  = "thrap-identity"
+	MetaTimestamp = "thrap-timestamp"
+	MetaSignature = "thrap-signature"
+	// MetaSession is the session token issued on login.  It is accepted in
+	// place of a request signature
+	MetaSession = "thrap-session"
+	// MetaNonce is the single use nonce issued by the agent that signed
+	// streaming calls sign in place of the request hash
+	MetaNonce = "thrap-nonce"
+)
+
+// maxNonces caps the nonces issued to an identity and not yet used within
+// the allowed skew
+const maxNonces = 16
+
+var errNonceNotIssued = errors.New("agent did not issue a request nonce")
+
+// Methods that can be called without a request signature.  Key rotations
+// and revocations carry their own signature and agent to agent calls the
+// cluster secret
+var publicMethods = map[string]bool{
+	"/Thrap/RegisterIdentity":  true,
+	"/Thrap/ConfirmIdentity":   true,
+	"/Thrap/GetIdentity":       true,
+	"/Thrap/RotateIdentityKey": true,
+	"/Thrap/RevokeIdentityKey": true,
+	"/Thrap/GetOIDCConfig":     true,
+	"/Thrap/Login":             true,
+	// Verified with the cluster secret
+	"/Cluster/JoinCluster": true,
+	"/Cluster/AddMember":   true,
+	"/Cluster/Apply":       true,
+}
+
+// Methods only admins can call
+var adminMethods = map[string]bool{
+	"/Thrap/IterAudit":       true,
+	"/Thrap/SaveSnapshot":    true,
+	"/Thrap/RestoreSnapshot": true,
+}
+
+type (
+	identityContextKey struct{}
+	groupsContextKey   struct{}
+)
+
+// IdentityFromContext returns the authenticated identity of the request or
+// nil if it was not signed
+func IdentityFromContext(ctx context.Context) *thrapb.Identity {
+	ident, _ := ctx.Value(identityContextKey{}).(*thrapb.Identity)
+	return ident
+}
+
+// GroupsFromContext returns the identity provider groups of a request
+// authenticated with a session token
+func GroupsFromContext(ctx context.Context) []string {
+	groups, _ := ctx.Value(groupsContextKey{}).([]string)
+	return groups
+}
+
+// RequestSigHash returns the hash signed by the client for a call to the
+// method at the unix nanosecond timestamp with the request hash.  Streaming
+// calls sign the nonce issued by the agent in place of the request hash
+func RequestSigHash(method string, ts int64, reqHash []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + strconv.FormatInt(ts, 10) + "\n"))
+	h.Write(reqHash)
+	return h.Sum(nil)
+}
+
+// RequestHash returns the sha256 hash of the protobuf encoding of a request.
+// Map entries are encoded in key order so the hash is reproducible.  It is
+// nil for requests that are not protobuf messages
+func RequestHash(req interface{}) []byte {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+// Authenticator verifies session tokens and request signatures against the
+// current key of the signing identity.  Signatures by revoked or rotated keys
+// are rejected.  If required, unauthenticated requests are only allowed for
+// public methods.  Admin methods are restricted to admin identities and
+// members of the admin identity provider groups
+type Authenticator struct {
+	idt     *core.Identity
+	sess    *core.Sessions
+	require bool
+
+	admins      map[string]bool
+	adminGroups map[string]bool
+
+	// Requests seen and nonces issued per identity within the allowed skew
+	// so requests cannot be replayed
+	mu     sync.Mutex
+	seen   map[string]int64
+	nonces map[string]map[string]int64
+}
+
+// NewAuthenticator returns an Authenticator using the identities to verify
+// signatures and the sessions to verify tokens
+func NewAuthenticator(idt *core.Identity, sess *core.Sessions, require bool) *Authenticator {
+	return &Authenticator{
+		idt:         idt,
+		sess:        sess,
+		require:     require,
+		admins:      make(map[string]bool),
+		adminGroups: make(map[string]bool),
+		seen:        make(map[string]int64),
+		nonces:      make(map[string]map[string]int64),
+	}
+}
+
+// SetAdmins sets the identities and identity provider groups allowed to call
+// admin methods
+func (auth *Authenticator) SetAdmins(ids, groups []string) {
+	for _, id := range ids {
+		auth.admins[id] = true
+	}
+	for _, g := range groups {
+		auth.adminGroups[g] = true
+	}
+}
+
+// IsAdmin returns true if the request was authenticated as an admin
+// identity or by a session of an admin group member
+func (auth *Authenticator) IsAdmin(ctx context.Context) bool {
+	if ident := IdentityFromContext(ctx); ident != nil && auth.admins[ident.ID] {
+		return true
+	}
+	for _, g := range GroupsFromContext(ctx) {
+		if auth.adminGroups[g] {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks the authenticated request can call the method
+func (auth *Authenticator) authorize(ctx context.Context, method string) error {
+	if adminMethods[method] && !auth.IsAdmin(ctx) {
+		return status.Error(codes.PermissionDenied, "admin identity or group required")
+	}
+	return nil
+}
+
+// replayed records the signed request returning true if it was already
+// seen.  Requests are keyed by what is signed rather than the signature as
+// ecdsa signatures can be re-encoded.  Requests older than the allowed skew
+// are rejected by their timestamp and dropped
+func (auth *Authenticator) replayed(id, method string, ts int64, reqHash []byte, now time.Time) bool {
+	key := id + "\n" + method + "\n" + strconv.FormatInt(ts, 10) + "\n" + hex.EncodeToString(reqHash)
+
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if _, ok := auth.seen[key]; ok {
+		return true
+	}
+
+	expired := now.Add(-core.MaxSignatureSkew).UnixNano()
+	for k, t := range auth.seen {
+		if t < expired {
+			delete(auth.seen, k)
+		}
+	}
+	auth.seen[key] = ts
+	return false
+}
+
+// issueNonce returns a new single use nonce for a signed streaming call by
+// the identity.  Each identity has at most maxNonces pending so one caller
+// cannot exhaust them for others
+func (auth *Authenticator) issueNonce(id string, now time.Time) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	expired := now.Add(-core.MaxSignatureSkew).UnixNano()
+	for i, issued := range auth.nonces {
+		for n, t := range issued {
+			if t < expired {
+				delete(issued, n)
+			}
+		}
+		if len(issued) == 0 {
+			delete(auth.nonces, i)
+		}
+	}
+
+	issued, ok := auth.nonces[id]
+	if !ok {
+		issued = make(map[string]int64)
+		auth.nonces[id] = issued
+	}
+	if len(issued) >= maxNonces {
+		return "", status.Error(codes.ResourceExhausted, "too many pending request nonces")
+	}
+	issued[nonce] = now.UnixNano()
+	return nonce, nil
+}
+
+// useNonce removes the nonce of the identity returning true if it was issued
+// to it within the allowed skew
+func (auth *Authenticator) useNonce(id, nonce string, now time.Time) bool {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	issued := auth.nonces[id]
+	t, ok := issued[nonce]
+	delete(issued, nonce)
+	return ok && t >= now.Add(-core.MaxSignatureSkew).UnixNano()
+}
+
+// authenticate verifies the session or signature of the call.  The request
+// hash is the nonce for streaming calls
+func (auth *Authenticator) authenticate(ctx context.Context, method string, reqHash []byte) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md[MetaSession]; len(tokens) > 0 {
+		ident, groups, err := auth.sess.Verify(tokens[0])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		ctx = context.WithValue(ctx, groupsContextKey{}, groups)
+		return context.WithValue(ctx, identityContextKey{}, ident), nil
+	}
+
+	ids := md[MetaIdentity]
+	if len(ids) == 0 {
+		if auth.require && !publicMethods[method] {
+			return nil, status.Error(codes.Unauthenticated, "request signature or session required")
+		}
+		return ctx, nil
+	}
+
+	var (
+		tss  = md[MetaTimestamp]
+		sigs = md[MetaSignature]
+	)
+	if len(tss) == 0 || len(sigs) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "request timestamp and signature required")
+	}
+	ts, err := strconv.ParseInt(tss[0], 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid request timestamp")
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigs[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid request signature")
+	}
+
+	ident, err := auth.idt.VerifyRequest(ids[0], ts, RequestSigHash(method, ts, reqHash), sig)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if auth.replayed(ids[0], method, ts, reqHash, time.Now()) {
+		return nil, status.Error(codes.Unauthenticated, "request signature already used")
+	}
+
+	return context.WithValue(ctx, identityContextKey{}, ident), nil
+}
+
+// UnaryInterceptor returns the server interceptor for unary calls
+func (auth *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := auth.authenticate(ctx, info.FullMethod, RequestHash(req))
+		if err != nil {
+			return nil, err
+		}
+		if err = auth.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns the server interceptor for streaming calls.  The
+// payload of a stream is not known when it starts so signed calls sign a
+// nonce instead.  Calls without one are rejected with a new nonce in the
+// MetaNonce header
+func (auth *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		nonce, err := auth.streamNonce(ss)
+		if err != nil {
+			return err
+		}
+		ctx, err := auth.authenticate(ss.Context(), info.FullMethod, nonce)
+		if err != nil {
+			return err
+		}
+		if err = auth.authorize(ctx, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// streamNonce returns the nonce a signed stream must sign or nil if it is not
+// signed.  Nonces are only issued to existing confirmed identities
+func (auth *Authenticator) streamNonce(ss grpc.ServerStream) ([]byte, error) {
+	md, _ := metadata.FromIncomingContext(ss.Context())
+	if len(md[MetaSession]) > 0 || len(md[MetaIdentity]) == 0 {
+		return nil, nil
+	}
+
+	id := md[MetaIdentity][0]
+	now := time.Now()
+	if nonces := md[MetaNonce]; len(nonces) > 0 && auth.useNonce(id, nonces[0], now) {
+		return []byte(nonces[0]), nil
+	}
+
+	ident, err := auth.idt.Get(id)
+	if err != nil || len(ident.Signature) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "unknown or unconfirmed identity")
+	}
+
+	nonce, err := auth.issueNonce(id, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = ss.SendHeader(metadata.Pairs(MetaNonce, nonce)); err != nil {
+		return nil, err
+	}
+	return nil, status.Error(codes.Unauthenticated, "request nonce required")
+}
+
+// authServerStream overrides the context of a stream with the authenticated
+// one
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *authServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+// SessionCredentials sends the session token with each request
+type SessionCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (token SessionCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{MetaSession: string(token)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.  Tokens
+// are bearer credentials so they are never sent in plaintext
+func (token SessionCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// RequestSigner signs outgoing client requests with the key of an identity
+type RequestSigner struct {
+	id string
+	kp *ecdsa.PrivateKey
+}
+
+// NewRequestSigner returns a signer for the identity with its current key
+func NewRequestSigner(id string, kp *ecdsa.PrivateKey) *RequestSigner {
+	return &RequestSigner{id: id, kp: kp}
+}
+
+func (rs *RequestSigner) sign(ctx context.Context, method string, reqHash []byte) (context.Context, error) {
+	ts := time.Now().UnixNano()
+	sig, err := utils.SignData(rs.kp, RequestSigHash(method, ts, reqHash))
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx,
+		MetaIdentity, rs.id,
+		MetaTimestamp, strconv.FormatInt(ts, 10),
+		MetaSignature, base64.StdEncoding.EncodeToString(sig),
+	), nil
+}
+
+// UnaryInterceptor returns the client interceptor for unary calls
+func (rs *RequestSigner) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := rs.sign(ctx, method, RequestHash(req))
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamInterceptor returns the client interceptor for streaming calls.  The
+// stream is opened once unsigned to get a nonce from the agent and again
+// signing it
+func (rs *RequestSigner) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		nonce, err := rs.nonce(ctx, desc, cc, method, streamer, opts...)
+		if err != nil {
+			return nil, err
+		}
+		ctx, err = rs.sign(ctx, method, []byte(nonce))
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, MetaNonce, nonce)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// nonce returns the nonce issued by the agent for a signed stream
+func (rs *RequestSigner) nonce(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (string, error) {
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(ctx, MetaIdentity, rs.id))
+	defer cancel()
+
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return "", err
+	}
+	hdr, err := cs.Header()
+	if err != nil {
+		return "", err
+	}
+	if nonces := hdr[MetaNonce]; len(nonces) > 0 {
+		return nonces[0], nil
+	}
+	return "", errNonceNotIssued
+}
@@ -0,0 +1,86 @@
+package thrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_RequestSigHash(t *testing.T) {
+	req := &thrapb.Stack{ID: "web", Packs: map[string]string{"a": "1", "b": "2", "c": "3"}}
+	h := RequestHash(req)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, h, RequestHash(&thrapb.Stack{ID: "web", Packs: map[string]string{"c": "3", "b": "2", "a": "1"}}))
+	}
+	assert.Nil(t, RequestHash(nil))
+
+	other := RequestHash(&thrapb.Stack{ID: "api"})
+	assert.NotEqual(t, RequestSigHash("/Thrap/CommitStack", 1, h), RequestSigHash("/Thrap/CommitStack", 1, other))
+	assert.NotEqual(t, RequestSigHash("/Thrap/CommitStack", 1, h), RequestSigHash("/Thrap/CommitStack", 2, h))
+}
+
+func Test_Authenticator_replayed(t *testing.T) {
+	auth := NewAuthenticator(nil, nil, true)
+	now := time.Now()
+	h := RequestHash(&thrapb.Stack{ID: "web"})
+
+	assert.False(t, auth.replayed("foo@bar.com", "/Thrap/CommitStack", now.UnixNano(), h, now))
+	assert.True(t, auth.replayed("foo@bar.com", "/Thrap/CommitStack", now.UnixNano(), h, now))
+	assert.False(t, auth.replayed("foo@bar.com", "/Thrap/CommitStack", now.UnixNano(), RequestHash(&thrapb.Stack{ID: "api"}), now))
+
+	// Expired requests are dropped
+	later := now.Add(2 * core.MaxSignatureSkew)
+	assert.False(t, auth.replayed("foo@bar.com", "/Thrap/CommitStack", later.UnixNano(), h, later))
+	assert.Equal(t, 1, len(auth.seen))
+}
+
+func Test_Authenticator_nonce(t *testing.T) {
+	auth := NewAuthenticator(nil, nil, true)
+	now := time.Now()
+
+	nonce, err := auth.issueNonce("foo@bar.com", now)
+	assert.Nil(t, err)
+	assert.False(t, auth.useNonce("foo@bar.com", "other", now))
+	// Nonces are bound to the identity they were issued to
+	assert.False(t, auth.useNonce("baz@bar.com", nonce, now))
+	assert.True(t, auth.useNonce("foo@bar.com", nonce, now))
+	// Nonces are single use
+	assert.False(t, auth.useNonce("foo@bar.com", nonce, now))
+
+	nonce, _ = auth.issueNonce("foo@bar.com", now)
+	assert.False(t, auth.useNonce("foo@bar.com", nonce, now.Add(2*core.MaxSignatureSkew)))
+
+	// Pending nonces are capped per identity
+	for i := 0; i < maxNonces; i++ {
+		_, err = auth.issueNonce("foo@bar.com", now)
+		assert.Nil(t, err)
+	}
+	_, err = auth.issueNonce("foo@bar.com", now)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = auth.issueNonce("baz@bar.com", now)
+	assert.Nil(t, err)
+}
+
+func Test_Authenticator_authorize(t *testing.T) {
+	auth := NewAuthenticator(nil, nil, true)
+	auth.SetAdmins([]string{"admin@bar.com"}, []string{"ops"})
+
+	ctx := context.Background()
+	assert.NotNil(t, auth.authorize(ctx, "/Thrap/IterAudit"))
+	assert.NotNil(t, auth.authorize(ctx, "/Thrap/RestoreSnapshot"))
+	assert.Nil(t, auth.authorize(ctx, "/Thrap/GetStack"))
+
+	user := context.WithValue(ctx, identityContextKey{}, &thrapb.Identity{ID: "foo@bar.com"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(auth.authorize(user, "/Thrap/IterAudit")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(auth.authorize(user, "/Thrap/SaveSnapshot")))
+	admin := context.WithValue(ctx, identityContextKey{}, &thrapb.Identity{ID: "admin@bar.com"})
+	assert.Nil(t, auth.authorize(admin, "/Thrap/IterAudit"))
+	member := context.WithValue(user, groupsContextKey{}, []string{"eng", "ops"})
+	assert.Nil(t, auth.authorize(member, "/Thrap/IterAudit"))
+}
@@ -1,24 +1,37 @@
 package cli
 
 import (
+	"crypto/tls"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/euforia/thrap"
+	"github.com/euforia/thrap/cluster"
 	"github.com/euforia/thrap/config"
 	"github.com/euforia/thrap/consts"
 	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/snapshot"
+	"github.com/euforia/thrap/store"
 	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gopkg.in/urfave/cli.v2"
 )
 
+var errWebhookSecretRequired = errors.New("--webhook-secret required with --webhook-addr")
+
 func commandAgent() *cli.Command {
 	return &cli.Command{
 		Name:  "agent",
 		Usage: "Run a server agent",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:  "bind-addr",
 				Usage: "bind address",
@@ -29,17 +42,231 @@ func commandAgent() *cli.Command {
 				Usage: "Data directory",
 				Value: consts.DefaultDataDir,
 			},
-			// &cli.StringFlag{
-			// 	Name:  "adv-addr",
-			// 	Usage: "advertise address",
-			// },
+			&cli.StringFlag{
+				Name:  "webhook-addr",
+				Usage: "bind address for the vcs webhook listener. Empty to disable",
+			},
+			&cli.StringFlag{
+				Name:    "webhook-secret",
+				Usage:   "secret used to verify webhook deliveries.  Required with --webhook-addr",
+				EnvVars: []string{"THRAP_WEBHOOK_SECRET"},
+			},
+			&cli.StringFlag{
+				Name:  "http-addr",
+				Usage: "bind address for the REST/JSON api gateway and web dashboard. Empty to disable",
+			},
+			&cli.BoolFlag{
+				Name:  "http-insecure",
+				Usage: "accept session tokens over plain http on the gateway and dashboard e.g. behind a tls proxy",
+			},
+			&cli.StringFlag{
+				Name:  "mailer",
+				Usage: "mailer used to send identity confirmation codes [smtp, file, log]",
+				Value: "log",
+			},
+			&cli.StringFlag{
+				Name:  "smtp-addr",
+				Usage: "smtp server `address` i.e. host:port",
+			},
+			&cli.StringFlag{
+				Name:  "smtp-from",
+				Usage: "confirmation mail sender `address`",
+			},
+			&cli.StringFlag{
+				Name:  "smtp-user",
+				Usage: "smtp `username`",
+			},
+			&cli.StringFlag{
+				Name:    "smtp-password",
+				Usage:   "smtp `password`",
+				EnvVars: []string{"THRAP_SMTP_PASSWORD"},
+			},
+			&cli.StringFlag{
+				Name:  "mail-dir",
+				Usage: "`directory` confirmation mails are written to by the file mailer. Defaults to <data-dir>/mail",
+			},
+			&cli.StringFlag{
+				Name:  "oidc-issuer",
+				Usage: "oidc identity provider issuer `url` enabling thrap login",
+			},
+			&cli.StringFlag{
+				Name:  "oidc-client-id",
+				Usage: "oidc client `id` used by the cli device flow",
+			},
+			&cli.StringSliceFlag{
+				Name:  "oidc-scope",
+				Usage: "additional oidc `scope` to request e.g. groups",
+			},
+			&cli.StringFlag{
+				Name:  "oidc-groups-claim",
+				Usage: "userinfo `claim` containing the user groups",
+				Value: oidc.DefaultGroupsClaim,
+			},
+			&cli.StringSliceFlag{
+				Name:  "oidc-allowed-group",
+				Usage: "only allow members of the `group` to login",
+			},
+			&cli.StringSliceFlag{
+				Name:  "admin",
+				Usage: "identity `id` allowed to call admin methods e.g. reading the audit log",
+			},
+			&cli.StringSliceFlag{
+				Name:  "admin-group",
+				Usage: "identity provider `group` whose members are allowed to call admin methods",
+			},
+			&cli.DurationFlag{
+				Name:  "session-ttl",
+				Usage: "lifetime of login sessions",
+				Value: core.DefaultSessionTTL,
+			},
+			&cli.DurationFlag{
+				Name:  "snapshot-interval",
+				Usage: "interval of scheduled snapshots. Zero to disable",
+			},
+			&cli.StringFlag{
+				Name:  "snapshot-dir",
+				Usage: "`directory` scheduled snapshots are written to. Defaults to <data-dir>/snapshots",
+			},
+			&cli.IntFlag{
+				Name:  "snapshot-retain",
+				Usage: "number of scheduled snapshots to keep. Zero keeps all",
+				Value: 24,
+			},
+			&cli.StringFlag{
+				Name:  "storage",
+				Usage: "storage `driver` for agent state [badger, bolt, consul]",
+				Value: store.DriverBadger,
+			},
+			&cli.BoolFlag{
+				Name:  "ignore-audit-errors",
+				Usage: "start even if the audit log fails verification",
+			},
+			&cli.BoolFlag{
+				Name:  "require-auth",
+				Usage: "require signed requests for all but identity registration calls",
+				Value: true,
+			},
+			&cli.StringFlag{
+				Name:  "adv-addr",
+				Usage: "advertise `address` of the server to cluster members. Defaults to the bind address",
+			},
+			&cli.StringFlag{
+				Name:  "node-id",
+				Usage: "unique cluster node `id`. Defaults to the hostname",
+			},
+			&cli.StringFlag{
+				Name:  "raft-addr",
+				Usage: "raft bind `address` enabling clustering e.g. 0.0.0.0:10001",
+			},
+			&cli.StringFlag{
+				Name:  "raft-adv-addr",
+				Usage: "raft advertise `address`. Defaults to the raft bind address",
+			},
+			&cli.BoolFlag{
+				Name:  "bootstrap",
+				Usage: "bootstrap a new cluster with this agent as the first member",
+			},
+			&cli.StringFlag{
+				Name:  "join",
+				Usage: "join the cluster of the agent at the `address` on start",
+			},
+			&cli.StringFlag{
+				Name:    "cluster-secret",
+				Usage:   "`secret` shared by all cluster members",
+				EnvVars: []string{"THRAP_CLUSTER_SECRET"},
+			},
+			&cli.StringFlag{
+				Name:  "tls-cert",
+				Usage: "tls certificate `file` of the server.  Required for clustering",
+			},
+			&cli.StringFlag{
+				Name:  "tls-key",
+				Usage: "tls private key `file` of the server",
+			},
+			&cli.StringFlag{
+				Name:  "tls-ca",
+				Usage: "ca certificate `file` verifying cluster members.  Required for clustering",
+			},
+		}, consulFlags()...),
+		Subcommands: []*cli.Command{
+			commandAgentMigrate(),
+			commandAgentJoin(),
+			commandAgentMembers(),
+			commandAgentSnapshot(),
 		},
 		Action: func(ctx *cli.Context) error {
+			// Deliveries tear down previews so they must be verified
+			if ctx.String("webhook-addr") != "" && ctx.String("webhook-secret") == "" {
+				return errWebhookSecretRequired
+			}
+
 			conf := &core.Config{
-				DataDir: ctx.String("data-dir"),
-				Logger:  log.New(os.Stderr, "", log.LstdFlags|log.Lmicroseconds),
+				DataDir:    ctx.String("data-dir"),
+				Logger:     log.New(os.Stderr, "", log.LstdFlags|log.Lmicroseconds),
+				SessionTTL: ctx.Duration("session-ttl"),
+				Storage:    storageConfig(ctx, ctx.String("storage")),
+			}
+			if issuer := ctx.String("oidc-issuer"); issuer != "" {
+				conf.OIDC = &oidc.Config{
+					Issuer:        issuer,
+					ClientID:      ctx.String("oidc-client-id"),
+					Scopes:        ctx.StringSlice("oidc-scope"),
+					GroupsClaim:   ctx.String("oidc-groups-claim"),
+					AllowedGroups: ctx.StringSlice("oidc-allowed-group"),
+				}
 			}
 
+			tlsConf, err := loadTLSConfig(ctx.String("tls-cert"), ctx.String("tls-key"), ctx.String("tls-ca"))
+			if err != nil {
+				return err
+			}
+			if tlsConf != nil && len(tlsConf.Certificates) == 0 {
+				return errTLSKeyPairRequired
+			}
+
+			baddr := ctx.String("bind-addr")
+			if raddr := ctx.String("raft-addr"); raddr != "" {
+				// Members authenticate each other with the cluster ca.
+				// Calls between members require a verified client
+				// certificate, other clients connect without one
+				if tlsConf == nil {
+					return errTLSKeyPairRequired
+				}
+				if ctx.String("tls-ca") == "" {
+					return errClusterCARequired
+				}
+				advAddr := ctx.String("adv-addr")
+				if advAddr == "" {
+					advAddr = baddr
+				}
+				conf.Cluster = &cluster.Config{
+					NodeID:        ctx.String("node-id"),
+					RaftAddr:      raddr,
+					AdvertiseAddr: ctx.String("raft-adv-addr"),
+					RPCAddr:       advAddr,
+					Bootstrap:     ctx.Bool("bootstrap"),
+					Secret:        ctx.String("cluster-secret"),
+					TLS:           tlsConf,
+				}
+			}
+
+			mailDir := ctx.String("mail-dir")
+			if mailDir == "" {
+				mailDir = filepath.Join(conf.DataDir, "mail")
+			}
+			mlr, err := mailer.New(&mailer.Config{
+				Provider: ctx.String("mailer"),
+				Addr:     ctx.String("smtp-addr"),
+				From:     ctx.String("smtp-from"),
+				Username: ctx.String("smtp-user"),
+				Password: ctx.String("smtp-password"),
+				Dir:      mailDir,
+			}, conf.Logger)
+			if err != nil {
+				return err
+			}
+			conf.Mailer = mlr
+
 			pconf, err := config.ReadProjectConfig(".")
 			if err == nil {
 				conf.ThrapConfig = pconf
@@ -49,16 +276,161 @@ func commandAgent() *cli.Command {
 			if err != nil {
 				return err
 			}
+			if _, err = core.Audit().Verify(); err != nil {
+				if !ctx.Bool("ignore-audit-errors") {
+					return errors.Wrap(err, "audit log verification failed")
+				}
+				conf.Logger.Println("Audit log verification failed:", err)
+			}
+
+			if interval := ctx.Duration("snapshot-interval"); interval > 0 {
+				sdir := ctx.String("snapshot-dir")
+				if sdir == "" {
+					sdir = filepath.Join(conf.DataDir, consts.SnapshotsDir)
+				}
+				sched := &snapshot.Scheduler{
+					Dir:        sdir,
+					Interval:   interval,
+					Retain:     ctx.Int("snapshot-retain"),
+					Passphrase: []byte(os.Getenv(consts.EnvVarSnapshotPassphrase)),
+					Save: func(w io.Writer, passphrase []byte) error {
+						_, err := core.Snapshot(w, passphrase)
+						return err
+					},
+					Log: conf.Logger,
+				}
+				conf.Logger.Printf("Scheduled snapshots dir=%s interval=%s", sdir, interval)
+				go sched.Run(nil)
+			}
+
+			auth := thrap.NewAuthenticator(core.Identity(), core.Sessions(), ctx.Bool("require-auth"))
+			auth.SetAdmins(ctx.StringSlice("admin"), ctx.StringSlice("admin-group"))
+			audit := thrap.NewAuditor(core.Audit())
+			interceptors := []grpc.UnaryServerInterceptor{
+				auth.UnaryInterceptor(),
+				audit.UnaryInterceptor(),
+			}
+			if clu := core.Cluster(); clu != nil {
+				// Redirect writes before they are authenticated and audited
+				interceptors = append([]grpc.UnaryServerInterceptor{
+					thrap.LeaderRedirectInterceptor(clu),
+				}, interceptors...)
+			}
 
-			srv := grpc.NewServer()
+			srvOpts := []grpc.ServerOption{
+				grpc.UnaryInterceptor(thrap.ChainUnaryInterceptors(interceptors...)),
+				grpc.StreamInterceptor(thrap.ChainStreamInterceptors(
+					auth.StreamInterceptor(),
+					audit.StreamInterceptor(),
+				)),
+			}
+			if tlsConf != nil {
+				srvOpts = append(srvOpts, grpc.Creds(credentials.NewTLS(tlsConf)))
+			}
+			srv := grpc.NewServer(srvOpts...)
 			svc := thrap.NewService(core, conf.Logger)
 			thrapb.RegisterThrapServer(srv, svc)
 
-			baddr := ctx.String("bind-addr")
+			if clu := core.Cluster(); clu != nil {
+				thrapb.RegisterClusterServer(srv, thrap.NewClusterService(clu))
+				if addr := ctx.String("join"); addr != "" {
+					conf.Logger.Println("Joining cluster:", addr)
+					if _, err = clu.Join(addr); err != nil {
+						return err
+					}
+				}
+			}
+
 			lis, err := net.Listen("tcp", baddr)
 			if err != nil {
 				return err
 			}
+
+			// The webhook and gateway share a listener when bound to the
+			// same address
+			muxes := map[string]*http.ServeMux{}
+			serveMux := func(addr string) *http.ServeMux {
+				if mux, ok := muxes[addr]; ok {
+					return mux
+				}
+				mux := http.NewServeMux()
+				muxes[addr] = mux
+				return mux
+			}
+
+			if waddr := ctx.String("webhook-addr"); waddr != "" {
+				profs, err := store.LoadHCLFileProfileStorage(".")
+				if err != nil {
+					// Only the default profile is available outside a project
+					profs = store.NewHCLFileProfileStorage("")
+				}
+
+				hook := thrap.NewPreviewWebhook(core, profs, ctx.String("webhook-secret"), conf.Logger)
+				serveMux(waddr).Handle(thrap.PreviewWebhookPath, hook)
+				conf.Logger.Println("Webhook enabled:", waddr)
+			}
+
+			if haddr := ctx.String("http-addr"); haddr != "" {
+				// Call the agent over grpc so requests are authenticated,
+				// audited and redirected to the leader as any other
+				var loopConf *tls.Config
+				if tlsConf != nil {
+					loopConf = tlsConf.Clone()
+					if loopConf.ServerName, err = certServerName(tlsConf.Certificates[0]); err != nil {
+						return err
+					}
+				}
+				redirect := thrap.NewLeaderRedirect(transportOption(tlsConf))
+				cc, err := grpc.Dial(loopbackAddr(lis.Addr()),
+					transportOption(loopConf),
+					grpc.WithUnaryInterceptor(redirect.UnaryInterceptor()),
+				)
+				if err != nil {
+					return err
+				}
+				defer cc.Close()
+
+				client := thrapb.NewThrapClient(cc)
+				gw := thrap.NewGateway(client, conf.Logger)
+				gw.AllowInsecure = ctx.Bool("http-insecure")
+				dash := thrap.NewDashboard(client, conf.Logger)
+				dash.AllowInsecure = gw.AllowInsecure
+
+				mux := serveMux(haddr)
+				mux.Handle(thrap.GatewayPathPrefix, gw)
+				mux.Handle(thrap.OpenAPIPath, gw)
+				mux.Handle(thrap.DashboardPath, dash)
+				mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/" {
+						http.NotFound(w, r)
+						return
+					}
+					http.Redirect(w, r, thrap.DashboardPath, http.StatusFound)
+				})
+				conf.Logger.Println("Gateway and dashboard enabled:", haddr)
+			}
+
+			// Http listeners are served with the agent tls config when
+			// available as session tokens are sent over them
+			for addr, mux := range muxes {
+				conf.Logger.Println("Starting http listener:", addr)
+				go func(addr string, mux *http.ServeMux) {
+					var (
+						hsrv = &http.Server{Addr: addr, Handler: mux}
+						err  error
+					)
+					if tlsConf != nil {
+						hsrv.TLSConfig = tlsConf.Clone()
+						err = hsrv.ListenAndServeTLS("", "")
+					} else {
+						err = hsrv.ListenAndServe()
+					}
+					if err != nil {
+						conf.Logger.Println("Http listener failed:", err)
+					}
+				}(addr, mux)
+			}
+
 			conf.Logger.Println("Starting server:", lis.Addr().String())
 
 			err = srv.Serve(lis)
@@ -66,3 +438,16 @@ func commandAgent() *cli.Command {
 		},
 	}
 }
+
+// loopbackAddr returns the address to dial a local listener on.  Unspecified
+// bind addresses are dialed on the loopback interface
+func loopbackAddr(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
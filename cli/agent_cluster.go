@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/euforia/thrap/cluster"
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var (
+	errMemberAddrRequired = errors.New("cluster member address required")
+	errJoinTLSRequired    = errors.New("--tls-ca required to send the cluster secret")
+)
+
+func commandAgentJoin() *cli.Command {
+	return &cli.Command{
+		Name:      "join",
+		Usage:     "Join the agent at --thrap-addr to the cluster of another agent",
+		ArgsUsage: "<address>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "cluster-secret",
+				Usage:   "`secret` shared by all cluster members",
+				EnvVars: []string{"THRAP_CLUSTER_SECRET"},
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			addr := ctx.Args().Get(0)
+			if addr == "" {
+				return errMemberAddrRequired
+			}
+			// The secret is only sent to a verified agent
+			if ctx.String("tls-ca") == "" {
+				return errJoinTLSRequired
+			}
+
+			client, err := newClusterClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			rctx := metadata.AppendToOutgoingContext(context.Background(),
+				cluster.MetaSecret, ctx.String("cluster-secret"))
+			member, err := client.JoinCluster(rctx, &thrapb.JoinRequest{Addr: addr})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Joined cluster as %s (%s)\n", member.ID, member.RaftAddr)
+			return nil
+		},
+	}
+}
+
+func commandAgentMembers() *cli.Command {
+	return &cli.Command{
+		Name:  "members",
+		Usage: "List the cluster members of the agent at --thrap-addr",
+		Action: func(ctx *cli.Context) error {
+			client, err := newClusterClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetMembers(context.Background(), &thrapb.ClusterMembers{})
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.StripEscape)
+			fmt.Fprintf(w, "ID\tRAFT\tRPC\tLEADER\n")
+			for _, m := range resp.Members {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", m.ID, m.RaftAddr, m.RPCAddr, m.Leader)
+			}
+			w.Flush()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/utils"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func consulFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "consul-addr",
+			Usage:   "consul `address` used by the consul storage driver",
+			EnvVars: []string{"CONSUL_HTTP_ADDR"},
+		},
+		&cli.StringFlag{
+			Name:  "consul-prefix",
+			Usage: "consul kv `prefix` agent state is stored under",
+			Value: "thrap",
+		},
+		&cli.StringFlag{
+			Name:    "consul-token",
+			Usage:   "consul acl `token`",
+			EnvVars: []string{"CONSUL_HTTP_TOKEN"},
+		},
+	}
+}
+
+// storageConfig returns the config for the named storage driver from the
+// command flags
+func storageConfig(ctx *cli.Context, name string) *store.DriverConfig {
+	return &store.DriverConfig{
+		Name:   name,
+		Addr:   ctx.String("consul-addr"),
+		Prefix: ctx.String("consul-prefix"),
+		Token:  ctx.String("consul-token"),
+	}
+}
+
+func commandAgentMigrate() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Copy agent state from one storage driver to another",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "data-dir",
+				Usage: "Data directory",
+				Value: consts.DefaultDataDir,
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "source storage `driver`",
+				Value: store.DriverBadger,
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "destination storage `driver` [badger, bolt, consul]",
+			},
+		}, consulFlags()...),
+		Action: func(ctx *cli.Context) error {
+			from, to := ctx.String("from"), ctx.String("to")
+			if to == "" {
+				return errors.New("destination driver required")
+			}
+			if from == to {
+				return errors.New("source and destination drivers are the same")
+			}
+
+			datadir, err := utils.GetAbsPath(ctx.String("data-dir"))
+			if err != nil {
+				return err
+			}
+
+			srcConf := storageConfig(ctx, from)
+			srcConf.DataDir = datadir
+			src, err := store.OpenDriver(srcConf)
+			if err != nil {
+				return errors.Wrap(err, from)
+			}
+			defer src.Close()
+
+			dstConf := storageConfig(ctx, to)
+			dstConf.DataDir = datadir
+			dst, err := store.OpenDriver(dstConf)
+			if err != nil {
+				return errors.Wrap(err, to)
+			}
+			defer dst.Close()
+
+			n, err := store.Copy(dst, src)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Migrated %d keys from %s to %s\n", n, from, to)
+			return nil
+		},
+	}
+}
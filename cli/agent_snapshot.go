@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/euforia/thrap"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/snapshot"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errSnapshotFileRequired = errors.New("snapshot file required")
+
+func commandAgentSnapshot() *cli.Command {
+	return &cli.Command{
+		Name:  "snapshot",
+		Usage: "Save and restore snapshots of the agent state",
+		Description: "Snapshots are encrypted with the passphrase in " + consts.EnvVarSnapshotPassphrase +
+			" if set",
+		Subcommands: []*cli.Command{
+			commandAgentSnapshotSave(),
+			commandAgentSnapshotRestore(),
+		},
+	}
+}
+
+func snapshotPassphrase() []byte {
+	return []byte(os.Getenv(consts.EnvVarSnapshotPassphrase))
+}
+
+func commandAgentSnapshotSave() *cli.Command {
+	return &cli.Command{
+		Name:      "save",
+		Usage:     "Save a snapshot of the agent at --thrap-addr to a file",
+		ArgsUsage: "<file>",
+		Action: func(ctx *cli.Context) error {
+			fpath := ctx.Args().Get(0)
+			if fpath == "" {
+				return errSnapshotFileRequired
+			}
+
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+			stream, err := tclient.SaveSnapshot(context.Background(), &thrapb.SnapshotHeader{})
+			if err != nil {
+				return err
+			}
+
+			sr, err := snapshot.NewReader(thrap.NewSnapshotReader(stream.Recv), nil)
+			if err != nil {
+				return err
+			}
+
+			// Only rename into place once the checksum is verified
+			tmp := fpath + ".tmp"
+			fh, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			hdr := &thrapb.SnapshotHeader{Format: sr.Header.Format, Created: sr.Header.Created}
+			err = copySnapshot(fh, hdr, sr, snapshotPassphrase())
+			if cerr := fh.Close(); err == nil {
+				err = cerr
+			}
+			if err == nil {
+				err = os.Rename(tmp, fpath)
+			}
+			if err != nil {
+				os.Remove(tmp)
+				return err
+			}
+
+			fmt.Printf("Snapshot saved: %s (format=%s encrypted=%v)\n", fpath, hdr.Format, hdr.Encrypted)
+			return nil
+		},
+	}
+}
+
+func commandAgentSnapshotRestore() *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Restore a snapshot file to the agent at --thrap-addr",
+		ArgsUsage: "<file>",
+		Action: func(ctx *cli.Context) error {
+			fpath := ctx.Args().Get(0)
+			if fpath == "" {
+				return errSnapshotFileRequired
+			}
+
+			fh, err := os.Open(fpath)
+			if err != nil {
+				return err
+			}
+			defer fh.Close()
+
+			sr, err := snapshot.NewReader(fh, snapshotPassphrase())
+			if err != nil {
+				return err
+			}
+
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+			// Cancelled on failure so the agent aborts the restore
+			cctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := tclient.RestoreSnapshot(cctx)
+			if err != nil {
+				return err
+			}
+
+			// Sent decrypted.  The agent verifies the checksum before loading
+			w := thrap.NewSnapshotWriter(stream.Send)
+			hdr := &thrapb.SnapshotHeader{Format: sr.Header.Format, Created: sr.Header.Created}
+			if err = copySnapshot(w, hdr, sr, nil); err != nil {
+				return err
+			}
+			if err = w.Flush(); err != nil {
+				return err
+			}
+
+			if hdr, err = stream.CloseAndRecv(); err != nil {
+				return err
+			}
+
+			fmt.Printf("Snapshot restored: %s (format=%s created=%s)\n", fpath, hdr.Format,
+				time.Unix(0, hdr.Created).UTC().Format(time.RFC3339))
+			return nil
+		},
+	}
+}
+
+// copySnapshot writes the verified data of the reader as a new snapshot with
+// the header
+func copySnapshot(w io.Writer, hdr *thrapb.SnapshotHeader, sr *snapshot.Reader, passphrase []byte) error {
+	sw, err := snapshot.NewWriter(w, hdr, passphrase)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(sw, sr); err != nil {
+		return err
+	}
+	return sw.Close()
+}
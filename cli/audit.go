@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func commandAudit() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Query, verify and export the agent audit log",
+		Subcommands: []*cli.Command{
+			commandAuditList(),
+			commandAuditVerify(),
+			commandAuditExport(),
+		},
+	}
+}
+
+func auditFilterFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "identity",
+			Usage: "filter by `identity`",
+		},
+		&cli.StringFlag{
+			Name:  "method",
+			Usage: "filter by `method` e.g. RegisterStack",
+		},
+		&cli.StringFlag{
+			Name:  "resource",
+			Usage: "filter by resource id `prefix`",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "entries at or after the RFC3339 `time`",
+		},
+		&cli.StringFlag{
+			Name:  "until",
+			Usage: "entries before the RFC3339 `time`",
+		},
+	}
+}
+
+func auditOptions(ctx *cli.Context) (*thrapb.AuditOptions, error) {
+	opts := &thrapb.AuditOptions{
+		Identity: ctx.String("identity"),
+		Method:   ctx.String("method"),
+		Resource: ctx.String("resource"),
+	}
+
+	for name, field := range map[string]*int64{"since": &opts.Since, "until": &opts.Until} {
+		val := ctx.String(name)
+		if val == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+		*field = t.UnixNano()
+	}
+
+	return opts, nil
+}
+
+// iterAudit calls f with each entry matching the options
+func iterAudit(ctx *cli.Context, opts *thrapb.AuditOptions, f func(*thrapb.AuditEntry) error) error {
+	tclient, err := newThrapClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	stream, err := tclient.IterAudit(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return stream.CloseSend()
+			}
+			return err
+		}
+		if err = f(entry); err != nil {
+			return err
+		}
+	}
+}
+
+func commandAuditList() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Usage:   "List audit entries",
+		Aliases: []string{"ls"},
+		Flags:   auditFilterFlags(),
+		Action: func(ctx *cli.Context) error {
+			opts, err := auditOptions(ctx)
+			if err != nil {
+				return err
+			}
+
+			return iterAudit(ctx, opts, func(entry *thrapb.AuditEntry) error {
+				ts := time.Unix(0, entry.Timestamp).Format(time.RFC3339)
+				identity := entry.Identity
+				if identity == "" {
+					identity = "-"
+				}
+				fmt.Printf("%6d  %s  %-24s  %-22s  %-9s  %s  %s\n", entry.Seq, ts, identity,
+					entry.Method, entry.Outcome, entry.Resource, entry.Error)
+				return nil
+			})
+		},
+	}
+}
+
+func commandAuditVerify() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Verify the hash chain of the whole audit log",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "expect",
+				Usage: "`head` printed by an earlier verification the log must still hold i.e. <seq>:<hash>",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			chain := thrapb.NewAuditChain(sha256.New)
+			if val := ctx.String("expect"); val != "" {
+				anchor, err := thrapb.ParseAuditAnchor(val)
+				if err != nil {
+					return err
+				}
+				chain.Expect(anchor)
+			}
+
+			err := iterAudit(ctx, &thrapb.AuditOptions{}, chain.Add)
+			if err != nil {
+				return err
+			}
+			if err = chain.Anchored(); err != nil {
+				return err
+			}
+
+			if last := chain.Last(); last != nil {
+				head := &thrapb.AuditAnchor{Seq: last.Seq, Hash: last.Hash}
+				fmt.Printf("Verified %d entries. Head: %s\n", last.Seq, head)
+			} else {
+				fmt.Println("Audit log empty")
+			}
+			return nil
+		},
+	}
+}
+
+func commandAuditExport() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export audit entries as JSON lines",
+		Flags: append(auditFilterFlags(),
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output `file`. Defaults to stdout",
+			},
+		),
+		Action: func(ctx *cli.Context) error {
+			opts, err := auditOptions(ctx)
+			if err != nil {
+				return err
+			}
+
+			out := os.Stdout
+			if fpath := ctx.String("output"); fpath != "" {
+				if out, err = os.Create(fpath); err != nil {
+					return err
+				}
+				defer out.Close()
+			}
+
+			enc := json.NewEncoder(out)
+			return iterAudit(ctx, opts, func(entry *thrapb.AuditEntry) error {
+				return enc.Encode(entry)
+			})
+		},
+	}
+}
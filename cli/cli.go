@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"google.golang.org/grpc"
 
+	"github.com/euforia/thrap"
 	"github.com/euforia/thrap/consts"
 	"github.com/euforia/thrap/store"
 	"github.com/euforia/thrap/utils"
@@ -21,8 +24,9 @@ import (
 )
 
 var (
-	errThrapAddrRequired = errors.New("--thrap-addr required")
-	errNotConfigured     = errors.New("thrap not configured. Try running 'thrap configure'")
+	errThrapAddrRequired  = errors.New("--thrap-addr required")
+	errSessionTLSRequired = errors.New("--tls-ca required to send the session token")
+	errNotConfigured      = errors.New("thrap not configured. Try running 'thrap configure'")
 )
 
 // NewCLI returns a new command line app
@@ -41,6 +45,11 @@ func NewCLI(version string) *cli.App {
 				Usage:   "thrap registry address",
 				EnvVars: []string{"THRAP_ADDR"},
 			},
+			&cli.StringFlag{
+				Name:    "tls-ca",
+				Usage:   "ca certificate `file` verifying the agent. Connections are insecure if not set",
+				EnvVars: []string{"THRAP_TLS_CA"},
+			},
 			&cli.BoolFlag{
 				Name:  "debug",
 				Usage: "Debug mode",
@@ -49,7 +58,10 @@ func NewCLI(version string) *cli.App {
 		Commands: []*cli.Command{
 			commandConfigure(),
 			commandIdentity(),
+			commandLogin(),
+			commandCreds(),
 			commandAgent(),
+			commandAudit(),
 			commandStack(),
 			commandPack(),
 			commandVersion(),
@@ -78,10 +90,13 @@ func commandConfigure() *cli.Command {
 		Usage: "Configure global settings",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:   vars.VcsID,
-				Usage:  "version control `provider`",
-				Value:  "github",
-				Hidden: true,
+				Name:  vars.VcsID,
+				Usage: "version control `provider` [github, gitlab, gitea]",
+				Value: "github",
+			},
+			&cli.StringFlag{
+				Name:  vars.VcsAddr,
+				Usage: "version control `address` for self-hosted providers",
 			},
 			&cli.StringFlag{
 				Name:  vars.VcsUsername,
@@ -101,6 +116,7 @@ func commandConfigure() *cli.Command {
 			opts := core.ConfigureOptions{
 				VCS: &config.VCSConfig{
 					ID:       ctx.String(vars.VcsID),
+					Addr:     ctx.String(vars.VcsAddr),
 					Username: ctx.String(vars.VcsUsername),
 				},
 				DataDir:  ctx.String("data-dir"),
@@ -114,18 +130,70 @@ func commandConfigure() *cli.Command {
 }
 
 func newThrapClient(ctx *cli.Context) (thrapb.ThrapClient, error) {
+	cc, err := dialThrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return thrapb.NewThrapClient(cc), nil
+}
+
+func newClusterClient(ctx *cli.Context) (thrapb.ClusterClient, error) {
+	cc, err := dialThrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return thrapb.NewClusterClient(cc), nil
+}
+
+// dialThrap connects to the remote agent authenticating with the session or
+// local identity.  Writes rejected by a cluster follower are retried on the
+// leader
+func dialThrap(ctx *cli.Context) (*grpc.ClientConn, error) {
 	// Check remote addr
 	remoteAddr := ctx.String("thrap-addr")
 	if remoteAddr == "" {
 		return nil, errThrapAddrRequired
 	}
 
-	cc, err := grpc.Dial(remoteAddr, grpc.WithInsecure())
+	tlsConf, err := loadTLSConfig("", "", ctx.String("tls-ca"))
 	if err != nil {
 		return nil, err
 	}
 
-	return thrapb.NewThrapClient(cc), nil
+	opts := []grpc.DialOption{transportOption(tlsConf)}
+	sess, err := loadSession()
+	if err == nil {
+		if tlsConf == nil {
+			return nil, errSessionTLSRequired
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(thrap.SessionCredentials(sess.Token)))
+	}
+
+	redirect := thrap.NewLeaderRedirect(opts...)
+	interceptors := []grpc.UnaryClientInterceptor{redirect.UnaryInterceptor()}
+	if sess == nil {
+		if signer := loadRequestSigner(); signer != nil {
+			interceptors = append(interceptors, signer.UnaryInterceptor())
+			opts = append(opts, grpc.WithStreamInterceptor(signer.StreamInterceptor()))
+		}
+	}
+	opts = append(opts, grpc.WithUnaryInterceptor(thrap.ChainUnaryClientInterceptors(interceptors...)))
+
+	return grpc.Dial(remoteAddr, opts...)
+}
+
+// loadRequestSigner returns a signer for the local registered identity or nil
+// if there is none.  Requests are then sent unsigned
+func loadRequestSigner() *thrap.RequestSigner {
+	ident, err := loadLocalIdentity()
+	if err != nil {
+		return nil
+	}
+	kp, err := utils.LoadECDSAKeyPair(filepath.Join(consts.DefaultDataDir, consts.KeyFile))
+	if err != nil {
+		return nil
+	}
+	return thrap.NewRequestSigner(ident.ID, kp)
 }
 
 func writeJSON(v interface{}) {
@@ -155,9 +223,34 @@ func loadCore(ctx *cli.Context) (*core.Core, error) {
 		return nil, err
 	}
 
+	// Build and deployment records are also sent to the agent when one is
+	// configured
+	if ctx.String("thrap-addr") != "" {
+		tclient, err := newThrapClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		conf.Records = &agentRecords{client: tclient}
+	}
+
 	return core.NewCore(conf)
 }
 
+// agentRecords sends build and deployment records to the agent
+type agentRecords struct {
+	client thrapb.ThrapClient
+}
+
+func (rec *agentRecords) CreateBuild(ctx context.Context, build *thrapb.StackBuild) error {
+	_, err := rec.client.CreateBuild(ctx, build)
+	return err
+}
+
+func (rec *agentRecords) CreateDeployment(ctx context.Context, deploy *thrapb.Deployment) error {
+	_, err := rec.client.CreateDeployment(ctx, deploy)
+	return err
+}
+
 func loadProfile(ctx *cli.Context) (*store.HCLFileProfileStorage, *thrapb.Profile, error) {
 	lpath, err := utils.GetLocalPath("")
 	if err != nil {
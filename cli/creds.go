@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/euforia/thrap/config"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/utils"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errCredNameRequired = errors.New("credential name required")
+
+func commandCreds() *cli.Command {
+	return &cli.Command{
+		Name:  "creds",
+		Usage: "Manage the encrypted credentials store",
+		Description: "Credentials are referenced in creds.hcl as store://<name>, " +
+			"env://<var> or vault://<path>#<key>.  The store key is derived from " +
+			consts.EnvVarCredsPassphrase + " if set otherwise " + consts.CredsKeyFile + " is used",
+		Subcommands: []*cli.Command{
+			commandCredsSet(),
+			commandCredsGet(),
+			commandCredsList(),
+			commandCredsRemove(),
+		},
+	}
+}
+
+func loadCredsStore() (*config.CredsStore, error) {
+	dir, err := utils.GetAbsPath(consts.DefaultDataDir)
+	if err != nil {
+		return nil, err
+	}
+	return config.OpenDataDirCredsStore(dir)
+}
+
+func commandCredsSet() *cli.Command {
+	return &cli.Command{
+		Name:      "set",
+		Usage:     "Set a credential prompting for the value if not given",
+		ArgsUsage: "<name> [value]",
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().Get(0)
+			if name == "" {
+				return errCredNameRequired
+			}
+
+			cs, err := loadCredsStore()
+			if err != nil {
+				return err
+			}
+
+			value := ctx.Args().Get(1)
+			if value == "" {
+				utils.PromptUntilNoError(name+": ", os.Stdout, os.Stdin, func(b []byte) error {
+					value = string(b)
+					if value == "" {
+						return errors.New("value required")
+					}
+					return nil
+				})
+			}
+
+			if err = cs.Set(name, value); err == nil {
+				fmt.Println(config.CredsRef(config.CredsSchemeStore, name))
+			}
+			return err
+		},
+	}
+}
+
+func commandCredsGet() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Print a credential",
+		ArgsUsage: "<name>",
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().Get(0)
+			if name == "" {
+				return errCredNameRequired
+			}
+
+			cs, err := loadCredsStore()
+			if err != nil {
+				return err
+			}
+
+			val, err := cs.Get(name)
+			if err == nil {
+				fmt.Println(val)
+			}
+			return err
+		},
+	}
+}
+
+func commandCredsList() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Usage:   "List credential names",
+		Aliases: []string{"ls"},
+		Action: func(ctx *cli.Context) error {
+			cs, err := loadCredsStore()
+			if err != nil {
+				return err
+			}
+
+			for _, name := range cs.List() {
+				fmt.Println(name)
+			}
+			return nil
+		},
+	}
+}
+
+func commandCredsRemove() *cli.Command {
+	return &cli.Command{
+		Name:      "rm",
+		Usage:     "Remove a credential",
+		ArgsUsage: "<name>",
+		Action: func(ctx *cli.Context) error {
+			name := ctx.Args().Get(0)
+			if name == "" {
+				return errCredNameRequired
+			}
+
+			cs, err := loadCredsStore()
+			if err != nil {
+				return err
+			}
+			return cs.Remove(name)
+		},
+	}
+}
@@ -17,6 +17,8 @@ func commandIdentity() *cli.Command {
 			commandIdentityRegister(),
 			commandIdentityShow(),
 			commandIdentityList(),
+			commandIdentityRotate(),
+			commandIdentityRevoke(),
 		},
 	}
 }
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/euforia/base58"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errNoLocalIdentity = errors.New("no registered identity. Try running 'thrap identity register'")
+
+func commandIdentityRotate() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate",
+		Usage: "Rotate the identity key",
+		Action: func(ctx *cli.Context) error {
+			ident, err := loadLocalIdentity()
+			if err != nil {
+				return err
+			}
+
+			keyfile, err := homedir.Expand(filepath.Join(consts.DefaultDataDir, consts.KeyFile))
+			if err != nil {
+				return err
+			}
+			kp, err := utils.LoadECDSAKeyPair(keyfile)
+			if err != nil {
+				return errors.Wrap(err, "loading keypair")
+			}
+
+			// Only replace the current key once the rotation has been accepted
+			newKeyfile := keyfile + ".new"
+			nkp, err := utils.GenerateECDSAKeyPair(newKeyfile, elliptic.P256())
+			if err != nil {
+				return err
+			}
+
+			rot := &thrapb.KeyRotation{
+				ID:        ident.ID,
+				PublicKey: utils.PublicKeyBytes(&nkp.PublicKey),
+				Timestamp: time.Now().UnixNano(),
+			}
+			rot.Signature, err = utils.SignData(kp, rot.SigHash(sha256.New()))
+			if err != nil {
+				return err
+			}
+
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+			resp, err := tclient.RotateIdentityKey(context.Background(), rot)
+			if err != nil {
+				os.Remove(newKeyfile)
+				os.Remove(newKeyfile + ".pub")
+				return err
+			}
+
+			if err = os.Rename(newKeyfile, keyfile); err != nil {
+				return err
+			}
+			if err = os.Rename(newKeyfile+".pub", keyfile+".pub"); err != nil {
+				return err
+			}
+
+			writeJSON(resp)
+			return writeLocalIdentity(resp)
+		},
+	}
+}
+
+func commandIdentityRevoke() *cli.Command {
+	return &cli.Command{
+		Name:      "revoke",
+		Usage:     "Revoke an identity key",
+		ArgsUsage: "<identity>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "base58 encoded public `key` to revoke. Defaults to the current key",
+			},
+			&cli.StringFlag{
+				Name:  "reason",
+				Usage: "revocation `reason`",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			identID := ctx.Args().Get(0)
+			if len(identID) == 0 {
+				return errors.New("identity required")
+			}
+
+			kp, err := utils.LoadECDSAKeyPair(filepath.Join(consts.DefaultDataDir, consts.KeyFile))
+			if err != nil {
+				return errors.Wrap(err, "loading keypair")
+			}
+
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			rev := &thrapb.KeyRevocation{
+				ID:        identID,
+				Reason:    ctx.String("reason"),
+				Timestamp: time.Now().UnixNano(),
+				Signer:    utils.PublicKeyBytes(&kp.PublicKey),
+			}
+			if key := ctx.String("key"); key != "" {
+				rev.PublicKey = base58.Decode([]byte(key))
+			} else {
+				ident, err := tclient.GetIdentity(context.Background(), &thrapb.Identity{ID: identID})
+				if err != nil {
+					return err
+				}
+				if len(ident.PublicKey) == 0 {
+					return fmt.Errorf("identity has no current key: %s", identID)
+				}
+				rev.PublicKey = ident.PublicKey
+			}
+
+			rev.Signature, err = utils.SignData(kp, rev.SigHash(sha256.New()))
+			if err != nil {
+				return err
+			}
+
+			resp, err := tclient.RevokeIdentityKey(context.Background(), rev)
+			if err != nil {
+				return err
+			}
+
+			writeJSON(resp)
+			return nil
+		},
+	}
+}
+
+// loadLocalIdentity returns the identity registered from this host
+func loadLocalIdentity() (*thrapb.Identity, error) {
+	fpath, err := homedir.Expand(filepath.Join(consts.DefaultDataDir, consts.IdentityFile))
+	if err != nil {
+		return nil, err
+	}
+	if !utils.FileExists(fpath) {
+		return nil, errNoLocalIdentity
+	}
+
+	idents, err := utils.LoadIdentities(fpath)
+	if err != nil {
+		return nil, err
+	}
+	for _, ident := range idents {
+		return ident, nil
+	}
+	return nil, errNoLocalIdentity
+}
+
+// writeLocalIdentity writes the identity registered from this host
+func writeLocalIdentity(ident *thrapb.Identity) error {
+	fpath, err := homedir.Expand(filepath.Join(consts.DefaultDataDir, consts.IdentityFile))
+	if err != nil {
+		return err
+	}
+	return utils.WriteIdentities(fpath, map[string]*thrapb.Identity{ident.ID: ident})
+}
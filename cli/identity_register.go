@@ -3,8 +3,6 @@ package cli
 import (
 	"context"
 	"crypto/ecdsa"
-	"crypto/rand"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
@@ -31,8 +29,8 @@ func commandIdentityRegister() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:    "code",
-				Aliases: []string{"c"},
-				Usage:   "registration `confirmation` code",
+				Aliases: []string{"c", "confirm"},
+				Usage:   "registration `confirmation` code sent to the email address",
 			},
 		},
 		Before: func(ctx *cli.Context) error {
@@ -55,7 +53,7 @@ func commandIdentityRegister() *cli.Command {
 				return errNotConfigured
 			}
 
-			kp, err := utils.LoadECDSAKeyPair(filepath.Join(consts.DefaultDataDir, "ecdsa256"))
+			kp, err := utils.LoadECDSAKeyPair(filepath.Join(consts.DefaultDataDir, consts.KeyFile))
 			if err != nil {
 				return errors.Wrap(err, "loading keypair")
 			}
@@ -63,7 +61,7 @@ func commandIdentityRegister() *cli.Command {
 
 			// Init and check identity
 			ident := thrapb.NewIdentity(ctx.String("email"))
-			ident.PublicKey = append(pk.X.Bytes(), pk.Y.Bytes()...)
+			ident.PublicKey = utils.PublicKeyBytes(&pk)
 			ident.Meta = map[string]string{
 				vcsp.ID + ".username": vcsp.Username,
 			}
@@ -81,25 +79,24 @@ func commandIdentityRegister() *cli.Command {
 			if confirmCode, ok := isConfirmRequest(ctx); ok {
 
 				idt, err := confirmUserRegistration(tclient, kp, ident, confirmCode)
-				if err == nil {
-					b, _ := json.MarshalIndent(idt, "", "  ")
-					fmt.Printf("%s\n", b)
+				if err != nil {
+					return err
 				}
-				return err
+
+				b, _ := json.MarshalIndent(idt, "", "  ")
+				fmt.Printf("%s\n", b)
+				// Used to sign subsequent requests
+				return writeLocalIdentity(idt)
 
 			}
 
-			// Submit registration request
+			// Submit registration request.  Running it again resends the code
 			resp, err := tclient.RegisterIdentity(context.Background(), ident)
 			if err != nil {
 				return err
 			}
 
-			// Generate code
-			h := sha256.New()
-			sh := resp.SigHash(h)
-			code := base58.Encode(sh)
-			fmt.Printf("Code: %s\n", code)
+			fmt.Printf("Confirmation code sent to %s\n", resp.Email)
 			return nil
 		},
 	}
@@ -107,11 +104,11 @@ func commandIdentityRegister() *cli.Command {
 
 func confirmUserRegistration(cc thrapb.ThrapClient, kp *ecdsa.PrivateKey, ident *thrapb.Identity, confirmCode string) (*thrapb.Identity, error) {
 	code := base58.Decode([]byte(confirmCode))
-	r, s, err := ecdsa.Sign(rand.Reader, kp, code)
+	sig, err := utils.SignData(kp, code)
 	if err != nil {
 		return nil, err
 	}
-	ident.Signature = append(r.Bytes(), s.Bytes()...)
+	ident.Signature = sig
 
 	id, err := cc.ConfirmIdentity(context.Background(), ident)
 
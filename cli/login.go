@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/thrapb"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errSessionExpired = errors.New("session expired. Try running 'thrap login'")
+
+func commandLogin() *cli.Command {
+	return &cli.Command{
+		Name:  "login",
+		Usage: "Login with the agent identity provider",
+		Action: func(ctx *cli.Context) error {
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			oconf, err := tclient.GetOIDCConfig(context.Background(), &thrapb.OIDCConfig{})
+			if err != nil {
+				return err
+			}
+			idp, err := oidc.NewProvider(&oidc.Config{
+				Issuer:   oconf.Issuer,
+				ClientID: oconf.ClientID,
+				Scopes:   oconf.Scopes,
+			})
+			if err != nil {
+				return err
+			}
+
+			da, err := idp.DeviceAuth(context.Background())
+			if err != nil {
+				return err
+			}
+			if da.VerificationURIComplete != "" {
+				fmt.Printf("Open %s to login\n", da.VerificationURIComplete)
+			} else {
+				fmt.Printf("Open %s and enter the code: %s\n", da.VerificationURI, da.UserCode)
+			}
+
+			tok, err := idp.PollToken(context.Background(), da)
+			if err != nil {
+				return err
+			}
+
+			sess, err := tclient.Login(context.Background(), &thrapb.LoginRequest{
+				AccessToken: tok.AccessToken,
+				IDToken:     tok.IDToken,
+			})
+			if err != nil {
+				return err
+			}
+			if err = writeSession(sess); err != nil {
+				return err
+			}
+
+			fmt.Printf("Logged in as %s until %s\n", sess.Identity.ID,
+				time.Unix(0, sess.Expires).Format(time.RFC1123))
+			return nil
+		},
+	}
+}
+
+func sessionFile() (string, error) {
+	return homedir.Expand(filepath.Join(consts.DefaultDataDir, consts.SessionFile))
+}
+
+// loadSession returns the login session if it has not expired
+func loadSession() (*thrapb.Session, error) {
+	fpath, err := sessionFile()
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	var sess thrapb.Session
+	if err = json.Unmarshal(b, &sess); err != nil {
+		return nil, err
+	}
+	if time.Now().UnixNano() >= sess.Expires {
+		return nil, errSessionExpired
+	}
+	return &sess, nil
+}
+
+func writeSession(sess *thrapb.Session) error {
+	fpath, err := sessionFile()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fpath, b, 0600)
+}
@@ -2,13 +2,16 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"text/tabwriter"
 
 	"github.com/euforia/thrap/consts"
 	"github.com/euforia/thrap/packs"
+	"github.com/euforia/thrap/utils"
 	"github.com/pkg/errors"
+	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/urfave/cli.v2"
 )
 
@@ -19,6 +22,184 @@ func commandPack() *cli.Command {
 		Subcommands: []*cli.Command{
 			commandPackUpdate(),
 			commandPackList(),
+			commandPackAdd(),
+			commandPackRemove(),
+			commandPackSource(),
+			commandPackSign(),
+			commandPackNew(),
+			commandPackValidate(),
+			commandPackTest(),
+		},
+	}
+}
+
+func loadPacks() (*packs.Packs, error) {
+	packdir := filepath.Join(consts.DefaultDataDir, consts.PacksDir)
+	return packs.New(packdir)
+}
+
+func commandPackAdd() *cli.Command {
+	return &cli.Command{
+		Name:      "add",
+		Usage:     "Install a pack pinning it in the lockfile",
+		ArgsUsage: "<type>/<id>[@version]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "source",
+				Aliases: []string{"s"},
+				Usage:   "`source` to install from. Defaults to the first providing the pack",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("pack name required")
+			}
+
+			pks, err := loadPacks()
+			if err != nil {
+				return err
+			}
+
+			locked, err := pks.Add(ctx.Args().First(), ctx.String("source"))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Installed %s from %s\n\n", ctx.Args().First(), locked.Source)
+			fmt.Printf("  Version:  %s\n", locked.Version)
+			fmt.Printf("  Digest:   %s\n", locked.Digest)
+			fmt.Printf("  Verified: %v\n\n", locked.Verified)
+			return nil
+		},
+	}
+}
+
+func commandPackRemove() *cli.Command {
+	return &cli.Command{
+		Name:      "remove",
+		Usage:     "Uninstall a pack",
+		Aliases:   []string{"rm"},
+		ArgsUsage: "<type>/<id>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("pack name required")
+			}
+
+			pks, err := loadPacks()
+			if err != nil {
+				return err
+			}
+			return pks.Remove(ctx.Args().First())
+		},
+	}
+}
+
+func commandPackSource() *cli.Command {
+	return &cli.Command{
+		Name:  "source",
+		Usage: "Manage pack sources",
+		Subcommands: []*cli.Command{
+			&cli.Command{
+				Name:      "add",
+				Usage:     "Add a git, local path or http tarball source",
+				ArgsUsage: "<name> <url>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "ref",
+						Usage: "git branch or tag, or version reported for a tarball",
+					},
+					&cli.StringFlag{
+						Name:  "pubkey",
+						Usage: "hex encoded public `key` packs must be signed with",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 2 {
+						return errors.New("source name and url required")
+					}
+
+					pks, err := loadPacks()
+					if err != nil {
+						return err
+					}
+
+					src, err := packs.NewSource(ctx.Args().Get(0), ctx.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					src.Ref = ctx.String("ref")
+					src.PublicKey = ctx.String("pubkey")
+
+					return pks.AddSource(src)
+				},
+			},
+			&cli.Command{
+				Name:      "remove",
+				Usage:     "Remove a source",
+				Aliases:   []string{"rm"},
+				ArgsUsage: "<name>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return errors.New("source name required")
+					}
+
+					pks, err := loadPacks()
+					if err != nil {
+						return err
+					}
+					return pks.RemoveSource(ctx.Args().First())
+				},
+			},
+			&cli.Command{
+				Name:    "list",
+				Usage:   "List sources",
+				Aliases: []string{"ls"},
+				Action: func(ctx *cli.Context) error {
+					pks, err := loadPacks()
+					if err != nil {
+						return err
+					}
+
+					srcs, err := pks.Sources()
+					if err != nil {
+						return err
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.StripEscape)
+					fmt.Fprintf(w, "NAME\tTYPE\tURL\tREF\tSIGNED\n")
+					for _, src := range srcs {
+						fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%v\n", src.Name, src.Type, src.URL,
+							src.Ref, src.PublicKey != "")
+					}
+					w.Flush()
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func commandPackSign() *cli.Command {
+	return &cli.Command{
+		Name:      "sign",
+		Usage:     "Sign a pack directory with the local keypair",
+		ArgsUsage: "<dir>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("pack directory required")
+			}
+
+			kp, err := utils.LoadECDSAKeyPair(filepath.Join(consts.DefaultDataDir, consts.KeyFile))
+			if err != nil {
+				return err
+			}
+
+			if err = packs.Sign(ctx.Args().First(), kp); err != nil {
+				return err
+			}
+
+			fmt.Printf("Public key: %x\n", utils.PublicKeyBytes(&kp.PublicKey))
+			return nil
 		},
 	}
 }
@@ -28,13 +209,20 @@ func commandPackUpdate() *cli.Command {
 		Name:  "update",
 		Usage: "Update packs",
 		Action: func(ctx *cli.Context) error {
-			packdir := filepath.Join(consts.DefaultDataDir, consts.PacksDir)
-			pks, err := packs.New(packdir)
+			pks, err := loadPacks()
 			if err != nil {
 				return err
 			}
 
-			return pks.Update()
+			err = pks.Update()
+			if err == git.NoErrAlreadyUpToDate {
+				fmt.Println("Sources up to date")
+				return nil
+			}
+			if err == nil {
+				fmt.Println("Sources updated. Use 'pack add' to upgrade installed packs")
+			}
+			return err
 		},
 	}
 }
@@ -50,14 +238,29 @@ func commandPackList() *cli.Command {
 				Aliases: []string{"t"},
 				Usage:   "filter by `type`",
 			},
+			&cli.StringFlag{
+				Name:    "source",
+				Aliases: []string{"s"},
+				Usage:   "list packs available from the `source`",
+			},
+			&cli.BoolFlag{
+				Name:  "locked",
+				Usage: "list installed packs with their locked versions",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
-			packdir := filepath.Join(consts.DefaultDataDir, consts.PacksDir)
-			pks, err := packs.New(packdir)
+			pks, err := loadPacks()
 			if err != nil {
 				return err
 			}
 
+			if src := ctx.String("source"); src != "" {
+				return printAvailablePacks(pks, src, ctx.String("type"))
+			}
+			if ctx.Bool("locked") {
+				return printLockedPacks(pks)
+			}
+
 			typ := ctx.String("type")
 			switch typ {
 			case "web":
@@ -81,6 +284,13 @@ func commandPackList() *cli.Command {
 					fmt.Println(l)
 				}
 
+			case "stack":
+				p := pks.Stack()
+				list, _ := p.List()
+				for _, l := range list {
+					fmt.Println(l)
+				}
+
 			case "":
 				w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.StripEscape)
 				fmt.Fprintf(w, "TYPE\tID\n")
@@ -102,6 +312,12 @@ func commandPackList() *cli.Command {
 				for _, l := range list {
 					fmt.Fprintf(w, "web\t%s\n", l)
 				}
+
+				sp := pks.Stack()
+				list, _ = sp.List()
+				for _, l := range list {
+					fmt.Fprintf(w, "stack\t%s\n", l)
+				}
 				w.Flush()
 
 			default:
@@ -112,3 +328,156 @@ func commandPackList() *cli.Command {
 		},
 	}
 }
+
+func printAvailablePacks(pks *packs.Packs, source, typ string) error {
+	avail, err := pks.Available(source)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.StripEscape)
+	fmt.Fprintf(w, "TYPE\tID\tVERSION\n")
+	for _, ref := range avail {
+		if typ != "" && ref.Type != typ {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ref.Type, ref.ID, ref.Version)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func printLockedPacks(pks *packs.Packs) error {
+	lock, err := pks.Installed()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.StripEscape)
+	fmt.Fprintf(w, "PACK\tSOURCE\tVERSION\tVERIFIED\tDIGEST\n")
+	for _, name := range lock.Names() {
+		l := lock.Packs[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n", name, l.Source, l.Version, l.Verified, l.Digest)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func commandPackNew() *cli.Command {
+	return &cli.Command{
+		Name:      "new",
+		Usage:     "Create a skeleton pack",
+		ArgsUsage: "<type> <id>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Usage: "packs tree `directory` containing the type directories",
+				Value: ".",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("pack type and id required")
+			}
+
+			typ := ctx.Args().Get(0)
+			dir := filepath.Join(ctx.String("dir"), typ)
+			pdir, err := packs.Scaffold(dir, typ, ctx.Args().Get(1))
+			if err == nil {
+				fmt.Println("Created", pdir)
+			}
+			return err
+		},
+	}
+}
+
+func commandPackValidate() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "Validate a pack manifest and render its files",
+		ArgsUsage: "[dir]",
+		Flags:     []cli.Flag{packTypeFlag()},
+		Action: func(ctx *cli.Context) error {
+			dir, typ, err := packDirAndType(ctx)
+			if err != nil {
+				return err
+			}
+
+			if errs := packs.ValidatePack(dir, typ); len(errs) > 0 {
+				return utils.FlattenErrors(errs)
+			}
+
+			fmt.Printf("%s/%s is valid\n", typ, filepath.Base(dir))
+			return nil
+		},
+	}
+}
+
+func commandPackTest() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "Scaffold a pack into a temporary directory and parse the result",
+		ArgsUsage: "[dir]",
+		Flags: []cli.Flag{
+			packTypeFlag(),
+			&cli.BoolFlag{
+				Name:  "keep",
+				Usage: "keep the scaffolded output",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			dir, typ, err := packDirAndType(ctx)
+			if err != nil {
+				return err
+			}
+
+			outdir, err := ioutil.TempDir("", "thrap-pack-")
+			if err != nil {
+				return err
+			}
+			if ctx.Bool("keep") {
+				fmt.Println("Output:", outdir)
+			} else {
+				defer os.RemoveAll(outdir)
+			}
+
+			if err = packs.TestPack(dir, typ, outdir); err != nil {
+				return err
+			}
+
+			fmt.Printf("%s/%s passed\n", typ, filepath.Base(dir))
+			return nil
+		},
+	}
+}
+
+func packTypeFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "type",
+		Aliases: []string{"t"},
+		Usage:   "pack `type`. Defaults to the name of the parent directory",
+	}
+}
+
+// packDirAndType returns the absolute pack directory from the first argument
+// or the current directory and the pack type
+func packDirAndType(ctx *cli.Context) (string, string, error) {
+	dir := "."
+	if ctx.NArg() > 0 {
+		dir = ctx.Args().First()
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	typ := ctx.String("type")
+	if typ == "" {
+		typ, err = packs.PackType(dir)
+	}
+
+	return dir, typ, err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"text/tabwriter"
 
 	"github.com/euforia/thrap/core"
@@ -12,6 +13,7 @@ import (
 	"github.com/euforia/thrap/store"
 	"github.com/euforia/thrap/thrapb"
 	"github.com/euforia/thrap/utils"
+	"github.com/euforia/thrap/vcs"
 	"gopkg.in/urfave/cli.v2"
 )
 
@@ -26,21 +28,33 @@ func commandStack() *cli.Command {
 				Usage:   "`profile` to use",
 				Value:   "local",
 			},
+			&cli.StringFlag{
+				Name:    "manifest",
+				Aliases: []string{"m"},
+				Usage:   "stack manifest `file` for repos containing multiple stacks",
+			},
 		},
 		Subcommands: []*cli.Command{
 			commandStackList(),
+			commandStackManifests(),
 			commandStackInit(),
+			commandStackImport(),
+			commandStackValidate(),
 			commandStackRegister(),
 			commandStackEnsure(),
 			commandStackCommit(),
 			commandStackBuild(),
 			commandStackArtifacts(),
 			commandStackDeploy(),
+			commandStackPreview(),
+			commandStackBuilds(),
+			commandStackDeployments(),
 			commandStackStatus(),
 			commandStackLogs(),
 			commandStackStop(),
 			commandStackDestroy(),
 			commandStackVersion(),
+			commandStackRelease(),
 			commandProfile(),
 		},
 	}
@@ -51,7 +65,7 @@ func commandStackVersion() *cli.Command {
 		Name:  "version",
 		Usage: "Show stack version",
 		Action: func(ctx *cli.Context) error {
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err == nil {
 				fmt.Println(stack.Version)
 			}
@@ -70,10 +84,14 @@ func commandStackBuild() *cli.Command {
 				Name:  "pub",
 				Usage: "publish artifacts",
 			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "build all components including those without changes",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
@@ -110,6 +128,7 @@ func commandStackBuild() *cli.Command {
 			opt := core.BuildOptions{
 				Workdir: lpath,
 				Publish: ctx.Bool("pub"),
+				All:     ctx.Bool("all"),
 			}
 
 			return stm.Build(context.Background(), stack, opt)
@@ -124,7 +143,7 @@ func commandStackLogs() *cli.Command {
 		ArgsUsage: "[component]",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
@@ -197,12 +216,56 @@ func commandStackList() *cli.Command {
 	}
 }
 
+func commandStackManifests() *cli.Command {
+	return &cli.Command{
+		Name:  "manifests",
+		Usage: "List all stack manifests in the repo and their component versions",
+		Action: func(ctx *cli.Context) error {
+			lpath, err := utils.GetLocalPath("")
+			if err != nil {
+				return err
+			}
+
+			root, err := vcs.FindRepoRoot(lpath)
+			if err != nil {
+				root = lpath
+			}
+
+			mfiles, err := manifest.FindManifests(root)
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.StripEscape)
+			fmt.Fprintf(tw, "MANIFEST\tSTACK\tCOMPONENT\tVERSION\n")
+			for _, mfile := range mfiles {
+				rel, _ := filepath.Rel(lpath, mfile)
+
+				stack, err := manifest.LoadManifest(mfile)
+				if err != nil {
+					fmt.Fprintf(tw, "%s\t%v\t\t\n", rel, err)
+					continue
+				}
+				stack.Validate()
+
+				fmt.Fprintf(tw, "%s\t%s\t\t%s\n", rel, stack.ID, stack.Version)
+				for id, comp := range stack.Components {
+					fmt.Fprintf(tw, "\t\t%s\t%s\n", id, comp.Version)
+				}
+			}
+			tw.Flush()
+
+			return nil
+		},
+	}
+}
+
 func commandStackEnsure() *cli.Command {
 	return &cli.Command{
 		Name:  "ensure",
 		Usage: "Ensure resources exist",
 		Action: func(ctx *cli.Context) error {
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
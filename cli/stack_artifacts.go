@@ -20,7 +20,7 @@ func commandStackArtifacts() *cli.Command {
 		Usage:   "List stack artifacts",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
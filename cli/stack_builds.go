@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func commandStackBuilds() *cli.Command {
+	return &cli.Command{
+		Name:      "builds",
+		Usage:     "List build records",
+		ArgsUsage: "[stack]",
+		Action: func(ctx *cli.Context) error {
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			stream, err := tclient.IterBuilds(context.Background(), &thrapb.IterOptions{
+				Prefix: ctx.Args().Get(0),
+			})
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.StripEscape)
+			fmt.Fprintf(tw, "ID\tSTACK\tREVISION\tPROFILE\tIDENTITY\tOUTCOME\tSTARTED\tDURATION\n")
+			for {
+				build, err := stream.Recv()
+				if err != nil {
+					defer tw.Flush()
+					if err == io.EOF {
+						return stream.CloseSend()
+					}
+					return err
+				}
+
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", build.ID, build.Stack,
+					build.Revision, build.Profile, build.Identity, build.Outcome,
+					formatRecordTime(build.Start), formatRecordDuration(build.Start, build.End))
+				printComponentRecords(tw, build.Components)
+			}
+		},
+	}
+}
+
+func commandStackDeployments() *cli.Command {
+	return &cli.Command{
+		Name:      "deployments",
+		Usage:     "List deployment records",
+		ArgsUsage: "[stack]",
+		Action: func(ctx *cli.Context) error {
+			tclient, err := newThrapClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			stream, err := tclient.IterDeployments(context.Background(), &thrapb.IterOptions{
+				Prefix: ctx.Args().Get(0),
+			})
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.StripEscape)
+			fmt.Fprintf(tw, "ID\tSTACK\tREVISION\tPROFILE\tIDENTITY\tOUTCOME\tSTARTED\tDURATION\n")
+			for {
+				deploy, err := stream.Recv()
+				if err != nil {
+					defer tw.Flush()
+					if err == io.EOF {
+						return stream.CloseSend()
+					}
+					return err
+				}
+
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", deploy.ID, deploy.Stack,
+					deploy.Revision, deploy.Profile, deploy.Identity, deploy.Outcome,
+					formatRecordTime(deploy.Start), formatRecordDuration(deploy.Start, deploy.End))
+				printComponentRecords(tw, deploy.Components)
+			}
+		},
+	}
+}
+
+// printComponentRecords writes an indented line per component under the
+// parent record
+func printComponentRecords(tw io.Writer, comps []*thrapb.ComponentRecord) {
+	for _, c := range comps {
+		artifact := c.Artifact
+		if c.Version != "" {
+			artifact += ":" + c.Version
+		}
+		status := "ok"
+		if c.Error != "" {
+			status = c.Error
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t\t\t%s\t\t\n", c.ID, artifact, c.Digest, status)
+	}
+}
+
+func formatRecordTime(t int64) string {
+	if t == 0 {
+		return "-"
+	}
+	return time.Unix(0, t).Format(time.RFC3339)
+}
+
+func formatRecordDuration(start, end int64) string {
+	if start == 0 || end == 0 {
+		return "-"
+	}
+	return time.Duration(end - start).Round(time.Second).String()
+}
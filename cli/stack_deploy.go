@@ -25,7 +25,7 @@ func commandStackDeploy() *cli.Command {
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/manifest"
+	"github.com/euforia/thrap/utils"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errImportSourceRequired = errors.New("--from required")
+
+func commandStackImport() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Import a stack from a docker-compose file or nomad job",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "docker-compose or nomad job (.nomad, .hcl, .json) `file` to import",
+			},
+			&cli.StringFlag{
+				Name:    "name",
+				Aliases: []string{"n"},
+				Usage:   "stack `name` (default: <compose project, job id or directory>)",
+			},
+			&cli.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "manifest `file` to write",
+				Value:   consts.DefaultManifestFile,
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "overwrite an existing manifest",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			from := ctx.String("from")
+			if from == "" {
+				return errImportSourceRequired
+			}
+
+			out := ctx.String("out")
+			if utils.FileExists(out) && !ctx.Bool("force") {
+				return fmt.Errorf("manifest %s already exists", out)
+			}
+
+			stack, report, err := manifest.Import(from)
+			if err != nil {
+				return err
+			}
+
+			if name := ctx.String("name"); name != "" {
+				stack.ID, stack.Name = name, name
+			}
+
+			// Validation failures are reported rather than fatal so the
+			// manifest can be fixed up by hand
+			printImportReport(report, stack.Validate())
+
+			fh, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			defer fh.Close()
+
+			if err = manifest.WriteYAMLManifest(stack, fh); err == nil {
+				fmt.Printf("\nWrote %s with %d components\n", out, len(stack.Components))
+			}
+			return err
+		},
+	}
+}
+
+func printImportReport(report *manifest.ImportReport, errs map[string]error) {
+	if !report.HasWarnings() && len(errs) == 0 {
+		return
+	}
+
+	fmt.Println("Warnings:")
+	for _, w := range report.Warnings {
+		fmt.Println("  " + w)
+	}
+
+	keys := make([]string, 0, len(errs))
+	for k := range errs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %s: %v\n", k, errs[k])
+	}
+}
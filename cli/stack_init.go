@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/urfave/cli.v2"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/euforia/thrap/core"
 	"github.com/euforia/thrap/manifest"
 	"github.com/euforia/thrap/packs"
+	"github.com/euforia/thrap/registry"
 	"github.com/euforia/thrap/thrapb"
 	"github.com/euforia/thrap/utils"
 	"github.com/euforia/thrap/vars"
@@ -43,6 +45,16 @@ func commandStackInit() *cli.Command {
 				Aliases: []string{"l"},
 				Usage:   "programming `language`",
 			},
+			&cli.StringFlag{
+				Name:    "template",
+				Aliases: []string{"t"},
+				Usage:   "stack pack `id` to init from",
+			},
+			&cli.StringSliceFlag{
+				Name:    "param",
+				Aliases: []string{"p"},
+				Usage:   "stack pack parameter as `key=value`",
+			},
 			&cli.StringFlag{
 				Name:   vars.VcsID,
 				Usage:  "version control `provider` (experimental)",
@@ -82,11 +94,18 @@ func commandStackInit() *cli.Command {
 				return fmt.Errorf("manifest %s already exists", consts.DefaultManifestFile)
 			}
 
+			// Detect language, frameworks, datastores and ports to use as
+			// defaults
+			proj := analysis.AnalyzeProject(projPath)
+
 			pks := cr.Packs()
-			// Set language from input or otherwise and other related params
-			_, err = setLanguage(ctx, pks.Dev(), projPath)
-			if err != nil {
-				return err
+			tmpl := ctx.String("template")
+			if tmpl == "" {
+				// Set language from input or otherwise and other related params
+				_, err = setLanguage(ctx, pks.Dev(), proj)
+				if err != nil {
+					return err
+				}
 			}
 
 			gconf := cr.Config()
@@ -107,20 +126,24 @@ func commandStackInit() *cli.Command {
 				},
 			}
 
-			// Prompt for missing
-			bsc, err := promptComps(projName, ctx.String("lang"), pks)
-			if err != nil {
-				return err
-			}
-
-			fmt.Println()
-
 			stm, err := cr.Stack(thrapb.DefaultProfile())
 			if err != nil {
 				return err
 			}
 
-			stack, err := stm.Init(bsc, opts)
+			var stack *thrapb.Stack
+			if tmpl != "" {
+				stack, err = initTemplate(stm, projName, tmpl, ctx.StringSlice("param"), pks, opts)
+			} else {
+				// Prompt for missing
+				var bsc *asm.BasicStackConfig
+				bsc, err = promptComps(projName, ctx.String("lang"), pks, proj)
+				if err != nil {
+					return err
+				}
+				fmt.Println()
+				stack, err = stm.Init(bsc, opts)
+			}
 			if err != nil {
 				return err
 			}
@@ -155,26 +178,49 @@ func isSupported(val string, supported []string) bool {
 	return false
 }
 
-func setLanguage(ctx *cli.Context, devpacks *packs.DevPacks, dir string) (*packs.DevPack, error) {
+// setLanguage sets the language from input, otherwise from the project
+// analysis only prompting if the detected language is not supported
+func setLanguage(ctx *cli.Context, devpacks *packs.DevPacks, proj *analysis.Project) (*packs.DevPack, error) {
 	supported, err := devpacks.List()
 	if err != nil {
 		return nil, err
 	}
 
-	// Do not prompt if input is valid
-	lang := ctx.String("lang")
+	// Do not prompt if input is valid. The version may be exact or a
+	// constraint e.g. go:1.x
+	input := thrapb.LanguageID(ctx.String("lang"))
+	lang := input.Lang()
+	detected := false
 	if !isSupported(lang, supported) {
+		if lang == "" && isSupported(proj.Language, supported) {
+			lang = proj.Language
+			fmt.Printf("Language: %s (detected)\n", lang)
+		} else {
+			prompt := "Language"
+			lang = promptForSupported(prompt, supported, proj.Language)
+		}
 
-		// Set guestimate as default
-		lang = analysis.EstimateLanguage(dir)
-
-		prompt := "Language"
-		lang = promptForSupported(prompt, supported, lang)
+		if lang == proj.Language {
+			input = thrapb.LanguageID(lang + ":" + proj.LanguageVersion)
+			detected = true
+		} else {
+			input = thrapb.LanguageID(lang)
+		}
 	}
 
 	devpack, err := devpacks.Load(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	langver, err := devpack.ResolveVersion(input.Version(), registry.NewHubTagLister(""))
+	if err != nil && detected {
+		// Detected versions are a hint.  Fallback to the default
+		fmt.Printf("Language version: %s (%v)\n", devpack.DefaultVersion, err)
+		langver, err = devpack.DefaultVersion, nil
+	}
 	if err == nil {
-		err = ctx.Set("lang", devpack.Name+":"+devpack.DefaultVersion)
+		err = ctx.Set("lang", devpack.Name+":"+langver)
 	}
 
 	return devpack, err
@@ -199,18 +245,22 @@ func setRepoOwner(ctx *cli.Context, vcsID, defRepoOwner string) string {
 	return repoOwner
 }
 
-func promptComps(name, lang string, pks *packs.Packs) (*asm.BasicStackConfig, error) {
+func promptComps(name, lang string, pks *packs.Packs, proj *analysis.Project) (*asm.BasicStackConfig, error) {
 	c := &asm.BasicStackConfig{
 		Name:     name,
 		Language: thrapb.LanguageID(lang),
+		Ports:    make([]int32, len(proj.Ports)),
+	}
+	for i, p := range proj.Ports {
+		c.Ports[i] = int32(p)
 	}
 
 	var err error
-	c.WebServer, err = promptPack(pks.Web(), "Web Server")
+	c.WebServer, err = promptPack(pks.Web(), "Web Server", nil)
 	if err != nil {
 		return nil, err
 	}
-	c.DataStore, err = promptPack(pks.Datastore(), "Data Store")
+	c.DataStore, err = promptPack(pks.Datastore(), "Data Store", proj.Datastores)
 	if err != nil {
 		return nil, err
 	}
@@ -218,11 +268,104 @@ func promptComps(name, lang string, pks *packs.Packs) (*asm.BasicStackConfig, er
 	return c, nil
 }
 
-func promptPack(wp *packs.BasePacks, prompt string) (string, error) {
+// promptPack prompts for a pack from the list.  The first detected pack
+// that is available is selected without prompting
+func promptPack(wp *packs.BasePacks, prompt string, detected []string) (string, error) {
 	list, err := wp.List()
 	if err != nil {
 		return "", err
 	}
+
+	for _, d := range detected {
+		if isSupported(d, list) {
+			fmt.Printf("%s: %s (detected)\n", prompt, d)
+			return d, nil
+		}
+	}
+
 	supported := append(list, "none")
 	return promptForSupported(prompt, supported, "none"), nil
 }
+
+// initTemplate prompts for the stack pack parameters and inits the stack
+// from the pack
+func initTemplate(stm *core.Stack, name, tmpl string, params []string, pks *packs.Packs, opts core.ConfigureOptions) (*thrapb.Stack, error) {
+	tconf, err := promptTemplate(name, tmpl, params, pks)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println()
+
+	return stm.InitTemplate(tconf, opts)
+}
+
+// promptTemplate loads the stack pack installing it from the pack sources if
+// needed.  Parameters not given as key=value are prompted for
+func promptTemplate(name, tmpl string, params []string, pks *packs.Packs) (*asm.TemplateStackConfig, error) {
+	spacks := pks.Stack()
+	sp, err := spacks.Load(tmpl)
+	if os.IsNotExist(errors.Cause(err)) {
+		if _, err = pks.Add("stack/"+tmpl, ""); err != nil {
+			return nil, err
+		}
+		sp, err = spacks.Load(tmpl)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &asm.TemplateStackConfig{
+		Name:     name,
+		Template: tmpl,
+		Params:   make(map[string]string, len(sp.Params)),
+	}
+	for _, kv := range params {
+		i := strings.Index(kv, "=")
+		if i < 1 {
+			return nil, fmt.Errorf("invalid parameter: '%s'", kv)
+		}
+		c.Params[kv[:i]] = kv[i+1:]
+	}
+
+	if sp.Description != "" {
+		fmt.Println(sp.Description)
+	}
+	for _, p := range sp.Params {
+		if _, ok := c.Params[p.Name]; ok {
+			continue
+		}
+
+		prompt := p.Prompt
+		if prompt == "" {
+			prompt = p.Name
+		}
+		if len(p.Options) > 0 {
+			c.Params[p.Name] = promptForSupported(prompt, p.Options, p.Default)
+			continue
+		}
+		c.Params[p.Name] = promptWithDefault(prompt, p.Default)
+	}
+
+	return c, nil
+}
+
+// promptWithDefault prompts for any non-empty value using the default if
+// one is given
+func promptWithDefault(prompt, defaultVal string) string {
+	if defaultVal != "" {
+		prompt += " [" + defaultVal + "]"
+	}
+	prompt += ": "
+
+	val := defaultVal
+	utils.PromptUntilNoError(prompt, os.Stdout, os.Stdin, func(db []byte) error {
+		if input := string(db); input != "" {
+			val = input
+		} else if val == "" {
+			return errors.New("value required")
+		}
+		return nil
+	})
+
+	return val
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/manifest"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/euforia/thrap/vcs"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func commandStackPreview() *cli.Command {
+	refFlag := &cli.StringFlag{
+		Name:  "ref",
+		Usage: "`branch` to preview. Defaults to the current branch",
+	}
+
+	return &cli.Command{
+		Name:  "preview",
+		Usage: "Manage branch preview environments",
+		Subcommands: []*cli.Command{
+			&cli.Command{
+				Name:  "up",
+				Usage: "Build and deploy an isolated preview of a branch",
+				Flags: []cli.Flag{
+					refFlag,
+					&cli.BoolFlag{
+						Name:    "dryrun",
+						Aliases: []string{"dry"},
+						Usage:   "perform a dry run",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					st, stm, lpath, ref, err := loadPreviewContext(ctx)
+					if err != nil {
+						return err
+					}
+
+					opt := core.PreviewOptions{
+						Ref:     ref,
+						Workdir: lpath,
+						Dryrun:  ctx.Bool("dryrun"),
+					}
+
+					preview, err := stm.PreviewUp(context.Background(), st, opt)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("Preview: %s\n", preview.ID)
+					fmt.Printf("Commit:  %s\n", preview.Commit)
+					if preview.URL != "" {
+						fmt.Printf("URL:     %s\n", preview.URL)
+					}
+					fmt.Println()
+
+					return nil
+				},
+			},
+			&cli.Command{
+				Name:  "down",
+				Usage: "Tear down the preview of a branch",
+				Flags: []cli.Flag{refFlag},
+				Action: func(ctx *cli.Context) error {
+					st, stm, _, ref, err := loadPreviewContext(ctx)
+					if err != nil {
+						return err
+					}
+
+					report, err := stm.PreviewDown(context.Background(), st, ref)
+					if err == nil {
+						fmt.Printf("Preview: %s\n\n", core.PreviewID(st.ID, ref))
+						defaultPrintStackResults(report)
+					}
+					return err
+				},
+			},
+		},
+	}
+}
+
+// loadPreviewContext loads the manifest, the core stack for the requested
+// profile, the repo path and the branch to preview
+func loadPreviewContext(ctx *cli.Context) (*thrapb.Stack, *core.Stack, string, string, error) {
+	st, err := manifest.LoadManifest(ctx.String("manifest"))
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	lpath, err := utils.GetLocalPath("")
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	ref := ctx.String("ref")
+	if ref == "" {
+		if ref, err = vcs.CurrentBranch(lpath); err != nil {
+			return nil, nil, "", "", err
+		}
+	}
+
+	_, prof, err := loadProfile(ctx)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	cr, err := loadCore(ctx)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	stm, err := cr.Stack(prof)
+	return st, stm, lpath, ref, err
+}
@@ -17,7 +17,7 @@ func commandStackRegister() *cli.Command {
 		Usage: "Register a new stack",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
@@ -78,7 +78,7 @@ func commandStackCommit() *cli.Command {
 		Usage: "Commit stack definition",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
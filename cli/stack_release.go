@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/euforia/thrap/utils"
+	"github.com/euforia/thrap/vcs"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errMultipleBumps = errors.New("only one of --major, --minor or --patch allowed")
+
+func commandStackRelease() *cli.Command {
+	return &cli.Command{
+		Name:  "release",
+		Usage: "Tag a new release based on the commit history",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "major",
+				Usage: "force a major version increment",
+			},
+			&cli.BoolFlag{
+				Name:  "minor",
+				Usage: "force a minor version increment",
+			},
+			&cli.BoolFlag{
+				Name:  "patch",
+				Usage: "force a patch version increment",
+			},
+			&cli.StringFlag{
+				Name:  "pre",
+				Usage: "release as a numbered prerelease with the `identifier` e.g. rc",
+			},
+			&cli.StringFlag{
+				Name:  "changelog",
+				Usage: "changelog `file` to update. Empty to skip",
+				Value: vcs.DefaultChangelogFile,
+			},
+			&cli.BoolFlag{
+				Name:  "push",
+				Usage: "push the release commit and tag to the remote",
+			},
+			&cli.BoolFlag{
+				Name:    "dryrun",
+				Aliases: []string{"dry"},
+				Usage:   "show the release without applying it",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			opts := vcs.ReleaseOptions{Prerelease: ctx.String("pre")}
+			for flag, bump := range map[string]vcs.Bump{
+				"major": vcs.BumpMajor,
+				"minor": vcs.BumpMinor,
+				"patch": vcs.BumpPatch,
+			} {
+				if !ctx.Bool(flag) {
+					continue
+				}
+				if opts.Bump != vcs.BumpNone {
+					return errMultipleBumps
+				}
+				opts.Bump = bump
+			}
+
+			lpath, err := utils.GetLocalPath("")
+			if err != nil {
+				return err
+			}
+
+			repo, err := git.PlainOpen(lpath)
+			if err != nil {
+				return err
+			}
+
+			rel, err := vcs.NextRelease(repo, opts)
+			if err != nil {
+				return err
+			}
+
+			prev := "none"
+			if rel.Previous != nil {
+				prev = rel.Previous.Name
+			}
+			fmt.Printf("\nRelease: %s (previous: %s, increment: %s)\n\n", rel.Tag(), prev, rel.Bump)
+			fmt.Println(rel.Changelog(time.Now()))
+
+			if ctx.Bool("dryrun") {
+				return nil
+			}
+
+			gvcs := vcs.NewGitVCS()
+			if err = gvcs.Init(nil); err != nil {
+				return err
+			}
+
+			err = rel.Apply(repo, lpath, vcs.ApplyOptions{
+				Changelog: ctx.String("changelog"),
+				Signature: object.Signature{
+					Name:  gvcs.GlobalUser(),
+					Email: gvcs.GlobalEmail(),
+				},
+				Push: ctx.Bool("push"),
+			})
+			if err == nil {
+				fmt.Println("Tagged", rel.Tag())
+			}
+
+			return err
+		},
+	}
+}
@@ -19,7 +19,7 @@ func commandStackStatus() *cli.Command {
 		Usage: "Show status",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
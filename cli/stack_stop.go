@@ -17,7 +17,7 @@ func commandStackStop() *cli.Command {
 		Usage: "Stop stack components",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
@@ -64,7 +64,7 @@ func commandStackDestroy() *cli.Command {
 		Usage: "Destroy stack components",
 		Action: func(ctx *cli.Context) error {
 
-			stack, err := manifest.LoadManifest("")
+			stack, err := manifest.LoadManifest(ctx.String("manifest"))
 			if err != nil {
 				return err
 			}
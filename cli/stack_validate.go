@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/manifest"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var errManifestInvalid = errors.New("manifest invalid")
+
+func commandStackValidate() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "Strictly validate the stack manifest",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "schema",
+				Usage: "print the manifest JSON schema and exit",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.Bool("schema") {
+				b, err := manifest.JSONSchema()
+				if err == nil {
+					fmt.Printf("%s\n", b)
+				}
+				return err
+			}
+
+			mfile := ctx.String("manifest")
+			if mfile == "" {
+				mfile = consts.DefaultManifestFile
+			}
+			mpath, err := utils.GetLocalPath(mfile)
+			if err != nil {
+				return err
+			}
+
+			var stack *thrapb.Stack
+			if strings.HasSuffix(mpath, ".hcl") {
+				stack, err = manifest.ParseHCLStrict(mpath)
+			} else {
+				stack, err = manifest.ParseYAMLStrict(mpath)
+			}
+
+			if err != nil {
+				if derrs, ok := err.(manifest.DecodeErrors); ok {
+					for _, e := range derrs {
+						fmt.Fprintf(os.Stderr, "%s:%v\n", mfile, e)
+					}
+					return errManifestInvalid
+				}
+				return err
+			}
+
+			errs := make(map[string]error)
+			for k, v := range stack.Validate() {
+				errs[k] = v
+			}
+			for k, v := range stack.ValidateReferences() {
+				errs[k] = v
+			}
+			if len(errs) == 0 {
+				fmt.Printf("%s: ok\n", mfile)
+				return nil
+			}
+
+			keys := make([]string, 0, len(errs))
+			for k := range errs {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Fprintf(os.Stderr, "%s: %s: %v\n", mfile, k, errs[k])
+			}
+
+			return errManifestInvalid
+		},
+	}
+}
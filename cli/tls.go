@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	errTLSKeyPairRequired = errors.New("--tls-cert and --tls-key required")
+	errInvalidCA          = errors.New("no certificates found in ca file")
+	errClusterCARequired  = errors.New("--tls-ca required for clustering")
+)
+
+// loadTLSConfig returns the tls config of the certificate, key and ca files.
+// The ca verifies peers and defaults to the system roots.  Client
+// certificates are verified by the ca when presented so the agent can tell
+// members from clients.  It returns nil if no files are given
+func loadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errInvalidCA
+		}
+		conf.RootCAs = pool
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return conf, nil
+}
+
+// transportOption returns the dial option for the tls config.  Connections
+// are insecure if it is nil
+func transportOption(conf *tls.Config) grpc.DialOption {
+	if conf == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(conf))
+}
+
+// certServerName returns a name the certificate is valid for so the agent
+// can verify itself when dialed on a loopback address
+func certServerName(cert tls.Certificate) (string, error) {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(leaf.DNSNames) > 0:
+		return leaf.DNSNames[0], nil
+	case len(leaf.IPAddresses) > 0:
+		return leaf.IPAddresses[0].String(), nil
+	}
+	return leaf.Subject.CommonName, nil
+}
@@ -0,0 +1,159 @@
+package thrap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/euforia/thrap/cluster"
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// MetaLeader is the trailer holding the grpc address of the cluster leader
+// when a follower rejects a write
+const MetaLeader = "thrap-leader"
+
+var errMemberCertRequired = errors.New("cluster member certificate required")
+
+// ClusterService implements the server-side grpc cluster service
+type ClusterService struct {
+	c *cluster.Cluster
+}
+
+// NewClusterService returns the grpc cluster service of the agent
+func NewClusterService(c *cluster.Cluster) *ClusterService {
+	return &ClusterService{c: c}
+}
+
+// JoinCluster joins the agent to the cluster of the member at the address.
+// Callers must hold the cluster secret
+func (s *ClusterService) JoinCluster(ctx context.Context, req *thrapb.JoinRequest) (*thrapb.ClusterMember, error) {
+	if err := s.verify(ctx); err != nil {
+		return nil, err
+	}
+	if req.Addr == "" {
+		return nil, status.Error(codes.InvalidArgument, "address required")
+	}
+	return s.c.Join(req.Addr)
+}
+
+// AddMember adds an agent to the cluster.  It is called by joining agents
+// with the cluster secret and a certificate signed by the cluster ca
+func (s *ClusterService) AddMember(ctx context.Context, member *thrapb.ClusterMember) (*thrapb.ClusterMember, error) {
+	if err := s.verifyMember(ctx); err != nil {
+		return nil, err
+	}
+	return s.c.AddMember(member)
+}
+
+// GetMembers returns all cluster members
+func (s *ClusterService) GetMembers(ctx context.Context, _ *thrapb.ClusterMembers) (*thrapb.ClusterMembers, error) {
+	members, err := s.c.Members()
+	if err != nil {
+		return nil, err
+	}
+	return &thrapb.ClusterMembers{Members: members}, nil
+}
+
+// Apply applies a storage write forwarded by a follower
+func (s *ClusterService) Apply(ctx context.Context, cmd *thrapb.ClusterCommand) (*thrapb.ClusterResult, error) {
+	if err := s.verifyMember(ctx); err != nil {
+		return nil, err
+	}
+	return cluster.Result(s.c.Apply(cmd)), nil
+}
+
+func (s *ClusterService) verify(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if err := s.c.VerifySecret(md); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// verifyMember verifies the caller is a member i.e. holds the cluster secret
+// and presented a client certificate verified by the cluster ca
+func (s *ClusterService) verifyMember(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, errMemberCertRequired.Error())
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return status.Error(codes.Unauthenticated, errMemberCertRequired.Error())
+	}
+	return s.verify(ctx)
+}
+
+// LeaderRedirectInterceptor returns a server interceptor rejecting writes,
+// i.e. audited calls, on followers.  The leader address is returned in the
+// MetaLeader trailer
+func LeaderRedirectInterceptor(c *cluster.Cluster) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, "/Thrap/") || !audited(info.FullMethod) || c.IsLeader() {
+			return handler(ctx, req)
+		}
+
+		leader, err := c.Leader()
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		grpc.SetTrailer(ctx, metadata.Pairs(MetaLeader, leader.RPCAddr))
+		return nil, status.Error(codes.FailedPrecondition, cluster.ErrNotLeader.Error())
+	}
+}
+
+// LeaderRedirect retries calls rejected by a follower on the cluster leader
+type LeaderRedirect struct {
+	opts []grpc.DialOption
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewLeaderRedirect returns a LeaderRedirect dialing leaders with the options
+func NewLeaderRedirect(opts ...grpc.DialOption) *LeaderRedirect {
+	return &LeaderRedirect{opts: opts, conns: make(map[string]*grpc.ClientConn)}
+}
+
+func (lr *LeaderRedirect) dial(addr string) (*grpc.ClientConn, error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	cc, ok := lr.conns[addr]
+	if !ok {
+		var err error
+		if cc, err = grpc.Dial(addr, lr.opts...); err != nil {
+			return nil, err
+		}
+		lr.conns[addr] = cc
+	}
+	return cc, nil
+}
+
+// UnaryInterceptor returns the client interceptor for unary calls.  It must
+// be the first interceptor so later ones run again for the leader
+func (lr *LeaderRedirect) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+
+		addrs := trailer[MetaLeader]
+		if status.Code(err) != codes.FailedPrecondition || len(addrs) == 0 {
+			return err
+		}
+
+		leader, err := lr.dial(addrs[0])
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, leader, opts...)
+	}
+}
@@ -0,0 +1,480 @@
+package cluster
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MetaSecret is the grpc metadata key carrying the cluster secret on
+	// calls between agents
+	MetaSecret = "thrap-cluster-secret"
+
+	// Replicated member records keyed by node id
+	memberPrefix = store.ClusterPrefix + "member/"
+	// Max time to wait for a command to be committed
+	applyTimeout = 10 * time.Second
+	// Number of raft snapshots retained
+	snapshotRetain = 2
+)
+
+var (
+	// ErrNotLeader is returned when a command is applied on a follower
+	ErrNotLeader = errors.New("not the cluster leader")
+	// ErrNoLeader is returned when the cluster has no known leader
+	ErrNoLeader = errors.New("no cluster leader")
+	// ErrInvalidSecret is returned when an agent call has a missing or
+	// wrong cluster secret
+	ErrInvalidSecret = errors.New("invalid cluster secret")
+
+	errRaftAddrRequired = errors.New("raft address required")
+	errRPCAddrRequired  = errors.New("rpc address required")
+	errSecretRequired   = errors.New("cluster secret required")
+	errTLSRequired      = errors.New("cluster tls config required")
+	errDataDirRequired  = errors.New("cluster data directory required")
+	errMemberIncomplete = errors.New("member id, raft and rpc address required")
+)
+
+// Config holds the cluster configuration of an agent
+type Config struct {
+	// Unique node id.  Defaults to the hostname
+	NodeID string
+	// Raft bind address
+	RaftAddr string
+	// Raft address advertised to other members.  Defaults to RaftAddr
+	AdvertiseAddr string
+	// Grpc address of the agent advertised to other members and clients
+	RPCAddr string
+	// Directory holding the raft log and snapshots
+	DataDir string
+	// Bootstrap a new cluster with this agent as the only member.  It is
+	// ignored if the agent already has cluster state
+	Bootstrap bool
+	// Secret shared by all members to authenticate agent to agent calls
+	Secret string
+	// TLS config of raft and agent to agent grpc connections holding the
+	// agent certificate and the ca other members are verified with.  It is
+	// required as calls carry the secret
+	TLS *tls.Config
+	// Raft log output.  Defaults to stderr
+	LogOutput io.Writer
+}
+
+// Validate checks required fields and sets defaults
+func (conf *Config) Validate() error {
+	if conf.NodeID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		conf.NodeID = hostname
+	}
+	if conf.RaftAddr == "" {
+		return errRaftAddrRequired
+	}
+	if conf.AdvertiseAddr == "" {
+		conf.AdvertiseAddr = conf.RaftAddr
+	}
+	if conf.RPCAddr == "" {
+		return errRPCAddrRequired
+	}
+	if conf.Secret == "" {
+		return errSecretRequired
+	}
+	if conf.LogOutput == nil {
+		conf.LogOutput = os.Stderr
+	}
+	return nil
+}
+
+// forwarder sends requests to another member, usually the leader
+type forwarder interface {
+	Apply(addr string, cmd *thrapb.ClusterCommand) error
+	AddMember(addr string, member *thrapb.ClusterMember) (*thrapb.ClusterMember, error)
+}
+
+// Cluster replicates the local driver across agents using raft.  It
+// implements store.Driver serving reads locally and applying writes through
+// the raft log.  Writes on followers are forwarded to the leader
+type Cluster struct {
+	conf *Config
+
+	raft *raft.Raft
+	fsm  *fsm
+
+	fwd forwarder
+	// raft stores and transport closed on shutdown
+	closers []io.Closer
+
+	shutdownCh chan struct{}
+
+	log *log.Logger
+}
+
+// New starts the raft node of the agent replicating the local driver
+func New(conf *Config, local store.Driver, logger *log.Logger) (*Cluster, error) {
+	err := conf.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if conf.DataDir == "" {
+		return nil, errDataDirRequired
+	}
+	if conf.TLS == nil {
+		return nil, errTLSRequired
+	}
+	if err = os.MkdirAll(conf.DataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	adv, err := net.ResolveTCPAddr("tcp", conf.AdvertiseAddr)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := newTLSStreamLayer(conf.RaftAddr, adv, conf.TLS)
+	if err != nil {
+		return nil, err
+	}
+	trans := raft.NewNetworkTransport(stream, 3, 10*time.Second, conf.LogOutput)
+
+	logs, err := raftboltdb.NewBoltStore(filepath.Join(conf.DataDir, "raft.db"))
+	if err != nil {
+		trans.Close()
+		return nil, err
+	}
+
+	snaps, err := raft.NewFileSnapshotStore(conf.DataDir, snapshotRetain, conf.LogOutput)
+	if err != nil {
+		logs.Close()
+		trans.Close()
+		return nil, err
+	}
+
+	rconf := raft.DefaultConfig()
+	rconf.LogOutput = conf.LogOutput
+
+	fwd := newGRPCForwarder(conf.Secret, conf.TLS)
+	c, err := newCluster(conf, local, rconf, logs, logs, snaps, trans, fwd, logger)
+	if err != nil {
+		fwd.Close()
+		logs.Close()
+		trans.Close()
+		return nil, err
+	}
+	c.closers = []io.Closer{fwd, trans, logs}
+
+	return c, nil
+}
+
+func newCluster(conf *Config, local store.Driver, rconf *raft.Config, logs raft.LogStore,
+	stable raft.StableStore, snaps raft.SnapshotStore, trans raft.Transport, fwd forwarder,
+	logger *log.Logger) (*Cluster, error) {
+
+	rconf.LocalID = raft.ServerID(conf.NodeID)
+
+	if conf.Bootstrap {
+		exists, err := raft.HasExistingState(logs, stable, snaps)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			logger.Println("Bootstrapping cluster:", conf.NodeID)
+			err = raft.BootstrapCluster(rconf, logs, stable, snaps, trans, raft.Configuration{
+				Servers: []raft.Server{{ID: rconf.LocalID, Address: trans.LocalAddr()}},
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c := &Cluster{
+		conf:       conf,
+		fsm:        &fsm{d: local},
+		fwd:        fwd,
+		shutdownCh: make(chan struct{}),
+		log:        logger,
+	}
+
+	var err error
+	c.raft, err = raft.NewRaft(rconf, c.fsm, logs, stable, snaps, trans)
+	if err != nil {
+		return nil, err
+	}
+
+	go c.monitorLeadership()
+
+	return c, nil
+}
+
+// monitorLeadership registers the agent as a member each time it becomes
+// the leader so followers can find its rpc address
+func (c *Cluster) monitorLeadership() {
+	for {
+		select {
+		case isLeader := <-c.raft.LeaderCh():
+			if !isLeader {
+				c.log.Println("Cluster leadership lost:", c.conf.NodeID)
+				continue
+			}
+			c.log.Println("Cluster leadership acquired:", c.conf.NodeID)
+			if err := c.putMember(c.Self()); err != nil {
+				c.log.Println("Failed to register cluster member:", err)
+			}
+
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// Self returns the member record of the agent
+func (c *Cluster) Self() *thrapb.ClusterMember {
+	return &thrapb.ClusterMember{
+		ID:       c.conf.NodeID,
+		RaftAddr: c.conf.AdvertiseAddr,
+		RPCAddr:  c.conf.RPCAddr,
+	}
+}
+
+// IsLeader returns true if the agent is the cluster leader
+func (c *Cluster) IsLeader() bool {
+	return c.raft.State() == raft.Leader
+}
+
+// Leader returns the member record of the current leader
+func (c *Cluster) Leader() (*thrapb.ClusterMember, error) {
+	addr := string(c.raft.Leader())
+	if addr == "" {
+		return nil, ErrNoLeader
+	}
+
+	var leader *thrapb.ClusterMember
+	err := c.iterMembers(func(m *thrapb.ClusterMember) error {
+		if m.RaftAddr == addr {
+			leader = m
+		}
+		return nil
+	})
+	if err == nil && leader == nil {
+		// Elected but not yet registered
+		err = ErrNoLeader
+	}
+	return leader, err
+}
+
+// Members returns all members in the raft configuration
+func (c *Cluster) Members() ([]*thrapb.ClusterMember, error) {
+	future := c.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]*thrapb.ClusterMember)
+	err := c.iterMembers(func(m *thrapb.ClusterMember) error {
+		known[m.ID] = m
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	leader := c.raft.Leader()
+	servers := future.Configuration().Servers
+	members := make([]*thrapb.ClusterMember, 0, len(servers))
+	for _, server := range servers {
+		m, ok := known[string(server.ID)]
+		if !ok {
+			m = &thrapb.ClusterMember{ID: string(server.ID), RaftAddr: string(server.Address)}
+		}
+		m.Leader = server.Address == leader
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// AddMember adds the member as a voter.  Followers forward the request to
+// the leader
+func (c *Cluster) AddMember(member *thrapb.ClusterMember) (*thrapb.ClusterMember, error) {
+	if member.ID == "" || member.RaftAddr == "" || member.RPCAddr == "" {
+		return nil, errMemberIncomplete
+	}
+
+	if !c.IsLeader() {
+		leader, err := c.Leader()
+		if err != nil {
+			return nil, err
+		}
+		return c.fwd.AddMember(leader.RPCAddr, member)
+	}
+
+	future := c.raft.AddVoter(raft.ServerID(member.ID), raft.ServerAddress(member.RaftAddr), 0, 0)
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+	if err := c.putMember(member); err != nil {
+		return nil, err
+	}
+
+	c.log.Println("Cluster member added:", member.ID, member.RaftAddr)
+	return member, nil
+}
+
+// Join joins the cluster of the member at the rpc address
+func (c *Cluster) Join(addr string) (*thrapb.ClusterMember, error) {
+	return c.fwd.AddMember(addr, c.Self())
+}
+
+// VerifySecret checks the cluster secret sent with an agent call
+func (c *Cluster) VerifySecret(md metadata.MD) error {
+	secrets := md[MetaSecret]
+	if len(secrets) == 0 ||
+		subtle.ConstantTimeCompare([]byte(secrets[0]), []byte(c.conf.Secret)) != 1 {
+		return ErrInvalidSecret
+	}
+	return nil
+}
+
+// Apply applies the command through the raft log.  It returns ErrNotLeader
+// if the agent is not the leader
+func (c *Cluster) Apply(cmd *thrapb.ClusterCommand) error {
+	if !c.IsLeader() {
+		return ErrNotLeader
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	future := c.raft.Apply(b, applyTimeout)
+	if err = future.Error(); err != nil {
+		if err == raft.ErrNotLeader {
+			err = ErrNotLeader
+		}
+		return err
+	}
+
+	if err, ok := future.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// apply applies the command on the leader forwarding it if needed
+func (c *Cluster) apply(cmd *thrapb.ClusterCommand) error {
+	err := c.Apply(cmd)
+	if err != ErrNotLeader {
+		return err
+	}
+
+	leader, err := c.Leader()
+	if err != nil {
+		return err
+	}
+	return c.fwd.Apply(leader.RPCAddr, cmd)
+}
+
+func (c *Cluster) putMember(member *thrapb.ClusterMember) error {
+	rec := *member
+	rec.Leader = false
+
+	val, err := proto.Marshal(&rec)
+	if err != nil {
+		return err
+	}
+	return c.apply(&thrapb.ClusterCommand{Op: thrapb.ClusterOp_PUT, Key: memberPrefix + member.ID, Value: val})
+}
+
+func (c *Cluster) iterMembers(f func(*thrapb.ClusterMember) error) error {
+	return c.fsm.d.Iter(memberPrefix, "", func(_ string, val []byte) error {
+		var m thrapb.ClusterMember
+		if err := proto.Unmarshal(val, &m); err != nil {
+			return err
+		}
+		return f(&m)
+	})
+}
+
+// Get returns the value from the local replica
+func (c *Cluster) Get(key string) ([]byte, error) {
+	return c.fsm.d.Get(key)
+}
+
+// Create replicates the creation of the key
+func (c *Cluster) Create(key string, val []byte) error {
+	return c.apply(&thrapb.ClusterCommand{Op: thrapb.ClusterOp_CREATE, Key: key, Value: val})
+}
+
+// Update replicates the update of an existing key
+func (c *Cluster) Update(key string, val []byte) error {
+	return c.apply(&thrapb.ClusterCommand{Op: thrapb.ClusterOp_UPDATE, Key: key, Value: val})
+}
+
+// Put replicates writing the key
+func (c *Cluster) Put(key string, val []byte) error {
+	return c.apply(&thrapb.ClusterCommand{Op: thrapb.ClusterOp_PUT, Key: key, Value: val})
+}
+
+// Delete replicates the removal of the key
+func (c *Cluster) Delete(key string) error {
+	return c.apply(&thrapb.ClusterCommand{Op: thrapb.ClusterOp_DELETE, Key: key})
+}
+
+// Iter iterates over the local replica
+func (c *Cluster) Iter(prefix, start string, f func(string, []byte) error) error {
+	return c.fsm.d.Iter(prefix, start, f)
+}
+
+// Close shuts down the raft node and closes the local driver
+func (c *Cluster) Close() error {
+	close(c.shutdownCh)
+
+	err := c.raft.Shutdown().Error()
+	for _, closer := range c.closers {
+		closer.Close()
+	}
+	if cerr := c.fsm.d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Result returns the grpc result of a forwarded command
+func Result(err error) *thrapb.ClusterResult {
+	if err == nil {
+		return &thrapb.ClusterResult{}
+	}
+	return &thrapb.ClusterResult{Error: err.Error()}
+}
+
+// resultError returns the error of a forwarded command result.  Driver
+// errors are mapped back so callers can compare them
+func resultError(result *thrapb.ClusterResult) error {
+	switch result.Error {
+	case "":
+		return nil
+	case store.ErrKeyExists.Error():
+		return store.ErrKeyExists
+	case store.ErrKeyNotFound.Error():
+		return store.ErrKeyNotFound
+	case ErrNotLeader.Error():
+		return ErrNotLeader
+	}
+	return errors.New(result.Error)
+}
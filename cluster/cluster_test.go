@@ -0,0 +1,270 @@
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+)
+
+// testForwarder calls members directly by rpc address
+type testForwarder map[string]*Cluster
+
+func (fwd testForwarder) Apply(addr string, cmd *thrapb.ClusterCommand) error {
+	// Round trip through the result as grpc would
+	return resultError(Result(fwd[addr].Apply(cmd)))
+}
+
+func (fwd testForwarder) AddMember(addr string, member *thrapb.ClusterMember) (*thrapb.ClusterMember, error) {
+	return fwd[addr].AddMember(member)
+}
+
+type testNode struct {
+	*Cluster
+	trans *raft.InmemTransport
+	dir   string
+}
+
+// newTestCluster starts n in-process agents.  The first bootstraps the
+// cluster and the rest join it
+func newTestCluster(t *testing.T, n int) []*testNode {
+	var (
+		fwd    = testForwarder{}
+		nodes  = make([]*testNode, n)
+		logger = log.New(ioutil.Discard, "", 0)
+	)
+
+	for i := range nodes {
+		_, trans := raft.NewInmemTransport("")
+		dir, err := ioutil.TempDir("", "thrap-cluster")
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[i] = &testNode{trans: trans, dir: dir}
+	}
+	for _, a := range nodes {
+		for _, b := range nodes {
+			if a != b {
+				a.trans.Connect(b.trans.LocalAddr(), b.trans)
+			}
+		}
+	}
+
+	for i, node := range nodes {
+		conf := &Config{
+			NodeID:    fmt.Sprintf("node%d", i),
+			RaftAddr:  string(node.trans.LocalAddr()),
+			RPCAddr:   fmt.Sprintf("rpc%d", i),
+			Bootstrap: i == 0,
+			Secret:    "secret",
+		}
+		assert.Nil(t, conf.Validate())
+
+		local, err := store.OpenDriver(&store.DriverConfig{Name: store.DriverBolt, DataDir: node.dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rconf := raft.DefaultConfig()
+		rconf.HeartbeatTimeout = 50 * time.Millisecond
+		rconf.ElectionTimeout = 50 * time.Millisecond
+		rconf.LeaderLeaseTimeout = 50 * time.Millisecond
+		rconf.CommitTimeout = 5 * time.Millisecond
+		rconf.LogOutput = ioutil.Discard
+
+		logs := raft.NewInmemStore()
+		node.Cluster, err = newCluster(conf, local, rconf, logs, logs, raft.NewInmemSnapshotStore(),
+			node.trans, fwd, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fwd[conf.RPCAddr] = node.Cluster
+
+		if i == 0 {
+			waitFor(t, func() bool {
+				_, err := node.Leader()
+				return err == nil
+			})
+			continue
+		}
+		if _, err = node.Join("rpc0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return nodes
+}
+
+// stop shuts down the node and disconnects it from the rest
+func (node *testNode) stop(nodes []*testNode) {
+	for _, other := range nodes {
+		other.trans.Disconnect(node.trans.LocalAddr())
+	}
+	node.trans.DisconnectAll()
+	node.Close()
+	os.RemoveAll(node.dir)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+	t.Fatal("timed out")
+}
+
+func leaderOf(nodes []*testNode) *testNode {
+	for _, node := range nodes {
+		if node.IsLeader() {
+			return node
+		}
+	}
+	return nil
+}
+
+func Test_Cluster(t *testing.T) {
+	nodes := newTestCluster(t, 3)
+	defer func() {
+		for _, node := range nodes {
+			node.stop(nodes)
+		}
+	}()
+
+	// Member records replicate to followers
+	var members []*thrapb.ClusterMember
+	waitFor(t, func() bool {
+		members, _ = nodes[2].Members()
+		for _, m := range members {
+			if m.RPCAddr == "" {
+				return false
+			}
+		}
+		return len(members) == 3
+	})
+	assert.True(t, members[0].Leader)
+	assert.Equal(t, "rpc0", members[0].RPCAddr)
+
+	// Follower writes are forwarded to the leader
+	assert.Nil(t, nodes[1].Create("/stack/foo", []byte("v1")))
+	assert.Equal(t, store.ErrKeyExists, nodes[2].Create("/stack/foo", []byte("v2")))
+	assert.Equal(t, store.ErrKeyNotFound, nodes[2].Update("/stack/bar", []byte("v2")))
+
+	for _, node := range nodes {
+		waitFor(t, func() bool {
+			val, err := node.Get("/stack/foo")
+			return err == nil && bytes.Equal(val, []byte("v1"))
+		})
+	}
+
+	// Fail the leader
+	nodes[0].stop(nodes)
+	nodes = nodes[1:]
+	waitFor(t, func() bool {
+		leader := leaderOf(nodes)
+		if leader == nil {
+			return false
+		}
+		_, err := leader.Leader()
+		return err == nil
+	})
+
+	leader := leaderOf(nodes)
+	var follower *testNode
+	for _, node := range nodes {
+		if node != leader {
+			follower = node
+		}
+	}
+	// Leadership may still settle after the election
+	waitFor(t, func() bool {
+		return follower.Put("/stack/foo", []byte("v3")) == nil
+	})
+	waitFor(t, func() bool {
+		val, _ := follower.Get("/stack/foo")
+		return bytes.Equal(val, []byte("v3"))
+	})
+}
+
+type testSink struct {
+	bytes.Buffer
+}
+
+func (sink *testSink) ID() string    { return "test" }
+func (sink *testSink) Cancel() error { return nil }
+func (sink *testSink) Close() error  { return nil }
+
+func Test_fsm_SnapshotRestore(t *testing.T) {
+	var drivers [2]store.Driver
+	for i := range drivers {
+		dir, err := ioutil.TempDir("", "thrap-fsm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		drivers[i], err = store.OpenDriver(&store.DriverConfig{Name: store.DriverBolt, DataDir: dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer drivers[i].Close()
+	}
+
+	src, dst := &fsm{d: drivers[0]}, &fsm{d: drivers[1]}
+	assert.Nil(t, src.d.Put("/stack/a", []byte("a")))
+	assert.Nil(t, dst.d.Put("/stack/stale", []byte("x")))
+	assert.Nil(t, dst.d.Put(memberPrefix+"node0", []byte("m")))
+
+	snap, err := src.Snapshot()
+	assert.Nil(t, err)
+	sink := &testSink{}
+	assert.Nil(t, snap.Persist(sink))
+	assert.Nil(t, dst.Restore(ioutil.NopCloser(&sink.Buffer)))
+
+	val, err := dst.d.Get("/stack/a")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a"), val)
+	_, err = dst.d.Get("/stack/stale")
+	assert.Equal(t, store.ErrKeyNotFound, err)
+	_, err = dst.d.Get(memberPrefix + "node0")
+	assert.Nil(t, err)
+}
+
+func Test_Cluster_BackupRestore(t *testing.T) {
+	nodes := newTestCluster(t, 1)
+	node := nodes[0]
+	defer node.stop(nodes)
+
+	waitFor(t, func() bool {
+		members, _ := node.Members()
+		return len(members) == 1 && members[0].RPCAddr != ""
+	})
+	assert.Nil(t, node.Put("/stack/foo", []byte("v1")))
+
+	var buf bytes.Buffer
+	assert.Nil(t, store.Backup(node, &buf))
+	assert.Nil(t, node.Put("/stack/bar", []byte("v2")))
+
+	// Restore as the agent does
+	assert.Nil(t, store.Clear(node))
+	assert.Nil(t, store.Load(node, store.SnapshotFormatKV, &buf))
+
+	val, err := node.Get("/stack/foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), val)
+	_, err = node.Get("/stack/bar")
+	assert.Equal(t, store.ErrKeyNotFound, err)
+
+	leader, err := node.Leader()
+	assert.Nil(t, err)
+	assert.Equal(t, "rpc0", leader.RPCAddr)
+}
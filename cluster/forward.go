@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcForwarder calls other members over grpc with the cluster secret.  The
+// secret is only sent over tls to members verified by the cluster ca
+type grpcForwarder struct {
+	secret string
+	creds  credentials.TransportCredentials
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newGRPCForwarder(secret string, conf *tls.Config) *grpcForwarder {
+	return &grpcForwarder{
+		secret: secret,
+		creds:  credentials.NewTLS(conf.Clone()),
+		conns:  make(map[string]*grpc.ClientConn),
+	}
+}
+
+// client returns a client to the address re-using connections
+func (fwd *grpcForwarder) client(addr string) (thrapb.ClusterClient, error) {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	cc, ok := fwd.conns[addr]
+	if !ok {
+		var err error
+		cc, err = grpc.Dial(addr, grpc.WithTransportCredentials(fwd.creds))
+		if err != nil {
+			return nil, err
+		}
+		fwd.conns[addr] = cc
+	}
+	return thrapb.NewClusterClient(cc), nil
+}
+
+func (fwd *grpcForwarder) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+	return metadata.AppendToOutgoingContext(ctx, MetaSecret, fwd.secret), cancel
+}
+
+func (fwd *grpcForwarder) Apply(addr string, cmd *thrapb.ClusterCommand) error {
+	client, err := fwd.client(addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := fwd.context()
+	defer cancel()
+
+	result, err := client.Apply(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+func (fwd *grpcForwarder) AddMember(addr string, member *thrapb.ClusterMember) (*thrapb.ClusterMember, error) {
+	client, err := fwd.client(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := fwd.context()
+	defer cancel()
+
+	return client.AddMember(ctx, member)
+}
+
+// Close closes all connections
+func (fwd *grpcForwarder) Close() error {
+	fwd.mu.Lock()
+	defer fwd.mu.Unlock()
+
+	for addr, cc := range fwd.conns {
+		cc.Close()
+		delete(fwd.conns, addr)
+	}
+	return nil
+}
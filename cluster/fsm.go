@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/hashicorp/raft"
+)
+
+// fsm applies replicated commands to the local driver
+type fsm struct {
+	d store.Driver
+}
+
+// Apply applies a committed command.  The driver error if any is returned as
+// the response
+func (f *fsm) Apply(l *raft.Log) interface{} {
+	var cmd thrapb.ClusterCommand
+	if err := proto.Unmarshal(l.Data, &cmd); err != nil {
+		return err
+	}
+	if err := applyCommand(f.d, &cmd); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Snapshot copies all keys.  Writes are not applied until it returns
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	snap := &fsmSnapshot{}
+	err := f.d.Iter("/", "", func(key string, val []byte) error {
+		snap.Entries = append(snap.Entries, &thrapb.ClusterCommand{Key: key, Value: val})
+		return nil
+	})
+	return snap, err
+}
+
+// Restore replaces all local keys with the snapshot.  Member records are
+// kept until overwritten by the snapshot so the leader can still be looked up.
+// The replicated audit log is append-only so local entries are overwritten by
+// those in the snapshot as well
+func (f *fsm) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	var snap thrapb.ClusterSnapshot
+	if err = proto.Unmarshal(b, &snap); err != nil {
+		return err
+	}
+
+	if err = store.Clear(f.d); err != nil {
+		return err
+	}
+
+	for _, entry := range snap.Entries {
+		if err = f.d.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fsmSnapshot struct {
+	thrapb.ClusterSnapshot
+}
+
+func (snap *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	b, err := proto.Marshal(&snap.ClusterSnapshot)
+	if err == nil {
+		_, err = sink.Write(b)
+	}
+	if err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (snap *fsmSnapshot) Release() {}
+
+// applyCommand applies the command to the driver
+func applyCommand(d store.Driver, cmd *thrapb.ClusterCommand) error {
+	switch cmd.Op {
+	case thrapb.ClusterOp_CREATE:
+		return d.Create(cmd.Key, cmd.Value)
+	case thrapb.ClusterOp_UPDATE:
+		return d.Update(cmd.Key, cmd.Value)
+	case thrapb.ClusterOp_DELETE:
+		return d.Delete(cmd.Key)
+	}
+	return d.Put(cmd.Key, cmd.Value)
+}
@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+var (
+	errAdvertiseAddrInvalid = errors.New("raft advertise address must be a specific ip")
+	errCARequired           = errors.New("cluster ca required")
+)
+
+// tlsStreamLayer is a raft stream layer over mutually authenticated tls so
+// only agents holding a certificate signed by the cluster ca replicate.  The
+// ca must be set as the system roots would accept any public certificate
+type tlsStreamLayer struct {
+	net.Listener
+	adv  net.Addr
+	conf *tls.Config
+}
+
+func newTLSStreamLayer(bindAddr string, adv *net.TCPAddr, conf *tls.Config) (*tlsStreamLayer, error) {
+	if adv.IP == nil || adv.IP.IsUnspecified() {
+		return nil, errAdvertiseAddrInvalid
+	}
+	if conf.RootCAs == nil {
+		return nil, errCARequired
+	}
+
+	sconf := conf.Clone()
+	sconf.ClientAuth = tls.RequireAndVerifyClientCert
+	if sconf.ClientCAs == nil {
+		sconf.ClientCAs = sconf.RootCAs
+	}
+
+	lis, err := tls.Listen("tcp", bindAddr, sconf)
+	if err != nil {
+		return nil, err
+	}
+	return &tlsStreamLayer{Listener: lis, adv: adv, conf: conf}, nil
+}
+
+// Addr returns the advertise address
+func (sl *tlsStreamLayer) Addr() net.Addr {
+	return sl.adv
+}
+
+// Dial connects to the member verifying its certificate
+func (sl *tlsStreamLayer) Dial(addr raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	return tls.DialWithDialer(dialer, "tcp", string(addr), sl.conf)
+}
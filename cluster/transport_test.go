@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTLSConfig returns a config with a certificate for 127.0.0.1 signed
+// by a new ca
+func newTestTLSConfig(t *testing.T) *tls.Config {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "agent"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		RootCAs:      pool,
+	}
+}
+
+func Test_tlsStreamLayer(t *testing.T) {
+	conf := newTestTLSConfig(t)
+	adv := &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}
+
+	_, err := newTLSStreamLayer("127.0.0.1:0", &net.TCPAddr{}, conf)
+	assert.Equal(t, errAdvertiseAddrInvalid, err)
+
+	// The system roots are never used to verify members
+	noCA := conf.Clone()
+	noCA.RootCAs = nil
+	_, err = newTLSStreamLayer("127.0.0.1:0", adv, noCA)
+	assert.Equal(t, errCARequired, err)
+
+	sl, err := newTLSStreamLayer("127.0.0.1:0", adv, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sl.Close()
+	addr := sl.Listener.Addr().String()
+
+	go func() {
+		for {
+			conn, err := sl.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.Write([]byte("ok"))
+				conn.Close()
+			}()
+		}
+	}()
+
+	conn, err := sl.Dial("127.0.0.1:0", time.Second)
+	assert.NotNil(t, err)
+
+	conn, err = sl.Dial(raft.ServerAddress(addr), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 2)
+	_, err = conn.Read(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", string(buf))
+	conn.Close()
+
+	// Members of another cluster are rejected
+	other := newTestTLSConfig(t)
+	conn, err = (&tlsStreamLayer{conf: other}).Dial(raft.ServerAddress(addr), time.Second)
+	if err == nil {
+		_, err = conn.Read(buf)
+		conn.Close()
+	}
+	assert.NotNil(t, err)
+
+	// Clients without a certificate are rejected
+	noCert := conf.Clone()
+	noCert.Certificates = nil
+	conn, err = (&tlsStreamLayer{conf: noCert}).Dial(raft.ServerAddress(addr), time.Second)
+	if err == nil {
+		_, err = conn.Read(buf)
+		conn.Close()
+	}
+	assert.NotNil(t, err)
+}
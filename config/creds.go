@@ -2,11 +2,13 @@ package config
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/euforia/hclencoder"
 	"github.com/euforia/thrap/consts"
 	"github.com/hashicorp/hcl"
+	vault "github.com/hashicorp/vault/api"
 )
 
 // CredsConfig holds creds
@@ -94,15 +96,77 @@ func ReadCredsConfig(fpath string) (*CredsConfig, error) {
 	return &cc, err
 }
 
-// WriteCredsConfig writes the creds to the given file
+// WriteCredsConfig writes the creds to the given file readable only by the
+// owner
 func WriteCredsConfig(cc *CredsConfig, fpath string) error {
 	b, err := hclencoder.Encode(cc)
 	if err == nil {
-		err = ioutil.WriteFile(fpath, b, 0644)
+		err = ioutil.WriteFile(fpath, b, 0600)
+		if err == nil {
+			// WriteFile does not change the mode of existing files
+			err = os.Chmod(fpath, 0600)
+		}
 	}
 	return err
 }
 
+// OpenDataDirCredsStore opens the encrypted credentials store in the data
+// directory.  The key is derived from the passphrase in the environment if
+// set otherwise the key file next to the keypair is used
+func OpenDataDirCredsStore(dir string) (*CredsStore, error) {
+	return OpenCredsStore(
+		filepath.Join(dir, consts.CredsStoreFile),
+		filepath.Join(dir, consts.CredsKeyFile),
+		[]byte(os.Getenv(consts.EnvVarCredsPassphrase)),
+	)
+}
+
+// SealDataDirCredsConfig moves plaintext values of the creds file into the
+// encrypted store in the data directory and rewrites the file with store
+// references.  The store is only opened if there is something to move.  It
+// returns the names of the moved credentials
+func SealDataDirCredsConfig(dir string, cc *CredsConfig) ([]string, error) {
+	if !cc.hasPlain() {
+		return nil, nil
+	}
+
+	cs, err := OpenDataDirCredsStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := cc.Seal(cs)
+	if err != nil {
+		return nil, err
+	}
+	return sealed, WriteCredsConfig(cc, filepath.Join(dir, consts.CredsFile))
+}
+
+// DataDirCredsSources returns the env, store and vault sources.  The store in
+// the data directory is only opened when referenced.  Vault is configured
+// from the standard VAULT_* environment variables
+func DataDirCredsSources(dir string) map[string]CredsSource {
+	var store *CredsStore
+	sources := map[string]CredsSource{
+		CredsSchemeEnv: EnvCredsSource,
+		CredsSchemeStore: CredsSourceFunc(func(ref string) (string, error) {
+			if store == nil {
+				cs, err := OpenDataDirCredsStore(dir)
+				if err != nil {
+					return "", err
+				}
+				store = cs
+			}
+			return store.Get(ref)
+		}),
+	}
+
+	if client, err := vault.NewClient(vault.DefaultConfig()); err == nil {
+		sources[CredsSchemeVault] = NewVaultCredsSource(client)
+	}
+
+	return sources
+}
+
 // DefaultCredsConfig returns minimal credential config
 func DefaultCredsConfig() *CredsConfig {
 	return &CredsConfig{
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Credential reference schemes i.e. <scheme>://<ref>
+const (
+	// CredsSchemeEnv references an environment variable
+	CredsSchemeEnv = "env"
+	// CredsSchemeStore references a credential in the encrypted store
+	CredsSchemeStore = "store"
+	// CredsSchemeVault references a vault secret key as <path>#<key>
+	CredsSchemeVault = "vault"
+)
+
+// CredsSource resolves credential references of a scheme
+type CredsSource interface {
+	Resolve(ref string) (string, error)
+}
+
+// CredsSourceFunc is a function implementing CredsSource
+type CredsSourceFunc func(ref string) (string, error)
+
+// Resolve calls the function
+func (f CredsSourceFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// EnvCredsSource resolves credentials from environment variables
+var EnvCredsSource = CredsSourceFunc(func(ref string) (string, error) {
+	if val, ok := os.LookupEnv(ref); ok {
+		return val, nil
+	}
+	return "", errCredNotFound
+})
+
+// Resolve returns the named credential from the store
+func (cs *CredsStore) Resolve(ref string) (string, error) {
+	return cs.Get(ref)
+}
+
+// NewVaultCredsSource returns a source resolving <path>#<key> references
+// from vault.  Both kv version 1 and 2 secrets are supported
+func NewVaultCredsSource(client *vault.Client) CredsSource {
+	return CredsSourceFunc(func(ref string) (string, error) {
+		i := strings.LastIndex(ref, "#")
+		if i < 1 {
+			return "", fmt.Errorf("vault reference must be <path>#<key>: %s", ref)
+		}
+
+		secret, err := client.Logical().Read(ref[:i])
+		if err != nil {
+			return "", err
+		}
+		if secret == nil {
+			return "", errCredNotFound
+		}
+
+		data := secret.Data
+		if d, ok := data["data"].(map[string]interface{}); ok {
+			data = d
+		}
+		if val, ok := data[ref[i+1:]].(string); ok {
+			return val, nil
+		}
+		return "", errCredNotFound
+	})
+}
+
+// CredsRef returns the reference to the credential for the scheme
+func CredsRef(scheme, ref string) string {
+	return scheme + "://" + ref
+}
+
+// parseCredsRef returns the scheme and reference of a value if it is a
+// reference
+func parseCredsRef(val string) (string, string, bool) {
+	i := strings.Index(val, "://")
+	if i < 1 {
+		return "", "", false
+	}
+	return val[:i], val[i+3:], true
+}
+
+// sections returns all creds by section name
+func (cc *CredsConfig) sections() map[string]map[string]map[string]string {
+	return map[string]map[string]map[string]string{
+		"registry":     cc.Registry,
+		"vcs":          cc.VCS,
+		"secrets":      cc.Secrets,
+		"orchestrator": cc.Orchestrator,
+	}
+}
+
+// Resolve replaces all references with the values from the sources keyed
+// by scheme.  Plain values are left as is
+func (cc *CredsConfig) Resolve(sources map[string]CredsSource) error {
+	for section, creds := range cc.sections() {
+		for id, kvs := range creds {
+			for k, v := range kvs {
+				scheme, ref, ok := parseCredsRef(v)
+				if !ok {
+					continue
+				}
+
+				src, ok := sources[scheme]
+				if !ok {
+					return fmt.Errorf("%s.%s.%s: unsupported creds source: %s", section, id, k, scheme)
+				}
+				val, err := src.Resolve(ref)
+				if err != nil {
+					return fmt.Errorf("%s.%s.%s: %v", section, id, k, err)
+				}
+				kvs[k] = val
+			}
+		}
+	}
+	return nil
+}
+
+// hasPlain returns true if any value is neither empty nor a reference
+func (cc *CredsConfig) hasPlain() bool {
+	for _, creds := range cc.sections() {
+		for _, kvs := range creds {
+			for _, v := range kvs {
+				if _, _, ok := parseCredsRef(v); !ok && v != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Seal moves all plain non-empty values into the store as
+// <section>/<id>/<key> replacing them with store references.  It returns
+// the names of the moved credentials
+func (cc *CredsConfig) Seal(cs *CredsStore) ([]string, error) {
+	var sealed []string
+	for section, creds := range cc.sections() {
+		for id, kvs := range creds {
+			for k, v := range kvs {
+				if _, _, ok := parseCredsRef(v); ok || v == "" {
+					continue
+				}
+
+				name := section + "/" + id + "/" + k
+				cs.creds[name] = v
+				kvs[k] = CredsRef(CredsSchemeStore, name)
+				sealed = append(sealed, name)
+			}
+		}
+	}
+
+	if len(sealed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(sealed)
+	return sealed, cs.save()
+}
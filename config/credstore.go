@@ -0,0 +1,200 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/euforia/thrap/utils"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Key derived from a passphrase
+	credsKDFScrypt = "scrypt"
+	// Random key stored in a file
+	credsKDFKeyFile = "keyfile"
+
+	credsKeySize  = 32
+	credsSaltSize = 16
+)
+
+var (
+	errCredNotFound       = errors.New("credential not found")
+	errPassphraseRequired = errors.New("creds store passphrase required")
+	errInvalidCredsKey    = errors.New("invalid creds store key")
+	errUnknownCredsKDF    = errors.New("unknown creds store key derivation")
+)
+
+// encrypted file format
+type credsStoreFile struct {
+	KDF   string
+	Salt  []byte `json:",omitempty"`
+	Nonce []byte
+	Data  []byte
+}
+
+// CredsStore is an AES-GCM encrypted credentials file.  The key is either
+// derived from a passphrase or read from a key file
+type CredsStore struct {
+	fpath string
+
+	kdf  string
+	salt []byte
+	aead cipher.AEAD
+
+	creds map[string]string
+}
+
+// OpenCredsStore opens the encrypted store at fpath.  New stores use a key
+// derived from the passphrase if one is given, otherwise a random key in
+// keyfile which is created if missing.  Existing stores require the same
+// kind of key they were created with
+func OpenCredsStore(fpath, keyfile string, passphrase []byte) (*CredsStore, error) {
+	cs := &CredsStore{fpath: fpath, creds: make(map[string]string)}
+
+	var sf credsStoreFile
+	if utils.FileExists(fpath) {
+		b, err := ioutil.ReadFile(fpath)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(b, &sf); err != nil {
+			return nil, err
+		}
+	} else if len(passphrase) > 0 {
+		sf.KDF = credsKDFScrypt
+		sf.Salt = make([]byte, credsSaltSize)
+		if _, err := io.ReadFull(rand.Reader, sf.Salt); err != nil {
+			return nil, err
+		}
+	} else {
+		sf.KDF = credsKDFKeyFile
+	}
+
+	var (
+		key []byte
+		err error
+	)
+	switch sf.KDF {
+	case credsKDFScrypt:
+		if len(passphrase) == 0 {
+			return nil, errPassphraseRequired
+		}
+		key, err = scrypt.Key(passphrase, sf.Salt, 1<<15, 8, 1, credsKeySize)
+	case credsKDFKeyFile:
+		key, err = loadCredsKey(keyfile)
+	default:
+		err = errUnknownCredsKDF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if cs.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, err
+	}
+	cs.kdf, cs.salt = sf.KDF, sf.Salt
+
+	if len(sf.Data) == 0 {
+		return cs, nil
+	}
+
+	plain, err := cs.aead.Open(nil, sf.Nonce, sf.Data, []byte(sf.KDF))
+	if err != nil {
+		return nil, errInvalidCredsKey
+	}
+	err = json.Unmarshal(plain, &cs.creds)
+
+	return cs, err
+}
+
+// loadCredsKey reads the key from the file generating and writing a new one
+// if it does not exist
+func loadCredsKey(keyfile string) ([]byte, error) {
+	if utils.FileExists(keyfile) {
+		key, err := ioutil.ReadFile(keyfile)
+		if err == nil && len(key) != credsKeySize {
+			err = errInvalidCredsKey
+		}
+		return key, err
+	}
+
+	key := make([]byte, credsKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, ioutil.WriteFile(keyfile, key, 0600)
+}
+
+// Get returns the named credential
+func (cs *CredsStore) Get(name string) (string, error) {
+	if val, ok := cs.creds[name]; ok {
+		return val, nil
+	}
+	return "", errCredNotFound
+}
+
+// Set sets the named credential and writes the store
+func (cs *CredsStore) Set(name, value string) error {
+	cs.creds[name] = value
+	return cs.save()
+}
+
+// Remove removes the named credential and writes the store
+func (cs *CredsStore) Remove(name string) error {
+	if _, ok := cs.creds[name]; !ok {
+		return errCredNotFound
+	}
+	delete(cs.creds, name)
+	return cs.save()
+}
+
+// List returns the sorted names of all credentials
+func (cs *CredsStore) List() []string {
+	names := make([]string, 0, len(cs.creds))
+	for k := range cs.creds {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cs *CredsStore) save() error {
+	plain, err := json.Marshal(cs.creds)
+	if err != nil {
+		return err
+	}
+
+	sf := credsStoreFile{
+		KDF:   cs.kdf,
+		Salt:  cs.salt,
+		Nonce: make([]byte, cs.aead.NonceSize()),
+	}
+	if _, err = io.ReadFull(rand.Reader, sf.Nonce); err != nil {
+		return err
+	}
+	sf.Data = cs.aead.Seal(nil, sf.Nonce, plain, []byte(sf.KDF))
+
+	b, err := json.Marshal(sf)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a failed write does not lose the store
+	tmp := cs.fpath + ".tmp"
+	if err = ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, cs.fpath)
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CredsStore(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "credstore-")
+	defer os.RemoveAll(tmpdir)
+
+	var (
+		fpath   = filepath.Join(tmpdir, "creds.enc")
+		keyfile = filepath.Join(tmpdir, "creds.key")
+	)
+
+	cs, err := OpenCredsStore(fpath, keyfile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, cs.Set("vcs/github/token", "secret-token"))
+	assert.Nil(t, cs.Set("registry/ecr/key", "key"))
+
+	b, _ := ioutil.ReadFile(fpath)
+	assert.NotContains(t, string(b), "secret-token")
+	fi, _ := os.Stat(keyfile)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	cs, err = OpenCredsStore(fpath, keyfile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"registry/ecr/key", "vcs/github/token"}, cs.List())
+	val, err := cs.Get("vcs/github/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret-token", val)
+
+	assert.Nil(t, cs.Remove("registry/ecr/key"))
+	assert.Equal(t, errCredNotFound, cs.Remove("registry/ecr/key"))
+
+	ioutil.WriteFile(keyfile, make([]byte, credsKeySize), 0600)
+	_, err = OpenCredsStore(fpath, keyfile, nil)
+	assert.Equal(t, errInvalidCredsKey, err)
+}
+
+func Test_CredsStore_passphrase(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "credstore-")
+	defer os.RemoveAll(tmpdir)
+
+	fpath := filepath.Join(tmpdir, "creds.enc")
+	cs, err := OpenCredsStore(fpath, "", []byte("pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, cs.Set("foo", "bar"))
+
+	_, err = OpenCredsStore(fpath, "", nil)
+	assert.Equal(t, errPassphraseRequired, err)
+	_, err = OpenCredsStore(fpath, "", []byte("wrong"))
+	assert.Equal(t, errInvalidCredsKey, err)
+
+	cs, err = OpenCredsStore(fpath, "", []byte("pass"))
+	assert.Nil(t, err)
+	val, _ := cs.Get("foo")
+	assert.Equal(t, "bar", val)
+}
+
+func Test_CredsConfig_Seal_Resolve(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "credstore-")
+	defer os.RemoveAll(tmpdir)
+
+	cs, err := OpenCredsStore(filepath.Join(tmpdir, "creds.enc"), filepath.Join(tmpdir, "creds.key"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("TEST_THRAP_NOMAD_TOKEN", "nomad-token")
+	cc := DefaultCredsConfig()
+	cc.VCS["github"]["token"] = "gh-token"
+	cc.Orchestrator["nomad"] = map[string]string{"token": "env://TEST_THRAP_NOMAD_TOKEN"}
+
+	sealed, err := cc.Seal(cs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vcs/github/token"}, sealed)
+	assert.Equal(t, "store://vcs/github/token", cc.VCS["github"]["token"])
+
+	err = cc.Resolve(map[string]CredsSource{
+		CredsSchemeEnv:   EnvCredsSource,
+		CredsSchemeStore: cs,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "gh-token", cc.VCS["github"]["token"])
+	assert.Equal(t, "nomad-token", cc.Orchestrator["nomad"]["token"])
+
+	cc.Secrets["vault"] = map[string]string{"token": "foo://bar"}
+	assert.NotNil(t, cc.Resolve(nil))
+}
+
+func Test_SealDataDirCredsConfig(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "credstore-")
+	defer os.RemoveAll(tmpdir)
+	os.Unsetenv(consts.EnvVarCredsPassphrase)
+
+	// Nothing to move does not create a store
+	cc := DefaultCredsConfig()
+	sealed, err := SealDataDirCredsConfig(tmpdir, cc)
+	assert.Nil(t, err)
+	assert.Nil(t, sealed)
+	_, err = os.Stat(filepath.Join(tmpdir, consts.CredsStoreFile))
+	assert.True(t, os.IsNotExist(err))
+
+	cc.VCS["github"]["token"] = "gh-token"
+	sealed, err = SealDataDirCredsConfig(tmpdir, cc)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vcs/github/token"}, sealed)
+
+	b, _ := ioutil.ReadFile(filepath.Join(tmpdir, consts.CredsFile))
+	assert.NotContains(t, string(b), "gh-token")
+	cc, err = ReadCredsConfig(filepath.Join(tmpdir, consts.CredsFile))
+	assert.Nil(t, err)
+	assert.Equal(t, "store://vcs/github/token", cc.VCS["github"]["token"])
+}
@@ -8,16 +8,36 @@ const (
 	ConfigFile = "config.hcl"
 	// CredsFile is the default credentials filename
 	CredsFile = "creds.hcl"
+	// CredsStoreFile is the encrypted credentials store filename
+	CredsStoreFile = "creds.enc"
+	// CredsKeyFile is the encrypted credentials store key file used when no
+	// passphrase is set
+	CredsKeyFile = "creds.key"
 	// IdentityFile is the identity filename
 	IdentityFile = "identity.hcl"
+	// SessionFile is the login session filename
+	SessionFile = "session.json"
 	// ProfilesFile is the profiles filename
 	ProfilesFile = "profiles.hcl"
 	// KeyFile is the keypair file
 	KeyFile = "ecdsa256"
 	// EnvVarVersion is the env. var. name for the version injected by thrap
 	EnvVarVersion = "STACK_VERSION"
+	// EnvVarCredsPassphrase is the env. var. name of the passphrase the
+	// credentials store key is derived from
+	EnvVarCredsPassphrase = "THRAP_CREDS_PASSPHRASE"
+	// EnvVarSnapshotPassphrase is the env. var. name of the passphrase
+	// snapshots are encrypted with
+	EnvVarSnapshotPassphrase = "THRAP_SNAPSHOT_PASSPHRASE"
+	// EnvVarInterpPrefix is the prefix of the env. vars. the env function
+	// can read when interpolating component definitions
+	EnvVarInterpPrefix = "THRAP_VAR_"
 	// PacksDir is the directory name where packs are stored
 	PacksDir = "packs"
+	// LogsDir is the directory name where build logs are stored
+	LogsDir = "logs"
+	// SnapshotsDir is the directory name where scheduled snapshots are stored
+	SnapshotsDir = "snapshots"
 )
 
 const (
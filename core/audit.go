@@ -0,0 +1,43 @@
+package core
+
+import (
+	"crypto/sha256"
+	"log"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+// Audit is the canonical interface to record and query the audit log of
+// agent mutations
+type Audit struct {
+	store AuditStorage
+	log   *log.Logger
+}
+
+// Record appends the entry to the log.  The sequence number and hashes are
+// set by the store
+func (aud *Audit) Record(entry *thrapb.AuditEntry) (*thrapb.AuditEntry, error) {
+	appended, err := aud.store.Append(entry)
+	if err != nil {
+		aud.log.Printf("Failed to record audit entry method=%s resource=%s: %v",
+			entry.Method, entry.Resource, err)
+	}
+	return appended, err
+}
+
+// Iter iterates over each entry matching the options
+func (aud *Audit) Iter(opts *thrapb.AuditOptions, f func(*thrapb.AuditEntry) error) error {
+	return aud.store.Iter(opts.Start, func(entry *thrapb.AuditEntry) error {
+		if opts.Match(entry) {
+			return f(entry)
+		}
+		return nil
+	})
+}
+
+// Verify verifies the hash chain of the whole log returning the last entry
+func (aud *Audit) Verify() (*thrapb.AuditEntry, error) {
+	chain := thrapb.NewAuditChain(sha256.New)
+	err := aud.store.Iter(0, chain.Add)
+	return chain.Last(), err
+}
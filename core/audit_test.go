@@ -0,0 +1,61 @@
+package core
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+)
+
+type memAuditStorage []*thrapb.AuditEntry
+
+func (m *memAuditStorage) Append(entry *thrapb.AuditEntry) (*thrapb.AuditEntry, error) {
+	entry.Seq = uint64(len(*m) + 1)
+	if len(*m) > 0 {
+		entry.Prev = (*m)[len(*m)-1].Hash
+	}
+	entry.Hash = entry.ComputeHash(sha256.New())
+	*m = append(*m, entry)
+	return entry, nil
+}
+
+func (m *memAuditStorage) Iter(start uint64, f func(*thrapb.AuditEntry) error) error {
+	for _, e := range *m {
+		if e.Seq < start {
+			continue
+		}
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Test_Audit(t *testing.T) {
+	st := new(memAuditStorage)
+	aud := &Audit{store: st, log: DefaultLogger(ioutil.Discard)}
+
+	for _, m := range []string{"/Thrap/RegisterStack", "/Thrap/CommitStack", "/Thrap/RegisterStack"} {
+		_, err := aud.Record(&thrapb.AuditEntry{Method: m, Resource: "api", Outcome: thrapb.Outcome_SUCCEEDED})
+		assert.Nil(t, err)
+	}
+
+	var seqs []uint64
+	err := aud.Iter(&thrapb.AuditOptions{Method: "RegisterStack"}, func(e *thrapb.AuditEntry) error {
+		seqs = append(seqs, e.Seq)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 3}, seqs)
+
+	last, err := aud.Verify()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), last.Seq)
+
+	(*st)[1].Outcome = thrapb.Outcome_FAILED
+	last, err = aud.Verify()
+	assert.NotNil(t, err)
+	assert.Equal(t, uint64(1), last.Seq)
+}
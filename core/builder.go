@@ -24,6 +24,10 @@ type BuildOptions struct {
 	// If true the build is published despite the auto-publish check,
 	// essentially a force publish
 	Publish bool
+	// If true all components are built.  Otherwise, with component
+	// versioning, components whose artifact exists for the current version
+	// and have no uncommitted changes are skipped
+	All bool
 }
 
 // CompBuildResult is the result of a component build
@@ -39,6 +43,8 @@ type CompBuildResult struct {
 	Log *crt.DockerBuildLog
 	// Whether the image was published or not
 	Published bool
+	// Whether the build was skipped as the component had no changes
+	Skipped bool
 }
 
 // HasError returns true if the build result contains an error
@@ -67,6 +73,9 @@ type stackBuilder struct {
 	// Time to spin up dependent services
 	svcTime *metrics.Runtime
 
+	// Components not to be built
+	skip map[string]bool
+
 	// Build result per component
 	results map[string]*CompBuildResult
 	// Overall build status
@@ -159,7 +168,11 @@ func (bldr *stackBuilder) buildByHead(ctx context.Context, head bool) error {
 			continue
 		}
 
-		bldr.doBuild(ctx, comp)
+		if bldr.skip[comp.ID] {
+			bldr.skipBuild(comp)
+		} else {
+			bldr.doBuild(ctx, comp)
+		}
 
 		// Start container from image that was just built, if this component
 		// is not the head
@@ -197,6 +210,15 @@ func (bldr *stackBuilder) doBuild(ctx context.Context, comp *thrapb.Component) {
 	}
 }
 
+// skipBuild adds a skipped result for the component
+func (bldr *stackBuilder) skipBuild(comp *thrapb.Component) {
+	runtime := (&metrics.Runtime{}).Start()
+	runtime.End()
+
+	fmt.Printf("\nSkipping %s: no changes since %s\n", comp.ID, comp.Version)
+	bldr.results[comp.ID] = &CompBuildResult{Runtime: runtime, Skipped: true}
+}
+
 func (bldr *stackBuilder) getBuildTags(comp *thrapb.Component) []string {
 	// Local tags
 	base := bldr.stack.ArtifactName(comp.ID)
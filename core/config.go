@@ -4,9 +4,14 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"time"
 
+	"github.com/euforia/thrap/cluster"
 	"github.com/euforia/thrap/config"
 	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/store"
 )
 
 // Config holds the core configuration
@@ -20,6 +25,22 @@ type Config struct {
 	Logger *log.Logger
 	// Data directory. This must exist
 	DataDir string
+	// Mailer used to send identity confirmation codes.  Defaults to logging
+	// them
+	Mailer mailer.Mailer
+	// Identity provider for single sign-on.  Disabled if nil
+	OIDC *oidc.Config
+	// Lifetime of session tokens issued on login.  Defaults to
+	// DefaultSessionTTL
+	SessionTTL time.Duration
+	// Storage driver for agent state.  Defaults to badger in the data
+	// directory
+	Storage *store.DriverConfig
+	// Replicate the storage driver across agents.  Disabled if nil
+	Cluster *cluster.Config
+	// Additional destination of build and deployment records such as a
+	// remote agent.  Records are only stored locally if nil
+	Records RecordSink
 }
 
 // Validate checks required fields and sets defaults where ever possible.  It
@@ -56,12 +56,20 @@ func ConfigureGlobal(opts ConfigureOptions) error {
 		}
 	}
 
-	if opts.VCS.Username != "" {
-		if _, ok := conf.VCS[opts.VCS.ID]; !ok {
-			return fmt.Errorf("unknown vcs provider: '%s'", opts.VCS.ID)
+	if _, ok := conf.VCS[opts.VCS.ID]; !ok {
+		vc, err := newVCSConfig(opts.VCS.ID)
+		if err != nil {
+			return err
 		}
+		conf.VCS[opts.VCS.ID] = vc
+	}
+	if opts.VCS.Username != "" {
 		conf.VCS[opts.VCS.ID].Username = opts.VCS.Username
 	}
+	if opts.VCS.Addr != "" {
+		conf.VCS[opts.VCS.ID].Addr = opts.VCS.Addr
+	}
+	configureVCSAddr(conf.VCS[opts.VCS.ID], opts.NoPrompt)
 	configureHomeVars(conf.VCS[opts.VCS.ID], opts.NoPrompt)
 
 	err = config.WriteThrapConfig(conf, varsfile)
@@ -85,11 +93,17 @@ func ConfigureGlobal(opts ConfigureOptions) error {
 		}
 	}
 	configureVCSCreds(cconf, opts.VCS.ID, opts.NoPrompt)
+
+	// Move plaintext creds into the encrypted store
+	if _, err = config.SealDataDirCredsConfig(opts.DataDir, cconf); err != nil {
+		return err
+	}
 	err = config.WriteCredsConfig(cconf, credsFile)
 	if err != nil {
 		return err
 	}
 	fmt.Fprintf(tw, "Creds:\t%s\n", credsFile)
+	fmt.Fprintf(tw, "Creds store:\t%s\n", filepath.Join(opts.DataDir, consts.CredsStoreFile))
 
 	// Key file
 	keypath := filepath.Join(opts.DataDir, consts.KeyFile)
@@ -102,6 +116,57 @@ func ConfigureGlobal(opts ConfigureOptions) error {
 	return err
 }
 
+// newVCSConfig returns a new config for a supported vcs provider with the
+// default address if it has one
+func newVCSConfig(id string) (*config.VCSConfig, error) {
+	vc := &config.VCSConfig{ID: id}
+
+	switch id {
+	case "github":
+		vc.Addr = "github.com"
+	case "gitlab":
+		vc.Addr = "gitlab.com"
+	case "gitea", "git":
+		// Self-hosted only
+	default:
+		return nil, fmt.Errorf("unknown vcs provider: '%s'", id)
+	}
+
+	return vc, nil
+}
+
+// configureVCSAddr prompts for the address of a self-hosted vcs if one is not
+// set.  For gitlab the address is prompted for with gitlab.com as the default
+func configureVCSAddr(conf *config.VCSConfig, noprompt bool) {
+	if noprompt {
+		return
+	}
+
+	switch conf.ID {
+	case "gitlab":
+		prompt := fmt.Sprintf("%s address [%s]: ", conf.ID, conf.Addr)
+		utils.PromptUntilNoError(prompt, os.Stdout, os.Stdin, func(input []byte) error {
+			if addr := string(input); addr != "" {
+				conf.Addr = addr
+			}
+			return nil
+		})
+
+	case "gitea":
+		if conf.Addr != "" {
+			return
+		}
+		prompt := fmt.Sprintf("%s address: ", conf.ID)
+		utils.PromptUntilNoError(prompt, os.Stdout, os.Stdin, func(input []byte) error {
+			conf.Addr = string(input)
+			if conf.Addr == "" {
+				return fmt.Errorf("%s address required", conf.ID)
+			}
+			return nil
+		})
+	}
+}
+
 func configureHomeVars(conf *config.VCSConfig, noprompt bool) {
 	ghvcs, _ := vcs.New(&vcs.Config{Provider: "git"})
 	if conf.Username == "" {
@@ -128,6 +193,18 @@ func configureHomeVars(conf *config.VCSConfig, noprompt bool) {
 }
 
 func configureVCSCreds(conf *config.CredsConfig, vcsID string, noprompt bool) {
+	// Plain git has no remote api
+	if vcsID == "git" {
+		return
+	}
+
+	if conf.VCS == nil {
+		conf.VCS = make(map[string]map[string]string)
+	}
+	if _, ok := conf.VCS[vcsID]; !ok {
+		conf.VCS[vcsID] = map[string]string{"token": ""}
+	}
+
 	token := conf.VCS[vcsID]["token"]
 	if token != "" || noprompt {
 		return
@@ -4,8 +4,12 @@ import (
 	"crypto/ecdsa"
 	"log"
 	"path/filepath"
+	"time"
 
+	"github.com/euforia/thrap/cluster"
 	"github.com/euforia/thrap/crt"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/oidc"
 	"github.com/euforia/thrap/thrapb"
 
 	"github.com/euforia/thrap/packs"
@@ -17,6 +21,8 @@ import (
 	"github.com/euforia/thrap/orchestrator"
 	"github.com/euforia/thrap/registry"
 	"github.com/euforia/thrap/secrets"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/utils"
 	"github.com/euforia/thrap/vcs"
 )
 
@@ -58,10 +64,34 @@ type Core struct {
 
 	sst StackStorage
 	ist IdentityStorage
+	bst BuildStorage
+	dst DeploymentStorage
+	ast AuditStorage
+
+	// Additional destination of build and deployment records
+	rec RecordSink
+
+	// Storage driver backing all of the above
+	drv store.Driver
+	// Replicated storage.  Nil if not clustered
+	cluster *cluster.Cluster
 
 	// Load keypair. Currently 1 per core
 	kp *ecdsa.PrivateKey
 
+	// Sends identity confirmation codes
+	mailer mailer.Mailer
+
+	// Single sign-on identity provider.  Nil if not configured
+	idp *oidc.Provider
+	// Session token lifetime
+	sessionTTL time.Duration
+	// Secret session tokens are signed with
+	sessionKey *sessionKey
+
+	// Data directory
+	datadir string
+
 	// Logger
 	log *log.Logger
 }
@@ -85,6 +115,13 @@ func NewCore(conf *Config) (*Core, error) {
 		return nil, err
 	}
 
+	c.initMailer(conf.Mailer)
+
+	err = c.initOIDC(conf.OIDC, conf.SessionTTL)
+	if err != nil {
+		return nil, err
+	}
+
 	err = c.initPacks(filepath.Join(conf.DataDir, consts.PacksDir))
 	if err != nil {
 		return nil, err
@@ -92,7 +129,7 @@ func NewCore(conf *Config) (*Core, error) {
 
 	err = c.initProviders()
 	if err == nil {
-		err = c.initStores(conf.DataDir)
+		err = c.initStores(conf.DataDir, conf.Storage, conf.Cluster)
 	}
 
 	return c, err
@@ -125,6 +162,10 @@ func (core *Core) Stack(profile *thrapb.Profile) (*Stack, error) {
 		vcs:   core.vcs,
 		packs: core.packs,
 		sst:   core.sst,
+		bst:   core.bst,
+		dst:   core.dst,
+		rec:   core.rec,
+		prof:  profile,
 		log:   core.log,
 	}
 
@@ -143,12 +184,40 @@ func (core *Core) Stack(profile *thrapb.Profile) (*Stack, error) {
 // Identity returns an Identity instance to perform operations against
 // identities
 func (core *Core) Identity() *Identity {
-	return &Identity{
+	idt := &Identity{
+		store:  core.ist,
+		log:    core.log,
+		mailer: core.mailer,
+	}
+	if core.kp != nil {
+		idt.admin = utils.PublicKeyBytes(&core.kp.PublicKey)
+	}
+	return idt
+}
+
+// Audit returns an Audit instance to record and query the audit log
+func (core *Core) Audit() *Audit {
+	return &Audit{store: core.ast, log: core.log}
+}
+
+// Sessions returns a Sessions instance to login identity provider users and
+// verify their session tokens
+func (core *Core) Sessions() *Sessions {
+	return &Sessions{
+		idp:   core.idp,
+		key:   core.sessionKey,
+		ttl:   core.sessionTTL,
 		store: core.ist,
 		log:   core.log,
 	}
 }
 
+// Cluster returns the replicated storage of the agent or nil if it is not
+// clustered
+func (core *Core) Cluster() *cluster.Cluster {
+	return core.cluster
+}
+
 // KeyPair returns the public-private key currently held by the core
 func (core *Core) KeyPair() *ecdsa.PrivateKey {
 	return core.kp
@@ -1,11 +1,14 @@
 package core
 
 import (
-	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/euforia/thrap/cluster"
 	"github.com/euforia/thrap/config"
 	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/oidc"
 	"github.com/euforia/thrap/orchestrator"
 	"github.com/euforia/thrap/packs"
 	"github.com/euforia/thrap/registry"
@@ -23,6 +26,7 @@ func (core *Core) loadConfigs(conf *Config) error {
 	}
 
 	core.log = conf.Logger
+	core.rec = conf.Records
 
 	conf.DataDir, err = utils.GetAbsPath(conf.DataDir)
 	if err != nil {
@@ -48,8 +52,19 @@ func (core *Core) loadConfigs(conf *Config) error {
 	if err != nil {
 		return err
 	}
+	// Move plaintext creds left from older versions into the encrypted store
+	sealed, err := config.SealDataDirCredsConfig(conf.DataDir, creds)
+	if err != nil {
+		core.log.Printf("Plaintext credentials not moved to store file=%s error=%q", credsFile, err)
+	} else if len(sealed) > 0 {
+		core.log.Printf("Plaintext credentials moved to store count=%d", len(sealed))
+	}
 
 	creds.Merge(conf.Creds)
+	// Replace env, store and vault references with their values
+	if err = creds.Resolve(config.DataDirCredsSources(conf.DataDir)); err != nil {
+		return err
+	}
 	core.creds = creds
 
 	return nil
@@ -61,6 +76,28 @@ func (core *Core) initKeyPair(dir string) (err error) {
 	return
 }
 
+// initMailer sets the mailer used for identity confirmation codes.  Codes
+// are logged if none is given
+func (core *Core) initMailer(m mailer.Mailer) {
+	if m == nil {
+		m = mailer.NewLogMailer(core.log)
+	}
+	core.mailer = m
+}
+
+// initOIDC sets up single sign-on if an identity provider is configured
+func (core *Core) initOIDC(conf *oidc.Config, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	core.sessionTTL = ttl
+
+	if conf != nil {
+		core.idp, err = oidc.NewProvider(conf)
+	}
+	return
+}
+
 func (core *Core) initPacks(dir string) error {
 	pks, err := packs.New(dir)
 	if err != nil {
@@ -94,6 +131,9 @@ func (core *Core) initVCS() (err error) {
 		Provider: vc.ID,
 		Conf:     map[string]interface{}{"username": vc.Username},
 	}
+	if vc.Addr != "" {
+		vconf.Conf["addr"] = vc.Addr
+	}
 
 	vcreds := core.creds.GetVCSCreds(vc.ID)
 	for k, v := range vcreds {
@@ -170,21 +210,38 @@ func (core *Core) initOrchestrators() error {
 	return nil
 }
 
-func (core *Core) initStores(datadir string) error {
-	dbdir := filepath.Join(datadir, "db")
-
-	if !utils.FileExists(dbdir) {
-		core.log.Println("Initializing new db:", dbdir)
-		os.MkdirAll(dbdir, 0755)
+func (core *Core) initStores(datadir string, conf *store.DriverConfig, cconf *cluster.Config) error {
+	if conf == nil {
+		conf = &store.DriverConfig{Name: store.DriverBadger}
+	}
+	if conf.DataDir == "" {
+		conf.DataDir = datadir
 	}
 
-	db, err := store.NewBadgerDB(dbdir)
+	core.log.Println("Storage driver:", conf.Name)
+	d, err := store.OpenDriver(conf)
 	if err != nil {
 		return err
 	}
 
-	core.sst = store.NewBadgerStackStorage(db)
-	core.ist = store.NewBadgerIdentityStorage(db)
+	if cconf != nil {
+		if cconf.DataDir == "" {
+			cconf.DataDir = filepath.Join(datadir, "raft")
+		}
+		if core.cluster, err = cluster.New(cconf, d, core.log); err != nil {
+			d.Close()
+			return err
+		}
+		d = core.cluster
+	}
+
+	core.drv, core.datadir = d, datadir
+	core.sst = store.NewStackStorage(d)
+	core.ist = store.NewIdentityStorage(d)
+	core.bst = store.NewBuildStorage(d)
+	core.dst = store.NewDeploymentStorage(d)
+	core.ast = store.NewAuditStorage(d)
+	core.sessionKey = &sessionKey{drv: d}
 
 	return nil
 }
@@ -1,11 +1,13 @@
 package core
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"log"
-	"math/rand"
+	"strings"
+	"time"
 
-	"github.com/euforia/base58"
+	"github.com/euforia/thrap/mailer"
 	"github.com/euforia/thrap/store"
 	"github.com/euforia/thrap/thrapb"
 	"github.com/euforia/thrap/utils"
@@ -17,16 +19,33 @@ var (
 	ErrIdentityAlreadySigned = errors.New("identity already signed")
 	// ErrIdentityAlreadyRegistered is used when identity has already been registered
 	ErrIdentityAlreadyRegistered = errors.New("identity already registered")
+	// ErrIdentityNotConfirmed is used when the identity has no confirmed key
+	ErrIdentityNotConfirmed = errors.New("identity not confirmed")
+	// ErrSignatureInvalid is used when a signature fails verification
+	ErrSignatureInvalid = errors.New("signature verification failed")
+	// ErrTimestampSkew is used when a signed request is too old or too far
+	// in the future
+	ErrTimestampSkew = errors.New("timestamp outside allowed skew")
+	// ErrKeyInUse is used when rotating to a current or past key
+	ErrKeyInUse = errors.New("key already used")
 )
 
+// MaxSignatureSkew is the maximum difference between the time of a signed
+// request and now
+const MaxSignatureSkew = 5 * time.Minute
+
 // Identity is the caninical interface to interact with identities
 type Identity struct {
 	store IdentityStorage
 	log   *log.Logger
+	// agent public key allowed to revoke any key
+	admin []byte
+	// sends confirmation codes
+	mailer mailer.Mailer
 }
 
-// Confirm confirms a identity registration request and completes it.
-// In this case the public key field is the signature from the client
+// Confirm confirms a identity registration request and completes it.  The
+// signature is of the mailed confirmation code by the registered key
 func (idt *Identity) Confirm(ident *thrapb.Identity) (*thrapb.Identity, error) {
 
 	sident, err := idt.store.Get(ident.ID)
@@ -37,17 +56,19 @@ func (idt *Identity) Confirm(ident *thrapb.Identity) (*thrapb.Identity, error) {
 	if len(sident.Signature) > 0 {
 		return nil, ErrIdentityAlreadySigned
 	}
+	if !confirmValid(sident, time.Now()) {
+		return nil, ErrConfirmCodeExpired
+	}
 
 	shash := sident.SigHash(sha256.New())
-
-	b58e := base58.Encode(shash)
-	idt.log.Printf("Verifying user registration code=%s", b58e)
-
-	if !utils.VerifySignature(ident.PublicKey, shash, ident.Signature) {
-		return nil, errors.New("signature verification failed")
+	if !bytes.Equal(ident.PublicKey, sident.PublicKey) ||
+		!utils.VerifySignature(sident.PublicKey, shash, ident.Signature) {
+		return nil, ErrSignatureInvalid
 	}
 
 	sident.Signature = ident.Signature
+	sident.ConfirmExpires = 0
+	sident.ConfirmSent = nil
 
 	_, err = idt.store.Update(sident)
 	if err == nil {
@@ -56,14 +77,37 @@ func (idt *Identity) Confirm(ident *thrapb.Identity) (*thrapb.Identity, error) {
 	return sident, err
 }
 
-// Register registers a new identity. It returns an error if the identity exists
-// or fails to register
+// Register registers a new identity mailing the confirmation code to it.
+// Registering a pending identity again resends a new code.  It returns an
+// error if the identity exists or fails to register
 func (idt *Identity) Register(ident *thrapb.Identity) (*thrapb.Identity, []*thrapb.ActionResult, error) {
 	err := ident.Validate()
 	if err != nil {
 		return nil, nil, err
 	}
-	ident.Nonce = rand.Uint64()
+	ident.Signature = nil
+	ident.History = nil
+	// Provider claims are only set on login
+	for k := range ident.Meta {
+		if strings.HasPrefix(k, metaOIDCPrefix) {
+			delete(ident.Meta, k)
+		}
+	}
+
+	// Codes sent for a pending registration count towards the limit
+	var sent []int64
+	if sident, err := idt.store.Get(ident.ID); err == nil {
+		sent = sident.ConfirmSent
+	}
+
+	now := time.Now()
+	if ident.ConfirmSent, err = limitSends(sent, now); err != nil {
+		return nil, nil, err
+	}
+	ident.ConfirmExpires = now.Add(confirmCodeTTL).UnixNano()
+	if ident.Nonce, err = newNonce(); err != nil {
+		return nil, nil, err
+	}
 
 	er := &thrapb.ActionResult{
 		Resource: ident.ID,
@@ -71,7 +115,10 @@ func (idt *Identity) Register(ident *thrapb.Identity) (*thrapb.Identity, []*thra
 	}
 	er.Data, er.Error = idt.store.Create(ident)
 	if er.Error == store.ErrIdentityExists {
-		er.Error = ErrIdentityAlreadyRegistered
+		er.Data, er.Error = idt.reregister(ident)
+	}
+	if er.Error == nil {
+		er.Error = idt.sendCode(ident)
 	}
 
 	// er.Action = thrapb.NewAction("create", "identity", ident.ID)
@@ -79,16 +126,184 @@ func (idt *Identity) Register(ident *thrapb.Identity) (*thrapb.Identity, []*thra
 		idt.log.Printf("User registration request user=%s", ident.ID)
 	}
 
-	return ident, []*thrapb.ActionResult{er}, er.Error
+	return redact(ident), []*thrapb.ActionResult{er}, er.Error
 }
 
 // Get returns an Identity by the id
 func (idt *Identity) Get(id string) (*thrapb.Identity, error) {
 	ident, err := idt.store.Get(id)
-	return ident, err
+	return redact(ident), err
 }
 
 // Iter iterates over each identity with the matching prefix
 func (idt *Identity) Iter(prefix string, f func(*thrapb.Identity) error) error {
-	return idt.store.Iter(prefix, f)
+	return idt.store.Iter(prefix, func(ident *thrapb.Identity) error {
+		return f(redact(ident))
+	})
+}
+
+// reregister replaces a pending identity or one whose key has been revoked
+// keeping its key history.  Pending identities can only be replaced by
+// another key once their code has expired.  The email cannot change so the
+// code is always mailed to the owner of the identity.  Identities created
+// by a provider login cannot be claimed
+func (idt *Identity) reregister(ident *thrapb.Identity) (*thrapb.Identity, error) {
+	sident, err := idt.store.Get(ident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(sident.Signature) > 0, sident.Email != ident.Email,
+		sident.Meta[MetaOIDCCreated] != "":
+		return nil, ErrIdentityAlreadyRegistered
+	case len(sident.PublicKey) > 0 && !bytes.Equal(sident.PublicKey, ident.PublicKey) &&
+		confirmValid(sident, time.Now()):
+		return nil, ErrIdentityAlreadyRegistered
+	}
+
+	// Past keys cannot be registered again
+	for _, k := range sident.History {
+		if bytes.Equal(k.PublicKey, ident.PublicKey) {
+			return nil, ErrKeyInUse
+		}
+	}
+
+	ident.History = sident.History
+	return idt.store.Update(ident)
+}
+
+// Rotate replaces the current key of a confirmed identity with a new one.
+// The rotation must be signed by the current key which is moved to the
+// identity history
+func (idt *Identity) Rotate(rot *thrapb.KeyRotation) (*thrapb.Identity, error) {
+	sident, err := idt.store.Get(rot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sident.PublicKey) == 0 || len(sident.Signature) == 0 {
+		return nil, ErrIdentityNotConfirmed
+	}
+
+	now := time.Now()
+	if err = checkSkew(rot.Timestamp, now); err != nil {
+		return nil, err
+	}
+	if !utils.VerifySignature(sident.PublicKey, rot.SigHash(sha256.New()), rot.Signature) {
+		return nil, ErrSignatureInvalid
+	}
+	if len(rot.PublicKey) == 0 || sident.CheckKey(rot.PublicKey, now.UnixNano()) != thrapb.ErrKeyUnknown {
+		return nil, ErrKeyInUse
+	}
+
+	sident.RetireKey(now.UnixNano(), "")
+	sident.PublicKey = rot.PublicKey
+	// The rotation signature confirms the new key
+	sident.Signature = rot.Signature
+
+	_, err = idt.store.Update(sident)
+	if err == nil {
+		idt.log.Printf("Identity key rotated user=%s", sident.ID)
+	}
+	return sident, err
+}
+
+// Revoke revokes a current or past key of an identity.  The revocation must
+// be signed by the current key of the identity or the agent key.  Revoking
+// the current key leaves the identity without a key until it registers again
+func (idt *Identity) Revoke(rev *thrapb.KeyRevocation) (*thrapb.Identity, error) {
+	sident, err := idt.store.Get(rev.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = checkSkew(rev.Timestamp, now); err != nil {
+		return nil, err
+	}
+
+	signer := len(rev.Signer) > 0 &&
+		(bytes.Equal(rev.Signer, sident.PublicKey) || bytes.Equal(rev.Signer, idt.admin))
+	if !signer || !utils.VerifySignature(rev.Signer, rev.SigHash(sha256.New()), rev.Signature) {
+		return nil, ErrSignatureInvalid
+	}
+
+	reason := rev.Reason
+	if reason == "" {
+		reason = "unspecified"
+	}
+
+	if len(sident.PublicKey) > 0 && bytes.Equal(rev.PublicKey, sident.PublicKey) {
+		sident.RetireKey(now.UnixNano(), reason)
+	} else if err = revokePastKey(sident, rev.PublicKey, now.UnixNano(), reason); err != nil {
+		return nil, err
+	}
+
+	_, err = idt.store.Update(sident)
+	if err == nil {
+		idt.log.Printf("Identity key revoked user=%s reason=%q", sident.ID, reason)
+	}
+	return sident, err
+}
+
+func revokePastKey(ident *thrapb.Identity, pubkey []byte, now int64, reason string) error {
+	for _, k := range ident.History {
+		if !bytes.Equal(k.PublicKey, pubkey) {
+			continue
+		}
+		if k.Revoked == 0 {
+			k.Revoked = now
+			k.RevokeReason = reason
+		}
+		return nil
+	}
+	return thrapb.ErrKeyUnknown
+}
+
+// Verify verifies the signature of data by the current key of the identity.
+// Signatures by revoked or rotated keys are rejected with the corresponding
+// key error
+func (idt *Identity) Verify(id string, data, signature []byte) (*thrapb.Identity, error) {
+	sident, err := idt.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sident.PublicKey) > 0 && len(sident.Signature) > 0 &&
+		utils.VerifySignature(sident.PublicKey, data, signature) {
+		return sident, nil
+	}
+
+	// Report why a past key is no longer accepted
+	now := time.Now().UnixNano()
+	for _, k := range sident.History {
+		if utils.VerifySignature(k.PublicKey, data, signature) {
+			if err = sident.CheckKey(k.PublicKey, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(sident.Signature) == 0 {
+		return nil, ErrIdentityNotConfirmed
+	}
+	return nil, ErrSignatureInvalid
+}
+
+// VerifyRequest verifies a request signed at the unix nanosecond timestamp
+// by the identity
+func (idt *Identity) VerifyRequest(id string, ts int64, data, signature []byte) (*thrapb.Identity, error) {
+	if err := checkSkew(ts, time.Now()); err != nil {
+		return nil, err
+	}
+	return idt.Verify(id, data, signature)
+}
+
+// checkSkew checks the unix nanosecond timestamp is within the allowed skew
+func checkSkew(ts int64, now time.Time) error {
+	d := now.Sub(time.Unix(0, ts))
+	if d > MaxSignatureSkew || d < -MaxSignatureSkew {
+		return ErrTimestampSkew
+	}
+	return nil
 }
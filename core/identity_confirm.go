@@ -0,0 +1,99 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/euforia/base58"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrConfirmCodeExpired is used when the confirmation code of a
+	// registration has expired or was never sent
+	ErrConfirmCodeExpired = errors.New("confirmation code expired. Register again to resend")
+	// ErrRateLimited is used when confirmation codes are requested too often
+	ErrRateLimited = errors.New("too many confirmation requests. Try again later")
+)
+
+const (
+	// confirmCodeTTL is how long a confirmation code is valid for
+	confirmCodeTTL = 30 * time.Minute
+	// confirmResendInterval is the minimum time between sending codes to an
+	// identity
+	confirmResendInterval = time.Minute
+	// confirmMaxSends is the maximum number of codes sent to an identity
+	// within confirmSendWindow
+	confirmMaxSends   = 5
+	confirmSendWindow = time.Hour
+)
+
+// limitSends returns the send times of an identity within the window with
+// now appended.  It returns ErrRateLimited if a code was requested too
+// recently or too many have been requested
+func limitSends(sent []int64, now time.Time) ([]int64, error) {
+	recent := make([]int64, 0, len(sent)+1)
+	for _, t := range sent {
+		if now.Sub(time.Unix(0, t)) < confirmSendWindow {
+			recent = append(recent, t)
+		}
+	}
+	n := len(recent)
+	if n >= confirmMaxSends || (n > 0 && now.Sub(time.Unix(0, recent[n-1])) < confirmResendInterval) {
+		return nil, ErrRateLimited
+	}
+
+	return append(recent, now.UnixNano()), nil
+}
+
+// confirmValid returns true if the confirmation code of the pending identity
+// has not expired
+func confirmValid(ident *thrapb.Identity, now time.Time) bool {
+	return ident.ConfirmExpires > 0 && now.Before(time.Unix(0, ident.ConfirmExpires))
+}
+
+// newNonce returns a random nonce for a registration.  The confirmation code
+// is derived from it so it must not be predictable
+func newNonce() (uint64, error) {
+	var nonce uint64
+	err := binary.Read(rand.Reader, binary.BigEndian, &nonce)
+	return nonce, err
+}
+
+// ConfirmCode returns the base58 encoded confirmation code of a pending
+// registration.  The registering key signs the decoded code to confirm it
+func ConfirmCode(ident *thrapb.Identity) string {
+	return string(base58.Encode(ident.SigHash(sha256.New())))
+}
+
+// sendCode mails the confirmation code to the identity
+func (idt *Identity) sendCode(ident *thrapb.Identity) error {
+	code := ConfirmCode(ident)
+	msg := &mailer.Message{
+		To:      ident.Email,
+		Subject: "thrap identity confirmation",
+		Body: fmt.Sprintf("Your confirmation code for %s is:\n\n    %s\n\n"+
+			"Confirm the registration within %v by running:\n\n"+
+			"    thrap identity register --email %s --confirm %s\n",
+			ident.ID, code, confirmCodeTTL, ident.Email, code),
+	}
+	return errors.Wrap(idt.mailer.Send(msg), "sending confirmation code")
+}
+
+// redact returns a copy of a pending identity without the nonce so the
+// confirmation code can only be obtained from the mail
+func redact(ident *thrapb.Identity) *thrapb.Identity {
+	if ident == nil || len(ident.Signature) > 0 {
+		return ident
+	}
+	c := *ident
+	c.Nonce = 0
+	c.ConfirmExpires = 0
+	c.ConfirmSent = nil
+	return &c
+}
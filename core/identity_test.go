@@ -0,0 +1,206 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/euforia/base58"
+	"github.com/euforia/thrap/mailer"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type memIdentityStorage map[string]*thrapb.Identity
+
+func (m memIdentityStorage) Get(id string) (*thrapb.Identity, error) {
+	if ident, ok := m[id]; ok {
+		return ident, nil
+	}
+	return nil, store.ErrIdentityNotFound
+}
+
+func (m memIdentityStorage) Create(ident *thrapb.Identity) (*thrapb.Identity, error) {
+	if _, ok := m[ident.ID]; ok {
+		return nil, store.ErrIdentityExists
+	}
+	m[ident.ID] = ident
+	return ident, nil
+}
+
+func (m memIdentityStorage) Update(ident *thrapb.Identity) (*thrapb.Identity, error) {
+	m[ident.ID] = ident
+	return ident, nil
+}
+
+func (m memIdentityStorage) Iter(string, func(*thrapb.Identity) error) error {
+	return nil
+}
+
+func Test_Identity_RotateRevoke(t *testing.T) {
+	kp1, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	kp2, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	idt := &Identity{store: make(memIdentityStorage), log: DefaultLogger(ioutil.Discard)}
+	ident := &thrapb.Identity{
+		ID:        "foo@bar.com",
+		Email:     "foo@bar.com",
+		PublicKey: utils.PublicKeyBytes(&kp1.PublicKey),
+		Signature: []byte("confirmed"),
+	}
+	idt.store.Create(ident)
+
+	data := sha256.New().Sum([]byte("request"))
+	sig, _ := utils.SignData(kp1, data)
+	_, err := idt.Verify(ident.ID, data, sig)
+	assert.Nil(t, err)
+
+	// Rotation signed by the wrong key
+	rot := &thrapb.KeyRotation{
+		ID:        ident.ID,
+		PublicKey: utils.PublicKeyBytes(&kp2.PublicKey),
+		Timestamp: time.Now().UnixNano(),
+	}
+	rot.Signature, _ = utils.SignData(kp2, rot.SigHash(sha256.New()))
+	_, err = idt.Rotate(rot)
+	assert.Equal(t, ErrSignatureInvalid, err)
+
+	rot.Signature, _ = utils.SignData(kp1, rot.SigHash(sha256.New()))
+	rotated, err := idt.Rotate(rot)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rotated.History))
+
+	_, err = idt.Verify(ident.ID, data, sig)
+	assert.Equal(t, thrapb.ErrKeyExpired, err)
+
+	// Revoke the past key with the current one
+	rev := &thrapb.KeyRevocation{
+		ID:        ident.ID,
+		PublicKey: utils.PublicKeyBytes(&kp1.PublicKey),
+		Reason:    "compromised",
+		Timestamp: time.Now().UnixNano(),
+		Signer:    utils.PublicKeyBytes(&kp2.PublicKey),
+	}
+	rev.Signature, _ = utils.SignData(kp2, rev.SigHash(sha256.New()))
+	_, err = idt.Revoke(rev)
+	assert.Nil(t, err)
+
+	_, err = idt.Verify(ident.ID, data, sig)
+	assert.Equal(t, thrapb.ErrKeyRevoked, err)
+
+	// Stale revocation
+	rev.Timestamp = time.Now().Add(-time.Hour).UnixNano()
+	_, err = idt.Revoke(rev)
+	assert.Equal(t, ErrTimestampSkew, err)
+}
+
+type testMailer []*mailer.Message
+
+func (m *testMailer) Send(msg *mailer.Message) error {
+	*m = append(*m, msg)
+	return nil
+}
+
+func Test_Identity_RegisterConfirm(t *testing.T) {
+	kp, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	mails := new(testMailer)
+	idt := &Identity{
+		store:  make(memIdentityStorage),
+		log:    DefaultLogger(ioutil.Discard),
+		mailer: mails,
+	}
+
+	newIdent := func() *thrapb.Identity {
+		ident := thrapb.NewIdentity("foo@bar.com")
+		ident.PublicKey = utils.PublicKeyBytes(&kp.PublicKey)
+		return ident
+	}
+	ident := newIdent()
+	resp, _, err := idt.Register(ident)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), resp.Nonce)
+	assert.Equal(t, 1, len(*mails))
+
+	sident, _ := idt.store.Get(ident.ID)
+	assert.NotEqual(t, uint64(0), sident.Nonce)
+	assert.Equal(t, 1, len(sident.ConfirmSent))
+	code := ConfirmCode(sident)
+	assert.Contains(t, (*mails)[0].Body, code)
+	assert.Equal(t, "foo@bar.com", (*mails)[0].To)
+
+	// Resend too soon
+	_, _, err = idt.Register(newIdent())
+	assert.Equal(t, ErrRateLimited, err)
+
+	sig, _ := utils.SignData(kp, base58.Decode([]byte(code)))
+	confirm := &thrapb.Identity{ID: ident.ID, PublicKey: ident.PublicKey, Signature: sig}
+
+	// Confirmation state is kept with the identity
+	expires := sident.ConfirmExpires
+	sident.ConfirmExpires = time.Now().Add(-time.Second).UnixNano()
+	idt.store.Update(sident)
+	_, err = idt.Confirm(confirm)
+	assert.Equal(t, ErrConfirmCodeExpired, err)
+
+	sident.ConfirmExpires = expires
+	idt.store.Update(sident)
+	_, err = idt.Confirm(confirm)
+	assert.Nil(t, err)
+
+	sident, _ = idt.store.Get(ident.ID)
+	assert.Equal(t, int64(0), sident.ConfirmExpires)
+	assert.Nil(t, sident.ConfirmSent)
+
+	_, _, err = idt.Register(newIdent())
+	assert.Equal(t, ErrIdentityAlreadyRegistered, err)
+}
+
+func Test_Identity_ReregisterRevoked(t *testing.T) {
+	kp1, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	kp2, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	mails := new(testMailer)
+	idt := &Identity{
+		store:  make(memIdentityStorage),
+		log:    DefaultLogger(ioutil.Discard),
+		mailer: mails,
+	}
+
+	ident := &thrapb.Identity{
+		ID:        "foo@bar.com",
+		Email:     "foo@bar.com",
+		PublicKey: utils.PublicKeyBytes(&kp1.PublicKey),
+		Signature: []byte("confirmed"),
+	}
+	idt.store.Create(ident)
+	ident.RetireKey(time.Now().UnixNano(), "compromised")
+	idt.store.Update(ident)
+
+	// Another email cannot claim the revoked id
+	claim := thrapb.NewIdentity("evil@bar.com")
+	claim.ID = ident.ID
+	claim.PublicKey = utils.PublicKeyBytes(&kp2.PublicKey)
+	_, _, err := idt.Register(claim)
+	assert.NotNil(t, err)
+
+	claim.Email = ident.Email
+	idt.store.(memIdentityStorage)[ident.ID].Email = "other@bar.com"
+	_, _, err = idt.Register(claim)
+	assert.Equal(t, ErrIdentityAlreadyRegistered, err)
+	assert.Equal(t, 0, len(*mails))
+
+	// The owner registers a new key
+	idt.store.(memIdentityStorage)[ident.ID].Email = ident.Email
+	_, _, err = idt.Register(claim)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(*mails))
+	assert.Equal(t, ident.Email, (*mails)[0].To)
+
+	sident, _ := idt.store.Get(ident.ID)
+	assert.Equal(t, 1, len(sident.History))
+}
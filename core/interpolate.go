@@ -0,0 +1,202 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/hashicorp/hil"
+	"github.com/hashicorp/hil/ast"
+)
+
+// interpFuncs are the functions available when interpolating component
+// definitions.  Definitions may come from the repo being built so functions
+// can only read files within the workdir and prefixed env vars
+func interpFuncs(workdir string) map[string]ast.Function {
+	return map[string]ast.Function{
+		// env(name) returns the environment variable or an empty string.
+		// Only variables with the interpolation prefix can be read
+		"env": {
+			ArgTypes:   []ast.Type{ast.TypeString},
+			ReturnType: ast.TypeString,
+			Callback: func(args []interface{}) (interface{}, error) {
+				name := args[0].(string)
+				if !strings.HasPrefix(name, consts.EnvVarInterpPrefix) {
+					return nil, fmt.Errorf("env: %s must start with %s", name, consts.EnvVarInterpPrefix)
+				}
+				return os.Getenv(name), nil
+			},
+		},
+		// default(value, fallback) returns fallback if value is empty
+		"default": {
+			ArgTypes:   []ast.Type{ast.TypeString, ast.TypeString},
+			ReturnType: ast.TypeString,
+			Callback: func(args []interface{}) (interface{}, error) {
+				if v := args[0].(string); v != "" {
+					return v, nil
+				}
+				return args[1], nil
+			},
+		},
+		// file(path) returns the contents of the file relative to the
+		// workdir
+		"file": {
+			ArgTypes:   []ast.Type{ast.TypeString},
+			ReturnType: ast.TypeString,
+			Callback: func(args []interface{}) (interface{}, error) {
+				fpath, err := workdirPath(workdir, args[0].(string))
+				if err != nil {
+					return nil, err
+				}
+				b, err := ioutil.ReadFile(fpath)
+				return string(b), err
+			},
+		},
+		"base64encode": {
+			ArgTypes:   []ast.Type{ast.TypeString},
+			ReturnType: ast.TypeString,
+			Callback: func(args []interface{}) (interface{}, error) {
+				return base64.StdEncoding.EncodeToString([]byte(args[0].(string))), nil
+			},
+		},
+		"base64decode": {
+			ArgTypes:   []ast.Type{ast.TypeString},
+			ReturnType: ast.TypeString,
+			Callback: func(args []interface{}) (interface{}, error) {
+				b, err := base64.StdEncoding.DecodeString(args[0].(string))
+				return string(b), err
+			},
+		},
+	}
+}
+
+// workdirPath returns the path relative to the workdir.  Absolute paths and
+// paths leaving the workdir, including through symlinks, are rejected
+func workdirPath(workdir, fpath string) (string, error) {
+	if filepath.IsAbs(fpath) {
+		return "", fmt.Errorf("file: path must be relative to the stack: %s", fpath)
+	}
+
+	root, err := filepath.Abs(workdir)
+	if err != nil {
+		return "", err
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return "", err
+	}
+	full, err := filepath.EvalSymlinks(filepath.Join(root, fpath))
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, full)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file: path outside the stack: %s", fpath)
+	}
+	return full, nil
+}
+
+// compInterpolator evaluates variables and functions in all string fields of
+// a component
+type compInterpolator struct {
+	scope *ast.BasicScope
+}
+
+// newCompInterpolator returns an interpolator reading files relative to the
+// workdir
+func newCompInterpolator(vars scope.Variables, workdir string) *compInterpolator {
+	return &compInterpolator{
+		scope: &ast.BasicScope{
+			VarMap:  map[string]ast.Variable(vars),
+			FuncMap: interpFuncs(workdir),
+		},
+	}
+}
+
+// eval evaluates a single value.  Values without interpolations are returned
+// as is.  Undefined variables and non-primitive results are errors
+func (ci *compInterpolator) eval(field, val string) (string, error) {
+	if !strings.Contains(val, "${") {
+		return val, nil
+	}
+
+	tree, err := hil.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", field, err)
+	}
+
+	result, err := hil.Eval(tree, &hil.EvalConfig{GlobalScope: ci.scope})
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", field, err)
+	}
+
+	switch result.Type {
+	case hil.TypeString:
+		return result.Value.(string), nil
+	case hil.TypeBool:
+		return strconv.FormatBool(result.Value.(bool)), nil
+	}
+
+	return "", fmt.Errorf("%s: must evaluate to a string: %s", field, val)
+}
+
+// Interpolate evaluates the component in place.  The version, build,
+// command, args, env, config, secrets, volumes and health check paths are
+// evaluated.  The first error is returned prefixed with the field
+func (ci *compInterpolator) Interpolate(comp *thrapb.Component) error {
+	var (
+		prefix = "component " + comp.ID + " "
+		err    error
+	)
+	eval := func(field string, val *string) {
+		if err == nil {
+			*val, err = ci.eval(prefix+field, *val)
+		}
+	}
+
+	eval("version", &comp.Version)
+	eval("cmd", &comp.Cmd)
+	for i := range comp.Args {
+		eval(fmt.Sprintf("args[%d]", i), &comp.Args[i])
+	}
+
+	if comp.Build != nil {
+		eval("build.context", &comp.Build.Context)
+		eval("build.dockerfile", &comp.Build.Dockerfile)
+	}
+
+	if comp.HasEnvVars() {
+		for k, v := range comp.Env.Vars {
+			eval("env.vars."+k, &v)
+			comp.Env.Vars[k] = v
+		}
+	}
+
+	for k, v := range comp.Config {
+		eval("config."+k, &v)
+		comp.Config[k] = v
+	}
+
+	if comp.Secrets != nil {
+		eval("secrets.destination", &comp.Secrets.Destination)
+		eval("secrets.template", &comp.Secrets.Template)
+	}
+
+	for i, vol := range comp.Volumes {
+		eval(fmt.Sprintf("volumes[%d].source", i), &vol.Source)
+		eval(fmt.Sprintf("volumes[%d].target", i), &vol.Target)
+	}
+
+	for i, hc := range comp.HealthChecks {
+		eval(fmt.Sprintf("healthchecks[%d].path", i), &hc.Path)
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/hashicorp/hil/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compInterpolator(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "interp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workdir)
+	fatal(t, ioutil.WriteFile(filepath.Join(workdir, "conf.txt"), []byte("from-file"), 0644))
+
+	os.Setenv("THRAP_VAR_TEST_INTERP", "from-env")
+	defer os.Unsetenv("THRAP_VAR_TEST_INTERP")
+
+	vars := scope.Variables{
+		"comp.db.container.addr.default": ast.Variable{Type: ast.TypeString, Value: "db.foo:5432"},
+		"comp.db.container.port.default": ast.Variable{Type: ast.TypeInt, Value: 5432},
+		"stack.version":                  ast.Variable{Type: ast.TypeString, Value: "1.2.3"},
+	}
+
+	comp := &thrapb.Component{
+		ID:      "api",
+		Version: "${stack.version}",
+		Cmd:     "/bin/${default(\"\", \"api\")}",
+		Args:    []string{"--db=${comp.db.container.addr.default}", "--port", "${comp.db.container.port.default}"},
+		Build:   &thrapb.Build{Context: ".", Dockerfile: "api-${stack.version}.dockerfile"},
+		Env:     &thrapb.Envionment{Vars: map[string]string{"FROM_ENV": "${env(\"THRAP_VAR_TEST_INTERP\")}"}},
+		Config:  map[string]string{"file": "${file(\"conf.txt\")}"},
+		Secrets: &thrapb.Secrets{Destination: "secrets", Template: "${base64decode(\"" + base64.StdEncoding.EncodeToString([]byte("tmpl")) + "\")}"},
+		Volumes: []*thrapb.Volume{{Source: "data-${stack.version}", Target: "/data"}},
+		HealthChecks: []*thrapb.HealthCheck{
+			{Path: "/v${stack.version}/health"},
+		},
+	}
+
+	err = newCompInterpolator(vars, workdir).Interpolate(comp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "1.2.3", comp.Version)
+	assert.Equal(t, "/bin/api", comp.Cmd)
+	assert.Equal(t, []string{"--db=db.foo:5432", "--port", "5432"}, comp.Args)
+	assert.Equal(t, "api-1.2.3.dockerfile", comp.Build.Dockerfile)
+	assert.Equal(t, "from-env", comp.Env.Vars["FROM_ENV"])
+	assert.Equal(t, "from-file", comp.Config["file"])
+	assert.Equal(t, "tmpl", comp.Secrets.Template)
+	assert.Equal(t, "data-1.2.3", comp.Volumes[0].Source)
+	assert.Equal(t, "/v1.2.3/health", comp.HealthChecks[0].Path)
+
+	comp = &thrapb.Component{ID: "api", Args: []string{"ok", "${comp.db.container.addr.defalt}"}}
+	err = newCompInterpolator(vars, workdir).Interpolate(comp)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "component api args[1]")
+		assert.Contains(t, err.Error(), "comp.db.container.addr.defalt")
+	}
+}
+
+func Test_compInterpolator_sandbox(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "interp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workdir)
+	fatal(t, os.Symlink("/etc", filepath.Join(workdir, "etc")))
+
+	os.Setenv("THRAP_TEST_SECRET", "secret")
+	defer os.Unsetenv("THRAP_TEST_SECRET")
+
+	ci := newCompInterpolator(scope.Variables{}, workdir)
+	for _, val := range []string{
+		"${file(\"/etc/hostname\")}",
+		"${file(\"../secret\")}",
+		"${file(\"etc/hostname\")}",
+		"${env(\"THRAP_TEST_SECRET\")}",
+	} {
+		_, err = ci.eval("config.x", val)
+		assert.NotNil(t, err, val)
+	}
+}
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/euforia/thrap/orchestrator"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/euforia/thrap/vcs"
+)
+
+const (
+	// previewInfix separates the stack id and branch slug in a preview id
+	previewInfix = "-preview-"
+	// maxRefSlugLen caps the branch portion of a preview id to keep
+	// container, job and image names within provider limits
+	maxRefSlugLen = 40
+	// length of the abbreviated commit hash used as the preview version
+	shortCommitLen = 8
+	// length of the branch hash suffix of a preview id
+	refHashLen = 8
+)
+
+var (
+	errRefRequired      = errors.New("ref required")
+	errRefNotCheckedOut = errors.New("ref must be checked out to build a preview")
+)
+
+var refSlugInvalidChars = regexp.MustCompile("[^a-z0-9]+")
+
+// PreviewOptions are options to bring up a preview environment
+type PreviewOptions struct {
+	// Branch the preview is for
+	Ref string
+	// Workdir is the root of the git repo containing the stack
+	Workdir string
+	// If true the preview is deployed as a dry run
+	Dryrun bool
+}
+
+// Preview is a deployed pull request preview environment
+type Preview struct {
+	// Isolated stack id derived from the stack and branch
+	ID string
+	// Branch the preview was deployed from
+	Ref string
+	// Commit the artifacts were built and tagged from
+	Commit string
+	// URL of the head component. Empty if the orchestrator cannot report
+	// addresses
+	URL string
+	// Deployed stack
+	Stack *thrapb.Stack
+}
+
+// RepoMatches returns true if the full repository name i.e. owner/name is
+// the vcs repository of the stack.  Both the name and owner must match.  The
+// owner is that of the vcs config falling back to the username.  If neither
+// is configured no repository matches
+func (st *Stack) RepoMatches(stack *thrapb.Stack, fullName string) bool {
+	owner, name := "", fullName
+	if i := strings.LastIndexByte(fullName, '/'); i >= 0 {
+		owner, name = fullName[:i], fullName[i+1:]
+	}
+	if !strings.EqualFold(name, stack.ID) {
+		return false
+	}
+
+	if st.vcs == nil || st.conf == nil {
+		return false
+	}
+	vc, ok := st.conf.VCS[st.vcs.ID()]
+	if !ok {
+		return false
+	}
+	want := vc.Username
+	if vc.Repo != nil && vc.Repo.Owner != "" {
+		want = vc.Repo.Owner
+	}
+	return want != "" && strings.EqualFold(owner, want)
+}
+
+// PreviewID returns the isolated stack id for a preview of the stack at the
+// given branch.  The branch slug is lossy so a short hash of the branch is
+// appended to keep ids of different branches apart
+func PreviewID(stackID, ref string) string {
+	slug := refSlugInvalidChars.ReplaceAllString(strings.ToLower(ref), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > maxRefSlugLen {
+		slug = strings.TrimRight(slug[:maxRefSlugLen], "-")
+	}
+
+	sum := sha256.Sum256([]byte(ref))
+	return stackID + previewInfix + slug + "-" + hex.EncodeToString(sum[:])[:refHashLen]
+}
+
+// newPreviewStack returns a copy of the stack namespaced to the branch with
+// all buildable components versioned by the commit.  The source stack is not
+// modified
+func newPreviewStack(stack *thrapb.Stack, ref, commit string) (*thrapb.Stack, error) {
+	b, err := stack.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	pstack := &thrapb.Stack{}
+	if err = pstack.Unmarshal(b); err != nil {
+		return nil, err
+	}
+
+	pstack.ID = PreviewID(stack.ID, ref)
+	pstack.Version = commit
+	for _, comp := range pstack.Components {
+		if comp.IsBuildable() {
+			comp.Version = commit
+		}
+	}
+
+	return pstack, nil
+}
+
+// PreviewUp builds the stack at the checked out branch with artifacts tagged
+// by commit and deploys it under an isolated stack id using the profile's
+// orchestrator
+func (st *Stack) PreviewUp(ctx context.Context, stack *thrapb.Stack, opt PreviewOptions) (*Preview, error) {
+	if opt.Ref == "" {
+		return nil, errRefRequired
+	}
+	if errs := stack.Validate(); len(errs) > 0 {
+		return nil, utils.FlattenErrors(errs)
+	}
+
+	commit, err := vcs.ResolveRef(opt.Workdir, opt.Ref)
+	if err != nil {
+		return nil, err
+	}
+	head, err := vcs.HeadCommit(opt.Workdir)
+	if err != nil {
+		return nil, err
+	}
+	if head != commit {
+		return nil, errRefNotCheckedOut
+	}
+
+	version := commit.String()[:shortCommitLen]
+	preview := &Preview{Ref: opt.Ref, Commit: commit.String()}
+
+	// Build and deploy evaluate variables in place so each gets its own copy
+	bstack, err := newPreviewStack(stack, opt.Ref, version)
+	if err != nil {
+		return nil, err
+	}
+	preview.ID = bstack.ID
+
+	if !opt.Dryrun {
+		err = st.Build(ctx, bstack, BuildOptions{Workdir: opt.Workdir, All: true})
+		if err != nil {
+			return preview, err
+		}
+	}
+
+	preview.Stack, err = newPreviewStack(stack, opt.Ref, version)
+	if err != nil {
+		return preview, err
+	}
+
+	err = st.deployFrom(opt.Workdir, preview.Stack, orchestrator.RequestOptions{Dryrun: opt.Dryrun})
+	if err == nil && !opt.Dryrun {
+		preview.URL = st.headURL(ctx, preview.Stack)
+	}
+
+	return preview, err
+}
+
+// PreviewDown destroys the preview environment of the stack for the branch
+func (st *Stack) PreviewDown(ctx context.Context, stack *thrapb.Stack, ref string) ([]*thrapb.ActionResult, error) {
+	if ref == "" {
+		return nil, errRefRequired
+	}
+	if errs := stack.Validate(); len(errs) > 0 {
+		return nil, utils.FlattenErrors(errs)
+	}
+
+	// Versions are not needed to destroy
+	pstack, err := newPreviewStack(stack, ref, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return st.Destroy(ctx, pstack), nil
+}
+
+// headURL returns the url of the first head component address.  It returns
+// an empty string if the orchestrator does not report addresses
+func (st *Stack) headURL(ctx context.Context, stack *thrapb.Stack) string {
+	addresser, ok := st.orch.(orchestrator.Addresser)
+	if !ok {
+		return ""
+	}
+
+	for _, comp := range stack.Components {
+		if !comp.Head {
+			continue
+		}
+
+		addrs, err := addresser.Addrs(ctx, stack, comp.ID)
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		return fmt.Sprintf("http://%s", addrs[0])
+	}
+
+	return ""
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/euforia/thrap/config"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/vcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PreviewID(t *testing.T) {
+	assert.True(t, strings.HasPrefix(PreviewID("app", "feature/Login"), "app-preview-feature-login-"))
+	assert.True(t, strings.HasPrefix(PreviewID("app", "--fix_1.2--"), "app-preview-fix-1-2-"))
+	assert.Equal(t, PreviewID("app", "feature/a"), PreviewID("app", "feature/a"))
+
+	id := PreviewID("app", "a-very-long-branch-name-that-goes-on-and-on-and-on")
+	assert.Equal(t, len("app-preview-")+40+1+refHashLen, len(id))
+
+	// Branches with the same slug get different ids
+	assert.NotEqual(t, PreviewID("app", "feature/a"), PreviewID("app", "feature-a"))
+	assert.NotEqual(t, PreviewID("app", "a-very-long-branch-name-that-goes-on-and-on-and-on"),
+		PreviewID("app", "a-very-long-branch-name-that-goes-on-and-on-and-off"))
+}
+
+func Test_newPreviewStack(t *testing.T) {
+	stack := &thrapb.Stack{
+		ID:      "app",
+		Version: "v1.0.0",
+		Components: map[string]*thrapb.Component{
+			"api": &thrapb.Component{ID: "api", Version: "v1.0.0", Build: &thrapb.Build{Dockerfile: "api.dockerfile"}},
+			"db":  &thrapb.Component{ID: "db", Name: "postgres", Version: "10"},
+		},
+	}
+
+	pstack, err := newPreviewStack(stack, "feature/x", "abcdef12")
+	fatal(t, err)
+	assert.Equal(t, PreviewID("app", "feature/x"), pstack.ID)
+	assert.Equal(t, "abcdef12", pstack.Components["api"].Version)
+	assert.Equal(t, "10", pstack.Components["db"].Version)
+
+	// Source is untouched
+	assert.Equal(t, "app", stack.ID)
+	assert.Equal(t, "v1.0.0", stack.Components["api"].Version)
+}
+
+func Test_Stack_RepoMatches(t *testing.T) {
+	stack := &thrapb.Stack{ID: "app"}
+	st := &Stack{
+		vcs: vcs.NewGitVCS(),
+		conf: &config.ThrapConfig{VCS: map[string]*config.VCSConfig{
+			"git": &config.VCSConfig{ID: "git", Username: "user", Repo: &config.VCSRepoConfig{Owner: "org"}},
+		}},
+	}
+	assert.True(t, st.RepoMatches(stack, "org/app"))
+	assert.True(t, st.RepoMatches(stack, "Org/App"))
+	assert.False(t, st.RepoMatches(stack, "user/app"))
+	assert.False(t, st.RepoMatches(stack, "org/other"))
+	assert.False(t, st.RepoMatches(stack, "other"))
+
+	// Without an owner the username is the owner
+	st.conf.VCS["git"].Repo = nil
+	assert.True(t, st.RepoMatches(stack, "user/app"))
+	assert.False(t, st.RepoMatches(stack, "org/app"))
+
+	// Nothing matches when no owner is configured
+	st.conf.VCS["git"].Username = ""
+	assert.False(t, st.RepoMatches(stack, "user/app"))
+	assert.False(t, st.RepoMatches(stack, "app"))
+	st.conf = nil
+	assert.False(t, st.RepoMatches(stack, "user/app"))
+}
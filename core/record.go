@@ -0,0 +1,256 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/vars"
+)
+
+var (
+	// ErrInvalidRecord is used when a build or deployment record is missing
+	// its id or stack
+	ErrInvalidRecord = errors.New("invalid record")
+)
+
+// RecordSink receives completed build and deployment records in addition to
+// the local store.  It is used to send records to a remote agent
+type RecordSink interface {
+	CreateBuild(context.Context, *thrapb.StackBuild) error
+	CreateDeployment(context.Context, *thrapb.Deployment) error
+}
+
+// newRecordID returns a record id based on the given time.  It is fixed
+// width so records sort chronologically within a stack
+func newRecordID(t time.Time) string {
+	return fmt.Sprintf("%016x", t.UnixNano())
+}
+
+// identity returns who is performing the current operation
+func (st *Stack) identity() string {
+	if st.vcs == nil {
+		return ""
+	}
+	return st.vcs.GlobalEmail()
+}
+
+func (st *Stack) profileID() string {
+	if st.prof == nil {
+		return ""
+	}
+	return st.prof.ID
+}
+
+func (st *Stack) newBuildRecord(stack *thrapb.Stack) *thrapb.StackBuild {
+	now := time.Now()
+	return &thrapb.StackBuild{
+		ID:       newRecordID(now),
+		Stack:    stack.ID,
+		Revision: stack.Version,
+		Identity: st.identity(),
+		Profile:  st.profileID(),
+		Start:    now.UnixNano(),
+	}
+}
+
+func (st *Stack) newDeploymentRecord(stack *thrapb.Stack) *thrapb.Deployment {
+	now := time.Now()
+	return &thrapb.Deployment{
+		ID:       newRecordID(now),
+		Stack:    stack.ID,
+		Revision: stack.Version,
+		Identity: st.identity(),
+		Profile:  st.profileID(),
+		Start:    now.UnixNano(),
+	}
+}
+
+// recordBuild completes the build record with the results and writes it to
+// the store.  Failing to record does not fail the build
+func (st *Stack) recordBuild(ctx context.Context, record *thrapb.StackBuild, stack *thrapb.Stack,
+	bldr *stackBuilder, pubResults map[string]error, opt BuildOptions, err error) {
+
+	record.End = time.Now().UnixNano()
+	record.Outcome = thrapb.Outcome_SUCCEEDED
+	if err != nil {
+		record.Error = err.Error()
+		record.Outcome = thrapb.Outcome_FAILED
+	}
+
+	if bldr != nil {
+		if !bldr.Succeeded() {
+			record.Outcome = thrapb.Outcome_FAILED
+		}
+
+		digests := st.artifactDigests(ctx, stack)
+		results := bldr.Results()
+		record.Components = make([]*thrapb.ComponentRecord, 0, len(results))
+
+		for id, result := range results {
+			comp := stack.Components[id]
+			crec := &thrapb.ComponentRecord{
+				ID:       id,
+				Version:  comp.Version,
+				Artifact: st.artifactName(stack, id),
+				Digest:   digests[id],
+				Start:    result.Runtime.StartTime().UnixNano(),
+				End:      result.Runtime.EndTime().UnixNano(),
+			}
+
+			if result.Error != nil {
+				crec.Error = result.Error.Error()
+			} else if perr, ok := pubResults[crec.Artifact+":"+crec.Version]; ok {
+				if perr != nil {
+					crec.Error = perr.Error()
+				} else {
+					crec.Published = true
+				}
+			}
+
+			if result.Log != nil && opt.Workdir != "" {
+				crec.Log, _ = writeBuildLog(opt.Workdir, record.ID, id, result.Log.Bytes())
+			}
+
+			record.Components = append(record.Components, crec)
+		}
+	}
+
+	if _, er := st.bst.Create(record); er != nil {
+		st.log.Println("Failed to record build:", er)
+	}
+	if st.rec != nil {
+		if er := st.rec.CreateBuild(ctx, record); er != nil {
+			st.log.Println("Failed to send build record:", er)
+		}
+	}
+}
+
+// recordDeployment completes the deployment record and writes it to the store.
+// Failing to record does not fail the deployment
+func (st *Stack) recordDeployment(ctx context.Context, record *thrapb.Deployment, stack *thrapb.Stack, err error) {
+	record.End = time.Now().UnixNano()
+	record.Outcome = thrapb.Outcome_SUCCEEDED
+	if err != nil {
+		record.Error = err.Error()
+		record.Outcome = thrapb.Outcome_FAILED
+	}
+
+	digests := st.artifactDigests(ctx, stack)
+	record.Components = make([]*thrapb.ComponentRecord, 0, len(stack.Components))
+	for id, comp := range stack.Components {
+		crec := &thrapb.ComponentRecord{
+			ID:      id,
+			Version: comp.Version,
+			Digest:  digests[id],
+		}
+
+		if comp.IsBuildable() {
+			crec.Artifact = st.artifactName(stack, id)
+		} else {
+			crec.Artifact = comp.Name
+		}
+
+		record.Components = append(record.Components, crec)
+	}
+
+	if _, er := st.dst.Create(record); er != nil {
+		st.log.Println("Failed to record deployment:", er)
+	}
+	if st.rec != nil {
+		if er := st.rec.CreateDeployment(ctx, record); er != nil {
+			st.log.Println("Failed to send deployment record:", er)
+		}
+	}
+}
+
+// CreateBuild writes a build record performed elsewhere, such as by a cli
+// reporting to the agent.  The stack must be registered
+func (st *Stack) CreateBuild(build *thrapb.StackBuild) (*thrapb.StackBuild, error) {
+	if err := st.checkRecord(build.ID, build.Stack); err != nil {
+		return nil, err
+	}
+	return st.bst.Create(build)
+}
+
+// CreateDeployment writes a deployment record performed elsewhere, such as
+// by a cli reporting to the agent.  The stack must be registered
+func (st *Stack) CreateDeployment(deploy *thrapb.Deployment) (*thrapb.Deployment, error) {
+	if err := st.checkRecord(deploy.ID, deploy.Stack); err != nil {
+		return nil, err
+	}
+	return st.dst.Create(deploy)
+}
+
+func (st *Stack) checkRecord(id, stackID string) error {
+	if id == "" {
+		return errors.Wrap(ErrInvalidRecord, "id required")
+	}
+	if stackID == "" {
+		return errors.Wrap(ErrInvalidRecord, "stack required")
+	}
+	// Ids are keyed under the stack
+	if strings.Contains(id, "/") {
+		return errors.Wrap(ErrInvalidRecord, "id contains '/'")
+	}
+	_, err := st.sst.Get(stackID)
+	return err
+}
+
+// artifactName returns the full artifact name for a component excluding
+// the tag
+func (st *Stack) artifactName(stack *thrapb.Stack, compID string) string {
+	name := stack.ArtifactName(compID)
+	if st.reg != nil {
+		name = st.reg.ImageName(name)
+	}
+	return name
+}
+
+// artifactDigests returns the digests of the local artifacts for the current
+// version of each buildable component in the stack
+func (st *Stack) artifactDigests(ctx context.Context, stack *thrapb.Stack) map[string]digest.Digest {
+	out := make(map[string]digest.Digest, len(stack.Components))
+
+	images, err := st.crt.ListImagesWithLabel(ctx, "stack="+stack.ID)
+	if err != nil {
+		return out
+	}
+
+	for _, img := range images {
+		id := img.Labels["component"]
+		comp, ok := stack.Components[id]
+		if !ok {
+			continue
+		}
+		if img.Labels[vars.ComponentVersion] == comp.Version {
+			out[id] = digest.Digest(img.ID)
+		}
+	}
+
+	return out
+}
+
+// writeBuildLog writes a component build log under the local working
+// directory returning the path it was written to
+func writeBuildLog(workdir, buildID, compID string, log []byte) (string, error) {
+	dir := filepath.Join(workdir, consts.WorkDir, consts.LogsDir, buildID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	fpath := filepath.Join(dir, compID+".log")
+	if err := ioutil.WriteFile(fpath, log, 0644); err != nil {
+		return "", err
+	}
+	return fpath, nil
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stack_CreateRecords(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "record-")
+	defer os.RemoveAll(tmpdir)
+
+	drv, err := store.OpenDriver(&store.DriverConfig{Name: "bolt", DataDir: tmpdir})
+	fatal(t, err)
+	defer drv.Close()
+
+	st := &Stack{
+		sst: store.NewStackStorage(drv),
+		bst: store.NewBuildStorage(drv),
+		dst: store.NewDeploymentStorage(drv),
+	}
+	_, err = st.sst.Create(&thrapb.Stack{ID: "web"})
+	fatal(t, err)
+	_, err = st.sst.Create(&thrapb.Stack{ID: "webapp"})
+	fatal(t, err)
+
+	_, err = st.CreateBuild(&thrapb.StackBuild{ID: "b1", Stack: "web", Outcome: thrapb.Outcome_SUCCEEDED})
+	assert.Nil(t, err)
+	_, err = st.CreateBuild(&thrapb.StackBuild{ID: "b2", Stack: "webapp"})
+	assert.Nil(t, err)
+	_, err = st.CreateDeployment(&thrapb.Deployment{ID: "d1", Stack: "web", Profile: "dev"})
+	assert.Nil(t, err)
+
+	_, err = st.CreateBuild(&thrapb.StackBuild{Stack: "web"})
+	assert.Equal(t, ErrInvalidRecord, errors.Cause(err))
+	_, err = st.CreateBuild(&thrapb.StackBuild{ID: "x/y", Stack: "web"})
+	assert.Equal(t, ErrInvalidRecord, errors.Cause(err))
+	_, err = st.CreateDeployment(&thrapb.Deployment{ID: "d2"})
+	assert.Equal(t, ErrInvalidRecord, errors.Cause(err))
+	_, err = st.CreateDeployment(&thrapb.Deployment{ID: "d2", Stack: "missing"})
+	assert.Equal(t, store.ErrStackNotFound, err)
+
+	var builds []*thrapb.StackBuild
+	err = st.Builds("web/", func(build *thrapb.StackBuild) error {
+		builds = append(builds, build)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(builds))
+	assert.Equal(t, "b1", builds[0].ID)
+	assert.Equal(t, thrapb.Outcome_SUCCEEDED, builds[0].Outcome)
+
+	var deploys []*thrapb.Deployment
+	err = st.Deployments("web/", func(deploy *thrapb.Deployment) error {
+		deploys = append(deploys, deploy)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(deploys))
+	assert.Equal(t, "dev", deploys[0].Profile)
+}
@@ -0,0 +1,280 @@
+package core
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrOIDCNotConfigured is used when single sign-on is not enabled
+	ErrOIDCNotConfigured = errors.New("oidc not configured")
+	// ErrEmailNotVerified is used when the provider has not verified the
+	// user email
+	ErrEmailNotVerified = errors.New("email not verified by the identity provider")
+	// ErrGroupNotAllowed is used when the user is not in an allowed group
+	ErrGroupNotAllowed = errors.New("not a member of an allowed group")
+	// ErrSessionInvalid is used when a session token fails verification
+	ErrSessionInvalid = errors.New("invalid session token")
+	// ErrSessionExpired is used when a session token has expired
+	ErrSessionExpired = errors.New("session expired")
+	// ErrOIDCUserMismatch is used when the identity of the email is linked
+	// to another provider user
+	ErrOIDCUserMismatch = errors.New("identity linked to another oidc user")
+)
+
+// DefaultSessionTTL is the lifetime of session tokens.  Group membership
+// changes at the identity provider apply once it expires
+const DefaultSessionTTL = time.Hour
+
+// Meta keys set from the identity provider on login.  Identities created by
+// a login are marked so they cannot be claimed by registering a key
+const (
+	MetaOIDCIssuer  = "oidc.issuer"
+	MetaOIDCSubject = "oidc.subject"
+	MetaOIDCEmail   = "oidc.email"
+	MetaOIDCGroups  = "oidc.groups"
+	MetaOIDCCreated = "oidc.created"
+
+	metaOIDCPrefix = "oidc."
+)
+
+// sessionKeyPath is the storage key of the secret session tokens are signed
+// with.  It is shared by all agents using the same storage
+const sessionKeyPath = "/session-key"
+
+// sessionClaims is the signed payload of a session token
+type sessionClaims struct {
+	ID      string
+	Groups  []string `json:",omitempty"`
+	Expires int64
+}
+
+// Sessions logs in identity provider users issuing session tokens signed by
+// a secret shared by all agents
+type Sessions struct {
+	idp   *oidc.Provider
+	key   *sessionKey
+	ttl   time.Duration
+	store IdentityStorage
+	log   *log.Logger
+}
+
+// sessionKey loads the session signing secret from the storage creating it
+// on first use
+type sessionKey struct {
+	drv store.Driver
+
+	mu  sync.Mutex
+	key []byte
+}
+
+func (sk *sessionKey) get() ([]byte, error) {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+
+	if sk.key != nil {
+		return sk.key, nil
+	}
+
+	key, err := sk.drv.Get(sessionKeyPath)
+	if err == store.ErrKeyNotFound {
+		key = make([]byte, 32)
+		if _, err = rand.Read(key); err != nil {
+			return nil, err
+		}
+		// Another agent may have created it first
+		if err = sk.drv.Create(sessionKeyPath, key); err == store.ErrKeyExists {
+			key, err = sk.drv.Get(sessionKeyPath)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sk.key = key
+	return key, nil
+}
+
+// OIDCConfig returns the provider config clients need to login
+func (sess *Sessions) OIDCConfig() (*thrapb.OIDCConfig, error) {
+	if sess.idp == nil {
+		return nil, ErrOIDCNotConfigured
+	}
+	conf := sess.idp.Config()
+	return &thrapb.OIDCConfig{
+		Issuer:   conf.Issuer,
+		ClientID: conf.ClientID,
+		Scopes:   conf.Scopes,
+	}, nil
+}
+
+// Login exchanges a provider access token for a session.  The id token must
+// be issued to the agent client for the user of the access token.  The
+// verified email and groups of the user are mapped onto the identity with
+// the email as its id, creating one without a key if needed.  Once mapped the
+// identity only accepts the same issuer and subject
+func (sess *Sessions) Login(ctx context.Context, accessToken, idToken string) (*thrapb.Session, error) {
+	if sess.idp == nil {
+		return nil, ErrOIDCNotConfigured
+	}
+
+	tok, err := sess.idp.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	ui, err := sess.idp.UserInfo(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if ui.Subject != tok.Subject {
+		return nil, oidc.ErrIDTokenInvalid
+	}
+	if ui.Email == "" || !ui.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	if !sess.idp.Allowed(ui) {
+		return nil, errors.Wrap(ErrGroupNotAllowed, ui.Email)
+	}
+
+	ident, err := sess.mapIdentity(tok.Issuer, ui)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &sessionClaims{
+		ID:      ident.ID,
+		Groups:  ui.Groups,
+		Expires: time.Now().Add(sess.ttl).UnixNano(),
+	}
+	token, err := sess.sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.log.Printf("User logged in user=%s groups=%s", ident.ID, strings.Join(ui.Groups, ","))
+
+	return &thrapb.Session{
+		Token:    token,
+		Expires:  claims.Expires,
+		Identity: redact(ident),
+		Groups:   ui.Groups,
+	}, nil
+}
+
+// mapIdentity sets the provider claims on the identity of the user.  An
+// existing identity must have the same email and be unlinked or linked to
+// the same provider user
+func (sess *Sessions) mapIdentity(issuer string, ui *oidc.UserInfo) (*thrapb.Identity, error) {
+	ident, err := sess.store.Get(ui.Email)
+	switch {
+	case err == store.ErrIdentityNotFound:
+		ident = &thrapb.Identity{
+			ID:    ui.Email,
+			Email: ui.Email,
+			Meta:  map[string]string{MetaOIDCCreated: "true"},
+		}
+	case err != nil:
+		return nil, err
+	case ident.Email != ui.Email, !oidcLinked(ident, issuer, ui.Subject):
+		return nil, errors.Wrap(ErrOIDCUserMismatch, ui.Email)
+	}
+
+	if ident.Meta == nil {
+		ident.Meta = make(map[string]string)
+	}
+	ident.Meta[MetaOIDCIssuer] = issuer
+	ident.Meta[MetaOIDCSubject] = ui.Subject
+	ident.Meta[MetaOIDCEmail] = ui.Email
+	ident.Meta[MetaOIDCGroups] = strings.Join(ui.Groups, ",")
+
+	if err == store.ErrIdentityNotFound {
+		return sess.store.Create(ident)
+	}
+	return sess.store.Update(ident)
+}
+
+// oidcLinked returns true if the identity is not linked to a provider user
+// or is linked to the given one
+func oidcLinked(ident *thrapb.Identity, issuer, subject string) bool {
+	if ident.Meta[MetaOIDCIssuer] == "" && ident.Meta[MetaOIDCSubject] == "" {
+		return true
+	}
+	return ident.Meta[MetaOIDCIssuer] == issuer && ident.Meta[MetaOIDCSubject] == subject
+}
+
+// Verify verifies a session token returning its identity and groups
+func (sess *Sessions) Verify(token string) (*thrapb.Identity, []string, error) {
+	i := strings.IndexByte(token, '.')
+	if sess.idp == nil || i < 0 {
+		return nil, nil, ErrSessionInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(token[:i])
+	if err != nil {
+		return nil, nil, ErrSessionInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return nil, nil, ErrSessionInvalid
+	}
+
+	key, err := sess.key.get()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal(sig, signPayload(key, payload)) {
+		return nil, nil, ErrSessionInvalid
+	}
+
+	var claims sessionClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, nil, ErrSessionInvalid
+	}
+	if time.Now().UnixNano() >= claims.Expires {
+		return nil, nil, ErrSessionExpired
+	}
+	// Apply changes to the allowed groups to existing sessions
+	if !sess.idp.Allowed(&oidc.UserInfo{Groups: claims.Groups}) {
+		return nil, nil, ErrGroupNotAllowed
+	}
+
+	ident, err := sess.store.Get(claims.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return redact(ident), claims.Groups, nil
+}
+
+func (sess *Sessions) sign(claims *sessionClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := sess.key.get()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signPayload(key, payload)), nil
+}
+
+// signPayload returns the hmac of the token payload
+func signPayload(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
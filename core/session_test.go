@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockOIDC returns a local provider serving the metadata, the key set of
+// the id token key and userinfo for the access tokens
+func newMockOIDC(kp *ecdsa.PrivateKey, users map[string]map[string]interface{}) *httptest.Server {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidc.DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidc.Discovery{
+			Issuer:           srv.URL,
+			UserinfoEndpoint: srv.URL + "/userinfo",
+			JWKSURI:          srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(kp.X.Bytes()),
+			"y":   base64.RawURLEncoding.EncodeToString(kp.Y.Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := users[r.Header.Get("Authorization")]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(user)
+	})
+	srv = httptest.NewServer(mux)
+	return srv
+}
+
+// newIDToken returns an id token for the subject issued to the thrap client
+func newIDToken(kp *ecdsa.PrivateKey, issuer, sub string) string {
+	hdr, _ := json.Marshal(map[string]string{"alg": "ES256"})
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss": issuer,
+		"sub": sub,
+		"aud": "thrap",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	signed := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	h := sha256.Sum256([]byte(signed))
+	r, s, _ := ecdsa.Sign(rand.Reader, kp, h[:])
+	sig := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):], rb)
+	copy(sig[64-len(sb):], sb)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func Test_Sessions(t *testing.T) {
+	kp, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	srv := newMockOIDC(kp, map[string]map[string]interface{}{
+		"Bearer eng": {"sub": "1", "email": "eng@bar.com", "email_verified": true, "groups": []string{"eng"}},
+		"Bearer ops": {"sub": "2", "email": "ops@bar.com", "email_verified": true, "groups": []string{"ops"}},
+		"Bearer unv": {"sub": "3", "email": "unv@bar.com", "groups": []string{"eng"}},
+	})
+	defer srv.Close()
+
+	tmpdir, _ := ioutil.TempDir("/tmp", "session-")
+	defer os.RemoveAll(tmpdir)
+	drv, err := store.OpenDriver(&store.DriverConfig{Name: store.DriverBolt, DataDir: tmpdir})
+	fatal(t, err)
+	defer drv.Close()
+
+	idp, _ := oidc.NewProvider(&oidc.Config{Issuer: srv.URL, ClientID: "thrap", AllowedGroups: []string{"eng"}})
+	sess := &Sessions{
+		idp:   idp,
+		key:   &sessionKey{drv: drv},
+		ttl:   time.Minute,
+		store: make(memIdentityStorage),
+		log:   DefaultLogger(ioutil.Discard),
+	}
+	ctx := context.Background()
+
+	s, err := sess.Login(ctx, "eng", newIDToken(kp, srv.URL, "1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "eng@bar.com", s.Identity.ID)
+	assert.Equal(t, "eng", s.Identity.Meta[MetaOIDCGroups])
+
+	ident, groups, err := sess.Verify(s.Token)
+	assert.Nil(t, err)
+	assert.Equal(t, "eng@bar.com", ident.ID)
+	assert.Equal(t, []string{"eng"}, groups)
+
+	_, _, err = sess.Verify(s.Token + "x")
+	assert.Equal(t, ErrSessionInvalid, err)
+
+	// Tokens of other agents sharing the storage are valid
+	other := &Sessions{idp: idp, key: &sessionKey{drv: drv}, store: sess.store}
+	_, _, err = other.Verify(s.Token)
+	assert.Nil(t, err)
+
+	// Identities created by a login cannot be claimed with a key
+	kp2, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	idt := &Identity{store: sess.store, log: DefaultLogger(ioutil.Discard), mailer: new(testMailer)}
+	claim := thrapb.NewIdentity("eng@bar.com")
+	claim.PublicKey = utils.PublicKeyBytes(&kp2.PublicKey)
+	claim.Meta = map[string]string{MetaOIDCSubject: "9"}
+	_, _, err = idt.Register(claim)
+	assert.Equal(t, ErrIdentityAlreadyRegistered, err)
+	sident, _ := sess.store.Get("eng@bar.com")
+	assert.Nil(t, sident.PublicKey)
+	assert.Equal(t, srv.URL, sident.Meta[MetaOIDCIssuer])
+
+	// Identities linked to another provider user are not mapped
+	sident.Meta[MetaOIDCSubject] = "9"
+	_, err = sess.Login(ctx, "eng", newIDToken(kp, srv.URL, "1"))
+	assert.Equal(t, ErrOIDCUserMismatch, errors.Cause(err))
+	sident.Meta[MetaOIDCSubject] = "1"
+
+	// The id token must be of the same user
+	_, err = sess.Login(ctx, "eng", newIDToken(kp, srv.URL, "2"))
+	assert.Equal(t, oidc.ErrIDTokenInvalid, err)
+	_, err = sess.Login(ctx, "eng", "")
+	assert.Equal(t, oidc.ErrIDTokenInvalid, err)
+
+	_, err = sess.Login(ctx, "ops", newIDToken(kp, srv.URL, "2"))
+	assert.NotNil(t, err)
+	_, err = sess.Login(ctx, "unv", newIDToken(kp, srv.URL, "3"))
+	assert.Equal(t, ErrEmailNotVerified, err)
+
+	// Sessions of groups no longer allowed are rejected
+	idp.Config().AllowedGroups = []string{"ops"}
+	_, _, err = sess.Verify(s.Token)
+	assert.Equal(t, ErrGroupNotAllowed, err)
+	idp.Config().AllowedGroups = []string{"eng"}
+
+	sess.ttl = -time.Second
+	s, _ = sess.Login(ctx, "eng", newIDToken(kp, srv.URL, "1"))
+	_, _, err = sess.Verify(s.Token)
+	assert.Equal(t, ErrSessionExpired, err)
+}
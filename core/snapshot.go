@@ -0,0 +1,67 @@
+package core
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/euforia/thrap/snapshot"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+)
+
+// Snapshot writes a snapshot of all agent state to w.  It is encrypted if a
+// passphrase is given.  The state includes the session signing key
+func (core *Core) Snapshot(w io.Writer, passphrase []byte) (*thrapb.SnapshotHeader, error) {
+	hdr := &thrapb.SnapshotHeader{
+		Format:  store.SnapshotFormat(core.drv),
+		Created: time.Now().UnixNano(),
+	}
+
+	sw, err := snapshot.NewWriter(w, hdr, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err = store.Backup(core.drv, sw); err != nil {
+		return nil, err
+	}
+
+	return hdr, sw.Close()
+}
+
+// Restore replaces the agent state with a snapshot.  The snapshot is
+// verified in full before anything is removed or loaded.  Cluster members
+// are not part of the state and are kept
+func (core *Core) Restore(r io.Reader, passphrase []byte) (*thrapb.SnapshotHeader, error) {
+	sr, err := snapshot.NewReader(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spool the verified data in the data directory
+	tmp, err := ioutil.TempFile(core.datadir, "restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	if _, err = io.Copy(tmp, sr); err != nil {
+		return nil, err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	hdr := sr.Header
+	core.log.Printf("Restoring snapshot format=%s created=%s", hdr.Format,
+		time.Unix(0, hdr.Created).UTC().Format(time.RFC3339))
+
+	if err = store.Clear(core.drv); err != nil {
+		return nil, err
+	}
+	return hdr, store.Load(core.drv, hdr.Format, tmp)
+}
@@ -7,15 +7,12 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"text/tabwriter"
 
-	"github.com/hashicorp/hil"
-	"github.com/hashicorp/hil/ast"
 	"github.com/pkg/errors"
 
-	"github.com/euforia/pseudo"
 	"github.com/euforia/pseudo/scope"
-	"github.com/euforia/thrap/consts"
 	"github.com/euforia/thrap/crt"
 	"github.com/euforia/thrap/metrics"
 	"github.com/euforia/thrap/orchestrator"
@@ -72,13 +69,27 @@ type Stack struct {
 	// stack store
 	sst StackStorage
 
+	// build and deployment record stores
+	bst BuildStorage
+	dst DeploymentStorage
+	// remote destination of build and deployment records.  Nil if records
+	// are only stored locally
+	rec RecordSink
+
+	// profile the instance was loaded with
+	prof *thrapb.Profile
+
 	log *log.Logger
 }
 
 // Assembler returns a new assembler for the stack
 func (st *Stack) Assembler(cwd string, stack *thrapb.Stack) (*asm.StackAsm, error) {
 	scopeVars := st.conf.VCS[st.vcs.ID()].ScopeVars("vcs.")
-	return asm.NewStackAsm(stack, cwd, st.vcs, nil, scopeVars, st.packs)
+	stasm, err := asm.NewStackAsm(stack, cwd, st.vcs, nil, scopeVars, st.packs)
+	if err == nil {
+		stasm.SetTagLister(registry.NewHubTagLister(""))
+	}
+	return stasm, err
 }
 
 // Register registers a new stack. It returns an error if the stack is
@@ -118,12 +129,38 @@ func (st *Stack) Commit(stack *thrapb.Stack) (*thrapb.Stack, error) {
 		return nil, utils.FlattenErrors(errs)
 	}
 
+	// The owner is only set on registration
+	cur, err := st.sst.Get(stack.ID)
+	if err != nil {
+		return nil, err
+	}
+	stack.Owner = cur.Owner
+
 	return st.sst.Update(stack)
 }
 
 // Init initializes a basic stack with the configuration and options provided. This should only be
 // used in the local cli case as the config is merged with the global.
 func (st *Stack) Init(stconf *asm.BasicStackConfig, opt ConfigureOptions) (*thrapb.Stack, error) {
+	return st.init(opt, func(scope.Variables) (*thrapb.Stack, map[string][]byte, error) {
+		stack, err := asm.NewBasicStack(stconf, st.packs)
+		return stack, nil, err
+	})
+}
+
+// InitTemplate initializes a stack from a stack pack with the configuration
+// and options provided.  The stack manifest and files of the pack are
+// rendered into the project.  Like Init it should only be used in the local
+// cli case
+func (st *Stack) InitTemplate(tconf *asm.TemplateStackConfig, opt ConfigureOptions) (*thrapb.Stack, error) {
+	return st.init(opt, func(svars scope.Variables) (*thrapb.Stack, map[string][]byte, error) {
+		return asm.NewTemplateStack(tconf, st.packs, svars)
+	})
+}
+
+// init configures the project and vcs, then assembles and materializes the
+// stack returned by the make function
+func (st *Stack) init(opt ConfigureOptions, makeStack func(scope.Variables) (*thrapb.Stack, map[string][]byte, error)) (*thrapb.Stack, error) {
 
 	_, err := ConfigureLocal(st.conf, opt)
 	if err != nil {
@@ -136,7 +173,8 @@ func (st *Stack) Init(stconf *asm.BasicStackConfig, opt ConfigureOptions) (*thra
 		return nil, err
 	}
 
-	stack, err := asm.NewBasicStack(stconf, st.packs)
+	scopeVars := st.conf.VCS[st.vcs.ID()].ScopeVars("vcs.")
+	stack, files, err := makeStack(scopeVars)
 	if err != nil {
 		return nil, err
 	}
@@ -146,11 +184,12 @@ func (st *Stack) Init(stconf *asm.BasicStackConfig, opt ConfigureOptions) (*thra
 
 	st.populateFromImageConf(stack)
 
-	scopeVars := st.conf.VCS[st.vcs.ID()].ScopeVars("vcs.")
 	stasm, err := asm.NewStackAsm(stack, opt.DataDir, vcsp, gitRepo, scopeVars, st.packs)
 	if err != nil {
 		return stack, err
 	}
+	stasm.SetTagLister(registry.NewHubTagLister(""))
+	stasm.AddFiles(files)
 
 	err = stasm.AssembleMaterialize()
 	if err == nil {
@@ -160,27 +199,13 @@ func (st *Stack) Init(stconf *asm.BasicStackConfig, opt ConfigureOptions) (*thra
 	return stack, err
 }
 
+// scopeVars returns the stack variables along with the service discovery
+// variables of the orchestrator
 func (st *Stack) scopeVars(stack *thrapb.Stack) scope.Variables {
 	svars := stack.ScopeVars()
-	for k, v := range stack.Components {
-
-		ipvar := ast.Variable{
-			Type:  ast.TypeString,
-			Value: k + "." + stack.ID,
-		}
-
-		// Set container ip var
-		svars[v.ScopeVarName(consts.CompVarPrefixKey+".", "container.ip")] = ipvar
-		// Set container.addr var per port label
-		for pl, p := range v.Ports {
-			svars[v.ScopeVarName(consts.CompVarPrefixKey+".", "container.addr."+pl)] = ast.Variable{
-				Type:  ast.TypeString,
-				Value: fmt.Sprintf("%s:%d", ipvar.Value, p),
-			}
-		}
-
+	for k, v := range orchestrator.DiscoveryVars(st.orch, stack) {
+		svars[k] = v
 	}
-
 	return svars
 }
 
@@ -239,6 +264,18 @@ func (st *Stack) Iter(prefix string, f func(*thrapb.Stack) error) error {
 	return st.sst.Iter(prefix, f)
 }
 
+// Builds iterates over each build record in the store. The prefix is
+// matched against the stack id
+func (st *Stack) Builds(prefix string, f func(*thrapb.StackBuild) error) error {
+	return st.bst.Iter(prefix, f)
+}
+
+// Deployments iterates over each deployment record in the store. The prefix
+// is matched against the stack id
+func (st *Stack) Deployments(prefix string, f func(*thrapb.Deployment) error) error {
+	return st.dst.Iter(prefix, f)
+}
+
 // Build starts all require services, then starts all the builds
 func (st *Stack) Build(ctx context.Context, stack *thrapb.Stack, opt BuildOptions) error {
 	if errs := stack.Validate(); len(errs) > 0 {
@@ -246,22 +283,33 @@ func (st *Stack) Build(ctx context.Context, stack *thrapb.Stack, opt BuildOption
 	}
 
 	var (
-		totalTime = (&metrics.Runtime{}).Start()
-		pubTime   = &metrics.Runtime{}
-		scopeVars = st.scopeVars(stack)
-		err       error
+		totalTime  = (&metrics.Runtime{}).Start()
+		pubTime    = &metrics.Runtime{}
+		scopeVars  = st.scopeVars(stack)
+		record     = st.newBuildRecord(stack)
+		bldr       *stackBuilder
+		pubResults map[string]error
+		err        error
 	)
 
+	// Record the build regardless of the outcome
+	defer func() {
+		st.recordBuild(ctx, record, stack, bldr, pubResults, opt, err)
+	}()
+
 	printScopeVars(scopeVars)
 
 	// Eval variables
 	for _, comp := range stack.Components {
-		if err = st.evalComponent(comp, scopeVars); err != nil {
+		if err = st.evalComponent(comp, scopeVars, opt.Workdir); err != nil {
 			return err
 		}
 	}
 
-	bldr := newStackBuilder(st.crt, st.reg, stack)
+	bldr = newStackBuilder(st.crt, st.reg, stack)
+	if stack.ComponentVersioning() && !opt.All {
+		bldr.skip = st.unchangedComponents(ctx, stack, opt.Workdir)
+	}
 	err = bldr.Build(ctx)
 	if err != nil {
 		return err
@@ -276,7 +324,6 @@ func (st *Stack) Build(ctx context.Context, stack *thrapb.Stack, opt BuildOption
 
 	var (
 		bldResults = bldr.Results()
-		pubResults map[string]error
 		canPublish bool
 	)
 
@@ -323,8 +370,15 @@ func (st *Stack) Build(ctx context.Context, stack *thrapb.Stack, opt BuildOption
 	return err
 }
 
-// Deploy deploys all components of the stack.
+// Deploy deploys all components of the stack.  Files referenced by the
+// components are read relative to the current directory
 func (st *Stack) Deploy(stack *thrapb.Stack, opts orchestrator.RequestOptions) error {
+	return st.deployFrom(".", stack, opts)
+}
+
+// deployFrom deploys all components of the stack reading files referenced by
+// the components relative to the workdir
+func (st *Stack) deployFrom(workdir string, stack *thrapb.Stack, opts orchestrator.RequestOptions) error {
 	if errs := stack.Validate(); len(errs) > 0 {
 		return utils.FlattenErrors(errs)
 	}
@@ -332,22 +386,34 @@ func (st *Stack) Deploy(stack *thrapb.Stack, opts orchestrator.RequestOptions) e
 	// Evaluate variables
 	svars := st.scopeVars(stack)
 	for _, comp := range stack.Components {
-		if err := st.evalComponent(comp, svars); err != nil {
+		if err := st.evalComponent(comp, svars, workdir); err != nil {
 			return err
 		}
 	}
 
 	printScopeVarsWithVals(svars)
 
+	var (
+		ctx    = context.Background()
+		record = st.newDeploymentRecord(stack)
+	)
+
 	// TODO: check artifact existence
 	fmt.Printf("\nArtifacts:\n\n")
 	err := st.checkArtifactsExist(stack)
-	if err != nil {
-		return err
+	if err == nil {
+		err = st.deploy(ctx, stack, opts)
 	}
 
-	ctx := context.Background()
+	// Dry runs do not change anything so they are not recorded
+	if !opts.Dryrun {
+		st.recordDeployment(ctx, record, stack, err)
+	}
+
+	return err
+}
 
+func (st *Stack) deploy(ctx context.Context, stack *thrapb.Stack, opts orchestrator.RequestOptions) error {
 	_, j, err := st.orch.Deploy(ctx, stack, opts)
 	if err != nil {
 		st.orch.Destroy(ctx, stack)
@@ -428,6 +494,53 @@ func (st *Stack) Stop(ctx context.Context, stack *thrapb.Stack) []*thrapb.Action
 	return ar
 }
 
+// unchangedComponents returns buildable components whose artifact exists for
+// the current version and that have no uncommitted changes in their build
+// context.  Components are versioned by the commits touching their context
+// so an existing artifact means nothing has changed
+func (st *Stack) unchangedComponents(ctx context.Context, stack *thrapb.Stack, workdir string) map[string]bool {
+	return unchangedComponents(stack, workdir, func(name string) bool {
+		return st.crt.HaveImage(ctx, name)
+	})
+}
+
+// unchangedComponents returns the unchanged buildable components of the
+// stack using haveImage to check for existing artifacts
+func unchangedComponents(stack *thrapb.Stack, workdir string, haveImage func(string) bool) map[string]bool {
+	out := make(map[string]bool, len(stack.Components))
+
+	root, err := vcs.FindRepoRoot(workdir)
+	if err != nil {
+		return out
+	}
+
+	for id, comp := range stack.Components {
+		if !comp.IsBuildable() {
+			continue
+		}
+
+		if !haveImage(stack.ArtifactName(id) + ":" + comp.Version) {
+			continue
+		}
+
+		ctxDir, err := filepath.Abs(comp.Build.Context)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(root, ctxDir)
+		if err != nil {
+			continue
+		}
+
+		changed, err := vcs.PathHasChanges(root, rel)
+		if err == nil && !changed {
+			out[id] = true
+		}
+	}
+
+	return out
+}
+
 // returns true if we can publish
 func (st *Stack) checkWorktree(opt BuildOptions) (bool, error) {
 	status, err := st.vcs.Status(vcs.Option{Path: opt.Workdir})
@@ -468,33 +581,8 @@ func (st *Stack) printArtifacts(stack *thrapb.Stack, printBase bool) {
 	}
 }
 
-func (st *Stack) evalComponent(comp *thrapb.Component, scopeVars scope.Variables) error {
-
-	var (
-		vm  = pseudo.NewVM()
-		err error
-	)
-
-	if comp.HasEnvVars() {
-		err = st.evalCompEnv(comp, vm, scopeVars)
-	}
-
-	// TODO: In the future, eval other parts of the component
-
-	return err
-}
-
-func (st *Stack) evalCompEnv(comp *thrapb.Component, vm *pseudo.VM, scopeVars scope.Variables) error {
-	for k, v := range comp.Env.Vars {
-		result, err := vm.ParseEval(v, scopeVars)
-		if err != nil {
-			return err
-		}
-		if result.Type != hil.TypeString {
-			return fmt.Errorf("env value must be string key=%s value=%s", k, v)
-		}
-		comp.Env.Vars[k] = result.Value.(string)
-	}
-
-	return nil
+// evalComponent evaluates all variables and functions in the component
+// definition.  Files are read relative to the workdir
+func (st *Stack) evalComponent(comp *thrapb.Component, scopeVars scope.Variables, workdir string) error {
+	return newCompInterpolator(scopeVars, workdir).Interpolate(comp)
 }
@@ -94,6 +94,7 @@ func (st *Stack) createVcsRepo(stack *thrapb.Stack) *thrapb.ActionResult {
 
 	var vcsOpt vcs.Option
 	_, created, err := st.vcs.Create(repo, vcsOpt)
+	er.Error = err
 	if err == nil {
 		if created {
 			er.Data = "created"
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/vcs"
+	"github.com/stretchr/testify/assert"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func Test_unchangedComponents(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "uc-")
+	defer os.RemoveAll(tmpdir)
+
+	_, repo, err := vcs.SetupLocalGitRepo("test", "me", tmpdir, "foo.com")
+	fatal(t, err)
+	wt, _ := repo.Worktree()
+	for _, fname := range []string{"api/main.go", "web/index.js"} {
+		fpath := filepath.Join(tmpdir, fname)
+		os.MkdirAll(filepath.Dir(fpath), 0755)
+		ioutil.WriteFile(fpath, []byte(fname), 0644)
+		wt.Add(fname)
+	}
+	_, err = wt.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "thrap", Email: "thrap", When: time.Now()},
+	})
+	fatal(t, err)
+
+	build := func(dir string) *thrapb.Build {
+		return &thrapb.Build{Context: filepath.Join(tmpdir, dir), Dockerfile: "Dockerfile"}
+	}
+	stack := &thrapb.Stack{ID: "app", Components: map[string]*thrapb.Component{
+		"api":    &thrapb.Component{ID: "api", Version: "v1.0.0", Build: build("api")},
+		"web":    &thrapb.Component{ID: "web", Version: "v1.0.0", Build: build("web")},
+		"worker": &thrapb.Component{ID: "worker", Version: "v1.0.0", Build: build("api")},
+		"db":     &thrapb.Component{ID: "db", Version: "10"},
+	}}
+	images := map[string]bool{"app/api:v1.0.0": true, "app/web:v1.0.0": true, "db:10": true}
+	haveImage := func(name string) bool { return images[name] }
+
+	// Components without an artifact and non-buildable ones are not skipped
+	skip := unchangedComponents(stack, tmpdir, haveImage)
+	assert.Equal(t, map[string]bool{"api": true, "web": true}, skip)
+
+	// Uncommitted changes in the build context are rebuilt
+	ioutil.WriteFile(filepath.Join(tmpdir, "web", "index.js"), []byte("changed"), 0644)
+	skip = unchangedComponents(stack, tmpdir, haveImage)
+	assert.Equal(t, map[string]bool{"api": true}, skip)
+
+	// Nothing is skipped outside a repo
+	other, _ := ioutil.TempDir("/tmp", "uc-")
+	defer os.RemoveAll(other)
+	assert.Equal(t, 0, len(unchangedComponents(stack, other, haveImage)))
+}
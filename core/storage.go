@@ -18,3 +18,23 @@ type IdentityStorage interface {
 	Update(*thrapb.Identity) (*thrapb.Identity, error)
 	Iter(string, func(*thrapb.Identity) error) error
 }
+
+// BuildStorage is a build record storage interface
+type BuildStorage interface {
+	Create(*thrapb.StackBuild) (*thrapb.StackBuild, error)
+	Iter(string, func(*thrapb.StackBuild) error) error
+}
+
+// DeploymentStorage is a deployment record storage interface
+type DeploymentStorage interface {
+	Create(*thrapb.Deployment) (*thrapb.Deployment, error)
+	Iter(string, func(*thrapb.Deployment) error) error
+}
+
+// AuditStorage is an append-only audit log storage interface
+type AuditStorage interface {
+	// Append sets the sequence number and hashes of the entry and writes it
+	Append(*thrapb.AuditEntry) (*thrapb.AuditEntry, error)
+	// Iter iterates over entries in order from the sequence number
+	Iter(uint64, func(*thrapb.AuditEntry) error) error
+}
@@ -83,7 +83,11 @@ func printBuildResults(stack *thrapb.Stack, results map[string]*CompBuildResult,
 			art    string
 		)
 
-		if r.Error == nil {
+		if r.Skipped {
+			status = "skipped"
+			art = stack.ArtifactName(k) + ":" + stack.Components[k].Version
+			msg = "no changes"
+		} else if r.Error == nil {
 			status = "succeeded"
 			art = stack.ArtifactName(k) + ":" + stack.Components[k].Version
 		} else {
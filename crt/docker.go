@@ -93,6 +93,11 @@ func (orch *Docker) CreateNetwork(ctx context.Context, netID string) error {
 	return err
 }
 
+// RemoveNetwork removes the network by the given id
+func (orch *Docker) RemoveNetwork(ctx context.Context, netID string) error {
+	return orch.cli.NetworkRemove(ctx, netID)
+}
+
 // ListImagesWithLabel returns a list of images that match the given label
 func (orch *Docker) ListImagesWithLabel(ctx context.Context, label string) ([]types.ImageSummary, error) {
 	args := filters.NewArgs(filters.Arg("label", label))
@@ -0,0 +1,282 @@
+package thrap
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DashboardPath is the path prefix the web dashboard is served on
+	DashboardPath = "/ui/"
+	// DashboardSessionCookie is the cookie a session token may be provided
+	// in when the agent requires authentication
+	DashboardSessionCookie = "thrap-session"
+
+	// Number of builds and deployments shown per stack
+	dashboardHistory = 20
+)
+
+// Dashboard is a read-only web dashboard of the stacks registered with the
+// agent, their components, builds and deployments.  Like the gateway it
+// calls the agent over grpc with the credentials of the browser
+type Dashboard struct {
+	// AllowInsecure accepts session tokens over plain http e.g. when tls is
+	// terminated by a proxy in front of the agent
+	AllowInsecure bool
+
+	client thrapb.ThrapClient
+	tmpl   *template.Template
+	log    *log.Logger
+}
+
+// NewDashboard returns a dashboard reading from the agent through the client
+func NewDashboard(client thrapb.ThrapClient, logger *log.Logger) *Dashboard {
+	return &Dashboard{
+		client: client,
+		tmpl:   template.Must(template.New("dashboard").Funcs(dashboardFuncs).Parse(dashboardTemplates)),
+		log:    logger,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	md := gatewayMetadata(r)
+	if c, err := r.Cookie(DashboardSessionCookie); err == nil && len(md[MetaSession]) == 0 {
+		md.Set(MetaSession, c.Value)
+	}
+	if err := checkSessionTransport(r, md, d.AllowInsecure); err != nil {
+		d.render(w, http.StatusUnauthorized, "error", err.Error())
+		return
+	}
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+	var (
+		name    string
+		data    interface{}
+		err     error
+		path    = strings.Trim(strings.TrimPrefix(r.URL.Path, DashboardPath), "/")
+		stackID = strings.TrimPrefix(path, "stacks/")
+	)
+	switch {
+	case path == "":
+		name = "stacks"
+		data, err = d.stacksPage(ctx)
+	case stackID != path && stackID != "" && !strings.Contains(stackID, "/"):
+		name = "stack"
+		data, err = d.stackPage(ctx, stackID)
+	default:
+		d.render(w, http.StatusNotFound, "error", "page not found")
+		return
+	}
+
+	if err != nil {
+		st, _ := status.FromError(err)
+		code := httpStatus(st.Code())
+		if code == http.StatusInternalServerError {
+			d.log.Printf("Dashboard request failed path=%s error='%v'", r.URL.Path, err)
+		}
+		d.render(w, code, "error", st.Message())
+		return
+	}
+	d.render(w, http.StatusOK, name, data)
+}
+
+// render executes the template to a buffer so failures do not send partial
+// pages
+func (d *Dashboard) render(w http.ResponseWriter, code int, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := d.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		d.log.Printf("Dashboard template failed name=%s error='%v'", name, err)
+		http.Error(w, "template error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(buf.Bytes())
+}
+
+// stackSummary is a stack along with its latest build and the latest
+// successful deployment to each profile
+type stackSummary struct {
+	Stack     *thrapb.Stack
+	LastBuild *thrapb.StackBuild
+	Deployed  map[string]*thrapb.Deployment
+}
+
+type stacksPage struct {
+	Stacks   []*stackSummary
+	Profiles []string
+}
+
+func (d *Dashboard) stacksPage(ctx context.Context) (*stacksPage, error) {
+	stacks, err := collectStacks(ctx, d.client, &thrapb.IterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	builds, err := collectBuilds(ctx, d.client, &thrapb.IterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	deploys, err := collectDeployments(ctx, d.client, &thrapb.IterOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &stacksPage{Stacks: make([]*stackSummary, len(stacks))}
+	index := make(map[string]*stackSummary, len(stacks))
+	for i, stack := range stacks {
+		page.Stacks[i] = &stackSummary{Stack: stack, Deployed: map[string]*thrapb.Deployment{}}
+		index[stack.ID] = page.Stacks[i]
+	}
+
+	for _, build := range builds {
+		if sum, ok := index[build.Stack]; ok {
+			if sum.LastBuild == nil || build.Start > sum.LastBuild.Start {
+				sum.LastBuild = build
+			}
+		}
+	}
+	page.Profiles = latestDeployments(deploys, func(deploy *thrapb.Deployment) map[string]*thrapb.Deployment {
+		if sum, ok := index[deploy.Stack]; ok {
+			return sum.Deployed
+		}
+		return nil
+	})
+
+	return page, nil
+}
+
+type stackPage struct {
+	Stack *thrapb.Stack
+	// Identity that registered the stack.  Nil if unknown
+	Owner       *thrapb.Identity
+	Builds      []*thrapb.StackBuild
+	Deployments []*thrapb.Deployment
+	Deployed    map[string]*thrapb.Deployment
+	Profiles    []string
+	Graph       *depGraph
+}
+
+func (d *Dashboard) stackPage(ctx context.Context, id string) (*stackPage, error) {
+	stack, err := d.client.GetStack(ctx, &thrapb.Stack{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	builds, err := collectBuilds(ctx, d.client, &thrapb.IterOptions{Prefix: id + "/"})
+	if err != nil {
+		return nil, err
+	}
+	deploys, err := collectDeployments(ctx, d.client, &thrapb.IterOptions{Prefix: id + "/"})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &stackPage{
+		Stack:    stack,
+		Owner:    d.stackOwner(ctx, stack),
+		Deployed: map[string]*thrapb.Deployment{},
+		Graph:    newDepGraph(stack),
+	}
+	page.Profiles = latestDeployments(deploys, func(*thrapb.Deployment) map[string]*thrapb.Deployment {
+		return page.Deployed
+	})
+
+	sort.Slice(builds, func(i, j int) bool { return builds[i].Start > builds[j].Start })
+	if len(builds) > dashboardHistory {
+		builds = builds[:dashboardHistory]
+	}
+	page.Builds = builds
+
+	sort.Slice(deploys, func(i, j int) bool { return deploys[i].Start > deploys[j].Start })
+	if len(deploys) > dashboardHistory {
+		deploys = deploys[:dashboardHistory]
+	}
+	page.Deployments = deploys
+
+	return page, nil
+}
+
+// stackOwner returns the identity that registered the stack.  It returns nil
+// if the stack has no owner.  Only the id is set if the identity no longer
+// exists
+func (d *Dashboard) stackOwner(ctx context.Context, stack *thrapb.Stack) *thrapb.Identity {
+	if stack.Owner == "" {
+		return nil
+	}
+
+	ident, err := d.client.GetIdentity(ctx, &thrapb.Identity{ID: stack.Owner})
+	if err != nil {
+		return &thrapb.Identity{ID: stack.Owner}
+	}
+	return ident
+}
+
+// latestDeployments adds the latest successful deployment per profile to the
+// map returned by f, returning the sorted profiles deployed to
+func latestDeployments(deploys []*thrapb.Deployment, f func(*thrapb.Deployment) map[string]*thrapb.Deployment) []string {
+	profiles := map[string]bool{}
+	for _, deploy := range deploys {
+		if deploy.Outcome != thrapb.Outcome_SUCCEEDED {
+			continue
+		}
+		deployed := f(deploy)
+		if deployed == nil {
+			continue
+		}
+		profiles[deploy.Profile] = true
+		if last, ok := deployed[deploy.Profile]; !ok || deploy.Start > last.Start {
+			deployed[deploy.Profile] = deploy
+		}
+	}
+
+	out := make([]string, 0, len(profiles))
+	for prof := range profiles {
+		out = append(out, prof)
+	}
+	sort.Strings(out)
+	return out
+}
+
+var dashboardFuncs = template.FuncMap{
+	"time": func(ns int64) string {
+		if ns == 0 {
+			return "-"
+		}
+		return time.Unix(0, ns).UTC().Format("2006-01-02 15:04:05")
+	},
+	"duration": func(start, end int64) string {
+		if start == 0 || end == 0 {
+			return "-"
+		}
+		return time.Duration(end - start).Round(time.Second).String()
+	},
+	"tag": func(rec *thrapb.ComponentRecord) string {
+		if rec.Artifact == "" {
+			return rec.ID + ":" + rec.Version
+		}
+		return rec.Artifact + ":" + rec.Version
+	},
+	"lower": func(v interface{}) string {
+		if s, ok := v.(interface {
+			String() string
+		}); ok {
+			return strings.ToLower(s.String())
+		}
+		return ""
+	},
+}
@@ -0,0 +1,138 @@
+package thrap
+
+import (
+	"sort"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+// Dependency graph layout in pixels
+const (
+	graphNodeWidth  = 160
+	graphNodeHeight = 36
+	graphColGap     = 60
+	graphRowGap     = 16
+	graphMargin     = 10
+)
+
+// graphNode is a positioned component or dependency box
+type graphNode struct {
+	ID    string
+	Label string
+	// True for stack dependencies as opposed to components
+	Dependency bool
+	External   bool
+	Head       bool
+	X, Y       int
+	level      int
+}
+
+// graphEdge is a line from the left edge of a component to the right edge
+// of a component or dependency it depends on
+type graphEdge struct {
+	X1, Y1, X2, Y2 int
+}
+
+// depGraph is the component dependency graph of a stack laid out left to
+// right.  Components are placed one column right of the right most
+// component or dependency they depend on
+type depGraph struct {
+	Width, Height         int
+	NodeWidth, NodeHeight int
+	Nodes                 []*graphNode
+	Edges                 []*graphEdge
+}
+
+// newDepGraph returns the laid out dependency graph of the stack manifest
+func newDepGraph(stack *thrapb.Stack) *depGraph {
+	nodes := make(map[string]*graphNode, len(stack.Components)+len(stack.Dependencies))
+	for id, dep := range stack.Dependencies {
+		nodes[id] = &graphNode{ID: id, Label: graphLabel(id, dep), Dependency: true, External: dep.External}
+	}
+	for id, comp := range stack.Components {
+		nodes[id] = &graphNode{ID: id, Label: graphLabel(id, comp), External: comp.External, Head: comp.Head}
+	}
+
+	// Assign levels by the longest dependency chain.  Cycles are broken at
+	// the node visited twice
+	const visiting = -1
+	levels := make(map[string]int, len(nodes))
+	var level func(id string) int
+	level = func(id string) int {
+		if l, ok := levels[id]; ok {
+			if l == visiting {
+				return 0
+			}
+			return l
+		}
+		levels[id] = visiting
+		l := 0
+		if comp, ok := stack.Components[id]; ok {
+			for _, dep := range comp.DependsOn {
+				if _, ok := nodes[dep]; ok {
+					if dl := level(dep) + 1; dl > l {
+						l = dl
+					}
+				}
+			}
+		}
+		levels[id] = l
+		return l
+	}
+
+	graph := &depGraph{
+		NodeWidth:  graphNodeWidth,
+		NodeHeight: graphNodeHeight,
+		Nodes:      make([]*graphNode, 0, len(nodes)),
+	}
+	for id, node := range nodes {
+		node.level = level(id)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if a.level != b.level {
+			return a.level < b.level
+		}
+		return a.ID < b.ID
+	})
+
+	rows := map[int]int{}
+	for _, node := range graph.Nodes {
+		node.X = graphMargin + node.level*(graphNodeWidth+graphColGap)
+		node.Y = graphMargin + rows[node.level]*(graphNodeHeight+graphRowGap)
+		rows[node.level]++
+
+		if w := node.X + graphNodeWidth + graphMargin; w > graph.Width {
+			graph.Width = w
+		}
+		if h := node.Y + graphNodeHeight + graphMargin; h > graph.Height {
+			graph.Height = h
+		}
+	}
+
+	for id, comp := range stack.Components {
+		from := nodes[id]
+		for _, dep := range comp.DependsOn {
+			to, ok := nodes[dep]
+			if !ok {
+				continue
+			}
+			graph.Edges = append(graph.Edges, &graphEdge{
+				X1: from.X,
+				Y1: from.Y + graphNodeHeight/2,
+				X2: to.X + graphNodeWidth,
+				Y2: to.Y + graphNodeHeight/2,
+			})
+		}
+	}
+
+	return graph
+}
+
+func graphLabel(id string, comp *thrapb.Component) string {
+	if comp.Version == "" {
+		return id
+	}
+	return id + ":" + comp.Version
+}
@@ -0,0 +1,161 @@
+package thrap
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testBuildStream struct {
+	grpc.ClientStream
+	builds []*thrapb.StackBuild
+}
+
+func (s *testBuildStream) Recv() (*thrapb.StackBuild, error) {
+	if len(s.builds) == 0 {
+		return nil, io.EOF
+	}
+	build := s.builds[0]
+	s.builds = s.builds[1:]
+	return build, nil
+}
+
+type testDeployStream struct {
+	grpc.ClientStream
+	deploys []*thrapb.Deployment
+}
+
+func (s *testDeployStream) Recv() (*thrapb.Deployment, error) {
+	if len(s.deploys) == 0 {
+		return nil, io.EOF
+	}
+	deploy := s.deploys[0]
+	s.deploys = s.deploys[1:]
+	return deploy, nil
+}
+
+func (c *testThrapClient) IterBuilds(ctx context.Context, in *thrapb.IterOptions, opts ...grpc.CallOption) (thrapb.Thrap_IterBuildsClient, error) {
+	stream := &testBuildStream{}
+	for _, build := range c.builds {
+		if strings.HasPrefix(build.Stack+"/"+build.ID, in.Prefix) {
+			stream.builds = append(stream.builds, build)
+		}
+	}
+	return stream, nil
+}
+
+func (c *testThrapClient) IterDeployments(ctx context.Context, in *thrapb.IterOptions, opts ...grpc.CallOption) (thrapb.Thrap_IterDeploymentsClient, error) {
+	stream := &testDeployStream{}
+	for _, deploy := range c.deploys {
+		if strings.HasPrefix(deploy.Stack+"/"+deploy.ID, in.Prefix) {
+			stream.deploys = append(stream.deploys, deploy)
+		}
+	}
+	return stream, nil
+}
+
+func (c *testThrapClient) GetIdentity(ctx context.Context, in *thrapb.Identity, opts ...grpc.CallOption) (*thrapb.Identity, error) {
+	if ident, ok := c.idents[in.ID]; ok {
+		return ident, nil
+	}
+	return nil, status.Error(codes.NotFound, "identity not found")
+}
+
+func newTestDashboard() *Dashboard {
+	client := &testThrapClient{
+		stacks: map[string]*thrapb.Stack{
+			"web": &thrapb.Stack{
+				ID:      "web",
+				Version: "0.2.0",
+				Owner:   "foo",
+				Components: map[string]*thrapb.Component{
+					"api": &thrapb.Component{ID: "api", DependsOn: []string{"db"}},
+				},
+				Dependencies: map[string]*thrapb.Component{
+					"db": &thrapb.Component{ID: "db", Version: "10"},
+				},
+			},
+		},
+		builds: []*thrapb.StackBuild{
+			{ID: "b1", Stack: "web", Revision: "0.2.0", Start: 1, Outcome: thrapb.Outcome_SUCCEEDED,
+				Components: []*thrapb.ComponentRecord{{ID: "api", Version: "0.2.0", Artifact: "registry/web/api"}}},
+		},
+		deploys: []*thrapb.Deployment{
+			{ID: "d1", Stack: "web", Revision: "0.1.0", Profile: "staging", Start: 1, Outcome: thrapb.Outcome_SUCCEEDED},
+			{ID: "d2", Stack: "web", Revision: "0.2.0", Profile: "staging", Start: 2, Outcome: thrapb.Outcome_FAILED},
+		},
+		idents: map[string]*thrapb.Identity{
+			"foo": &thrapb.Identity{ID: "foo", Email: "foo@bar.com"},
+		},
+	}
+	return NewDashboard(client, log.New(ioutil.Discard, "", 0))
+}
+
+func Test_Dashboard(t *testing.T) {
+	dash := newTestDashboard()
+
+	w := httptest.NewRecorder()
+	dash.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ui/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `href="/ui/stacks/web"`)
+	assert.Contains(t, body, "<th>staging</th>")
+	// The failed deployment is not what is running
+	assert.Contains(t, body, "0.1.0")
+
+	w = httptest.NewRecorder()
+	dash.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ui/stacks/web", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	body = w.Body.String()
+	assert.Contains(t, body, "foo@bar.com")
+	assert.Contains(t, body, "registry/web/api:0.2.0")
+	assert.Contains(t, body, "<svg")
+	assert.Contains(t, body, "db:10")
+
+	w = httptest.NewRecorder()
+	dash.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ui/stacks/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	dash.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ui/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func Test_newDepGraph(t *testing.T) {
+	stack := &thrapb.Stack{
+		Components: map[string]*thrapb.Component{
+			"ui":  &thrapb.Component{DependsOn: []string{"api"}},
+			"api": &thrapb.Component{DependsOn: []string{"db", "missing"}},
+			// Cycles must not recurse forever
+			"a": &thrapb.Component{DependsOn: []string{"b"}},
+			"b": &thrapb.Component{DependsOn: []string{"a"}},
+		},
+		Dependencies: map[string]*thrapb.Component{
+			"db": &thrapb.Component{},
+		},
+	}
+
+	graph := newDepGraph(stack)
+	assert.Equal(t, 5, len(graph.Nodes))
+	assert.Equal(t, 4, len(graph.Edges))
+
+	levels := map[string]int{}
+	for _, node := range graph.Nodes {
+		levels[node.ID] = node.level
+	}
+	assert.Equal(t, 0, levels["db"])
+	assert.Equal(t, 1, levels["api"])
+	assert.Equal(t, 2, levels["ui"])
+	assert.Equal(t, graph.Nodes[len(graph.Nodes)-1].X+graphNodeWidth+graphMargin, graph.Width)
+}
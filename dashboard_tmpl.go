@@ -0,0 +1,174 @@
+package thrap
+
+// dashboardTemplates are the html templates of the dashboard pages.  They
+// are embedded so the agent binary has no assets to ship
+const dashboardTemplates = `
+{{define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>thrap</title>
+<style>
+body { font-family: -apple-system, "Helvetica Neue", Arial, sans-serif; margin: 0; color: #222; }
+header { background: #263238; color: #fff; padding: 12px 24px; }
+header a { color: #fff; text-decoration: none; font-weight: bold; }
+main { padding: 12px 24px; }
+h2 { margin-top: 32px; font-size: 1.1em; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #e0e0e0; vertical-align: top; }
+th { background: #f5f5f5; }
+code { font-size: 0.95em; }
+.muted { color: #888; }
+.succeeded { color: #2e7d32; }
+.failed { color: #c62828; }
+.unknown { color: #888; }
+.node rect { fill: #e3f2fd; stroke: #1565c0; }
+.node.dependency rect { fill: #f5f5f5; stroke: #757575; }
+.node.head rect { stroke-width: 2; }
+.node text { font-size: 12px; }
+.edge { stroke: #90a4ae; marker-end: url(#arrow); }
+</style>
+</head>
+<body>
+<header><a href="/ui/">thrap</a></header>
+<main>
+{{end}}
+
+{{define "footer"}}</main>
+</body>
+</html>
+{{end}}
+
+{{define "outcome"}}<span class="{{lower .}}">{{.}}</span>{{end}}
+
+{{define "error"}}{{template "header"}}
+<p class="failed">{{.}}</p>
+{{template "footer"}}{{end}}
+
+{{define "stacks"}}{{template "header"}}
+<h2>Stacks</h2>
+<table>
+<tr>
+  <th>Stack</th><th>Version</th><th>Components</th><th>Last build</th>
+  {{range .Profiles}}<th>{{.}}</th>{{end}}
+</tr>
+{{range $sum := .Stacks}}
+<tr>
+  <td><a href="/ui/stacks/{{$sum.Stack.ID}}">{{$sum.Stack.ID}}</a><div class="muted">{{$sum.Stack.Description}}</div></td>
+  <td>{{$sum.Stack.Version}}</td>
+  <td>{{len $sum.Stack.Components}}</td>
+  <td>{{with $sum.LastBuild}}{{.Revision}} {{template "outcome" .Outcome}}<div class="muted">{{time .Start}}</div>{{else}}-{{end}}</td>
+  {{range $p := $.Profiles}}<td>{{with index $sum.Deployed $p}}{{.Revision}}<div class="muted">{{time .End}}</div>{{else}}-{{end}}</td>{{end}}
+</tr>
+{{else}}
+<tr><td colspan="4" class="muted">No stacks registered</td></tr>
+{{end}}
+</table>
+{{template "footer"}}{{end}}
+
+{{define "stack"}}{{template "header"}}
+{{$stack := .Stack}}
+<h1>{{$stack.ID}} <span class="muted">{{$stack.Version}}</span></h1>
+<p>{{$stack.Description}}</p>
+<p>
+  Owner: {{with .Owner}}{{.ID}}{{with .Email}} &lt;{{.}}&gt;{{end}}{{else}}<span class="muted">unknown</span>{{end}}
+  {{with .Owner}}{{range $k, $v := .Meta}}<br><span class="muted">{{$k}}: {{$v}}</span>{{end}}{{end}}
+</p>
+
+<h2>Deployed</h2>
+<table>
+<tr><th>Profile</th><th>Revision</th><th>Artifacts</th><th>Deployed by</th><th>Finished</th></tr>
+{{range $p := .Profiles}}{{with index $.Deployed $p}}
+<tr>
+  <td>{{$p}}</td>
+  <td>{{.Revision}}</td>
+  <td>{{range .Components}}<code>{{tag .}}</code><br>{{end}}</td>
+  <td>{{.Identity}}</td>
+  <td>{{time .End}}</td>
+</tr>
+{{end}}{{else}}
+<tr><td colspan="5" class="muted">Not deployed</td></tr>
+{{end}}
+</table>
+
+<h2>Components</h2>
+<table>
+<tr><th>ID</th><th>Type</th><th>Language</th><th>Version</th><th>Ports</th><th>Depends on</th></tr>
+{{range $id, $comp := $stack.Components}}
+<tr>
+  <td>{{$id}}{{if $comp.Head}} <span class="muted">head</span>{{end}}{{if $comp.External}} <span class="muted">external</span>{{end}}</td>
+  <td>{{$comp.Type}}</td>
+  <td>{{$comp.Language}}</td>
+  <td>{{$comp.Version}}</td>
+  <td>{{range $name, $port := $comp.Ports}}{{$name}}={{$port}} {{end}}</td>
+  <td>{{range $comp.DependsOn}}{{.}} {{end}}</td>
+</tr>
+{{end}}
+</table>
+
+<h2>Dependencies</h2>
+<table>
+<tr><th>ID</th><th>Name</th><th>Version</th><th>External</th></tr>
+{{range $id, $dep := $stack.Dependencies}}
+<tr><td>{{$id}}</td><td>{{$dep.Name}}</td><td>{{$dep.Version}}</td><td>{{$dep.External}}</td></tr>
+{{else}}
+<tr><td colspan="4" class="muted">None</td></tr>
+{{end}}
+</table>
+
+<h2>Dependency graph</h2>
+{{with $g := .Graph}}
+<svg width="{{.Width}}" height="{{.Height}}" xmlns="http://www.w3.org/2000/svg">
+<defs>
+  <marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto">
+    <path d="M 0 0 L 10 5 L 0 10 z" fill="#90a4ae"/>
+  </marker>
+</defs>
+{{range .Edges}}<line class="edge" x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}"/>
+{{end}}
+{{range .Nodes}}<g class="node{{if .Dependency}} dependency{{end}}{{if .Head}} head{{end}}">
+  <rect x="{{.X}}" y="{{.Y}}" width="{{$g.NodeWidth}}" height="{{$g.NodeHeight}}" rx="4"/>
+  <text x="{{.X}}" y="{{.Y}}" dx="8" dy="22">{{.Label}}</text>
+</g>
+{{end}}
+</svg>
+{{end}}
+
+<h2>Builds</h2>
+<table>
+<tr><th>ID</th><th>Revision</th><th>Profile</th><th>Outcome</th><th>Artifacts</th><th>Built by</th><th>Started</th><th>Duration</th></tr>
+{{range .Builds}}
+<tr>
+  <td>{{.ID}}</td>
+  <td>{{.Revision}}</td>
+  <td>{{.Profile}}</td>
+  <td>{{template "outcome" .Outcome}}{{with .Error}}<div class="failed">{{.}}</div>{{end}}</td>
+  <td>{{range .Components}}<code>{{tag .}}</code>{{if .Published}} <span class="muted">published</span>{{end}}<br>{{end}}</td>
+  <td>{{.Identity}}</td>
+  <td>{{time .Start}}</td>
+  <td>{{duration .Start .End}}</td>
+</tr>
+{{else}}
+<tr><td colspan="8" class="muted">No builds</td></tr>
+{{end}}
+</table>
+
+<h2>Deployments</h2>
+<table>
+<tr><th>ID</th><th>Revision</th><th>Profile</th><th>Outcome</th><th>Deployed by</th><th>Started</th><th>Duration</th></tr>
+{{range .Deployments}}
+<tr>
+  <td>{{.ID}}</td>
+  <td>{{.Revision}}</td>
+  <td>{{.Profile}}</td>
+  <td>{{template "outcome" .Outcome}}{{with .Error}}<div class="failed">{{.}}</div>{{end}}</td>
+  <td>{{.Identity}}</td>
+  <td>{{time .Start}}</td>
+  <td>{{duration .Start .End}}</td>
+</tr>
+{{else}}
+<tr><td colspan="7" class="muted">No deployments</td></tr>
+{{end}}
+</table>
+{{template "footer"}}{{end}}
+`
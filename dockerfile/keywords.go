@@ -47,8 +47,10 @@ const (
 )
 
 var (
-	errInvalidInstruction   = errors.New("invalid instruction")
-	errUnsupportInstruction = errors.New("unsupported instruction")
+	errInvalidInstruction = errors.New("invalid instruction")
+	// ErrUnsupportedInstruction is returned when an instruction has no
+	// concrete type.  The raw instruction remains usable
+	ErrUnsupportedInstruction = errors.New("unsupported instruction")
 )
 
 // ParseInstruction parses a raw instruction into a concrete instruction
@@ -69,7 +71,7 @@ func ParseInstruction(r *RawInstruction) (Instruction, error) {
 		return ParseComment(r.Data)
 
 	default:
-		return nil, errUnsupportInstruction
+		return nil, ErrUnsupportedInstruction
 	}
 }
 
@@ -0,0 +1,411 @@
+package thrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// OpenAPIPath is the path the OpenAPI document of the gateway is served on
+	OpenAPIPath = "/openapi.json"
+	// GatewayPathPrefix is the path prefix of all gateway api calls
+	GatewayPathPrefix = "/v1/"
+
+	// maxGatewayBodySize is the largest request body read
+	maxGatewayBodySize = 4 << 20
+)
+
+// errSessionTLSRequired is returned when a session token is sent over plain
+// http and insecure transport has not been allowed
+var errSessionTLSRequired = errors.New("session tokens require https")
+
+// gatewayRequest is a matched gateway http request
+type gatewayRequest struct {
+	*http.Request
+	// Value of the {id} path parameter
+	id string
+}
+
+// decode unmarshals the json request body into v
+func (req *gatewayRequest) decode(v interface{}) error {
+	err := json.NewDecoder(req.Body).Decode(v)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// iterOptions returns the iterator options from the query parameters
+func (req *gatewayRequest) iterOptions() *thrapb.IterOptions {
+	return &thrapb.IterOptions{Prefix: req.URL.Query().Get("prefix")}
+}
+
+// gatewayRoute maps an http method and path to a grpc call
+type gatewayRoute struct {
+	method string
+	// Path with an optional {id} parameter
+	path string
+	// Grpc method called i.e. /Thrap/<rpc>
+	rpc     string
+	summary string
+	// Query parameters
+	query []string
+	// Request body type or nil if there is none
+	body interface{}
+	// Response type.  Stream responses are returned as arrays
+	resp   interface{}
+	stream bool
+	call   func(context.Context, thrapb.ThrapClient, *gatewayRequest) (interface{}, error)
+}
+
+// match returns the {id} parameter and whether the path matches the route
+func (route *gatewayRoute) match(path string) (string, bool) {
+	want := strings.Split(route.path, "/")
+	got := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	if len(want) != len(got) {
+		return "", false
+	}
+
+	var id string
+	for i := range want {
+		if want[i] == "{id}" {
+			if got[i] == "" {
+				return "", false
+			}
+			id = got[i]
+		} else if want[i] != got[i] {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+var gatewayRoutes = []*gatewayRoute{
+	{
+		method: http.MethodGet, path: "/v1/stacks", rpc: "IterStacks",
+		summary: "List stacks", query: []string{"prefix"},
+		resp: &thrapb.Stack{}, stream: true,
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return collectStacks(ctx, client, req.iterOptions())
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/stacks", rpc: "RegisterStack",
+		summary: "Register a new stack",
+		body:    &thrapb.Stack{}, resp: &thrapb.Stack{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			var stack thrapb.Stack
+			if err := req.decode(&stack); err != nil {
+				return nil, err
+			}
+			return client.RegisterStack(ctx, &stack)
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/stacks/{id}", rpc: "GetStack",
+		summary: "Get a stack",
+		resp:    &thrapb.Stack{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return client.GetStack(ctx, &thrapb.Stack{ID: req.id})
+		},
+	},
+	{
+		method: http.MethodPut, path: "/v1/stacks/{id}", rpc: "CommitStack",
+		summary: "Commit changes to an existing stack",
+		body:    &thrapb.Stack{}, resp: &thrapb.Stack{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			var stack thrapb.Stack
+			if err := req.decode(&stack); err != nil {
+				return nil, err
+			}
+			stack.ID = req.id
+			return client.CommitStack(ctx, &stack)
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/stacks/{id}/builds", rpc: "IterBuilds",
+		summary: "List the builds of a stack",
+		resp:    &thrapb.StackBuild{}, stream: true,
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return collectBuilds(ctx, client, &thrapb.IterOptions{Prefix: req.id + "/"})
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/stacks/{id}/deployments", rpc: "IterDeployments",
+		summary: "List the deployments of a stack",
+		resp:    &thrapb.Deployment{}, stream: true,
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return collectDeployments(ctx, client, &thrapb.IterOptions{Prefix: req.id + "/"})
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/builds", rpc: "IterBuilds",
+		summary: "List builds across all stacks", query: []string{"prefix"},
+		resp: &thrapb.StackBuild{}, stream: true,
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return collectBuilds(ctx, client, req.iterOptions())
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/identities", rpc: "IterIdentities",
+		summary: "List identities", query: []string{"prefix"},
+		resp: &thrapb.Identity{}, stream: true,
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return collectIdentities(ctx, client, req.iterOptions())
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/identities", rpc: "RegisterIdentity",
+		summary: "Register a new identity.  A confirmation code is mailed to it",
+		body:    &thrapb.Identity{}, resp: &thrapb.Identity{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			var ident thrapb.Identity
+			if err := req.decode(&ident); err != nil {
+				return nil, err
+			}
+			return client.RegisterIdentity(ctx, &ident)
+		},
+	},
+	{
+		method: http.MethodGet, path: "/v1/identities/{id}", rpc: "GetIdentity",
+		summary: "Get an identity",
+		resp:    &thrapb.Identity{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			return client.GetIdentity(ctx, &thrapb.Identity{ID: req.id})
+		},
+	},
+	{
+		method: http.MethodPost, path: "/v1/identities/{id}/confirm", rpc: "ConfirmIdentity",
+		summary: "Confirm a registered identity",
+		body:    &thrapb.Identity{}, resp: &thrapb.Identity{},
+		call: func(ctx context.Context, client thrapb.ThrapClient, req *gatewayRequest) (interface{}, error) {
+			var ident thrapb.Identity
+			if err := req.decode(&ident); err != nil {
+				return nil, err
+			}
+			ident.ID = req.id
+			return client.ConfirmIdentity(ctx, &ident)
+		},
+	},
+}
+
+// collectStacks returns all stacks streamed by the agent
+func collectStacks(ctx context.Context, client thrapb.ThrapClient, opts *thrapb.IterOptions) ([]*thrapb.Stack, error) {
+	stream, err := client.IterStacks(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := []*thrapb.Stack{}
+	for {
+		stack, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, stack)
+	}
+}
+
+// collectBuilds returns all builds streamed by the agent
+func collectBuilds(ctx context.Context, client thrapb.ThrapClient, opts *thrapb.IterOptions) ([]*thrapb.StackBuild, error) {
+	stream, err := client.IterBuilds(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := []*thrapb.StackBuild{}
+	for {
+		build, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, build)
+	}
+}
+
+// collectDeployments returns all deployments streamed by the agent
+func collectDeployments(ctx context.Context, client thrapb.ThrapClient, opts *thrapb.IterOptions) ([]*thrapb.Deployment, error) {
+	stream, err := client.IterDeployments(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := []*thrapb.Deployment{}
+	for {
+		deploy, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, deploy)
+	}
+}
+
+// collectIdentities returns all identities streamed by the agent
+func collectIdentities(ctx context.Context, client thrapb.ThrapClient, opts *thrapb.IterOptions) ([]*thrapb.Identity, error) {
+	stream, err := client.IterIdentities(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := []*thrapb.Identity{}
+	for {
+		ident, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		out = append(out, ident)
+	}
+}
+
+// Gateway is an http handler translating REST/JSON requests to grpc calls
+// on the agent. Callers authenticate with a session token in the
+// Authorization header.  Request signatures cover the protobuf encoding of
+// the request which http clients do not have so they are not accepted.  The
+// gateway forwards the token as grpc metadata so the agent authenticates and
+// audits the call as it would any grpc call
+type Gateway struct {
+	// AllowInsecure accepts session tokens over plain http e.g. when tls is
+	// terminated by a proxy in front of the agent
+	AllowInsecure bool
+
+	client thrapb.ThrapClient
+	spec   []byte
+	log    *log.Logger
+}
+
+// NewGateway returns a gateway calling the agent through the given client
+func NewGateway(client thrapb.ThrapClient, logger *log.Logger) *Gateway {
+	spec, err := json.Marshal(OpenAPI())
+	if err != nil {
+		panic(err)
+	}
+	return &Gateway{
+		client: client,
+		spec:   spec,
+		log:    logger,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (gw *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == OpenAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(gw.spec)
+		return
+	}
+
+	var (
+		route   *gatewayRoute
+		id      string
+		matched bool
+	)
+	for _, rt := range gatewayRoutes {
+		pid, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		matched = true
+		if rt.method == r.Method {
+			route, id = rt, pid
+			break
+		}
+	}
+	if route == nil {
+		if matched {
+			writeGatewayError(w, http.StatusMethodNotAllowed, "method not allowed")
+		} else {
+			writeGatewayError(w, http.StatusNotFound, "not found")
+		}
+		return
+	}
+
+	md := gatewayMetadata(r)
+	if err := checkSessionTransport(r, md, gw.AllowInsecure); err != nil {
+		writeGatewayError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxGatewayBodySize)
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+	resp, err := route.call(ctx, gw.client, &gatewayRequest{Request: r, id: id})
+	if err != nil {
+		st, _ := status.FromError(err)
+		code := httpStatus(st.Code())
+		if code == http.StatusInternalServerError {
+			gw.log.Printf("Gateway call failed method=%s path=%s error='%v'", r.Method, r.URL.Path, err)
+		}
+		writeGatewayError(w, code, st.Message())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// gatewayMetadata returns the grpc session metadata from the http headers
+func gatewayMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		md.Set(MetaSession, strings.TrimPrefix(auth, "Bearer "))
+	}
+	return md
+}
+
+// checkSessionTransport returns an error if the metadata carries a session
+// token that was received over plain http and insecure is not set.  Tokens
+// are bearer credentials and must not travel in plaintext
+func checkSessionTransport(r *http.Request, md metadata.MD, insecure bool) error {
+	if r.TLS == nil && !insecure && len(md.Get(MetaSession)) > 0 {
+		return errSessionTLSRequired
+	}
+	return nil
+}
+
+func writeGatewayError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// httpStatus returns the http status code for a grpc status code
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,170 @@
+package thrap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testStackStream struct {
+	grpc.ClientStream
+	stacks []*thrapb.Stack
+}
+
+func (s *testStackStream) Recv() (*thrapb.Stack, error) {
+	if len(s.stacks) == 0 {
+		return nil, io.EOF
+	}
+	stack := s.stacks[0]
+	s.stacks = s.stacks[1:]
+	return stack, nil
+}
+
+type testThrapClient struct {
+	thrapb.ThrapClient
+	stacks  map[string]*thrapb.Stack
+	builds  []*thrapb.StackBuild
+	deploys []*thrapb.Deployment
+	idents  map[string]*thrapb.Identity
+	md      metadata.MD
+}
+
+func (c *testThrapClient) GetStack(ctx context.Context, in *thrapb.Stack, opts ...grpc.CallOption) (*thrapb.Stack, error) {
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	if stack, ok := c.stacks[in.ID]; ok {
+		return stack, nil
+	}
+	return nil, status.Error(codes.NotFound, "stack not found")
+}
+
+func (c *testThrapClient) CommitStack(ctx context.Context, in *thrapb.Stack, opts ...grpc.CallOption) (*thrapb.Stack, error) {
+	c.stacks[in.ID] = in
+	return in, nil
+}
+
+func (c *testThrapClient) IterStacks(ctx context.Context, in *thrapb.IterOptions, opts ...grpc.CallOption) (thrapb.Thrap_IterStacksClient, error) {
+	stream := &testStackStream{}
+	for id, stack := range c.stacks {
+		if strings.HasPrefix(id, in.Prefix) {
+			stream.stacks = append(stream.stacks, stack)
+		}
+	}
+	return stream, nil
+}
+
+func newTestGateway() (*Gateway, *testThrapClient) {
+	client := &testThrapClient{stacks: map[string]*thrapb.Stack{
+		"web": &thrapb.Stack{ID: "web", Name: "web"},
+	}}
+	return NewGateway(client, log.New(ioutil.Discard, "", 0)), client
+}
+
+func Test_Gateway(t *testing.T) {
+	gw, client := newTestGateway()
+
+	req := httptest.NewRequest(http.MethodGet, "https://thrap/v1/stacks/web", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	gw.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"token"}, client.md.Get(MetaSession))
+
+	// Session tokens are not accepted over plain http unless allowed
+	client.md = nil
+	req = httptest.NewRequest(http.MethodGet, "/v1/stacks/web", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, client.md)
+
+	gw.AllowInsecure = true
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	gw.AllowInsecure = false
+
+	var stack thrapb.Stack
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &stack))
+	assert.Equal(t, "web", stack.Name)
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stacks/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "stack not found")
+
+	body := strings.NewReader(`{"ID":"ignored","Name":"api"}`)
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/stacks/api", body))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "api", client.stacks["api"].ID)
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/stacks/api", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Bodies are limited
+	big := `{"Name":"` + strings.Repeat("a", maxGatewayBodySize) + `"}`
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/stacks/api", strings.NewReader(big)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Only sessions are forwarded
+	req = httptest.NewRequest(http.MethodGet, "/v1/stacks/web", nil)
+	req.Header.Set(MetaIdentity, "foo@bar.com")
+	req.Header.Set(MetaSignature, "sig")
+	gw.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, 0, len(client.md.Get(MetaIdentity)))
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stacks?prefix=we", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stacks []*thrapb.Stack
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &stacks))
+	assert.Equal(t, 1, len(stacks))
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/stacks/web", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func Test_Gateway_OpenAPI(t *testing.T) {
+	gw, _ := newTestGateway()
+
+	w := httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		Paths      map[string]map[string]interface{}
+		Components struct {
+			Schemas map[string]interface{}
+		}
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	for _, route := range gatewayRoutes {
+		_, ok := doc.Paths[route.path][strings.ToLower(route.method)]
+		assert.True(t, ok, route.method+" "+route.path)
+	}
+	for _, name := range []string{"Stack", "Identity", "StackBuild", "Deployment", "Error"} {
+		_, ok := doc.Components.Schemas[name]
+		assert.True(t, ok, name)
+	}
+}
@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const localSender = "thrap@localhost"
+
+// FileMailer writes each message to a file in a directory named
+// <unix nano>-<recipient>.eml
+type FileMailer struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileMailer returns a mailer writing to the directory, creating it if
+// needed
+func NewFileMailer(dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir}, nil
+}
+
+// Send writes the message to a new file
+func (m *FileMailer) Send(msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fpath := filepath.Join(m.dir, fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), msg.To))
+	return ioutil.WriteFile(fpath, msg.Bytes(localSender), 0600)
+}
+
+// LogMailer writes messages to a logger
+type LogMailer struct {
+	log *log.Logger
+}
+
+// NewLogMailer returns a mailer writing to the logger
+func NewLogMailer(logger *log.Logger) *LogMailer {
+	return &LogMailer{log: logger}
+}
+
+// Send logs the message
+func (m *LogMailer) Send(msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	m.log.Printf("Mail to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
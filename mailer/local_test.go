@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FileMailer(t *testing.T) {
+	dir, _ := ioutil.TempDir("/tmp", "mailer-")
+	defer os.RemoveAll(dir)
+
+	m, err := New(&Config{Provider: "file", Dir: dir}, nil)
+	assert.Nil(t, err)
+
+	err = m.Send(&Message{To: "foo", Subject: "code", Body: "abc"})
+	assert.Equal(t, errRecipientRequired, err)
+
+	err = m.Send(&Message{To: "foo@bar.com", Subject: "code", Body: "abc"})
+	assert.Nil(t, err)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Equal(t, 1, len(files))
+	assert.True(t, strings.HasSuffix(files[0].Name(), "-foo@bar.com.eml"))
+
+	b, _ := ioutil.ReadFile(dir + "/" + files[0].Name())
+	assert.Contains(t, string(b), "Subject: code\r\n\r\nabc")
+}
+
+func Test_LogMailer(t *testing.T) {
+	buf := new(bytes.Buffer)
+	m, err := New(&Config{}, log.New(buf, "", 0))
+	assert.Nil(t, err)
+	assert.Nil(t, m.Send(&Message{To: "foo@bar.com", Subject: "code", Body: "abc"}))
+	assert.Contains(t, buf.String(), "abc")
+
+	_, err = New(&Config{Provider: "foo"}, nil)
+	assert.NotNil(t, err)
+
+	_, err = New(&Config{Provider: "smtp"}, nil)
+	assert.Equal(t, errSMTPConfig, err)
+}
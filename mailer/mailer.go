@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+var (
+	errRecipientRequired = errors.New("recipient required")
+	errUnknownProvider   = errors.New("unknown mailer provider")
+)
+
+// Message is a plain text email message
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Validate checks the message has a valid recipient
+func (msg *Message) Validate() error {
+	if msg.To == "" || !strings.Contains(msg.To, "@") {
+		return errRecipientRequired
+	}
+	return nil
+}
+
+// Bytes returns the message in RFC 822 format from the sender
+func (msg *Message) Bytes(from string) []byte {
+	return []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		from, msg.To, msg.Subject, msg.Body))
+}
+
+// Mailer implements sending email messages
+type Mailer interface {
+	Send(*Message) error
+}
+
+// Config holds the mailer configuration
+type Config struct {
+	// smtp, file or log
+	Provider string
+	// SMTP server address i.e. host:port
+	Addr string
+	// Sender address
+	From string
+	// SMTP credentials.  Authentication is skipped if no username is given
+	Username string
+	Password string
+	// Directory messages are written to by the file mailer
+	Dir string
+}
+
+// New returns a new Mailer based on the config.  The log mailer writes to
+// the logger
+func New(conf *Config, logger *log.Logger) (Mailer, error) {
+	switch conf.Provider {
+	case "smtp":
+		return NewSMTPMailer(conf)
+	case "file":
+		return NewFileMailer(conf.Dir)
+	case "log", "":
+		return NewLogMailer(logger), nil
+	}
+	return nil, fmt.Errorf("%v: %s", errUnknownProvider, conf.Provider)
+}
@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"errors"
+	"net"
+	"net/smtp"
+)
+
+var errSMTPConfig = errors.New("smtp address and sender required")
+
+// SMTPMailer sends messages through an SMTP server
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer returns a mailer using the server in the config.  PLAIN
+// authentication is used when a username is set
+func NewSMTPMailer(conf *Config) (*SMTPMailer, error) {
+	if conf.Addr == "" || conf.From == "" {
+		return nil, errSMTPConfig
+	}
+
+	host, _, err := net.SplitHostPort(conf.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &SMTPMailer{addr: conf.Addr, from: conf.From}
+	if conf.Username != "" {
+		m.auth = smtp.PlainAuth("", conf.Username, conf.Password, host)
+	}
+	return m, nil
+}
+
+// Send sends the message
+func (m *SMTPMailer) Send(msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, msg.Bytes(m.from))
+}
@@ -0,0 +1,440 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/euforia/thrap/analysis"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/thrapb"
+	"gopkg.in/yaml.v2"
+)
+
+type composeFile struct {
+	Name     string                     `yaml:"name"`
+	Version  string                     `yaml:"version"`
+	Services map[string]*composeService `yaml:"services"`
+	// Top-level keys not translated
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+type composeService struct {
+	Image       string              `yaml:"image"`
+	Build       *composeBuild       `yaml:"build"`
+	Command     stringOrList        `yaml:"command"`
+	Environment composeEnv          `yaml:"environment"`
+	EnvFile     stringOrList        `yaml:"env_file"`
+	Ports       []composePort       `yaml:"ports"`
+	Expose      []composePort       `yaml:"expose"`
+	Volumes     []composeVolume     `yaml:"volumes"`
+	DependsOn   composeDependsOn    `yaml:"depends_on"`
+	Healthcheck *composeHealthcheck `yaml:"healthcheck"`
+	// Service keys not translated
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+type composeBuild struct {
+	Context    string                 `yaml:"context"`
+	Dockerfile string                 `yaml:"dockerfile"`
+	Args       map[string]interface{} `yaml:"args"`
+}
+
+func (b *composeBuild) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ctx string
+	if err := unmarshal(&ctx); err == nil {
+		b.Context = ctx
+		return nil
+	}
+	type plain composeBuild
+	return unmarshal((*plain)(b))
+}
+
+type stringOrList []string
+
+func (s *stringOrList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*s = list
+		return nil
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	*s = strings.Fields(str)
+	return nil
+}
+
+type composeEnv map[string]string
+
+func (e *composeEnv) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	env := make(composeEnv)
+
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		for _, kv := range list {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) == 2 {
+				env[parts[0]] = parts[1]
+			} else {
+				env[parts[0]] = ""
+			}
+		}
+		*e = env
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		if v == nil {
+			env[k] = ""
+		} else {
+			env[k] = fmt.Sprint(v)
+		}
+	}
+	*e = env
+	return nil
+}
+
+type composePort struct {
+	Target    string `yaml:"target"`
+	Published string `yaml:"published"`
+	Protocol  string `yaml:"protocol"`
+}
+
+func (p *composePort) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var short string
+	if err := unmarshal(&short); err == nil {
+		if i := strings.Index(short, "/"); i > 0 {
+			p.Protocol = short[i+1:]
+			short = short[:i]
+		}
+		parts := strings.Split(short, ":")
+		p.Target = parts[len(parts)-1]
+		if len(parts) > 1 {
+			p.Published = parts[len(parts)-2]
+		}
+		return nil
+	}
+
+	var long map[string]interface{}
+	if err := unmarshal(&long); err != nil {
+		return err
+	}
+	for k, v := range long {
+		switch k {
+		case "target":
+			p.Target = fmt.Sprint(v)
+		case "published":
+			p.Published = fmt.Sprint(v)
+		case "protocol":
+			p.Protocol = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+type composeVolume struct {
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only"`
+	Mode     string `yaml:"-"`
+}
+
+func (v *composeVolume) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var short string
+	if err := unmarshal(&short); err == nil {
+		parts := strings.Split(short, ":")
+		switch len(parts) {
+		case 1:
+			v.Target = parts[0]
+		case 2:
+			v.Source, v.Target = parts[0], parts[1]
+		default:
+			v.Source, v.Target, v.Mode = parts[0], parts[1], parts[2]
+		}
+		return nil
+	}
+	type plain composeVolume
+	return unmarshal((*plain)(v))
+}
+
+type composeDependsOn []string
+
+func (d *composeDependsOn) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*d = list
+		return nil
+	}
+
+	// Long form keyed by service with conditions
+	var m map[string]interface{}
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	*d = out
+	return nil
+}
+
+type composeHealthcheck struct {
+	Test     stringOrList `yaml:"test"`
+	Interval string       `yaml:"interval"`
+	Timeout  string       `yaml:"timeout"`
+	Retries  int          `yaml:"retries"`
+	Disable  bool         `yaml:"disable"`
+}
+
+// ImportCompose translates a docker-compose file into a stack.  Services map
+// to components and the head component is inferred.  Anything that could not
+// be translated is added to the returned report
+func ImportCompose(fpath string) (*thrapb.Stack, *ImportReport, error) {
+	data, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	abs, err := filepath.Abs(fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parseCompose(data, filepath.Dir(abs))
+}
+
+func parseCompose(data []byte, dir string) (*thrapb.Stack, *ImportReport, error) {
+	var cf composeFile
+	if err := yaml.Unmarshal(data, &cf); err != nil {
+		return nil, nil, err
+	}
+	if len(cf.Services) == 0 {
+		return nil, nil, fmt.Errorf("no services found")
+	}
+
+	report := &ImportReport{}
+
+	name := cf.Name
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	st := &thrapb.Stack{
+		ID:         name,
+		Name:       name,
+		Components: make(map[string]*thrapb.Component, len(cf.Services)),
+	}
+
+	for _, k := range sortedKeys(cf.Extra) {
+		if k == "volumes" || strings.HasPrefix(k, "x-") {
+			continue
+		}
+		report.warnf("%s not supported", k)
+	}
+
+	for id, svc := range cf.Services {
+		st.Components[id] = composeServiceComp(id, svc, dir, report)
+	}
+
+	for id, comp := range st.Components {
+		for _, d := range comp.DependsOn {
+			if _, ok := st.Components[d]; !ok {
+				report.warnf("service %s: depends on unknown service %s", id, d)
+			}
+		}
+	}
+
+	inferHead(st, report)
+	sort.Strings(report.Warnings)
+
+	return st, report, nil
+}
+
+func composeServiceComp(id string, svc *composeService, dir string, report *ImportReport) *thrapb.Component {
+	warnf := func(format string, args ...interface{}) {
+		report.warnf("service "+id+": "+format, args...)
+	}
+
+	comp := &thrapb.Component{ID: id, Name: id}
+
+	if svc.Image != "" {
+		comp.Name, comp.Version = thrapb.SplitImage(svc.Image)
+	}
+	comp.Type = imageCompType(comp.Name)
+
+	if svc.Build != nil {
+		comp.Build = &thrapb.Build{
+			Context:    svc.Build.Context,
+			Dockerfile: svc.Build.Dockerfile,
+		}
+		if comp.Build.Context == "" {
+			comp.Build.Context = consts.DefaultBuildContext
+		}
+		if comp.Build.Dockerfile == "" {
+			// compose default
+			comp.Build.Dockerfile = "Dockerfile"
+		}
+		if len(svc.Build.Args) > 0 {
+			warnf("build args not supported")
+		}
+		if lang := analysis.EstimateLanguage(filepath.Join(dir, comp.Build.Context)); lang != "" {
+			comp.Language = thrapb.LanguageID(lang)
+		}
+		// Buildable versions are derived from the repo
+		comp.Version = ""
+
+	} else if svc.Image == "" {
+		warnf("no image or build")
+
+	} else if comp.Version == "" || comp.Version == "latest" {
+		warnf("image %s is not pinned to a version", svc.Image)
+	}
+
+	if len(svc.Command) > 0 {
+		comp.Cmd = svc.Command[0]
+		comp.Args = svc.Command[1:]
+	}
+
+	if len(svc.Environment) > 0 || len(svc.EnvFile) > 0 {
+		comp.Env = &thrapb.Envionment{Vars: map[string]string(svc.Environment)}
+		if len(svc.EnvFile) > 0 {
+			comp.Env.File = svc.EnvFile[0]
+			if len(svc.EnvFile) > 1 {
+				warnf("only the first env_file %s is used", svc.EnvFile[0])
+			}
+		}
+	}
+
+	comp.Ports = composePorts(svc, warnf)
+	comp.Volumes = composeVolumes(svc.Volumes, warnf)
+	comp.DependsOn = svc.DependsOn
+
+	if hc := svc.Healthcheck; hc != nil && !hc.Disable {
+		if check := composeHealthCheck(hc, comp.Ports); check != nil {
+			comp.HealthChecks = []*thrapb.HealthCheck{check}
+		} else {
+			warnf("healthcheck %q not supported", strings.Join(hc.Test, " "))
+		}
+	}
+
+	for _, k := range sortedKeys(svc.Extra) {
+		warnf("%s not supported", k)
+	}
+
+	return comp
+}
+
+func composePorts(svc *composeService, warnf func(string, ...interface{})) map[string]int32 {
+	var (
+		seen  = make(map[int32]bool)
+		ports []int32
+	)
+
+	for _, p := range append(svc.Ports, svc.Expose...) {
+		if strings.Contains(p.Target, "-") {
+			warnf("port range %s not supported", p.Target)
+			continue
+		}
+		port, err := strconv.Atoi(p.Target)
+		if err != nil {
+			warnf("invalid port %s", p.Target)
+			continue
+		}
+		if p.Protocol != "" && p.Protocol != "tcp" {
+			warnf("port %d protocol %s not supported", port, p.Protocol)
+		}
+		if p.Published != "" && p.Published != p.Target {
+			warnf("published port %s for %d not preserved", p.Published, port)
+		}
+
+		if !seen[int32(port)] {
+			seen[int32(port)] = true
+			ports = append(ports, int32(port))
+		}
+	}
+
+	return thrapb.NamePorts(ports)
+}
+
+func composeVolumes(vols []composeVolume, warnf func(string, ...interface{})) []*thrapb.Volume {
+	if len(vols) == 0 {
+		return nil
+	}
+
+	out := make([]*thrapb.Volume, 0, len(vols))
+	for _, v := range vols {
+		if v.Type == "tmpfs" {
+			warnf("tmpfs volume %s not supported", v.Target)
+			continue
+		}
+		if v.ReadOnly || v.Mode != "" {
+			warnf("volume %s mode not preserved", v.Target)
+		}
+		out = append(out, &thrapb.Volume{Source: v.Source, Target: v.Target})
+	}
+	return out
+}
+
+// composeHealthCheck translates checks that curl or wget an http(s) url.  It
+// returns nil for any other check
+func composeHealthCheck(hc *composeHealthcheck, ports map[string]int32) *thrapb.HealthCheck {
+	for _, arg := range hc.Test {
+		u, err := url.Parse(strings.Trim(arg, `"'`))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		check := &thrapb.HealthCheck{
+			Protocol: u.Scheme,
+			Path:     u.Path,
+			Method:   "GET",
+		}
+		if check.Path == "" {
+			check.Path = "/"
+		}
+		if d, err := time.ParseDuration(hc.Interval); err == nil {
+			check.Interval = int64(d)
+		}
+		if d, err := time.ParseDuration(hc.Timeout); err == nil {
+			check.Timeout = int64(d)
+		}
+
+		port := u.Port()
+		if port == "" {
+			port = "80"
+			if u.Scheme == "https" {
+				port = "443"
+			}
+		}
+		if p, err := strconv.Atoi(port); err == nil {
+			check.PortLabel = portLabel(ports, int32(p))
+		}
+
+		return check
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
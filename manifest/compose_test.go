@@ -0,0 +1,62 @@
+package manifest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportCompose(t *testing.T) {
+	st, report, err := ImportCompose("../test-fixtures/docker-compose.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "test-fixtures", st.ID)
+	assert.Equal(t, 3, len(st.Components))
+
+	web := st.Components["web"]
+	assert.Equal(t, thrapb.CompTypeWeb, web.Type)
+	assert.Equal(t, "nginx", web.Name)
+	assert.Equal(t, "1.15-alpine", web.Version)
+	assert.True(t, web.Head)
+	assert.EqualValues(t, 80, web.Ports["default"])
+	assert.Equal(t, []string{"api"}, web.DependsOn)
+
+	api := st.Components["api"]
+	assert.Equal(t, thrapb.CompTypeAPI, api.Type)
+	assert.True(t, api.IsBuildable())
+	assert.Equal(t, "test.dockerfile", api.Build.Dockerfile)
+	assert.Equal(t, "/app", api.Cmd)
+	assert.Equal(t, []string{"serve", "--debug"}, api.Args)
+	assert.Equal(t, "db", api.Env.Vars["DB_HOST"])
+	assert.Equal(t, "1", api.Env.Vars["DEBUG"])
+	assert.Equal(t, ".env", api.Env.File)
+	assert.Equal(t, []string{"db"}, api.DependsOn)
+	assert.False(t, api.Head)
+	if assert.Equal(t, 1, len(api.HealthChecks)) {
+		hc := api.HealthChecks[0]
+		assert.Equal(t, "http", hc.Protocol)
+		assert.Equal(t, "/health", hc.Path)
+		assert.Equal(t, "default", hc.PortLabel)
+		assert.Equal(t, int64(30*time.Second), hc.Interval)
+		assert.Equal(t, int64(5*time.Second), hc.Timeout)
+	}
+
+	db := st.Components["db"]
+	assert.Equal(t, thrapb.CompTypeDatastore, db.Type)
+	assert.Equal(t, "secret", db.Env.Vars["POSTGRES_PASSWORD"])
+	assert.Equal(t, 2, len(db.Volumes))
+	assert.Equal(t, "pgdata", db.Volumes[0].Source)
+
+	assert.Equal(t, []string{
+		"networks not supported",
+		"service api: restart not supported",
+		"service db: healthcheck \"pg_isready -U postgres\" not supported",
+		"service db: image postgres is not pinned to a version",
+		"service db: port 5432 protocol udp not supported",
+		"service db: volume /docker-entrypoint-initdb.d/init.sql mode not preserved",
+	}, report.Warnings)
+}
@@ -0,0 +1,129 @@
+package manifest
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+// ImportReport holds everything that could not be translated when importing
+// a foreign format into a stack
+type ImportReport struct {
+	Warnings []string
+}
+
+func (r *ImportReport) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// HasWarnings returns true if anything was not translated
+func (r *ImportReport) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// Import translates a docker-compose file or nomad job spec into a stack.
+// Nomad job specs are identified by a .nomad, .hcl or .json extension
+func Import(fpath string) (*thrapb.Stack, *ImportReport, error) {
+	switch filepath.Ext(fpath) {
+	case ".nomad", ".hcl", ".json":
+		return ImportNomadJob(fpath)
+	default:
+		return ImportCompose(fpath)
+	}
+}
+
+// Image names mapped to component types other than api
+var imageCompTypes = map[string]thrapb.CompType{
+	"postgres":      thrapb.CompTypeDatastore,
+	"mysql":         thrapb.CompTypeDatastore,
+	"mariadb":       thrapb.CompTypeDatastore,
+	"redis":         thrapb.CompTypeDatastore,
+	"mongo":         thrapb.CompTypeDatastore,
+	"memcached":     thrapb.CompTypeDatastore,
+	"elasticsearch": thrapb.CompTypeDatastore,
+	"cassandra":     thrapb.CompTypeDatastore,
+	"couchdb":       thrapb.CompTypeDatastore,
+	"rabbitmq":      thrapb.CompTypeDatastore,
+	"consul":        thrapb.CompTypeDatastore,
+	"etcd":          thrapb.CompTypeDatastore,
+	"minio":         thrapb.CompTypeDatastore,
+	"nginx":         thrapb.CompTypeWeb,
+	"httpd":         thrapb.CompTypeWeb,
+	"haproxy":       thrapb.CompTypeWeb,
+	"traefik":       thrapb.CompTypeWeb,
+	"caddy":         thrapb.CompTypeWeb,
+	"envoy":         thrapb.CompTypeWeb,
+}
+
+// imageCompType returns the component type inferred from the image name
+// defaulting to api
+func imageCompType(name string) thrapb.CompType {
+	base := path.Base(name)
+	if typ, ok := imageCompTypes[base]; ok {
+		return typ
+	}
+	return thrapb.CompTypeAPI
+}
+
+// portLabel returns the label of the port in the component port map
+func portLabel(ports map[string]int32, port int32) string {
+	for k, v := range ports {
+		if v == port {
+			return k
+		}
+	}
+	return ""
+}
+
+// inferHead marks the head component.  Components that no other depends on
+// and expose ports are candidates.  Web components are preferred over apis.
+// Datastores are never heads
+func inferHead(st *thrapb.Stack, report *ImportReport) {
+	for _, comp := range st.Components {
+		if comp.Head {
+			return
+		}
+	}
+
+	depended := make(map[string]bool)
+	for _, comp := range st.Components {
+		for _, d := range comp.DependsOn {
+			depended[d] = true
+		}
+	}
+
+	ids := make([]string, 0, len(st.Components))
+	for id := range st.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var web, api []string
+	for _, id := range ids {
+		comp := st.Components[id]
+		if depended[id] || len(comp.Ports) == 0 {
+			continue
+		}
+		switch comp.Type {
+		case thrapb.CompTypeWeb:
+			web = append(web, id)
+		case thrapb.CompTypeAPI:
+			api = append(api, id)
+		}
+	}
+
+	candidates := append(web, api...)
+	if len(candidates) == 0 {
+		report.warnf("no head component could be inferred")
+		return
+	}
+
+	st.Components[candidates[0]].Head = true
+	if len(candidates) > 1 {
+		report.warnf("head component inferred as %s from %s", candidates[0], strings.Join(candidates, ", "))
+	}
+}
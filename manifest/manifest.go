@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -14,7 +15,9 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// LoadManifest loads a hcl or yaml manifest
+// LoadManifest loads a hcl or yaml manifest.  The manifest may be anywhere
+// within the repo.  If it is not in the current directory, relative build
+// contexts are rewritten to be relative to the current directory
 func LoadManifest(mfile string) (*thrapb.Stack, error) {
 
 	if mfile == "" {
@@ -28,25 +31,119 @@ func LoadManifest(mfile string) (*thrapb.Stack, error) {
 		}
 	}
 
-	mpath, err := utils.GetLocalPath(mfile)
-	if err != nil {
-		return nil, err
+	mpath := mfile
+	if !filepath.IsAbs(mfile) {
+		var err error
+		if mpath, err = utils.GetLocalPath(mfile); err != nil {
+			return nil, err
+		}
 	}
 
-	var st *thrapb.Stack
+	var (
+		st  *thrapb.Stack
+		err error
+	)
 	if strings.HasSuffix(mfile, ".hcl") {
 		st, err = ParseHCL(mpath)
 	} else {
 		st, err = ParseYAML(mpath)
 	}
 
-	if err == nil {
-		st.Version = vcs.GetRepoVersion(filepath.Dir(mpath)).String()
+	if err != nil {
+		return st, err
+	}
+
+	mdir := filepath.Dir(mpath)
+	root, err := vcs.FindRepoRoot(mdir)
+	if err != nil {
+		// Not a repo. Use the default
+		root = mdir
 	}
+	st.Version = vcs.GetRepoVersion(root).String()
+
+	if st.ComponentVersioning() {
+		setComponentVersions(st, root, mdir)
+	}
+
+	err = relativizeBuildContexts(st, mdir)
 
 	return st, err
 }
 
+// setComponentVersions sets the version of each buildable component based on
+// its path-scoped tags and commits touching its build context
+func setComponentVersions(st *thrapb.Stack, root, mdir string) {
+	for id, comp := range st.Components {
+		if !comp.IsBuildable() {
+			continue
+		}
+
+		ctxDir := filepath.Join(mdir, comp.Build.Context)
+		rel, err := filepath.Rel(root, ctxDir)
+		if err != nil {
+			continue
+		}
+
+		ver, err := vcs.GetPathVersion(root, id, rel)
+		if err == nil {
+			comp.Version = ver.String()
+		}
+	}
+}
+
+// relativizeBuildContexts rewrites relative build contexts to be relative to
+// the current working directory if the manifest directory is not the cwd
+func relativizeBuildContexts(st *thrapb.Stack, mdir string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if cwd == mdir {
+		return nil
+	}
+
+	for _, comp := range st.Components {
+		if !comp.IsBuildable() || filepath.IsAbs(comp.Build.Context) {
+			continue
+		}
+
+		ctx, err := filepath.Rel(cwd, filepath.Join(mdir, comp.Build.Context))
+		if err != nil {
+			return err
+		}
+		comp.Build.Context = ctx
+	}
+
+	return nil
+}
+
+// FindManifests returns the paths to all stack manifests under the root
+// directory.  Hidden directories and vendored dependencies are skipped
+func FindManifests(root string) ([]string, error) {
+	out := make([]string, 0)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Name() == consts.DefaultManifestFile {
+			out = append(out, path)
+		}
+		return nil
+	})
+
+	return out, err
+}
+
 // WriteYAMLManifest writes a manifest as yaml to the Writer
 func WriteYAMLManifest(st *thrapb.Stack, w io.Writer) error {
 	b, err := yaml.Marshal(st)
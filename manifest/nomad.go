@@ -2,6 +2,8 @@ package manifest
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/euforia/thrap/thrapb"
@@ -18,6 +20,7 @@ const (
 	defaultPortLabel     = "default"
 	defaultCheckTimeout  = 3e9
 	defaultCheckInterval = 20e9
+	defaultEnvTmplDest   = "local/discovery.env"
 )
 
 // MakeNomadJob returns a nomad job from the stack
@@ -105,9 +108,20 @@ func makeNomadTaskDocker(sid, gid string, comp *thrapb.Component) *api.Task {
 	// })
 	if comp.Env != nil && len(comp.Env.Vars) > 0 {
 		task.Env = make(map[string]string)
+		tmpls := make([]string, 0)
 		for k, v := range comp.Env.Vars {
+			// consul-template values i.e. discovery addresses are
+			// rendered into the environment by nomad
+			if strings.Contains(v, "{{") {
+				tmpls = append(tmpls, k+"="+v)
+				continue
+			}
 			task.Env[k] = v
 		}
+		if len(tmpls) > 0 {
+			sort.Strings(tmpls)
+			task.Templates = []*api.Template{makeEnvTemplate(tmpls)}
+		}
 	}
 
 	task.Services = make([]*api.Service, 0, len(comp.Ports))
@@ -162,6 +176,23 @@ func makeNomadTaskDocker(sid, gid string, comp *thrapb.Component) *api.Task {
 	return task
 }
 
+// makeEnvTemplate returns a template rendering the KEY=value lines into the
+// task environment.  The task is restarted when they change
+func makeEnvTemplate(lines []string) *api.Template {
+	var (
+		data       = strings.Join(lines, "\n") + "\n"
+		dest       = defaultEnvTmplDest
+		changeMode = "restart"
+		envvars    = true
+	)
+	return &api.Template{
+		EmbeddedTmpl: &data,
+		DestPath:     &dest,
+		ChangeMode:   &changeMode,
+		Envvars:      &envvars,
+	}
+}
+
 func makeServiceCheck(hc *thrapb.HealthCheck) api.ServiceCheck {
 	chk := api.ServiceCheck{
 		Type:      hc.Protocol,
@@ -0,0 +1,592 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+)
+
+// Intermediate hcl job spec.  Only fields that translate to a stack or are
+// inspected for the report are decoded. Everything else is reported as
+// unused. Nested blocks are decoded separately from the ast as single
+// unlabeled blocks cannot be decoded into slices
+type hclJob struct {
+	Name        string            `hcl:"name"`
+	Region      string            `hcl:"region"`
+	Type        string            `hcl:"type"`
+	Datacenters []string          `hcl:"datacenters"`
+	Meta        map[string]string `hcl:"meta"`
+}
+
+type hclGroup struct {
+	Count *int `hcl:"count"`
+}
+
+type hclTask struct {
+	Driver string                 `hcl:"driver"`
+	Config map[string]interface{} `hcl:"config"`
+	Env    map[string]string      `hcl:"env"`
+}
+
+type hclService struct {
+	Name      string   `hcl:"name"`
+	Tags      []string `hcl:"tags"`
+	PortLabel string   `hcl:"port"`
+}
+
+type hclCheck struct {
+	Name      string   `hcl:"name"`
+	Type      string   `hcl:"type"`
+	Path      string   `hcl:"path"`
+	Method    string   `hcl:"method"`
+	Protocol  string   `hcl:"protocol"`
+	PortLabel string   `hcl:"port"`
+	Command   string   `hcl:"command"`
+	Args      []string `hcl:"args"`
+	Interval  string   `hcl:"interval"`
+	Timeout   string   `hcl:"timeout"`
+}
+
+type hclResources struct {
+	CPU      *int `hcl:"cpu"`
+	MemoryMB *int `hcl:"memory"`
+}
+
+type hclNetwork struct {
+	MBits *int `hcl:"mbits"`
+}
+
+type hclPort struct {
+	Static int `hcl:"static"`
+}
+
+// ImportNomadJob translates a nomad job spec in hcl or json into a stack.
+// Each docker task becomes a component.  Anything that could not be
+// translated is added to the returned report
+func ImportNomadJob(fpath string) (*thrapb.Stack, *ImportReport, error) {
+	data, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &ImportReport{}
+
+	job, err := ParseNomadJob(data, report)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st, err := StackFromNomadJob(job, report)
+	return st, report, err
+}
+
+// ParseNomadJob parses a nomad job in json, either as returned by the api or
+// wrapped in a Job key, or hcl.  Unsupported hcl keys are added to the report
+func ParseNomadJob(data []byte, report *ImportReport) (*api.Job, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapped struct {
+			Job *api.Job
+		}
+		if err := json.Unmarshal(data, &wrapped); err == nil && wrapped.Job != nil {
+			return wrapped.Job, nil
+		}
+
+		var job api.Job
+		if err := json.Unmarshal(data, &job); err == nil && job.ID != nil {
+			return &job, nil
+		}
+	}
+
+	file, err := hcl.ParseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("job spec root should be an object")
+	}
+
+	jobs := root.Filter("job").Items
+	if len(jobs) != 1 {
+		return nil, fmt.Errorf("expected 1 job found %d", len(jobs))
+	}
+
+	return parseHCLJob(jobs[0], report)
+}
+
+// decodeBlock decodes the attributes of the block into v returning the block
+// label, body and keys that are neither fields of v nor one of the nested
+// blocks
+func decodeBlock(item *ast.ObjectItem, v interface{}, blocks ...string) (string, *ast.ObjectList, []string, error) {
+	var label string
+	if len(item.Keys) > 0 {
+		label, _ = item.Keys[0].Token.Value().(string)
+	}
+
+	ot, ok := item.Val.(*ast.ObjectType)
+	if !ok {
+		return label, nil, nil, fmt.Errorf("%s: should be a block", label)
+	}
+
+	if err := hcl.DecodeObject(v, item.Val); err != nil {
+		return label, nil, nil, err
+	}
+
+	known := make(map[string]bool, len(blocks))
+	for _, b := range blocks {
+		known[b] = true
+	}
+	typ := reflect.TypeOf(v).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		known[strings.Split(typ.Field(i).Tag.Get("hcl"), ",")[0]] = true
+	}
+
+	var unused []string
+	for _, oi := range ot.List.Items {
+		key, _ := oi.Keys[0].Token.Value().(string)
+		if !known[key] {
+			unused = append(unused, key)
+		}
+	}
+
+	return label, ot.List, unused, nil
+}
+
+func parseHCLJob(item *ast.ObjectItem, report *ImportReport) (*api.Job, error) {
+	var hj hclJob
+	id, body, unused, err := decodeBlock(item, &hj, "group")
+	if err != nil {
+		return nil, err
+	}
+	reportUnused(report, "job", unused)
+
+	job := &api.Job{
+		ID:          &id,
+		Name:        &id,
+		Datacenters: hj.Datacenters,
+		Meta:        hj.Meta,
+	}
+	if hj.Name != "" {
+		job.Name = &hj.Name
+	}
+	if hj.Region != "" {
+		job.Region = &hj.Region
+	}
+	if hj.Type != "" {
+		job.Type = &hj.Type
+	}
+
+	for _, gitem := range body.Filter("group").Items {
+		var hg hclGroup
+		gname, gbody, unused, err := decodeBlock(gitem, &hg, "task")
+		if err != nil {
+			return nil, err
+		}
+		reportUnused(report, "group "+gname, unused)
+
+		grp := api.NewTaskGroup(gname, 1)
+		if hg.Count != nil {
+			grp.Count = hg.Count
+		}
+
+		for _, titem := range gbody.Filter("task").Items {
+			task, err := parseHCLTask(titem, report)
+			if err != nil {
+				return nil, err
+			}
+			grp.AddTask(task)
+		}
+
+		job.TaskGroups = append(job.TaskGroups, grp)
+	}
+
+	return job, nil
+}
+
+func parseHCLTask(item *ast.ObjectItem, report *ImportReport) (*api.Task, error) {
+	var ht hclTask
+	name, body, unused, err := decodeBlock(item, &ht, "service", "resources")
+	if err != nil {
+		return nil, err
+	}
+	reportUnused(report, "task "+name, unused)
+
+	task := api.NewTask(name, ht.Driver)
+	task.Config = ht.Config
+	task.Env = ht.Env
+
+	for _, sitem := range body.Filter("service").Items {
+		svc, err := parseHCLService(name, sitem, report)
+		if err != nil {
+			return nil, err
+		}
+		task.Services = append(task.Services, svc)
+	}
+
+	for _, ritem := range body.Filter("resources").Items {
+		res, err := parseHCLResources(name, ritem, report)
+		if err != nil {
+			return nil, err
+		}
+		task.Resources = res
+	}
+
+	return task, nil
+}
+
+func parseHCLService(task string, item *ast.ObjectItem, report *ImportReport) (*api.Service, error) {
+	var hs hclService
+	_, body, unused, err := decodeBlock(item, &hs, "check")
+	if err != nil {
+		return nil, err
+	}
+	reportUnused(report, "task "+task+" service", unused)
+
+	svc := &api.Service{
+		Name:      hs.Name,
+		Tags:      hs.Tags,
+		PortLabel: hs.PortLabel,
+	}
+
+	for _, citem := range body.Filter("check").Items {
+		var hc hclCheck
+		_, _, unused, err = decodeBlock(citem, &hc)
+		if err != nil {
+			return nil, err
+		}
+		reportUnused(report, "task "+task+" check", unused)
+
+		chk := api.ServiceCheck{
+			Name:      hc.Name,
+			Type:      hc.Type,
+			Path:      hc.Path,
+			Method:    hc.Method,
+			Protocol:  hc.Protocol,
+			PortLabel: hc.PortLabel,
+			Command:   hc.Command,
+			Args:      hc.Args,
+		}
+		if hc.Interval != "" {
+			if chk.Interval, err = time.ParseDuration(hc.Interval); err != nil {
+				return nil, err
+			}
+		}
+		if hc.Timeout != "" {
+			if chk.Timeout, err = time.ParseDuration(hc.Timeout); err != nil {
+				return nil, err
+			}
+		}
+		svc.Checks = append(svc.Checks, chk)
+	}
+
+	return svc, nil
+}
+
+func parseHCLResources(task string, item *ast.ObjectItem, report *ImportReport) (*api.Resources, error) {
+	var hr hclResources
+	_, body, unused, err := decodeBlock(item, &hr, "network")
+	if err != nil {
+		return nil, err
+	}
+	reportUnused(report, "task "+task+" resources", unused)
+
+	res := &api.Resources{CPU: hr.CPU, MemoryMB: hr.MemoryMB}
+	for _, nitem := range body.Filter("network").Items {
+		var hn hclNetwork
+		_, nbody, unused, err := decodeBlock(nitem, &hn, "port")
+		if err != nil {
+			return nil, err
+		}
+
+		reportUnused(report, "task "+task+" network", unused)
+
+		net := &api.NetworkResource{MBits: hn.MBits}
+		for _, pitem := range nbody.Filter("port").Items {
+			var hp hclPort
+			label, _, _, err := decodeBlock(pitem, &hp)
+			if err != nil {
+				return nil, err
+			}
+			if hp.Static > 0 {
+				net.ReservedPorts = append(net.ReservedPorts, api.Port{Label: label, Value: hp.Static})
+			} else {
+				net.DynamicPorts = append(net.DynamicPorts, api.Port{Label: label})
+			}
+		}
+		res.Networks = append(res.Networks, net)
+	}
+
+	return res, nil
+}
+
+// reportUnused adds each unused key once to the report
+func reportUnused(report *ImportReport, prefix string, keys []string) {
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			report.warnf("%s: %s not supported", prefix, k)
+		}
+	}
+}
+
+// StackFromNomadJob translates a nomad job into a stack.  It is the reverse
+// of MakeNomadJob.  Docker tasks become components with the image name and
+// tag as the name and version, the port_map as ports and service checks as
+// health checks.  Lossy fields are added to the report
+func StackFromNomadJob(job *api.Job, report *ImportReport) (*thrapb.Stack, error) {
+	if job.ID == nil || *job.ID == "" {
+		return nil, fmt.Errorf("job id missing")
+	}
+
+	st := &thrapb.Stack{
+		ID:         *job.ID,
+		Name:       *job.ID,
+		Components: make(map[string]*thrapb.Component),
+	}
+	if job.Name != nil && *job.Name != "" {
+		st.Name = *job.Name
+	}
+
+	compType := thrapb.CompType(thrapb.CompTypeUnknown)
+	if job.Type != nil {
+		switch *job.Type {
+		case api.JobTypeBatch:
+			compType = thrapb.CompTypeBatch
+			if job.Periodic != nil {
+				compType = thrapb.CompTypePeriodic
+			}
+		case api.JobTypeService, "":
+		default:
+			report.warnf("job: type %s not supported", *job.Type)
+		}
+	}
+
+	if len(job.Constraints) > 0 {
+		report.warnf("job: constraints not supported")
+	}
+	if job.Update != nil {
+		report.warnf("job: update strategy not supported")
+	}
+	if len(job.Meta) > 0 {
+		report.warnf("job: meta not supported")
+	}
+	if job.ParameterizedJob != nil {
+		report.warnf("job: parameterized not supported")
+	}
+
+	for _, grp := range job.TaskGroups {
+		gname := ""
+		if grp.Name != nil {
+			gname = *grp.Name
+		}
+		if grp.Count != nil && *grp.Count > 1 {
+			report.warnf("group %s: count %d not preserved", gname, *grp.Count)
+		}
+		if len(grp.Constraints) > 0 {
+			report.warnf("group %s: constraints not supported", gname)
+		}
+
+		for _, task := range grp.Tasks {
+			if task.Driver != "docker" {
+				report.warnf("task %s: driver %s not supported", task.Name, task.Driver)
+				continue
+			}
+
+			id := nomadCompID(st, gname, task.Name)
+			st.Components[id] = nomadTaskComp(id, task, compType, report)
+		}
+	}
+
+	if len(st.Components) == 0 {
+		return nil, fmt.Errorf("no docker tasks found")
+	}
+
+	inferHead(st, report)
+	sort.Strings(report.Warnings)
+
+	return st, nil
+}
+
+// nomadCompID returns the component id for a task.  Tasks generated by
+// MakeNomadJob are named <stack>.<group>.<component>.  The group is used to
+// disambiguate tasks with the same name
+func nomadCompID(st *thrapb.Stack, group, task string) string {
+	id := task
+	if i := strings.LastIndex(task, "."); i >= 0 {
+		id = task[i+1:]
+	}
+	if _, ok := st.Components[id]; ok {
+		id = strings.Replace(group, ".", "-", -1) + "-" + id
+	}
+	return id
+}
+
+func nomadTaskComp(id string, task *api.Task, typ thrapb.CompType, report *ImportReport) *thrapb.Component {
+	warnf := func(format string, args ...interface{}) {
+		report.warnf("task "+task.Name+": "+format, args...)
+	}
+
+	comp := &thrapb.Component{ID: id, Name: id}
+
+	config := make(map[string]interface{}, len(task.Config))
+	for k, v := range task.Config {
+		config[k] = v
+	}
+
+	if image, ok := config["image"].(string); ok {
+		comp.Name, comp.Version = thrapb.SplitImage(image)
+		if _, err := version.NewVersion(comp.Version); err != nil {
+			warnf("image %s is not pinned to a version", image)
+		}
+	} else {
+		warnf("no image")
+	}
+	delete(config, "image")
+
+	comp.Type = typ
+	if comp.Type == thrapb.CompTypeUnknown {
+		comp.Type = imageCompType(comp.Name)
+	}
+
+	if cmd, ok := config["command"].(string); ok {
+		comp.Cmd = cmd
+		delete(config, "command")
+	}
+	if args, ok := config["args"].([]interface{}); ok {
+		for _, a := range args {
+			comp.Args = append(comp.Args, fmt.Sprint(a))
+		}
+		delete(config, "args")
+	}
+
+	if pm, ok := config["port_map"]; ok {
+		comp.Ports = nomadPortMap(pm)
+		delete(config, "port_map")
+	}
+
+	for _, k := range sortedKeys(config) {
+		warnf("config %s not supported", k)
+	}
+
+	if len(task.Env) > 0 {
+		comp.Env = &thrapb.Envionment{Vars: task.Env}
+	}
+
+	for _, svc := range task.Services {
+		if len(svc.Tags) > 0 {
+			warnf("service %s tags not preserved", svc.Name)
+		}
+		for _, chk := range svc.Checks {
+			if hc := nomadHealthCheck(svc, chk); hc != nil {
+				comp.HealthChecks = append(comp.HealthChecks, hc)
+			} else {
+				warnf("%s check %s not supported", chk.Type, chk.Name)
+			}
+		}
+	}
+
+	if res := task.Resources; res != nil {
+		if res.CPU != nil || res.MemoryMB != nil {
+			warnf("cpu and memory resources not supported")
+		}
+		for _, net := range res.Networks {
+			for _, p := range net.ReservedPorts {
+				warnf("static port %s = %d not preserved", p.Label, p.Value)
+			}
+		}
+	}
+
+	if task.Vault != nil {
+		warnf("vault not supported")
+	}
+	if len(task.Templates) > 0 {
+		warnf("templates not supported")
+	}
+	if len(task.Artifacts) > 0 {
+		warnf("artifacts not supported")
+	}
+	if len(task.Constraints) > 0 {
+		warnf("constraints not supported")
+	}
+	if task.User != "" {
+		warnf("user not supported")
+	}
+
+	return comp
+}
+
+// nomadPortMap translates a port_map as decoded from hcl i.e. a list of maps,
+// or json
+func nomadPortMap(pm interface{}) map[string]int32 {
+	var maps []map[string]interface{}
+	switch v := pm.(type) {
+	case map[string]interface{}:
+		maps = append(maps, v)
+	case []map[string]interface{}:
+		maps = v
+	case []interface{}:
+		for _, m := range v {
+			if mm, ok := m.(map[string]interface{}); ok {
+				maps = append(maps, mm)
+			}
+		}
+	}
+
+	ports := make(map[string]int32)
+	for _, m := range maps {
+		for label, val := range m {
+			switch p := val.(type) {
+			case int:
+				ports[label] = int32(p)
+			case int64:
+				ports[label] = int32(p)
+			case float64:
+				ports[label] = int32(p)
+			}
+		}
+	}
+
+	if len(ports) == 0 {
+		return nil
+	}
+	return ports
+}
+
+// nomadHealthCheck translates http(s) and tcp checks.  It returns nil for
+// all others
+func nomadHealthCheck(svc *api.Service, chk api.ServiceCheck) *thrapb.HealthCheck {
+	switch chk.Type {
+	case "http", "https", "tcp":
+	default:
+		return nil
+	}
+
+	hc := &thrapb.HealthCheck{
+		Protocol:  chk.Type,
+		Path:      chk.Path,
+		Method:    chk.Method,
+		Timeout:   int64(chk.Timeout),
+		Interval:  int64(chk.Interval),
+		PortLabel: chk.PortLabel,
+	}
+	if chk.Type == "http" && chk.Protocol == "https" {
+		hc.Protocol = "https"
+	}
+	if hc.PortLabel == "" {
+		hc.PortLabel = svc.PortLabel
+	}
+
+	return hc
+}
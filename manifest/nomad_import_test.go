@@ -0,0 +1,101 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportNomadJob(t *testing.T) {
+	st, report, err := ImportNomadJob("../test-fixtures/deploy.nomad")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "one-ingest", st.ID)
+	assert.Equal(t, 1, len(st.Components))
+
+	api := st.Components["api"]
+	assert.Equal(t, thrapb.CompTypeAPI, api.Type)
+	assert.Equal(t, "foo/bar", api.Name)
+	assert.Equal(t, "<APP_VERSION>", api.Version)
+	assert.True(t, api.Head)
+	assert.EqualValues(t, 9000, api.Ports["default"])
+	assert.Equal(t, "<APP_VERSION>", api.Env.Vars["APP_VERSION"])
+	if assert.Equal(t, 1, len(api.HealthChecks)) {
+		hc := api.HealthChecks[0]
+		assert.Equal(t, "http", hc.Protocol)
+		assert.Equal(t, "/v1/status", hc.Path)
+		assert.Equal(t, "default", hc.PortLabel)
+		assert.Equal(t, int64(10*time.Second), hc.Interval)
+		assert.Equal(t, int64(3*time.Second), hc.Timeout)
+	}
+
+	assert.Contains(t, report.Warnings, "job: constraint not supported")
+	assert.Contains(t, report.Warnings, "job: update not supported")
+	assert.Contains(t, report.Warnings, "group stack: count 2 not preserved")
+	assert.Contains(t, report.Warnings, "group stack: vault not supported")
+	assert.Contains(t, report.Warnings, "task api: template not supported")
+	assert.Contains(t, report.Warnings, "task api: config labels not supported")
+	assert.Contains(t, report.Warnings, "task api: config logging not supported")
+	assert.Contains(t, report.Warnings, "task api: image foo/bar:<APP_VERSION> is not pinned to a version")
+	assert.Contains(t, report.Warnings, "task api: cpu and memory resources not supported")
+}
+
+func Test_StackFromNomadJob_roundtrip(t *testing.T) {
+	st := &thrapb.Stack{
+		ID:   "foo",
+		Name: "foo",
+		Components: map[string]*thrapb.Component{
+			"api": &thrapb.Component{
+				Name:    "foo/api",
+				Version: "1.2.3",
+				Type:    thrapb.CompTypeAPI,
+				Head:    true,
+				Ports:   map[string]int32{"http": 8080},
+				Env:     &thrapb.Envionment{Vars: map[string]string{"KEY": "val"}},
+			},
+			"db": &thrapb.Component{
+				Name:    "postgres",
+				Version: "10.5",
+				Type:    thrapb.CompTypeDatastore,
+				Ports:   map[string]int32{"default": 5432},
+			},
+		},
+	}
+
+	st.Validate()
+
+	job, err := MakeNomadJob(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Go through json as ParseNomadJob would
+	b, _ := json.Marshal(map[string]interface{}{"Job": job})
+	report := &ImportReport{}
+	job, err = ParseNomadJob(b, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := StackFromNomadJob(job, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(out.Components))
+	api := out.Components["api"]
+	assert.Equal(t, "foo/api", api.Name)
+	assert.Equal(t, "1.2.3", api.Version)
+	assert.EqualValues(t, 8080, api.Ports["http"])
+	assert.Equal(t, "val", api.Env.Vars["KEY"])
+	assert.True(t, api.Head)
+
+	db := out.Components["db"]
+	assert.Equal(t, thrapb.CompTypeDatastore, db.Type)
+	assert.EqualValues(t, 5432, db.Ports["default"])
+}
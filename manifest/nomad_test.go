@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/euforia/thrap/thrapb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,3 +34,28 @@ func Test_MakeNomadJobYAML(t *testing.T) {
 	comp := desc.Components["api"]
 	assert.EqualValues(t, 80, comp.Ports["http"])
 }
+
+func Test_MakeNomadJob_envTemplate(t *testing.T) {
+	st := &thrapb.Stack{
+		ID: "foo",
+		Components: map[string]*thrapb.Component{
+			"api": &thrapb.Component{
+				ID:   "api",
+				Type: thrapb.CompTypeAPI,
+				Env: &thrapb.Envionment{Vars: map[string]string{
+					"LOG":     "debug",
+					"DB_ADDR": `{{ with service "default.db.foo" }}{{ end }}`,
+				}},
+			},
+		},
+	}
+
+	job, err := MakeNomadJob(st)
+	assert.Nil(t, err)
+
+	task := job.TaskGroups[0].Tasks[0]
+	assert.Equal(t, map[string]string{"LOG": "debug"}, task.Env)
+	assert.Equal(t, 1, len(task.Templates))
+	assert.Equal(t, `DB_ADDR={{ with service "default.db.foo" }}{{ end }}`+"\n", *task.Templates[0].EmbeddedTmpl)
+	assert.True(t, *task.Templates[0].Envvars)
+}
@@ -1,10 +1,16 @@
 package manifest
 
 import (
+	"fmt"
 	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 
 	pb "github.com/euforia/thrap/thrapb"
 	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
 	"gopkg.in/yaml.v2"
 )
 
@@ -55,3 +61,232 @@ func ParseHCLBytes(in []byte) (*pb.Stack, error) {
 	}
 	return stack, nil
 }
+
+// ParseHCLStrict parses a manifest hcl file rejecting unknown fields
+func ParseHCLStrict(file string) (*pb.Stack, error) {
+	in, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHCLBytesStrict(in)
+}
+
+// ParseHCLBytesStrict reads a hcl stack configuration rejecting unknown
+// fields.  The hcl decoder ignores keys it does not know so the syntax tree is
+// checked against the stack type first.  Unknown fields are returned as
+// DecodeErrors with the line and column of each
+func ParseHCLBytesStrict(in []byte) (*pb.Stack, error) {
+	file, err := hcl.ParseBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("manifest root should be an object")
+	}
+
+	var (
+		errs  DecodeErrors
+		stack = reflect.TypeOf(map[string]*pb.Stack{})
+	)
+	for _, item := range root.Items {
+		if key := hclKey(item.Keys[0]); key != "manifest" {
+			errs = append(errs, newHCLDecodeError(item.Keys[0], key))
+			continue
+		}
+		errs = append(errs, checkHCLItem(item.Keys[1:], item.Val, stack)...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return ParseHCLBytes(in)
+}
+
+// checkHCLItem checks the value and any remaining block labels of an item
+// decode into the type
+func checkHCLItem(keys []*ast.ObjectKey, val ast.Node, typ reflect.Type) DecodeErrors {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if len(keys) > 0 {
+		switch typ.Kind() {
+		case reflect.Map:
+			return checkHCLItem(keys[1:], val, typ.Elem())
+		case reflect.Slice:
+			return checkHCLItem(keys, val, typ.Elem())
+		}
+		return checkHCLItem(keys[1:], val, typ)
+	}
+
+	switch node := val.(type) {
+	case *ast.ObjectType:
+		return checkHCLObject(node.List, typ)
+	case *ast.ListType:
+		if typ.Kind() != reflect.Slice {
+			return nil
+		}
+		var errs DecodeErrors
+		for _, elem := range node.List {
+			errs = append(errs, checkHCLItem(nil, elem, typ.Elem())...)
+		}
+		return errs
+	}
+	return nil
+}
+
+// checkHCLObject checks each key of the object is a field of the struct
+// type.  Keys of maps are names and not checked
+func checkHCLObject(list *ast.ObjectList, typ reflect.Type) DecodeErrors {
+	var errs DecodeErrors
+	for _, item := range list.Items {
+		switch typ.Kind() {
+		case reflect.Map:
+			errs = append(errs, checkHCLItem(item.Keys[1:], item.Val, typ.Elem())...)
+
+		case reflect.Slice:
+			errs = append(errs, checkHCLObject(&ast.ObjectList{Items: []*ast.ObjectItem{item}}, typ.Elem())...)
+
+		case reflect.Struct:
+			key := hclKey(item.Keys[0])
+			ftyp, ok := hclField(typ, key)
+			if !ok {
+				errs = append(errs, newHCLDecodeError(item.Keys[0], key))
+				continue
+			}
+			errs = append(errs, checkHCLItem(item.Keys[1:], item.Val, ftyp)...)
+		}
+	}
+	return errs
+}
+
+// hclField returns the type of the struct field the hcl decoder matches the
+// key to.  Fields are matched case insensitively by their hcl tag name or
+// field name
+func hclField(typ reflect.Type, key string) (reflect.Type, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("hcl"), ",", 2)[0]
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
+
+func hclKey(key *ast.ObjectKey) string {
+	s, _ := key.Token.Value().(string)
+	return s
+}
+
+func newHCLDecodeError(key *ast.ObjectKey, name string) *DecodeError {
+	pos := key.Pos()
+	return &DecodeError{Line: pos.Line, Column: pos.Column, Msg: "unknown field " + name}
+}
+
+// ParseYAMLStrict parses a manifest yaml file rejecting unknown and duplicate
+// fields
+func ParseYAMLStrict(file string) (*pb.Stack, error) {
+	in, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseYAMLBytesStrict(in)
+}
+
+// ParseYAMLBytesStrict reads a stack configuration rejecting unknown and
+// duplicate fields.  Decode failures are returned as DecodeErrors with the
+// line and column of each offending field
+func ParseYAMLBytesStrict(in []byte) (*pb.Stack, error) {
+	var stack pb.Stack
+	err := yaml.UnmarshalStrict(in, &stack)
+	if err == nil {
+		return &stack, nil
+	}
+
+	if te, ok := err.(*yaml.TypeError); ok {
+		return nil, newDecodeErrors(in, te.Errors)
+	}
+	return nil, err
+}
+
+// DecodeError is a manifest decode error at a position in the source
+type DecodeError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// DecodeErrors holds all errors from decoding a manifest
+type DecodeErrors []*DecodeError
+
+func (errs DecodeErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	// e.g. line 12: field port not found in type thrapb.Component
+	yamlFieldErr = regexp.MustCompile(`^line (\d+): field (\S+) not found in type \S+$`)
+	// e.g. line 3: key "name" already set in map
+	yamlKeyErr = regexp.MustCompile(`^line (\d+): key "?(.+?)"? already set in map$`)
+	// e.g. line 4: cannot unmarshal !!str `x` into int32
+	yamlLineErr = regexp.MustCompile(`^line (\d+): (.*)$`)
+)
+
+// newDecodeErrors converts yaml type error messages which only contain the
+// line into errors with the line and column.  The column is that of the
+// offending key on the line
+func newDecodeErrors(src []byte, msgs []string) DecodeErrors {
+	lines := strings.Split(string(src), "\n")
+	column := func(line int, key string) int {
+		if line < 1 || line > len(lines) {
+			return 0
+		}
+		l := lines[line-1]
+		if key != "" {
+			if i := strings.Index(l, key); i >= 0 {
+				return i + 1
+			}
+		}
+		return len(l) - len(strings.TrimLeft(l, " \t")) + 1
+	}
+
+	errs := make(DecodeErrors, 0, len(msgs))
+	for _, msg := range msgs {
+		de := &DecodeError{Msg: msg}
+
+		if m := yamlFieldErr.FindStringSubmatch(msg); m != nil {
+			de.Line, _ = strconv.Atoi(m[1])
+			de.Column = column(de.Line, m[2]+":")
+			de.Msg = "unknown field " + m[2]
+		} else if m := yamlKeyErr.FindStringSubmatch(msg); m != nil {
+			de.Line, _ = strconv.Atoi(m[1])
+			de.Column = column(de.Line, m[2]+":")
+			de.Msg = "duplicate field " + m[2]
+		} else if m := yamlLineErr.FindStringSubmatch(msg); m != nil {
+			de.Line, _ = strconv.Atoi(m[1])
+			de.Column = column(de.Line, "")
+			de.Msg = m[2]
+		}
+
+		errs = append(errs, de)
+	}
+
+	return errs
+}
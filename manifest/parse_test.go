@@ -67,3 +67,73 @@ func Test_parse_HCL(t *testing.T) {
 	}
 	assert.Equal(t, "api.dockerfile", o.Components["api"].Build.Dockerfile)
 }
+
+func Test_ParseYAMLBytesStrict(t *testing.T) {
+	_, err := ParseYAMLStrict("../test-fixtures/thrap.yml")
+	assert.Nil(t, err)
+
+	in := []byte(`name: foo
+components:
+  api:
+    name: foo/api
+    type: api
+    port:
+      http: 8080
+  db:
+    name: postgres
+    version: "10"
+    type: datastore
+    version: "11"
+`)
+	_, err = ParseYAMLBytesStrict(in)
+	if !assert.NotNil(t, err) {
+		return
+	}
+
+	errs, ok := err.(DecodeErrors)
+	if !assert.True(t, ok) || !assert.Equal(t, 2, len(errs)) {
+		return
+	}
+	assert.Equal(t, &DecodeError{Line: 6, Column: 5, Msg: "unknown field port"}, errs[0])
+	assert.Equal(t, 12, errs[1].Line)
+	assert.Equal(t, 5, errs[1].Column)
+	assert.Contains(t, errs[1].Msg, "version")
+}
+
+func Test_ParseHCLBytesStrict(t *testing.T) {
+	_, err := ParseHCLBytesStrict([]byte(testHCLManifest))
+	assert.Nil(t, err)
+
+	// The secrets format is silently dropped by the lenient parser
+	_, err = ParseHCLStrict("../test-fixtures/thrap.hcl")
+	assert.Equal(t, DecodeErrors{{Line: 43, Column: 9, Msg: "unknown field format"}}, err)
+
+	in := []byte(`manifest "foo" {
+  components {
+    api {
+      name = "foo/api"
+      port {
+        http = 8080
+      }
+      build {
+        dockerfile = "api.dockerfile"
+        contxt     = "."
+      }
+    }
+  }
+}
+stack "bar" {}
+`)
+	_, err = ParseHCLBytesStrict(in)
+	if !assert.NotNil(t, err) {
+		return
+	}
+
+	errs, ok := err.(DecodeErrors)
+	if !assert.True(t, ok) || !assert.Equal(t, 3, len(errs)) {
+		return
+	}
+	assert.Equal(t, &DecodeError{Line: 5, Column: 7, Msg: "unknown field port"}, errs[0])
+	assert.Equal(t, &DecodeError{Line: 10, Column: 9, Msg: "unknown field contxt"}, errs[1])
+	assert.Equal(t, &DecodeError{Line: 15, Column: 1, Msg: "unknown field stack"}, errs[2])
+}
@@ -0,0 +1,125 @@
+package manifest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+// SchemaID is the id of the manifest JSON schema
+const SchemaID = "https://github.com/euforia/thrap/docs/thrap.schema.json"
+
+// Allowed values for string fields keyed by <type>.<field>
+var schemaEnums = map[string][]string{
+	"Component.type":       {"web", "api", "datastore", "batch", "periodic"},
+	"Stack.versioning":     {thrapb.VersioningRepo, thrapb.VersioningComponent},
+	"HealthCheck.protocol": {"udp", "tcp", "http", "https"},
+}
+
+// JSONSchema returns the JSON schema (draft-07) of the yaml stack manifest as
+// generated from the yaml field names of thrapb.Stack and the types it
+// contains.  Editors can use it to validate and complete manifests
+func JSONSchema() ([]byte, error) {
+	defs := make(map[string]interface{})
+	root := schemaObject(reflect.TypeOf(thrapb.Stack{}), defs)
+
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["$id"] = SchemaID
+	root["title"] = "thrap stack manifest"
+	root["required"] = []string{"name", "components"}
+	root["definitions"] = defs
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// schemaObject returns the schema of a struct adding any nested structs to
+// defs
+func schemaObject(typ reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	props := make(map[string]interface{})
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+
+		name := yamlFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		prop := schemaType(field.Type, defs)
+		if enum, ok := schemaEnums[typ.Name()+"."+name]; ok {
+			prop["enum"] = enum
+		}
+		props[name] = prop
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// schemaType returns the schema of the go type
+func schemaType(typ reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaType(typ.Elem(), defs),
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaType(typ.Elem(), defs),
+		}
+
+	case reflect.Struct:
+		name := typ.Name()
+		if _, ok := defs[name]; !ok {
+			// Placeholder to stop recursion
+			defs[name] = nil
+			defs[name] = schemaObject(typ, defs)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}
+	}
+
+	return map[string]interface{}{}
+}
+
+// yamlFieldName returns the yaml key of the field or an empty string if it
+// is omitted
+func yamlFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return ""
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+	return strings.ToLower(field.Name)
+}
@@ -0,0 +1,39 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JSONSchema(t *testing.T) {
+	b, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Properties  map[string]map[string]interface{}
+		Definitions map[string]struct {
+			Properties           map[string]map[string]interface{}
+			AdditionalProperties bool
+		}
+	}
+	if err = json.Unmarshal(b, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := schema.Properties["id"]
+	assert.False(t, ok)
+	assert.Equal(t, "object", schema.Properties["components"]["type"])
+
+	comp := schema.Definitions["Component"]
+	assert.False(t, comp.AdditionalProperties)
+	assert.Equal(t, "object", comp.Properties["ports"]["type"])
+	assert.Equal(t, "array", comp.Properties["healthchecks"]["type"])
+	assert.Equal(t, "array", comp.Properties["depends_on"]["type"])
+	assert.NotNil(t, comp.Properties["type"]["enum"])
+	_, ok = schema.Definitions["HealthCheck"]
+	assert.True(t, ok)
+}
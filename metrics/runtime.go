@@ -19,3 +19,13 @@ func (rt *Runtime) End() {
 func (rt *Runtime) Duration(round time.Duration) time.Duration {
 	return rt.end.Sub(rt.start).Round(round)
 }
+
+// StartTime returns the time the runtime was started
+func (rt *Runtime) StartTime() time.Time {
+	return rt.start
+}
+
+// EndTime returns the time the runtime was ended
+func (rt *Runtime) EndTime() time.Time {
+	return rt.end
+}
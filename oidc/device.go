@@ -0,0 +1,122 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// deviceCodeGrant is the device flow token grant type
+const deviceCodeGrant = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Device flow polling error codes
+const (
+	errCodeAuthorizationPending = "authorization_pending"
+	errCodeSlowDown             = "slow_down"
+)
+
+// defaultPollInterval is used when the provider does not specify one
+const defaultPollInterval = 5 * time.Second
+
+// TokenError is an OAuth2 error response
+type TokenError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (err *TokenError) Error() string {
+	if err.Description != "" {
+		return err.Code + ": " + err.Description
+	}
+	return err.Code
+}
+
+// DeviceAuth is a pending device authorization.  The user completes it by
+// entering the user code at the verification uri
+type DeviceAuth struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// Token is the token response of a completed device authorization
+type Token struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// DeviceAuth starts a device authorization
+func (p *Provider) DeviceAuth(ctx context.Context) (*DeviceAuth, error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if disc.DeviceAuthorizationEndpoint == "" {
+		return nil, errNoDeviceEndpoint
+	}
+
+	form := url.Values{
+		"client_id": {p.conf.ClientID},
+		"scope":     {p.conf.scope()},
+	}
+
+	var da DeviceAuth
+	err = p.postForm(ctx, disc.DeviceAuthorizationEndpoint, form, &da)
+	return &da, err
+}
+
+// PollToken polls the token endpoint until the user completes or denies the
+// authorization, it expires or the context is done
+func (p *Provider) PollToken(ctx context.Context, da *DeviceAuth) (*Token, error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultPollInterval
+	if da.Interval > 0 {
+		interval = time.Duration(da.Interval) * time.Second
+	}
+	if da.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(da.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form := url.Values{
+		"grant_type":  {deviceCodeGrant},
+		"device_code": {da.DeviceCode},
+		"client_id":   {p.conf.ClientID},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var tok Token
+		err = p.postForm(ctx, disc.TokenEndpoint, form, &tok)
+		if err == nil {
+			return &tok, nil
+		}
+
+		terr, ok := err.(*TokenError)
+		if !ok {
+			return nil, err
+		}
+		switch terr.Code {
+		case errCodeAuthorizationPending:
+		case errCodeSlowDown:
+			interval += defaultPollInterval
+		default:
+			return nil, terr
+		}
+	}
+}
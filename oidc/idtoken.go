@@ -0,0 +1,257 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Supported id token signing algorithms
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+)
+
+// keyRefetchInterval is the minimum time between fetches of the key set so
+// tokens with unknown key ids cannot make the agent hammer the provider
+const keyRefetchInterval = time.Minute
+
+var (
+	// ErrIDTokenInvalid is used when an id token fails verification or was
+	// not issued to the client
+	ErrIDTokenInvalid = errors.New("invalid oidc id token")
+	// ErrIDTokenExpired is used when an id token has expired
+	ErrIDTokenExpired = errors.New("oidc id token expired")
+
+	errNoJWKS = errors.New("oidc provider has no jwks_uri")
+)
+
+// IDToken holds the verified claims of an id token
+type IDToken struct {
+	Issuer   string
+	Subject  string
+	Audience []string
+	Expiry   time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer   string      `json:"iss"`
+	Subject  string      `json:"sub"`
+	Audience interface{} `json:"aud"`
+	Expiry   float64     `json:"exp"`
+}
+
+// jsonWebKey is a public key of the provider key set
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// VerifyIDToken verifies the signature of the id token by a provider key,
+// that it was issued by the provider to the client and has not expired
+func (p *Provider) VerifyIDToken(ctx context.Context, raw string) (*IDToken, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, ErrIDTokenInvalid
+	}
+
+	var hdr jwtHeader
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, ErrIDTokenInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrIDTokenInvalid
+	}
+
+	key, err := p.signingKey(ctx, hdr.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if !verifySignature(hdr.Alg, key, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, ErrIDTokenInvalid
+	}
+
+	var claims idTokenClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrIDTokenInvalid
+	}
+
+	tok := &IDToken{
+		Issuer:   strings.TrimSuffix(claims.Issuer, "/"),
+		Subject:  claims.Subject,
+		Audience: claimStrings(claims.Audience),
+		Expiry:   time.Unix(int64(claims.Expiry), 0),
+	}
+	if tok.Issuer != p.conf.Issuer || tok.Subject == "" || !contains(tok.Audience, p.conf.ClientID) {
+		return nil, ErrIDTokenInvalid
+	}
+	if !time.Now().Before(tok.Expiry) {
+		return nil, ErrIDTokenExpired
+	}
+
+	return tok, nil
+}
+
+// signingKey returns the provider key with the id.  The key set is fetched
+// again when the id is unknown in case the provider rotated its keys, at most
+// once per keyRefetchInterval
+func (p *Provider) signingKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.kmu.Lock()
+	defer p.kmu.Unlock()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	if time.Since(p.fetched) < keyRefetchInterval {
+		return nil, ErrIDTokenInvalid
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.fetched = time.Now()
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	return nil, ErrIDTokenInvalid
+}
+
+// lookupKey returns the key with the id or the only key if the token does
+// not name one
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// fetchKeys returns the supported signing keys of the provider by id
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if disc.JWKSURI == "" {
+		return nil, errNoJWKS
+	}
+
+	req, err := http.NewRequest(http.MethodGet, disc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = p.do(req.WithContext(ctx), &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key := jwk.publicKey(); key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// publicKey returns the rsa or P-256 key or nil if it is not supported
+func (jwk *jsonWebKey) publicKey() crypto.PublicKey {
+	switch jwk.Kty {
+	case "RSA":
+		n, err1 := decodeBigInt(jwk.N)
+		e, err2 := decodeBigInt(jwk.E)
+		if err1 != nil || err2 != nil || !e.IsInt64() {
+			return nil
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}
+
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil
+		}
+		x, err1 := decodeBigInt(jwk.X)
+		y, err2 := decodeBigInt(jwk.Y)
+		if err1 != nil || err2 != nil || !elliptic.P256().IsOnCurve(x, y) {
+			return nil
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	}
+	return nil
+}
+
+// verifySignature verifies the jws signature.  The algorithm must match the
+// key type so a token cannot choose a weaker one
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) bool {
+	h := sha256.Sum256(signed)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return alg == algRS256 && rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig) == nil
+
+	case *ecdsa.PublicKey:
+		if alg != algES256 || len(sig) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(k, h[:], r, s)
+	}
+	return false
+}
+
+func decodeSegment(seg string, out interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
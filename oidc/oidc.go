@@ -0,0 +1,259 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiscoveryPath is the provider metadata path relative to the issuer
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// DefaultGroupsClaim is the userinfo claim containing the user groups
+const DefaultGroupsClaim = "groups"
+
+var (
+	errIssuerRequired   = errors.New("oidc issuer required")
+	errClientIDRequired = errors.New("oidc client id required")
+	errIssuerMismatch   = errors.New("oidc issuer mismatch")
+	errNoDeviceEndpoint = errors.New("oidc provider does not support the device flow")
+	errNoUserinfo       = errors.New("oidc provider has no userinfo endpoint")
+)
+
+// Config holds the identity provider configuration
+type Config struct {
+	// Issuer url
+	Issuer string
+	// Client id registered with the provider
+	ClientID string
+	// Scopes requested in addition to openid
+	Scopes []string
+	// Userinfo claim holding the user groups.  Defaults to groups
+	GroupsClaim string
+	// Users must be a member of one of these groups if any
+	AllowedGroups []string
+}
+
+// Validate checks required fields and sets defaults
+func (conf *Config) Validate() error {
+	if conf.Issuer == "" {
+		return errIssuerRequired
+	}
+	if conf.ClientID == "" {
+		return errClientIDRequired
+	}
+	if conf.GroupsClaim == "" {
+		conf.GroupsClaim = DefaultGroupsClaim
+	}
+	conf.Issuer = strings.TrimSuffix(conf.Issuer, "/")
+	return nil
+}
+
+// scope returns the space separated scopes always including openid
+func (conf *Config) scope() string {
+	scopes := []string{"openid", "email"}
+	for _, s := range conf.Scopes {
+		if s != "openid" && s != "email" {
+			scopes = append(scopes, s)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// Discovery is the subset of the provider metadata used
+type Discovery struct {
+	Issuer                      string `json:"issuer"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	UserinfoEndpoint            string `json:"userinfo_endpoint"`
+	JWKSURI                     string `json:"jwks_uri"`
+}
+
+// UserInfo holds the claims of an authenticated user
+type UserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	// Sorted groups
+	Groups []string
+}
+
+// Provider is an OpenID Connect identity provider
+type Provider struct {
+	conf   *Config
+	client *http.Client
+
+	mu   sync.Mutex
+	disc *Discovery
+
+	// id token signing keys by id and when they were last fetched
+	kmu     sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// NewProvider returns a provider for the config.  The metadata is fetched on
+// first use
+func NewProvider(conf *Config) (*Provider, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	return &Provider{conf: conf, client: http.DefaultClient}, nil
+}
+
+// Config returns the provider config
+func (p *Provider) Config() *Config {
+	return p.conf
+}
+
+// Discover returns the provider metadata fetching it if needed
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.disc != nil {
+		return p.disc, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, p.conf.Issuer+DiscoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var disc Discovery
+	if err = p.do(req.WithContext(ctx), &disc); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(disc.Issuer, "/") != p.conf.Issuer {
+		return nil, fmt.Errorf("%v: %s", errIssuerMismatch, disc.Issuer)
+	}
+
+	p.disc = &disc
+	return p.disc, nil
+}
+
+// UserInfo returns the claims of the user the access token was issued to
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if disc.UserinfoEndpoint == "" {
+		return nil, errNoUserinfo
+	}
+
+	req, err := http.NewRequest(http.MethodGet, disc.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	claims := make(map[string]interface{})
+	if err = p.do(req.WithContext(ctx), &claims); err != nil {
+		return nil, err
+	}
+
+	ui := &UserInfo{
+		Subject:       claimString(claims["sub"]),
+		Email:         claimString(claims["email"]),
+		EmailVerified: claimBool(claims["email_verified"]),
+		Groups:        claimStrings(claims[p.conf.GroupsClaim]),
+	}
+	sort.Strings(ui.Groups)
+
+	return ui, nil
+}
+
+// Allowed returns true if the user is a member of one of the allowed
+// groups or none are configured
+func (p *Provider) Allowed(ui *UserInfo) bool {
+	if len(p.conf.AllowedGroups) == 0 {
+		return true
+	}
+	for _, g := range p.conf.AllowedGroups {
+		for _, ug := range ui.Groups {
+			if g == ug {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// postForm posts the form to the endpoint decoding the json response into
+// out.  Error responses are decoded into a *TokenError
+func (p *Provider) postForm(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return p.do(req.WithContext(ctx), out)
+}
+
+func (p *Provider) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		var terr TokenError
+		if json.Unmarshal(b, &terr) == nil && terr.Code != "" {
+			return &terr
+		}
+		return fmt.Errorf("oidc %s %s: %s", req.Method, req.URL.Path, resp.Status)
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+func claimString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// claimBool handles providers returning booleans as strings
+func claimBool(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return b == "true"
+	}
+	return false
+}
+
+// claimStrings handles a single string or a list
+func claimStrings(v interface{}) []string {
+	switch l := v.(type) {
+	case string:
+		return []string{l}
+	case []interface{}:
+		out := make([]string, 0, len(l))
+		for _, s := range l {
+			if str, ok := s.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockProvider is a local provider completing the device authorization
+// after the first poll
+type mockProvider struct {
+	*httptest.Server
+	polls int
+}
+
+func newMockProvider() *mockProvider {
+	m := &mockProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                      m.URL,
+			DeviceAuthorizationEndpoint: m.URL + "/device",
+			TokenEndpoint:               m.URL + "/token",
+			UserinfoEndpoint:            m.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(DeviceAuth{
+			DeviceCode:      "devcode",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: m.URL + "/activate",
+			ExpiresIn:       10,
+			Interval:        1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("device_code") != "devcode" || r.Form.Get("grant_type") != deviceCodeGrant {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(TokenError{Code: "invalid_grant"})
+			return
+		}
+		m.polls++
+		if m.polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(TokenError{Code: errCodeAuthorizationPending})
+			return
+		}
+		json.NewEncoder(w).Encode(Token{AccessToken: "access", TokenType: "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sub":            "1234",
+			"email":          "foo@bar.com",
+			"email_verified": "true",
+			"groups":         []string{"eng", "admin"},
+		})
+	})
+	m.Server = httptest.NewServer(mux)
+	return m
+}
+
+func Test_Provider_deviceFlow(t *testing.T) {
+	mock := newMockProvider()
+	defer mock.Close()
+
+	_, err := NewProvider(&Config{Issuer: mock.URL})
+	assert.Equal(t, errClientIDRequired, err)
+
+	p, err := NewProvider(&Config{Issuer: mock.URL + "/", ClientID: "thrap", AllowedGroups: []string{"admin"}})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	da, err := p.DeviceAuth(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCD-EFGH", da.UserCode)
+
+	tok, err := p.PollToken(ctx, da)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, mock.polls)
+
+	ui, err := p.UserInfo(ctx, tok.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, "foo@bar.com", ui.Email)
+	assert.True(t, ui.EmailVerified)
+	assert.Equal(t, []string{"admin", "eng"}, ui.Groups)
+	assert.True(t, p.Allowed(ui))
+
+	_, err = p.UserInfo(ctx, "bad")
+	assert.NotNil(t, err)
+
+	da.DeviceCode = "bad"
+	_, err = p.PollToken(ctx, da)
+	assert.Equal(t, "invalid_grant", err.(*TokenError).Code)
+}
+
+// signIDToken returns an ES256 id token of the claims signed by the key
+func signIDToken(kp *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	hdr, _ := json.Marshal(map[string]string{"alg": algES256, "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	h := sha256.Sum256([]byte(signed))
+	r, s, _ := ecdsa.Sign(rand.Reader, kp, h[:])
+	sig := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):], rb)
+	copy(sig[64-len(sb):], sb)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func Test_Provider_VerifyIDToken(t *testing.T) {
+	kp, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var (
+		srv     *httptest.Server
+		fetches int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Discovery{Issuer: srv.URL, JWKSURI: srv.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []jsonWebKey{{
+			Kty: "EC",
+			Kid: "k1",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(kp.X.Bytes()),
+			Y:   base64.RawURLEncoding.EncodeToString(kp.Y.Bytes()),
+		}}})
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	p, _ := NewProvider(&Config{Issuer: srv.URL, ClientID: "thrap"})
+	ctx := context.Background()
+	claims := func(aud interface{}, exp time.Duration) map[string]interface{} {
+		return map[string]interface{}{
+			"iss": srv.URL,
+			"sub": "1234",
+			"aud": aud,
+			"exp": time.Now().Add(exp).Unix(),
+		}
+	}
+
+	tok, err := p.VerifyIDToken(ctx, signIDToken(kp, "k1", claims("thrap", time.Minute)))
+	assert.Nil(t, err)
+	assert.Equal(t, "1234", tok.Subject)
+
+	_, err = p.VerifyIDToken(ctx, signIDToken(kp, "k1", claims([]string{"other", "thrap"}, time.Minute)))
+	assert.Nil(t, err)
+
+	// Issued to another client
+	_, err = p.VerifyIDToken(ctx, signIDToken(kp, "k1", claims("other", time.Minute)))
+	assert.Equal(t, ErrIDTokenInvalid, err)
+
+	_, err = p.VerifyIDToken(ctx, signIDToken(kp, "k1", claims("thrap", -time.Minute)))
+	assert.Equal(t, ErrIDTokenExpired, err)
+
+	// Signed by another key
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, err = p.VerifyIDToken(ctx, signIDToken(other, "k1", claims("thrap", time.Minute)))
+	assert.Equal(t, ErrIDTokenInvalid, err)
+	_, err = p.VerifyIDToken(ctx, signIDToken(kp, "k2", claims("thrap", time.Minute)))
+	assert.Equal(t, ErrIDTokenInvalid, err)
+
+	// Unknown key ids only refetch the key set once per interval
+	assert.Equal(t, 1, fetches)
+	p.fetched = time.Now().Add(-keyRefetchInterval)
+	_, err = p.VerifyIDToken(ctx, signIDToken(kp, "k2", claims("thrap", time.Minute)))
+	assert.Equal(t, ErrIDTokenInvalid, err)
+	assert.Equal(t, 2, fetches)
+
+	// Unsigned
+	valid := signIDToken(kp, "k1", claims("thrap", time.Minute))
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"k1"}`)) +
+		valid[strings.Index(valid, "."):strings.LastIndex(valid, ".")] + "."
+	_, err = p.VerifyIDToken(ctx, none)
+	assert.Equal(t, ErrIDTokenInvalid, err)
+}
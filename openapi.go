@@ -0,0 +1,201 @@
+package thrap
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// OpenAPI returns the OpenAPI 3 document describing the gateway routes.
+// Schemas are generated from the json tags of the thrapb messages
+func OpenAPI() map[string]interface{} {
+	var (
+		schemas = map[string]interface{}{
+			"Error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"error": map[string]interface{}{"type": "string"},
+				},
+			},
+		}
+		paths = map[string]interface{}{}
+	)
+
+	for _, route := range gatewayRoutes {
+		item, ok := paths[route.path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[route.path] = item
+		}
+		item[strings.ToLower(route.method)] = route.operation(schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "thrap agent",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"session": map[string]interface{}{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "Session token issued by thrap login",
+				},
+			},
+		},
+		// Authentication is optional if the agent does not require it
+		"security": []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"session": []string{}},
+		},
+	}
+}
+
+// operation returns the OpenAPI operation of the route, adding the schemas
+// it references
+func (route *gatewayRoute) operation(schemas map[string]interface{}) map[string]interface{} {
+	params := []interface{}{}
+	if strings.Contains(route.path, "{id}") {
+		params = append(params, map[string]interface{}{
+			"name":     "id",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, q := range route.query {
+		params = append(params, map[string]interface{}{
+			"name":   q,
+			"in":     "query",
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+
+	resp := schemaOf(reflect.TypeOf(route.resp), schemas)
+	if route.stream {
+		resp = map[string]interface{}{"type": "array", "items": resp}
+	}
+
+	op := map[string]interface{}{
+		"operationId": route.rpc,
+		"summary":     route.summary,
+		"parameters":  params,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "OK",
+				"content":     jsonContent(resp),
+			},
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content":     jsonContent(map[string]interface{}{"$ref": schemaRefPrefix + "Error"}),
+			},
+		},
+	}
+	if route.body != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(schemaOf(reflect.TypeOf(route.body), schemas)),
+		}
+	}
+	return op
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+// schemaOf returns the schema of the type.  Structs are added to schemas and
+// referenced by name
+func schemaOf(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), schemas)
+
+	case reflect.Struct:
+		ref := map[string]interface{}{"$ref": schemaRefPrefix + t.Name()}
+		if _, ok := schemas[t.Name()]; ok {
+			return ref
+		}
+		props := map[string]interface{}{}
+		schema := map[string]interface{}{"type": "object", "properties": props}
+		// Add before the fields for self referencing messages
+		schemas[t.Name()] = schema
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.PkgPath != "" || name == "" || name == "-" {
+				continue
+			}
+			props[name] = schemaOf(field.Type, schemas)
+		}
+		return ref
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaOf(t.Elem(), schemas),
+		}
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.Int32, reflect.Uint32:
+		schema := map[string]interface{}{"type": "integer", "format": "int32"}
+		if vals := proto.EnumValueMap(t.Name()); vals != nil {
+			schema["enum"], schema["description"] = enumValues(vals)
+		}
+		return schema
+
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	}
+
+	return map[string]interface{}{}
+}
+
+// enumValues returns the sorted values of a proto enum and a description
+// naming them
+func enumValues(vals map[string]int32) ([]int32, string) {
+	var (
+		nums  = make([]int32, 0, len(vals))
+		names = make(map[int32]string, len(vals))
+	)
+	for name, num := range vals {
+		nums = append(nums, num)
+		names[num] = name
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	desc := make([]string, len(nums))
+	for i, num := range nums {
+		desc[i] = names[num] + "=" + strconv.Itoa(int(num))
+	}
+	return nums, strings.Join(desc, ", ")
+}
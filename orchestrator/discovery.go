@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/hashicorp/hil/ast"
+)
+
+// Discoverer is implemented by orchestrators that provide their own service
+// discovery.  The returned variables are comp.<id>.container.ip and
+// comp.<id>.container.addr.<port label> for each component
+type Discoverer interface {
+	DiscoveryVars(stack *thrapb.Stack) scope.Variables
+}
+
+// DiscoveryVars returns the service discovery variables of the orchestrator
+// for the stack.  Orchestrators not implementing Discoverer get the docker
+// network names
+func DiscoveryVars(orch Orchestrator, stack *thrapb.Stack) scope.Variables {
+	if d, ok := orch.(Discoverer); ok {
+		return d.DiscoveryVars(stack)
+	}
+	return dockerDiscoveryVars(stack)
+}
+
+// DiscoveryVars returns the container names on the stack network
+func (orch *DockerOrchestrator) DiscoveryVars(stack *thrapb.Stack) scope.Variables {
+	return dockerDiscoveryVars(stack)
+}
+
+// dockerDiscoveryVars returns <comp>.<stack> as the container ip and
+// <comp>.<stack>:<port> as the address, both resolved by the stack network
+func dockerDiscoveryVars(stack *thrapb.Stack) scope.Variables {
+	svars := make(scope.Variables)
+	for k, comp := range stack.Components {
+		ip := k + "." + stack.ID
+		setDiscoveryVar(svars, comp, "container.ip", ip)
+		for pl, p := range comp.Ports {
+			setDiscoveryVar(svars, comp, "container.addr."+pl, fmt.Sprintf("%s:%d", ip, p))
+		}
+	}
+	return svars
+}
+
+// DiscoveryVars returns consul based discovery variables matching the
+// services registered by manifest.MakeNomadJob.  The container ip is the
+// consul dns name of the first port label i.e.
+// <label>.<comp>.<stack>.service.consul.  As ports are dynamic, addresses
+// are consul-template expressions which are rendered into the task
+// environment.  Components without ports are not registered and get no
+// variables
+func (orch *nomadOrchestrator) DiscoveryVars(stack *thrapb.Stack) scope.Variables {
+	svars := make(scope.Variables)
+	for _, comp := range stack.Components {
+		labels := make([]string, 0, len(comp.Ports))
+		for pl := range comp.Ports {
+			labels = append(labels, pl)
+		}
+		if len(labels) == 0 {
+			continue
+		}
+		sort.Strings(labels)
+
+		setDiscoveryVar(svars, comp, "container.ip", consulServiceName(stack.ID, comp.ID, labels[0])+".service.consul")
+		for _, pl := range labels {
+			setDiscoveryVar(svars, comp, "container.addr."+pl, consulServiceAddr(stack.ID, comp.ID, pl))
+		}
+	}
+	return svars
+}
+
+// consulServiceName returns the tag qualified consul service name of the
+// component port
+func consulServiceName(stackID, compID, label string) string {
+	return label + "." + compID + "." + stackID
+}
+
+// consulServiceAddr returns a consul-template expression rendering the
+// address of the first healthy instance of the component port
+func consulServiceAddr(stackID, compID, label string) string {
+	return fmt.Sprintf(`{{ with service "%s" }}{{ with index . 0 }}{{ .Address }}:{{ .Port }}{{ end }}{{ end }}`,
+		consulServiceName(stackID, compID, label))
+}
+
+func setDiscoveryVar(svars scope.Variables, comp *thrapb.Component, key, val string) {
+	svars[comp.ScopeVarName(consts.CompVarPrefixKey+".", key)] = ast.Variable{
+		Type:  ast.TypeString,
+		Value: val,
+	}
+}
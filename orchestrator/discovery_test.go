@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDiscoveryStack() *thrapb.Stack {
+	return &thrapb.Stack{
+		ID: "foo",
+		Components: map[string]*thrapb.Component{
+			"api": &thrapb.Component{
+				ID:    "api",
+				Ports: map[string]int32{"http": 8080, "admin": 9090},
+			},
+			"batch": &thrapb.Component{ID: "batch"},
+		},
+	}
+}
+
+func Test_DiscoveryVars_docker(t *testing.T) {
+	st := testDiscoveryStack()
+	svars := DiscoveryVars(nil, st)
+
+	assert.Equal(t, "api.foo", svars["comp.api.container.ip"].Value)
+	assert.Equal(t, "api.foo:8080", svars["comp.api.container.addr.http"].Value)
+	assert.Equal(t, "batch.foo", svars["comp.batch.container.ip"].Value)
+
+	assert.Equal(t, svars, DiscoveryVars(&DockerOrchestrator{}, st))
+}
+
+func Test_DiscoveryVars_nomad(t *testing.T) {
+	st := testDiscoveryStack()
+	svars := DiscoveryVars(&nomadOrchestrator{}, st)
+
+	assert.Equal(t, "admin.api.foo.service.consul", svars["comp.api.container.ip"].Value)
+	assert.Contains(t, svars["comp.api.container.addr.http"].Value, `service "http.api.foo"`)
+	_, ok := svars["comp.batch.container.ip"]
+	assert.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/docker/docker/api/types"
 	"github.com/euforia/thrap/crt"
@@ -145,9 +146,38 @@ func (orch *DockerOrchestrator) Destroy(ctx context.Context, stack *thrapb.Stack
 		}
 		ar = append(ar, r)
 	}
+
+	ar = append(ar, &thrapb.ActionResult{
+		Action:   "destroy",
+		Resource: "network",
+		Error:    orch.crt.RemoveNetwork(ctx, stack.ID),
+	})
+
 	return ar
 }
 
+// Addrs returns the host addresses of all published ports of a component
+func (orch *DockerOrchestrator) Addrs(ctx context.Context, stack *thrapb.Stack, compID string) ([]string, error) {
+	details, err := orch.crt.Inspect(ctx, compID+"."+stack.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(details.NetworkSettings.Ports))
+	for _, bindings := range details.NetworkSettings.Ports {
+		for _, b := range bindings {
+			host := b.HostIP
+			if host == "" || host == "0.0.0.0" {
+				host = "localhost"
+			}
+			addrs = append(addrs, host+":"+b.HostPort)
+		}
+	}
+	sort.Strings(addrs)
+
+	return addrs, nil
+}
+
 func (orch *DockerOrchestrator) startContainer(ctx context.Context, sid string, comp *thrapb.Component) error {
 	cfg := thrapb.NewContainer(sid, comp.ID)
 
@@ -124,3 +124,38 @@ func (orch *nomadOrchestrator) Destroy(ctx context.Context, stack *thrapb.Stack)
 
 	return ar
 }
+
+// Addrs returns the addresses of all dynamic ports allocated to the
+// component's task in running allocations
+func (orch *nomadOrchestrator) Addrs(ctx context.Context, stack *thrapb.Stack, compID string) ([]string, error) {
+	qopts := &nomad.QueryOptions{AllowStale: false}
+	stubs, _, err := orch.client.Jobs().Allocations(stack.ID, false, qopts)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0)
+	for _, stub := range stubs {
+		if stub.ClientStatus != nomad.AllocClientStatusRunning {
+			continue
+		}
+
+		alloc, _, err := orch.client.Allocations().Info(stub.ID, qopts)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, res := range alloc.TaskResources {
+			if !strings.HasSuffix(name, "."+compID) {
+				continue
+			}
+			for _, n := range res.Networks {
+				for _, p := range n.DynamicPorts {
+					addrs = append(addrs, fmt.Sprintf("%s:%d", n.IP, p.Value))
+				}
+			}
+		}
+	}
+
+	return addrs, nil
+}
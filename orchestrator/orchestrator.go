@@ -41,6 +41,13 @@ type Orchestrator interface {
 	Status(ctx context.Context, stack *thrapb.Stack) []*thrapb.CompStatus
 }
 
+// Addresser is implemented by orchestrators that can report the externally
+// reachable addresses of a deployed component
+type Addresser interface {
+	// Addrs returns host:port addresses for the component
+	Addrs(ctx context.Context, stack *thrapb.Stack, compID string) ([]string, error)
+}
+
 // New returns a new orchestrator based on the given config
 func New(conf *Config) (Orchestrator, error) {
 	var (
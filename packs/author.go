@@ -0,0 +1,458 @@
+package packs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/euforia/pseudo"
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/dockerfile"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/utils"
+	"github.com/euforia/thrap/vars"
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hil/ast"
+	"github.com/pkg/errors"
+)
+
+var (
+	errPackExists                = errors.New("pack exists")
+	errUnknownPackType           = errors.New("pack type must be dev, web, datastore or stack")
+	errDefaultVersionRequired    = errors.New("default version required")
+	errDefaultVersionUnsupported = errors.New("default version does not satisfy any version constraint")
+	errImageRequired             = errors.New("image required")
+	errFromMissing               = errors.New("stage does not begin with FROM")
+)
+
+const devManifestTmpl = `# Language name
+Name = "%s"
+
+# Supported language version constraints
+Versions = [">= 1.0"]
+
+# Version used when the stack does not specify one
+DefaultVersion = "1.0"
+
+# Source file extensions, the primary first
+FileExts = [".%s"]
+
+# Build output to exclude from the image context
+IgnoreFiles = []
+
+# Base images to build and publish with
+DevImages = ["%s"]
+PubImages = ["alpine"]
+
+# Files rendered into the project.  One must be a dockerfile
+ScaffoldFiles = ["dockerfile"]
+`
+
+const devDockerfileTmpl = `FROM ${lang.id}:${lang.version}
+
+WORKDIR /src
+COPY . .
+
+CMD ["/bin/sh"]
+`
+
+const baseManifestTmpl = `# Pack name
+Name = "%s"
+
+# Container image
+Image = "%s"
+
+# Supported image version constraints
+Versions = []
+
+# Version used when the stack does not specify one
+DefaultVersion = "latest"
+
+# Additional files rendered into the project
+Files = []
+`
+
+const stackManifestTmpl = `# Pack name
+Name = "%s"
+
+# Description shown when listing stack templates
+Description = ""
+
+# Parameters prompted for at init.  Available to the template and files as
+# param.<name>
+Param "datastore" {
+  Prompt  = "Data store image"
+  Default = "postgres"
+  Options = ["postgres", "mysql"]
+}
+
+Param "datastore_version" {
+  Prompt  = "Data store version"
+  Default = "10"
+}
+
+# Stack manifest template.  Variables evaluated at build or deploy time must
+# be escaped with $$
+Template = "stack.yml"
+
+# Additional files rendered into the project
+Files = []
+`
+
+const stackTemplateTmpl = `components:
+  api:
+    name: api
+    type: api
+    head: true
+    language: go
+    ports:
+      http: 8080
+    build:
+      dockerfile: api.dockerfile
+    env:
+      vars:
+        DB_ADDR: $${comp.db.container.ip}
+  db:
+    name: ${param.datastore}
+    version: "${param.datastore_version}"
+    type: datastore
+`
+
+// PackType returns the pack type from the directory of a pack within a
+// packs tree i.e. <type>/<id>
+func PackType(dir string) (string, error) {
+	typ := filepath.Base(filepath.Dir(dir))
+	switch typ {
+	case devPackID, webPackID, dsPackID, stackPackID:
+		return typ, nil
+	}
+	return "", errUnknownPackType
+}
+
+// SampleScopeVars returns scope variables with sample values for all
+// variables available to packs at scaffold time
+func SampleScopeVars() scope.Variables {
+	svars := make(scope.Variables)
+	for _, name := range []string{
+		vars.StackID, vars.StackName, vars.StackDescription,
+		vars.VcsID, vars.VcsAddr, vars.VcsUsername, vars.VcsRepoOwner, vars.VcsRepoName,
+	} {
+		svars[name] = ast.Variable{Type: ast.TypeString, Value: "sample"}
+	}
+	svars[vars.StackVersion] = ast.Variable{Type: ast.TypeString, Value: "v0.1.0"}
+
+	return svars
+}
+
+// Scaffold writes a skeleton pack of the given type to <dir>/<id> returning
+// the pack directory
+func Scaffold(dir, typ, id string) (string, error) {
+	pdir := filepath.Join(dir, id)
+	if utils.FileExists(pdir) {
+		return pdir, errPackExists
+	}
+
+	files := make(map[string]string, 2)
+	switch typ {
+	case devPackID:
+		files[packManfiestFile] = fmt.Sprintf(devManifestTmpl, id, id, id)
+		files[consts.DefaultDockerFile] = devDockerfileTmpl
+
+	case webPackID, dsPackID:
+		files[packManfiestFile] = fmt.Sprintf(baseManifestTmpl, id, id)
+
+	case stackPackID:
+		files[packManfiestFile] = fmt.Sprintf(stackManifestTmpl, id)
+		files[defaultStackTemplate] = stackTemplateTmpl
+
+	default:
+		return pdir, errUnknownPackType
+	}
+
+	if err := os.MkdirAll(pdir, 0755); err != nil {
+		return pdir, err
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(pdir, name), []byte(content), 0644); err != nil {
+			return pdir, err
+		}
+	}
+
+	return pdir, nil
+}
+
+// ValidatePack validates the pack in the directory.  It checks the manifest
+// fields and version constraints, that a dev pack has a dockerfile and
+// renders every file with sample scope variables.  Errors are keyed by the
+// field or file
+func ValidatePack(dir, typ string) map[string]error {
+	var errs map[string]error
+	switch typ {
+	case devPackID:
+		_, errs = validateDevPack(dir)
+	case webPackID, dsPackID:
+		_, errs = validateBasePack(dir)
+	case stackPackID:
+		_, errs = validateStackPack(dir)
+	default:
+		errs = map[string]error{"type": errUnknownPackType}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateDevPack(dir string) (*DevPack, map[string]error) {
+	errs := make(map[string]error)
+
+	var conf thrapb.Language
+	if err := readPackManifest(dir, &conf); err != nil {
+		errs["manifest"] = err
+		return nil, errs
+	}
+
+	lp := &DevPack{dir: dir, Language: &conf, vm: pseudo.NewVM()}
+	lp.Name = filepath.Base(dir)
+
+	if err := lp.setVersionContraints(); err != nil {
+		errs["versions"] = err
+	} else if err = checkDefaultVersion(conf.DefaultVersion, lp.vc); err != nil {
+		errs["defaultversion"] = err
+	}
+
+	if lp.getDockerfile() == "" {
+		errs["scaffoldfiles"] = errDockerfileMissing
+	}
+
+	svars := vars.MergeScopeVars(SampleScopeVars(), lp.ScopeVars())
+	for _, fpath := range conf.ScaffoldFiles {
+		if _, err := lp.parseEval(fpath, svars); err != nil {
+			errs["file."+fpath] = err
+		}
+	}
+
+	return lp, errs
+}
+
+func validateBasePack(dir string) (*BasePack, map[string]error) {
+	errs := make(map[string]error)
+
+	var conf thrapb.PackManifest
+	if err := readPackManifest(dir, &conf); err != nil {
+		errs["manifest"] = err
+		return nil, errs
+	}
+
+	if conf.Image == "" {
+		errs["image"] = errImageRequired
+	}
+
+	vcs := make([]version.Constraints, len(conf.Versions))
+	for i, v := range conf.Versions {
+		c, err := version.NewConstraint(v)
+		if err != nil {
+			errs["versions"] = err
+			break
+		}
+		vcs[i] = c
+	}
+	if _, ok := errs["versions"]; !ok {
+		if err := checkDefaultVersion(conf.DefaultVersion, vcs); err != nil {
+			errs["defaultversion"] = err
+		}
+	}
+
+	var (
+		vm    = pseudo.NewVM()
+		svars = SampleScopeVars()
+	)
+	for _, fpath := range conf.Files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, fpath))
+		if err == nil {
+			_, err = vm.ParseEval(string(b), svars)
+		}
+		if err != nil {
+			errs["file."+fpath] = err
+		}
+	}
+
+	return &BasePack{dir: dir, PackManifest: &conf}, errs
+}
+
+func validateStackPack(dir string) (*StackPack, map[string]error) {
+	errs := make(map[string]error)
+
+	sp, err := LoadStackPack(filepath.Base(dir), filepath.Dir(dir))
+	if err != nil {
+		errs["manifest"] = err
+		return nil, errs
+	}
+
+	for i, p := range sp.Params {
+		if p.Name == "" {
+			errs["param."+strconv.Itoa(i)] = errors.New("name required")
+		} else if p.Default != "" && len(p.Options) > 0 && !hasString(p.Options, p.Default) {
+			errs["param."+p.Name] = errors.Wrap(errParamNotAllowed, p.Default)
+		}
+	}
+
+	svars := vars.MergeScopeVars(SampleScopeVars(), sampleParamVars(sp))
+	stack, err := sp.Stack(svars)
+	if err != nil {
+		errs["template"] = err
+	} else {
+		stack.ID = "sample"
+		for k, v := range stack.Validate() {
+			errs["template."+k] = v
+		}
+	}
+
+	for _, fpath := range sp.StackManifest.Files {
+		if _, err := sp.parseEval(fpath, svars); err != nil {
+			errs["file."+fpath] = err
+		}
+	}
+
+	return sp, errs
+}
+
+// sampleParamVars returns the parameter defaults, the first option or a
+// sample value as scope variables
+func sampleParamVars(sp *StackPack) scope.Variables {
+	svars := make(scope.Variables, len(sp.Params))
+	for _, p := range sp.Params {
+		val := p.Default
+		if val == "" {
+			val = "sample"
+			if len(p.Options) > 0 {
+				val = p.Options[0]
+			}
+		}
+		svars[StackParamPrefix+p.Name] = ast.Variable{Type: ast.TypeString, Value: val}
+	}
+	return svars
+}
+
+func readPackManifest(dir string, v interface{}) error {
+	b, err := ioutil.ReadFile(filepath.Join(dir, packManfiestFile))
+	if err != nil {
+		return err
+	}
+	return hcl.Unmarshal(b, v)
+}
+
+// checkDefaultVersion checks the default version is set and satisfies at
+// least one constraint if there are any
+func checkDefaultVersion(dv string, vcs []version.Constraints) error {
+	if dv == "" {
+		return errDefaultVersionRequired
+	}
+	if len(vcs) == 0 {
+		return nil
+	}
+
+	v, err := version.NewVersion(dv)
+	if err != nil {
+		return err
+	}
+	for _, c := range vcs {
+		if c.Check(v) {
+			return nil
+		}
+	}
+
+	return errDefaultVersionUnsupported
+}
+
+// TestPack validates the pack then renders all of its files with sample
+// scope variables into outdir, parsing each rendered dockerfile.  Every
+// dockerfile stage must begin with a FROM instruction
+func TestPack(dir, typ, outdir string) error {
+	if errs := ValidatePack(dir, typ); len(errs) > 0 {
+		return utils.FlattenErrors(errs)
+	}
+
+	var (
+		files []string
+		svars = SampleScopeVars()
+		vm    = pseudo.NewVM()
+	)
+
+	switch typ {
+	case devPackID:
+		lp, _ := validateDevPack(dir)
+		svars = vars.MergeScopeVars(svars, lp.ScopeVars())
+		files = lp.ScaffoldFiles
+	case stackPackID:
+		sp, _ := validateStackPack(dir)
+		svars = vars.MergeScopeVars(svars, sampleParamVars(sp))
+		files = append([]string{sp.Template}, sp.StackManifest.Files...)
+	default:
+		bp, _ := validateBasePack(dir)
+		files = bp.Files
+	}
+
+	for _, fpath := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, fpath))
+		if err != nil {
+			return err
+		}
+		result, err := vm.ParseEval(string(b), svars)
+		if err != nil {
+			return errors.Wrap(err, fpath)
+		}
+
+		out := filepath.Join(outdir, fpath)
+		if err = writeFile(out, strings.NewReader(result.Value.(string)), 0644); err != nil {
+			return err
+		}
+
+		if isDockerfile(fpath) {
+			if err = testDockerfile(out); err != nil {
+				return errors.Wrap(err, fpath)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testDockerfile(fpath string) error {
+	raw, err := dockerfile.ParseFile(fpath)
+	if err != nil {
+		return err
+	}
+
+	for i, stage := range raw.Stages {
+		for j, inst := range stage {
+			if inst.Op == dockerfile.KeyComment {
+				continue
+			}
+			if isFirstNonComment(stage, j) && inst.Op != dockerfile.KeyFrom {
+				return errors.Wrapf(errFromMissing, "stage %d", i)
+			}
+			_, err = dockerfile.ParseInstruction(inst)
+			if err != nil && err != dockerfile.ErrUnsupportedInstruction {
+				return errors.Wrapf(err, "stage %d: %s", i, inst.Op)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isFirstNonComment(stage dockerfile.RawInstructions, idx int) bool {
+	for _, inst := range stage[:idx] {
+		if inst.Op != dockerfile.KeyComment {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package packs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scaffold_Validate_Test(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "packauthor-")
+	defer os.RemoveAll(tmpdir)
+
+	for _, typ := range []string{devPackID, webPackID, dsPackID, stackPackID} {
+		pdir, err := Scaffold(filepath.Join(tmpdir, typ), typ, "sample")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ptyp, err := PackType(pdir)
+		assert.Nil(t, err)
+		assert.Equal(t, typ, ptyp)
+
+		assert.Nil(t, ValidatePack(pdir, typ), typ)
+
+		outdir := filepath.Join(tmpdir, "out-"+typ)
+		assert.Nil(t, TestPack(pdir, typ, outdir), typ)
+
+		_, err = Scaffold(filepath.Join(tmpdir, typ), typ, "sample")
+		assert.Equal(t, errPackExists, err)
+	}
+
+	_, err := Scaffold(tmpdir, "foo", "sample")
+	assert.Equal(t, errUnknownPackType, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(tmpdir, "out-dev", "dockerfile"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "FROM sample:1.0")
+}
+
+func Test_ValidatePack_errors(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "packvalidate-")
+	defer os.RemoveAll(tmpdir)
+
+	pdir := filepath.Join(tmpdir, "dev", "bad")
+	os.MkdirAll(pdir, 0755)
+
+	manifest := `Versions = ["~> 2.0"]
+DefaultVersion = "1.0"
+ScaffoldFiles = ["main.go"]
+`
+	ioutil.WriteFile(filepath.Join(pdir, packManfiestFile), []byte(manifest), 0644)
+	ioutil.WriteFile(filepath.Join(pdir, "main.go"), []byte("package ${lang.id"), 0644)
+
+	errs := ValidatePack(pdir, devPackID)
+	assert.Equal(t, errDefaultVersionUnsupported, errs["defaultversion"])
+	assert.Equal(t, errDockerfileMissing, errs["scaffoldfiles"])
+	assert.NotNil(t, errs["file.main.go"])
+
+	ioutil.WriteFile(filepath.Join(pdir, packManfiestFile), []byte(`Versions = ["x.y"]`), 0644)
+	errs = ValidatePack(pdir, devPackID)
+	assert.NotNil(t, errs["versions"])
+
+	assert.Equal(t, errFromMissing, testDockerfileString(t, tmpdir, "WORKDIR /src\nFROM alpine\n"))
+	assert.Nil(t, testDockerfileString(t, tmpdir, "# comment\nFROM alpine\nRUN ls\n"))
+}
+
+func testDockerfileString(t *testing.T, dir, content string) error {
+	fpath := filepath.Join(dir, "dockerfile")
+	ioutil.WriteFile(fpath, []byte(content), 0644)
+	return errors.Cause(testDockerfile(fpath))
+}
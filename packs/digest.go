@@ -0,0 +1,120 @@
+package packs
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/euforia/thrap/utils"
+)
+
+// SignatureFile is the file within a pack directory holding the hex encoded
+// signature of the pack digest
+const SignatureFile = "pack.sig"
+
+// digestPrefix is prepended to hex encoded pack digests
+const digestPrefix = "sha256:"
+
+var (
+	errSignatureMissing = errors.New("pack signature missing")
+	errInvalidSignature = errors.New("invalid pack signature")
+)
+
+// Digest returns the sha256 digest of all files in the pack directory
+// excluding the signature file.  Files are hashed in lexical order of their
+// relative paths so the digest is stable across machines
+func Digest(dir string) (string, error) {
+	sum, err := digest(dir)
+	if err != nil {
+		return "", err
+	}
+	return digestPrefix + hex.EncodeToString(sum), nil
+}
+
+func digest(dir string) ([]byte, error) {
+	files := make([]string, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel != SignatureFile {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+
+	return h.Sum(nil), nil
+}
+
+// Sign signs the pack directory digest with the key writing the signature
+// file into the directory
+func Sign(dir string, kp *ecdsa.PrivateKey) error {
+	sum, err := digest(dir)
+	if err != nil {
+		return err
+	}
+
+	sig, err := utils.SignData(kp, sum)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, SignatureFile), []byte(hex.EncodeToString(sig)), 0644)
+}
+
+// Verify verifies the pack directory signature against the public key
+func Verify(dir string, pubkey []byte) error {
+	b, err := ioutil.ReadFile(filepath.Join(dir, SignatureFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errSignatureMissing
+		}
+		return err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	sum, err := digest(dir)
+	if err != nil {
+		return err
+	}
+
+	if !utils.VerifySignature(pubkey, sum, sig) {
+		return errInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package packs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/euforia/thrap/utils"
+	"github.com/pkg/errors"
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+var (
+	errInvalidPackName     = errors.New("pack name must be <type>/<id>")
+	errInvalidPackType     = errors.New("pack type must be dev, web, datastore or stack")
+	errPackNotFound        = errors.New("pack not found")
+	errPackNotInstalled    = errors.New("pack not installed")
+	errPackVersionMismatch = errors.New("installed pack version does not match")
+	errPackModified        = errors.New("installed pack does not match lockfile")
+)
+
+// PackRef references a pack available from a source
+type PackRef struct {
+	// One of dev, web or datastore
+	Type string
+	// Pack id within the type
+	ID string
+	// Source providing the pack
+	Source string
+	// Version the source is fetched at
+	Version string
+}
+
+// Name returns the pack name i.e. <type>/<id>
+func (ref *PackRef) Name() string {
+	return ref.Type + "/" + ref.ID
+}
+
+// ParsePackName parses <type>/<id>[@version] returning the name and version
+func ParsePackName(s string) (name string, version string, err error) {
+	name = s
+	if i := strings.LastIndex(s, "@"); i > 0 {
+		name, version = s[:i], s[i+1:]
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", errInvalidPackName
+	}
+
+	switch parts[0] {
+	case devPackID, webPackID, dsPackID, stackPackID:
+	default:
+		return "", "", errInvalidPackType
+	}
+
+	return name, version, nil
+}
+
+func (packs *Packs) lockFile() string {
+	return filepath.Join(packs.dir, LockFile)
+}
+
+// Installed returns the lock of all installed packs
+func (packs *Packs) Installed() (*Lock, error) {
+	return ReadLock(packs.lockFile())
+}
+
+// Available returns all packs available from the source
+func (packs *Packs) Available(source string) ([]*PackRef, error) {
+	src, err := packs.Source(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		root    = packs.sourceRoot(src)
+		version = packs.sourceVersion(src)
+		out     = make([]*PackRef, 0)
+	)
+
+	for _, typ := range []string{devPackID, dsPackID, webPackID, stackPackID} {
+		files, err := ioutil.ReadDir(filepath.Join(root, typ))
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if !f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+				continue
+			}
+			out = append(out, &PackRef{Type: typ, ID: f.Name(), Source: src.Name, Version: version})
+		}
+	}
+
+	return out, nil
+}
+
+// Add installs a pack given as <type>/<id>[@version] from the named source.
+// If no source is given the first source by name providing the pack is
+// used.  The pack signature is verified if the source has a public key and
+// the installed pack is recorded in the lockfile
+func (packs *Packs) Add(pack, source string) (*LockedPack, error) {
+	name, version, err := ParsePackName(pack)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := packs.findSource(name, source)
+	if err != nil {
+		return nil, err
+	}
+
+	// Packs are installed from the source as last fetched unless a
+	// different version is requested
+	current := packs.sourceVersion(src)
+	if version == "" {
+		version = current
+	} else if version != current {
+		if version, err = packs.fetch(src, version); err != nil {
+			return nil, err
+		}
+	}
+
+	srcDir := filepath.Join(packs.sourceRoot(src), filepath.FromSlash(name))
+	if !utils.FileExists(srcDir) {
+		return nil, errors.Wrap(errPackNotFound, name)
+	}
+
+	locked := &LockedPack{Source: src.Name, Version: version}
+
+	pubkey, err := src.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	if pubkey != nil {
+		if err = Verify(srcDir, pubkey); err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+		locked.Verified = true
+	}
+
+	dstDir := filepath.Join(packs.dir, filepath.FromSlash(name))
+	if err = os.RemoveAll(dstDir); err != nil {
+		return nil, err
+	}
+	if err = copyDir(srcDir, dstDir); err != nil {
+		return nil, err
+	}
+
+	if locked.Digest, err = Digest(dstDir); err != nil {
+		return nil, err
+	}
+
+	lock, err := packs.Installed()
+	if err != nil {
+		return nil, err
+	}
+	lock.Packs[name] = locked
+
+	return locked, lock.Write(packs.lockFile())
+}
+
+// findSource returns the named source or the first source by name
+// containing the pack
+func (packs *Packs) findSource(name, source string) (*Source, error) {
+	if source != "" {
+		return packs.Source(source)
+	}
+
+	srcs, err := packs.Sources()
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range srcs {
+		if utils.FileExists(filepath.Join(packs.sourceRoot(src), filepath.FromSlash(name))) {
+			return src, nil
+		}
+	}
+
+	return nil, errors.Wrap(errPackNotFound, name)
+}
+
+// Remove uninstalls a pack given as <type>/<id> removing it from the
+// lockfile
+func (packs *Packs) Remove(pack string) error {
+	name, _, err := ParsePackName(pack)
+	if err != nil {
+		return err
+	}
+
+	lock, err := packs.Installed()
+	if err != nil {
+		return err
+	}
+	if _, ok := lock.Packs[name]; !ok {
+		return errors.Wrap(errPackNotInstalled, name)
+	}
+
+	if err = os.RemoveAll(filepath.Join(packs.dir, filepath.FromSlash(name))); err != nil {
+		return err
+	}
+
+	delete(lock.Packs, name)
+	return lock.Write(packs.lockFile())
+}
+
+// Check verifies the pinned packs, keyed by <type>/<id> with the version as
+// the value, are installed at the version and unmodified since install
+func (packs *Packs) Check(pins map[string]string) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	lock, err := packs.Installed()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(pins))
+	for k := range pins {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		locked, ok := lock.Packs[name]
+		if !ok {
+			return errors.Wrap(errPackNotInstalled, name)
+		}
+		if want := pins[name]; want != "" && want != locked.Version {
+			return errors.Wrapf(errPackVersionMismatch, "%s: pinned=%s installed=%s",
+				name, want, locked.Version)
+		}
+
+		dgst, err := Digest(filepath.Join(packs.dir, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		if dgst != locked.Digest {
+			return errors.Wrap(errPackModified, name)
+		}
+	}
+
+	return nil
+}
+
+// Update fetches the latest from all sources.  Installed packs are not
+// changed and must be re-added to pick up updates.  It returns
+// git.NoErrAlreadyUpToDate if no source changed
+func (packs *Packs) Update() error {
+	// Packs cloned directly into the directory prior to sources
+	if utils.FileExists(filepath.Join(packs.dir, ".git")) {
+		return packs.updateLegacy()
+	}
+
+	srcs, err := packs.Sources()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, src := range srcs {
+		prev := packs.sourceVersion(src)
+		if _, err = packs.fetch(src, ""); err != nil {
+			return errors.Wrap(err, src.Name)
+		}
+		if packs.sourceVersion(src) != prev {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return git.NoErrAlreadyUpToDate
+	}
+	return nil
+}
+
+// updateLegacy performs a git pull on a packs directory that is a clone of
+// a packs repo
+func (packs *Packs) updateLegacy() error {
+	repo, err := git.PlainOpen(packs.dir)
+	if err != nil {
+		return err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	opt := &git.PullOptions{Progress: os.Stdout}
+	return wt.Pull(opt)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return writeFile(target, io.Reader(f), info.Mode().Perm())
+	})
+}
@@ -0,0 +1,131 @@
+package packs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euforia/thrap/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestPack(t *testing.T, dir string) {
+	os.MkdirAll(filepath.Join(dir, "files"), 0755)
+	err := ioutil.WriteFile(filepath.Join(dir, packManfiestFile), []byte(`name = "go"`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "files", "dockerfile"), []byte("FROM golang"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_ParsePackName(t *testing.T) {
+	name, version, err := ParsePackName("dev/go@v1.2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "dev/go", name)
+	assert.Equal(t, "v1.2.0", version)
+
+	name, version, err = ParsePackName("web/nginx")
+	assert.Nil(t, err)
+	assert.Equal(t, "web/nginx", name)
+	assert.Equal(t, "", version)
+
+	_, _, err = ParsePackName("go")
+	assert.Equal(t, errInvalidPackName, err)
+	_, _, err = ParsePackName("foo/go")
+	assert.Equal(t, errInvalidPackType, err)
+}
+
+func Test_Sign_Verify(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "packsign-")
+	defer os.RemoveAll(tmpdir)
+	writeTestPack(t, tmpdir)
+
+	kp, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pubkey := utils.PublicKeyBytes(&kp.PublicKey)
+
+	assert.Equal(t, errSignatureMissing, Verify(tmpdir, pubkey))
+
+	before, err := Digest(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Sign(tmpdir, kp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, Verify(tmpdir, pubkey))
+
+	// The signature file is not part of the digest
+	after, _ := Digest(tmpdir)
+	assert.Equal(t, before, after)
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Equal(t, errInvalidSignature, Verify(tmpdir, utils.PublicKeyBytes(&other.PublicKey)))
+
+	ioutil.WriteFile(filepath.Join(tmpdir, "files", "dockerfile"), []byte("FROM alpine"), 0644)
+	assert.Equal(t, errInvalidSignature, Verify(tmpdir, pubkey))
+}
+
+func Test_Packs_Check(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "packcheck-")
+	defer os.RemoveAll(tmpdir)
+
+	p, _ := New(tmpdir)
+	pdir := filepath.Join(tmpdir, "dev", "go")
+	writeTestPack(t, pdir)
+
+	dgst, _ := Digest(pdir)
+	lock := &Lock{Packs: map[string]*LockedPack{
+		"dev/go": &LockedPack{Source: "default", Version: "v1.0.0", Digest: dgst},
+	}}
+	if err := lock.Write(filepath.Join(tmpdir, LockFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	installed, err := p.Installed()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev/go"}, installed.Names())
+
+	assert.Nil(t, p.Check(nil))
+	assert.Nil(t, p.Check(map[string]string{"dev/go": "v1.0.0"}))
+	assert.Nil(t, p.Check(map[string]string{"dev/go": ""}))
+
+	err = p.Check(map[string]string{"dev/go": "v2.0.0"})
+	assert.Contains(t, err.Error(), errPackVersionMismatch.Error())
+
+	err = p.Check(map[string]string{"web/nginx": "v1.0.0"})
+	assert.Contains(t, err.Error(), errPackNotInstalled.Error())
+
+	// Simulate an upstream change to the installed files
+	ioutil.WriteFile(filepath.Join(pdir, "files", "dockerfile"), []byte("FROM alpine"), 0644)
+	err = p.Check(map[string]string{"dev/go": "v1.0.0"})
+	assert.Contains(t, err.Error(), errPackModified.Error())
+
+	err = p.Remove("dev/go")
+	assert.Nil(t, err)
+	assert.False(t, utils.FileExists(pdir))
+	err = p.Remove("dev/go")
+	assert.Contains(t, err.Error(), errPackNotInstalled.Error())
+}
+
+func Test_sourceType(t *testing.T) {
+	_, err := sourceType("ffo.com:8080")
+	assert.Equal(t, errPackSourceNotSupported, err)
+
+	for in, expected := range map[string]string{
+		"https://github.com/euforia/thrap-packs.git":   SourceGit,
+		"ssh://git@github.com/euforia/thrap-packs.git": SourceGit,
+		"https://example.com/packs-v1.0.0.tar.gz":      SourceHTTP,
+		"/opt/packs": SourcePath,
+	} {
+		typ, err := sourceType(in)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, typ, in)
+	}
+}
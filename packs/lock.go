@@ -0,0 +1,70 @@
+package packs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// LockFile is the filename of the packs lockfile within the packs directory
+const LockFile = "packs.lock"
+
+// LockedPack is an installed pack as recorded in the lockfile
+type LockedPack struct {
+	// Source the pack was installed from
+	Source string `json:"source"`
+	// Version of the source the pack was installed at
+	Version string `json:"version"`
+	// Digest of the installed pack files
+	Digest string `json:"digest"`
+	// Whether the pack signature was verified on install
+	Verified bool `json:"verified,omitempty"`
+}
+
+// Lock holds all installed packs keyed by <type>/<id>
+type Lock struct {
+	Packs map[string]*LockedPack `json:"packs"`
+}
+
+// ReadLock reads the lockfile at the path.  An empty lock is returned if the
+// file does not exist
+func ReadLock(fpath string) (*Lock, error) {
+	lock := &Lock{Packs: make(map[string]*LockedPack)}
+
+	b, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, lock); err != nil {
+		return nil, err
+	}
+	if lock.Packs == nil {
+		lock.Packs = make(map[string]*LockedPack)
+	}
+
+	return lock, nil
+}
+
+// Write writes the lock to the path
+func (lock *Lock) Write(fpath string) error {
+	b, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fpath, append(b, '\n'), 0644)
+}
+
+// Names returns the sorted names of all locked packs
+func (lock *Lock) Names() []string {
+	names := make([]string, 0, len(lock.Packs))
+	for k := range lock.Packs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,13 +2,10 @@ package packs
 
 import (
 	"io/ioutil"
-	"net/url"
-	"os"
 	"path/filepath"
 
 	"github.com/euforia/thrap/utils"
 	"github.com/pkg/errors"
-	git "gopkg.in/src-d/go-git.v4"
 
 	homedir "github.com/mitchellh/go-homedir"
 )
@@ -19,6 +16,8 @@ const (
 	webPackID = "web"
 	dsPackID  = "datastore"
 	devPackID = "dev"
+	// stackPackID holds multi-component stack templates
+	stackPackID = "stack"
 )
 
 var (
@@ -69,47 +68,32 @@ func (packs *Packs) Dir() string {
 	return packs.dir
 }
 
-// Load downloads packs from the given git remoteURL
+// Load adds the remote as the default source and installs all of its packs.
+// It returns an error if packs have already been loaded
 func (packs *Packs) Load(remoteURL string) error {
 	if utils.FileExists(packs.dir) {
 		return errPackDirExists
 	}
 
-	u, err := url.Parse(remoteURL)
+	src, err := NewSource(DefaultSourceName, remoteURL)
 	if err != nil {
 		return err
 	}
-
-	switch u.Scheme {
-	case "http", "https", "ssh":
-		_, err = git.PlainClone(packs.dir, false, &git.CloneOptions{
-			URL:      remoteURL,
-			Progress: os.Stdout,
-		})
-
-	default:
-		return errPackSourceNotSupported
-
-	}
-
-	return err
-}
-
-// Update performs a git pull on the packs repo to get the latest updates.  This
-// should be called after a successful call to load
-func (packs *Packs) Update() error {
-	repo, err := git.PlainOpen(packs.dir)
-	if err != nil {
+	if err = packs.AddSource(src); err != nil {
 		return err
 	}
 
-	wt, err := repo.Worktree()
+	avail, err := packs.Available(src.Name)
 	if err != nil {
 		return err
 	}
+	for _, ref := range avail {
+		if _, err = packs.Add(ref.Name(), src.Name); err != nil {
+			return err
+		}
+	}
 
-	opt := &git.PullOptions{Progress: os.Stdout}
-	return wt.Pull(opt)
+	return nil
 }
 
 // Web returns a web packs manager
@@ -122,6 +106,11 @@ func (packs *Packs) Dev() *DevPacks {
 	return NewDevPacks(filepath.Join(packs.dir, devPackID))
 }
 
+// Stack returns a stack template packs manager
+func (packs *Packs) Stack() *StackPacks {
+	return NewStackPacks(filepath.Join(packs.dir, stackPackID))
+}
+
 // Datastore returns a datastore packs manager
 func (packs *Packs) Datastore() *BasePacks {
 	return NewBasePacks(filepath.Join(packs.dir, dsPackID))
@@ -0,0 +1,541 @@
+package packs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/euforia/hclencoder"
+	"github.com/euforia/thrap/utils"
+	"github.com/hashicorp/hcl"
+	"github.com/pkg/errors"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+const (
+	// SourceGit is a git repository source
+	SourceGit = "git"
+	// SourcePath is a local directory source
+	SourcePath = "path"
+	// SourceHTTP is a gzipped tarball served over http
+	SourceHTTP = "http"
+)
+
+const (
+	// DefaultSourceName is the name of the source packs are initially
+	// loaded from
+	DefaultSourceName = "default"
+	// SourcesFile is the filename of the configured sources within the
+	// packs directory
+	SourcesFile = "sources.hcl"
+	// sourcesDir is the directory within the packs directory sources are
+	// fetched to
+	sourcesDir = ".sources"
+	// version reported for path sources as they are not versioned
+	localVersion = "local"
+	// version reported for http sources without a ref
+	latestVersion = "latest"
+	// length of the abbreviated commit hash used as a version
+	shortHashLen = 8
+)
+
+var (
+	errSourceNameRequired  = errors.New("source name required")
+	errSourceExists        = errors.New("source exists")
+	errSourceNotFound      = errors.New("source not found")
+	errVersionUnavailable  = errors.New("version not available from source")
+	errUnsafeArchivePath   = errors.New("archive path outside of destination")
+	errSourceTypeMismatch  = errors.New("source type does not match url")
+	errPublicKeyNotDecoded = errors.New("public key must be hex encoded")
+)
+
+// Source is a location packs are installed from
+type Source struct {
+	Name string `hcle:"omit"`
+	// One of git, path or http
+	Type string `hcl:"type"`
+	// Repo url, directory or tarball url
+	URL string `hcl:"url"`
+	// Branch or tag to use for git sources.  For http sources this is
+	// reported as the version
+	Ref string `hcl:"ref" hcle:"omitempty"`
+	// Hex encoded ecdsa public key.  If set all packs from the source must
+	// be signed by it
+	PublicKey string `hcl:"public_key" hcle:"omitempty"`
+}
+
+// NewSource returns a new source inferring the type from the url
+func NewSource(name, remoteURL string) (*Source, error) {
+	if name == "" {
+		return nil, errSourceNameRequired
+	}
+
+	typ, err := sourceType(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Source{Name: name, Type: typ, URL: remoteURL}, nil
+}
+
+// Validate checks the source type matches its url and the public key can be
+// decoded
+func (src *Source) Validate() error {
+	if src.Name == "" {
+		return errSourceNameRequired
+	}
+
+	typ, err := sourceType(src.URL)
+	if err != nil {
+		return err
+	}
+	// http(s) urls may be either a git repo or a tarball
+	if typ != src.Type && !(typ == SourceGit && src.Type == SourceHTTP) {
+		return errSourceTypeMismatch
+	}
+
+	_, err = src.publicKey()
+	return err
+}
+
+func (src *Source) publicKey() ([]byte, error) {
+	if src.PublicKey == "" {
+		return nil, nil
+	}
+	b, err := hex.DecodeString(src.PublicKey)
+	if err != nil {
+		return nil, errPublicKeyNotDecoded
+	}
+	return b, nil
+}
+
+func sourceType(remoteURL string) (string, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if strings.HasSuffix(u.Path, ".tar.gz") || strings.HasSuffix(u.Path, ".tgz") {
+			return SourceHTTP, nil
+		}
+		return SourceGit, nil
+
+	case "ssh":
+		return SourceGit, nil
+
+	case "", "file":
+		return SourcePath, nil
+
+	}
+
+	return "", errPackSourceNotSupported
+}
+
+type sourcesDB struct {
+	Sources map[string]*Source
+}
+
+func (packs *Packs) sourcesFile() string {
+	return filepath.Join(packs.dir, SourcesFile)
+}
+
+func (packs *Packs) readSources() (*sourcesDB, error) {
+	db := &sourcesDB{Sources: make(map[string]*Source)}
+
+	b, err := ioutil.ReadFile(packs.sourcesFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return db, nil
+		}
+		return nil, err
+	}
+
+	if err = hcl.Unmarshal(b, db); err != nil {
+		return nil, err
+	}
+	if db.Sources == nil {
+		db.Sources = make(map[string]*Source)
+	}
+	for k, v := range db.Sources {
+		v.Name = k
+	}
+
+	return db, nil
+}
+
+func (packs *Packs) writeSources(db *sourcesDB) error {
+	b, err := hclencoder.Encode(db)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(packs.sourcesFile(), b, 0644)
+}
+
+// Sources returns all configured sources sorted by name
+func (packs *Packs) Sources() ([]*Source, error) {
+	db, err := packs.readSources()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Source, 0, len(db.Sources))
+	for _, src := range db.Sources {
+		out = append(out, src)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out, nil
+}
+
+// Source returns the source by name
+func (packs *Packs) Source(name string) (*Source, error) {
+	db, err := packs.readSources()
+	if err != nil {
+		return nil, err
+	}
+	src, ok := db.Sources[name]
+	if !ok {
+		return nil, errors.Wrap(errSourceNotFound, name)
+	}
+	return src, nil
+}
+
+// AddSource adds a new source and fetches it
+func (packs *Packs) AddSource(src *Source) error {
+	if err := src.Validate(); err != nil {
+		return err
+	}
+
+	db, err := packs.readSources()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.Sources[src.Name]; ok {
+		return errors.Wrap(errSourceExists, src.Name)
+	}
+
+	if err = os.MkdirAll(packs.dir, 0755); err != nil {
+		return err
+	}
+	if _, err = packs.fetch(src, ""); err != nil {
+		return err
+	}
+
+	db.Sources[src.Name] = src
+	return packs.writeSources(db)
+}
+
+// RemoveSource removes the source and its fetched data.  Packs installed
+// from it remain installed
+func (packs *Packs) RemoveSource(name string) error {
+	db, err := packs.readSources()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.Sources[name]; !ok {
+		return errors.Wrap(errSourceNotFound, name)
+	}
+
+	delete(db.Sources, name)
+	if err = packs.writeSources(db); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(packs.sourceCache(name))
+}
+
+// sourceCache returns the directory the source is fetched into
+func (packs *Packs) sourceCache(name string) string {
+	return filepath.Join(packs.dir, sourcesDir, name)
+}
+
+// sourceRoot returns the directory containing the source pack types
+func (packs *Packs) sourceRoot(src *Source) string {
+	if src.Type == SourcePath {
+		return strings.TrimPrefix(src.URL, "file://")
+	}
+	return packs.sourceCache(src.Name)
+}
+
+// fetch retrieves the source at the ref, or the configured ref if empty,
+// returning the version fetched.  Path sources are read in place
+func (packs *Packs) fetch(src *Source, ref string) (string, error) {
+	if ref == "" {
+		ref = src.Ref
+	}
+
+	switch src.Type {
+	case SourceGit:
+		return fetchGit(packs.sourceCache(src.Name), src.URL, ref)
+
+	case SourceHTTP:
+		if ref != src.Ref {
+			return "", errVersionUnavailable
+		}
+		err := fetchTarball(packs.sourceCache(src.Name), src.URL)
+		if ref == "" {
+			ref = latestVersion
+		}
+		return ref, err
+
+	case SourcePath:
+		if ref != "" && ref != localVersion {
+			return "", errVersionUnavailable
+		}
+		if !utils.FileExists(packs.sourceRoot(src)) {
+			return "", fmt.Errorf("source directory not found: %s", src.URL)
+		}
+		return localVersion, nil
+
+	}
+
+	return "", errPackSourceNotSupported
+}
+
+// sourceVersion returns the version the source is currently fetched at
+func (packs *Packs) sourceVersion(src *Source) string {
+	switch src.Type {
+	case SourceGit:
+		repo, err := git.PlainOpen(packs.sourceCache(src.Name))
+		if err != nil {
+			return ""
+		}
+		head, err := repo.Head()
+		if err != nil {
+			return ""
+		}
+		if !head.Name().IsBranch() {
+			if tag := tagAt(repo, head.Hash()); tag != "" {
+				return tag
+			}
+		}
+		return head.Hash().String()[:shortHashLen]
+
+	case SourceHTTP:
+		if src.Ref == "" {
+			return latestVersion
+		}
+		return src.Ref
+
+	}
+
+	return localVersion
+}
+
+// fetchGit clones or fetches the repo into dir checking out the ref.  With
+// no ref the default branch is pulled.  The version is the ref or the
+// abbreviated head commit
+func fetchGit(dir, remoteURL, ref string) (string, error) {
+	repo, err := git.PlainOpen(dir)
+	if err == git.ErrRepositoryNotExists {
+		repo, err = git.PlainClone(dir, false, &git.CloneOptions{URL: remoteURL, Progress: os.Stdout})
+	} else if err == nil {
+		err = repo.Fetch(&git.FetchOptions{Progress: os.Stdout})
+		if err == git.NoErrAlreadyUpToDate {
+			err = nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if ref == "" {
+		hash, err := updateDefaultBranch(repo, wt)
+		if err != nil {
+			return "", err
+		}
+		return hash.String()[:shortHashLen], nil
+	}
+
+	hash, err := resolveGitRef(repo, ref)
+	if err != nil {
+		return "", errors.Wrap(errVersionUnavailable, ref)
+	}
+
+	err = wt.Checkout(&git.CheckoutOptions{Hash: hash, Force: true})
+	return ref, err
+}
+
+// tagAt returns the name of a tag pointing to the commit or an empty string
+func tagAt(repo *git.Repository, hash plumbing.Hash) string {
+	iter, err := repo.Tags()
+	if err != nil {
+		return ""
+	}
+
+	var name string
+	iter.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+		if tag, err := repo.TagObject(target); err == nil {
+			target = tag.Target
+		}
+		if target == hash && name == "" {
+			name = ref.Name().Short()
+		}
+		return nil
+	})
+
+	return name
+}
+
+// updateDefaultBranch checks out the local branch created on clone and
+// resets it to the fetched remote branch, returning the new head
+func updateDefaultBranch(repo *git.Repository, wt *git.Worktree) (plumbing.Hash, error) {
+	iter, err := repo.Branches()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	var branch *plumbing.Reference
+	iter.ForEach(func(ref *plumbing.Reference) error {
+		if branch == nil {
+			branch = ref
+		}
+		return nil
+	})
+	if branch == nil {
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return head.Hash(), nil
+	}
+
+	err = wt.Checkout(&git.CheckoutOptions{Branch: branch.Name(), Force: true})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	remote, err := repo.Reference(plumbing.ReferenceName("refs/remotes/origin/"+branch.Name().Short()), true)
+	if err != nil {
+		return branch.Hash(), nil
+	}
+
+	err = wt.Reset(&git.ResetOptions{Commit: remote.Hash(), Mode: git.HardReset})
+	return remote.Hash(), err
+}
+
+// resolveGitRef resolves tags, including annotated ones, and remote branches
+// to a commit
+func resolveGitRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	tref, err := repo.Reference(plumbing.ReferenceName("refs/tags/"+ref), true)
+	if err == nil {
+		if tag, err := repo.TagObject(tref.Hash()); err == nil {
+			return tag.Target, nil
+		}
+		return tref.Hash(), nil
+	}
+
+	rref, err := repo.Reference(plumbing.ReferenceName("refs/remotes/origin/"+ref), true)
+	if err == nil {
+		return rref.Hash(), nil
+	}
+
+	h, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return *h, nil
+}
+
+// fetchTarball downloads and extracts a gzipped tarball into dir replacing
+// any previous contents.  A single top-level directory, as produced by most
+// archive services, is stripped
+func fetchTarball(dir, remoteURL string) error {
+	resp, err := http.Get(remoteURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", remoteURL, resp.Status)
+	}
+
+	tmpdir := dir + ".tmp"
+	os.RemoveAll(tmpdir)
+	if err = extractTarball(resp.Body, tmpdir); err != nil {
+		os.RemoveAll(tmpdir)
+		return err
+	}
+
+	root := tmpdir
+	if files, err := ioutil.ReadDir(tmpdir); err == nil && len(files) == 1 && files[0].IsDir() {
+		root = filepath.Join(tmpdir, files[0].Name())
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		return err
+	}
+	err = os.Rename(root, dir)
+	os.RemoveAll(tmpdir)
+
+	return err
+}
+
+func extractTarball(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return errUnsafeArchivePath
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+
+		case tar.TypeReg, tar.TypeRegA:
+			err = writeFile(target, tr, os.FileMode(hdr.Mode).Perm())
+
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func writeFile(fpath string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(fpath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	f.Close()
+	return err
+}
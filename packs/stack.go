@@ -0,0 +1,229 @@
+package packs
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/euforia/pseudo"
+	"github.com/euforia/pseudo/scope"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/hashicorp/hil/ast"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// StackParamPrefix is the scope variable prefix of stack pack parameters
+// i.e. param.<name>
+const StackParamPrefix = "param."
+
+// defaultStackTemplate is the stack manifest template used when the pack
+// does not specify one
+const defaultStackTemplate = "stack.yml"
+
+var (
+	errParamRequired   = errors.New("parameter required")
+	errParamNotAllowed = errors.New("parameter value not allowed")
+	errParamUnknown    = errors.New("unknown parameter")
+	errNoComponents    = errors.New("stack template has no components")
+	errPackPathInvalid = errors.New("path must be relative to the pack")
+)
+
+// StackParam is a parameter prompted for when a stack is initialized from a
+// stack pack
+type StackParam struct {
+	Name string `hcl:",key"`
+	// Prompt shown to the user
+	Prompt string
+	// Default value.  Parameters without one are required
+	Default string
+	// Allowed values if any
+	Options []string
+}
+
+// StackManifest is the manifest of a stack pack
+type StackManifest struct {
+	Name        string
+	Description string
+	// Parameters available to the template and files as param.<name>
+	Params []*StackParam `hcl:"Param"`
+	// Stack manifest template. Defaults to stack.yml
+	Template string
+	// Additional files rendered into the project
+	Files []string
+}
+
+// StackPacks holds all available stack packs
+type StackPacks struct {
+	*basePackSet
+	packs map[string]*StackPack
+}
+
+// NewStackPacks returns a new StackPacks with the directory containing the
+// pack data
+func NewStackPacks(dir string) *StackPacks {
+	return &StackPacks{
+		basePackSet: &basePackSet{stackPackID, dir},
+		packs:       make(map[string]*StackPack),
+	}
+}
+
+// Load loads a pack by the id
+func (packs *StackPacks) Load(packID string) (*StackPack, error) {
+	if packID == "" {
+		return nil, errPackIDRequired
+	}
+
+	if val, ok := packs.packs[packID]; ok {
+		return val, nil
+	}
+
+	pack, err := LoadStackPack(packID, packs.dir)
+	if err == nil {
+		packs.packs[packID] = pack
+	}
+	return pack, err
+}
+
+// StackPack is a multi-component stack blueprint.  The stack manifest
+// template and files are rendered with the parameters and stack variables.
+// Variables to be evaluated at build or deploy time must be escaped i.e.
+// $${comp.api.container.ip}
+type StackPack struct {
+	*StackManifest
+	// directory containing pack files
+	dir string
+	// Variable eval vm
+	vm *pseudo.VM
+}
+
+// LoadStackPack loads a stack pack from the directory with the given id
+func LoadStackPack(packID, dir string) (*StackPack, error) {
+	pdir := filepath.Join(dir, packID)
+
+	var conf StackManifest
+	if err := readPackManifest(pdir, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Name == "" {
+		conf.Name = packID
+	}
+	if conf.Template == "" {
+		conf.Template = defaultStackTemplate
+	}
+
+	// Files are read from the pack and written to the project so neither
+	// may be left
+	for _, fpath := range append([]string{conf.Template}, conf.Files...) {
+		if err := checkPackPath(fpath); err != nil {
+			return nil, err
+		}
+	}
+
+	return &StackPack{StackManifest: &conf, dir: pdir, vm: pseudo.NewVM()}, nil
+}
+
+// Param returns the named parameter or nil if it does not exist
+func (sp *StackPack) Param(name string) *StackParam {
+	for _, p := range sp.Params {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// ScopeVars returns the parameter scope variables from the given values
+// falling back to the defaults.  Unknown parameters, missing required ones
+// and values not in the options are errors
+func (sp *StackPack) ScopeVars(values map[string]string) (scope.Variables, error) {
+	for k := range values {
+		if sp.Param(k) == nil {
+			return nil, errors.Wrap(errParamUnknown, k)
+		}
+	}
+
+	svars := make(scope.Variables, len(sp.Params))
+	for _, p := range sp.Params {
+		val, ok := values[p.Name]
+		if !ok {
+			val = p.Default
+		}
+		if val == "" {
+			return nil, errors.Wrap(errParamRequired, p.Name)
+		}
+		if len(p.Options) > 0 && !hasString(p.Options, val) {
+			return nil, errors.Wrapf(errParamNotAllowed, "%s: %s", p.Name, val)
+		}
+
+		svars[StackParamPrefix+p.Name] = ast.Variable{Type: ast.TypeString, Value: val}
+	}
+
+	return svars, nil
+}
+
+// Stack renders the stack manifest template with the variables returning
+// the decoded stack
+func (sp *StackPack) Stack(svars scope.Variables) (*thrapb.Stack, error) {
+	val, err := sp.parseEval(sp.Template, svars)
+	if err != nil {
+		return nil, errors.Wrap(err, sp.Template)
+	}
+
+	var stack thrapb.Stack
+	if err = yaml.UnmarshalStrict([]byte(val), &stack); err != nil {
+		return nil, errors.Wrap(err, sp.Template)
+	}
+	if len(stack.Components) == 0 {
+		return nil, errNoComponents
+	}
+
+	return &stack, nil
+}
+
+// Files renders all additional files with the variables
+func (sp *StackPack) Files(svars scope.Variables) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(sp.StackManifest.Files))
+	for _, fpath := range sp.StackManifest.Files {
+		val, err := sp.parseEval(fpath, svars)
+		if err != nil {
+			return nil, errors.Wrap(err, fpath)
+		}
+		out[fpath] = []byte(val)
+	}
+	return out, nil
+}
+
+func (sp *StackPack) parseEval(fpath string, svars scope.Variables) (string, error) {
+	d, err := ioutil.ReadFile(filepath.Join(sp.dir, fpath))
+	if err != nil {
+		return "", err
+	}
+
+	result, err := sp.vm.ParseEval(string(d), svars)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Value.(string), nil
+}
+
+// checkPackPath returns an error if the path is absolute or leaves the pack
+// directory
+func checkPackPath(fpath string) error {
+	clean := filepath.Clean(fpath)
+	if fpath == "" || filepath.IsAbs(fpath) || clean == ".." ||
+		strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return errors.Wrap(errPackPathInvalid, fpath)
+	}
+	return nil
+}
+
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package packs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euforia/thrap/vars"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StackPack(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "stackpack-")
+	defer os.RemoveAll(tmpdir)
+
+	sdir := filepath.Join(tmpdir, stackPackID)
+	_, err := Scaffold(sdir, stackPackID, "event-driven")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sp, err := NewStackPacks(sdir).Load("event-driven")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "stack.yml", sp.Template)
+	assert.NotNil(t, sp.Param("datastore"))
+
+	_, err = sp.ScopeVars(map[string]string{"foo": "bar"})
+	assert.Equal(t, errParamUnknown, errors.Cause(err))
+	_, err = sp.ScopeVars(map[string]string{"datastore": "redis"})
+	assert.Equal(t, errParamNotAllowed, errors.Cause(err))
+
+	svars, err := sp.ScopeVars(map[string]string{"datastore": "mysql", "datastore_version": "5.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stack, err := sp.Stack(vars.MergeScopeVars(SampleScopeVars(), svars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "mysql", stack.Components["db"].Name)
+	assert.Equal(t, "5.7", stack.Components["db"].Version)
+	assert.Equal(t, "${comp.db.container.ip}", stack.Components["api"].Env.Vars["DB_ADDR"])
+
+	sp.Params[0].Default = ""
+	_, err = sp.ScopeVars(nil)
+	assert.Equal(t, errParamRequired, errors.Cause(err))
+}
+
+func Test_StackPack_paths(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "stackpack-")
+	defer os.RemoveAll(tmpdir)
+
+	pdir, err := Scaffold(filepath.Join(tmpdir, stackPackID), stackPackID, "bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, files := range []string{`["../../.bashrc"]`, `["/etc/passwd"]`, `["a/../../b"]`} {
+		manifest := "Files = " + files + "\n"
+		ioutil.WriteFile(filepath.Join(pdir, packManfiestFile), []byte(manifest), 0644)
+
+		_, err = LoadStackPack("bad", filepath.Dir(pdir))
+		assert.Equal(t, errPackPathInvalid, errors.Cause(err), files)
+		assert.NotNil(t, ValidatePack(pdir, stackPackID)["manifest"], files)
+	}
+
+	ioutil.WriteFile(filepath.Join(pdir, packManfiestFile), []byte(`Template = "../stack.yml"`), 0644)
+	_, err = LoadStackPack("bad", filepath.Dir(pdir))
+	assert.Equal(t, errPackPathInvalid, errors.Cause(err))
+
+	assert.Nil(t, checkPackPath("config/app.yml"))
+	assert.Nil(t, checkPackPath("a/../b"))
+}
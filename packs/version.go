@@ -0,0 +1,122 @@
+package packs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/euforia/thrap/thrapb"
+	version "github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+)
+
+var errVersionNotSatisfied = errors.New("no version satisfies the constraint")
+
+// TagLister is implemented by registries that can list the tags of an image
+// repository
+type TagLister interface {
+	Tags(repo string) ([]string, error)
+}
+
+// ResolveVersion resolves the requested language version against the pack
+// version constraints and the tags of its dev and publish images, returning
+// the version to use.  An empty request resolves to the default version. An
+// exact version is returned as is if the pack supports it.  Wildcards and
+// constraints resolve to the highest matching image tag.  If a lister is
+// given the dev image repositories are queried for additional tags
+func (lp *DevPack) ResolveVersion(requested string, lister TagLister) (string, error) {
+	if requested == "" {
+		return lp.DefaultVersion, nil
+	}
+
+	if v, err := version.NewVersion(requested); err == nil {
+		if !lp.supports(v) {
+			return "", fmt.Errorf("%s %s not supported: supported versions %s",
+				lp.Name, requested, strings.Join(lp.Versions, " or "))
+		}
+		return requested, nil
+	}
+
+	want, err := thrapb.ParseVersionConstraint(requested)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		candidates = lp.candidateVersions(lister)
+		best       *version.Version
+		bestTag    string
+	)
+	for tag, v := range candidates {
+		if !want.Check(v) || !lp.supports(v) {
+			continue
+		}
+		if best == nil || v.GreaterThan(best) {
+			best, bestTag = v, tag
+		}
+	}
+
+	if best == nil {
+		return "", errors.Wrapf(errVersionNotSatisfied, "%s %s: available %s",
+			lp.Name, requested, strings.Join(sortedVersions(candidates), ", "))
+	}
+
+	return bestTag, nil
+}
+
+// supports returns true if the version satisfies any pack constraint or
+// the pack has none
+func (lp *DevPack) supports(v *version.Version) bool {
+	if len(lp.vc) == 0 {
+		return true
+	}
+	for _, c := range lp.vc {
+		if c.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidateVersions returns all versions tagged in the pack images and the
+// default version keyed by the original tag.  Tags that are not versions
+// e.g. latest or alpine are ignored
+func (lp *DevPack) candidateVersions(lister TagLister) map[string]*version.Version {
+	tags := []string{lp.DefaultVersion}
+
+	images := append(append([]string{}, lp.DevImages...), lp.PubImages...)
+	for _, img := range images {
+		if _, tag := thrapb.SplitImage(img); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if lister != nil {
+		for _, img := range lp.DevImages {
+			repo, _ := thrapb.SplitImage(img)
+			// Listing failures leave the pack declared tags to resolve with
+			if list, err := lister.Tags(repo); err == nil {
+				tags = append(tags, list...)
+			}
+		}
+	}
+
+	out := make(map[string]*version.Version, len(tags))
+	for _, tag := range tags {
+		if v, err := version.NewVersion(tag); err == nil && v.Prerelease() == "" {
+			out[tag] = v
+		}
+	}
+	return out
+}
+
+func sortedVersions(versions map[string]*version.Version) []string {
+	tags := make([]string, 0, len(versions))
+	for tag := range versions {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return versions[tags[i]].LessThan(versions[tags[j]])
+	})
+	return tags
+}
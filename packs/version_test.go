@@ -0,0 +1,68 @@
+package packs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTagLister map[string][]string
+
+func (tl testTagLister) Tags(repo string) ([]string, error) {
+	tags, ok := tl[repo]
+	if !ok {
+		return nil, errors.New("repo not found")
+	}
+	return tags, nil
+}
+
+func newTestDevPack(t *testing.T) *DevPack {
+	lp := &DevPack{Language: &thrapb.Language{
+		Name:           "go",
+		Versions:       []string{">= 1.9, < 2.0"},
+		DefaultVersion: "1.10",
+		DevImages:      []string{"golang", "localhost:5000/golang:1.9.7"},
+		PubImages:      []string{"alpine:3.8"},
+	}}
+	if err := lp.setVersionContraints(); err != nil {
+		t.Fatal(err)
+	}
+	return lp
+}
+
+func Test_DevPack_ResolveVersion(t *testing.T) {
+	lp := newTestDevPack(t)
+
+	v, err := lp.ResolveVersion("", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.10", v)
+
+	v, err = lp.ResolveVersion("1.11", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.11", v)
+
+	_, err = lp.ResolveVersion("1.8", nil)
+	assert.Contains(t, err.Error(), "not supported")
+
+	// Without a lister only declared tags are candidates
+	v, err = lp.ResolveVersion("1.x", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.10", v)
+
+	v, err = lp.ResolveVersion("< 1.10", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.9.7", v)
+
+	lister := testTagLister{"golang": []string{"latest", "1.11.2", "1.11", "1.12rc1", "2.0", "1.11-alpine"}}
+	v, err = lp.ResolveVersion("1.x", lister)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.11.2", v)
+
+	// 2.0 is tagged but not supported by the pack
+	_, err = lp.ResolveVersion(">= 2", lister)
+	assert.Equal(t, errVersionNotSatisfied, pkgerrors.Cause(err))
+	assert.Contains(t, err.Error(), "available 1.9.7, 1.10, 1.11, 1.11.2, 2.0")
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHubAPIAddr = "https://hub.docker.com"
+	hubTagsPageSize   = 100
+	// Upper bound on pages fetched per repo as popular images have
+	// thousands of tags
+	hubTagsMaxPages = 10
+)
+
+// HubTagLister lists image tags using the public Docker Hub api.  It does not
+// require credentials
+type HubTagLister struct {
+	addr   string
+	client *http.Client
+}
+
+// NewHubTagLister returns a tag lister for the Docker Hub api at addr.  The
+// public hub is used if addr is empty
+func NewHubTagLister(addr string) *HubTagLister {
+	if addr == "" {
+		addr = defaultHubAPIAddr
+	}
+	return &HubTagLister{
+		addr:   strings.TrimSuffix(addr, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type hubTagsPage struct {
+	Next    string
+	Results []struct {
+		Name string
+	}
+}
+
+// Tags returns the tags of the repo.  Official images may be given without
+// the library namespace
+func (hub *HubTagLister) Tags(repo string) ([]string, error) {
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+
+	var (
+		tags = make([]string, 0)
+		next = fmt.Sprintf("%s/v2/repositories/%s/tags?page_size=%d", hub.addr, repo, hubTagsPageSize)
+	)
+
+	for i := 0; i < hubTagsMaxPages && next != ""; i++ {
+		page, err := hub.getPage(next)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page.Results {
+			tags = append(tags, r.Name)
+		}
+		next = page.Next
+	}
+
+	return tags, nil
+}
+
+func (hub *HubTagLister) getPage(u string) (*hubTagsPage, error) {
+	resp, err := hub.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", u, resp.Status)
+	}
+
+	var page hubTagsPage
+	err = json.NewDecoder(resp.Body).Decode(&page)
+	return &page, err
+}
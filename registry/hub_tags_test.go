@@ -0,0 +1,34 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HubTagLister(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/repositories/library/golang/tags" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"next":null,"results":[{"name":"1.10"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"next":"%s/v2/repositories/library/golang/tags?page=2","results":[{"name":"1.11"},{"name":"latest"}]}`, srv.URL)
+	}))
+	defer srv.Close()
+
+	hub := NewHubTagLister(srv.URL)
+	tags, err := hub.Tags("golang")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.11", "latest", "1.10"}, tags)
+
+	_, err = hub.Tags("foo/bar")
+	assert.NotNil(t, err)
+}
@@ -5,8 +5,13 @@ import (
 	"log"
 
 	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/oidc"
+	"github.com/euforia/thrap/store"
 	"github.com/euforia/thrap/thrapb"
-	"google.golang.org/grpc/metadata"
+	"github.com/euforia/thrap/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GRPCService implements the server-side grpc service for thrap
@@ -29,8 +34,11 @@ func (s *GRPCService) RegisterIdentity(ctx context.Context, ident *thrapb.Identi
 	s.handleIncomingContext(ctx, "identity."+ident.ID+".register")
 
 	idt := s.core.Identity()
+	if err := ident.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	nident, _, err := idt.Register(ident)
-	return nident, err
+	return nident, grpcError(err)
 }
 
 // ConfirmIdentity implements the server-side grpc call
@@ -39,7 +47,7 @@ func (s *GRPCService) ConfirmIdentity(ctx context.Context, ident *thrapb.Identit
 
 	idt := s.core.Identity()
 	nident, err := idt.Confirm(ident)
-	return nident, err
+	return nident, grpcError(err)
 }
 
 // GetIdentity implements the server-side grpc call
@@ -47,7 +55,44 @@ func (s *GRPCService) GetIdentity(ctx context.Context, ident *thrapb.Identity) (
 	s.handleIncomingContext(ctx, "identity."+ident.ID+".get")
 
 	idt := s.core.Identity()
-	return idt.Get(ident.ID)
+	nident, err := idt.Get(ident.ID)
+	return nident, grpcError(err)
+}
+
+// RotateIdentityKey implements the server-side grpc call
+func (s *GRPCService) RotateIdentityKey(ctx context.Context, rot *thrapb.KeyRotation) (*thrapb.Identity, error) {
+	s.handleIncomingContext(ctx, "identity."+rot.ID+".rotate")
+
+	idt := s.core.Identity()
+	ident, err := idt.Rotate(rot)
+	return ident, grpcError(err)
+}
+
+// RevokeIdentityKey implements the server-side grpc call
+func (s *GRPCService) RevokeIdentityKey(ctx context.Context, rev *thrapb.KeyRevocation) (*thrapb.Identity, error) {
+	s.handleIncomingContext(ctx, "identity."+rev.ID+".revoke")
+
+	idt := s.core.Identity()
+	ident, err := idt.Revoke(rev)
+	return ident, grpcError(err)
+}
+
+// GetOIDCConfig implements the server-side grpc call
+func (s *GRPCService) GetOIDCConfig(ctx context.Context, _ *thrapb.OIDCConfig) (*thrapb.OIDCConfig, error) {
+	s.handleIncomingContext(ctx, "oidc.config")
+
+	sess := s.core.Sessions()
+	conf, err := sess.OIDCConfig()
+	return conf, grpcError(err)
+}
+
+// Login implements the server-side grpc call
+func (s *GRPCService) Login(ctx context.Context, req *thrapb.LoginRequest) (*thrapb.Session, error) {
+	s.handleIncomingContext(ctx, "session.login")
+
+	sess := s.core.Sessions()
+	session, err := sess.Login(ctx, req.AccessToken, req.IDToken)
+	return session, grpcError(err)
 }
 
 // IterIdentities implements the server-side grpc call
@@ -55,32 +100,58 @@ func (s *GRPCService) IterIdentities(opts *thrapb.IterOptions, stream thrapb.Thr
 	s.handleIncomingContext(stream.Context(), "identity.list")
 
 	idt := s.core.Identity()
-	return idt.Iter(opts.Prefix, func(ident *thrapb.Identity) error {
+	err := idt.Iter(opts.Prefix, func(ident *thrapb.Identity) error {
 		return stream.Send(ident)
 	})
+	return grpcError(err)
+}
+
+// IterAudit implements the server-side grpc call
+func (s *GRPCService) IterAudit(opts *thrapb.AuditOptions, stream thrapb.Thrap_IterAuditServer) error {
+	s.handleIncomingContext(stream.Context(), "audit.list")
+
+	aud := s.core.Audit()
+	err := aud.Iter(opts, func(entry *thrapb.AuditEntry) error {
+		return stream.Send(entry)
+	})
+	return grpcError(err)
 }
 
 // RegisterStack implements the server-side grpc call
 func (s *GRPCService) RegisterStack(ctx context.Context, st *thrapb.Stack) (*thrapb.Stack, error) {
 	s.handleIncomingContext(ctx, "stack."+st.ID+".register")
 
+	if errs := st.Validate(); len(errs) > 0 {
+		return nil, status.Error(codes.InvalidArgument, utils.FlattenErrors(errs).Error())
+	}
+
 	stk, err := s.core.Stack(thrapb.DefaultProfile())
 	if err != nil {
 		return nil, err
 	}
+	// Stacks are owned by the authenticated caller when known
+	st.Owner = ""
+	if ident := IdentityFromContext(ctx); ident != nil {
+		st.Owner = ident.ID
+	}
 	stack, _, err := stk.Register(st)
-	return stack, err
+	return stack, grpcError(err)
 }
 
 // CommitStack implements the server-side grpc call
 func (s *GRPCService) CommitStack(ctx context.Context, stack *thrapb.Stack) (*thrapb.Stack, error) {
 	s.handleIncomingContext(ctx, "stack."+stack.ID+".commit")
 
+	if errs := stack.Validate(); len(errs) > 0 {
+		return nil, status.Error(codes.InvalidArgument, utils.FlattenErrors(errs).Error())
+	}
+
 	stk, err := s.core.Stack(thrapb.DefaultProfile())
-	if err == nil {
-		return stk.Commit(stack)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	stack, err = stk.Commit(stack)
+	return stack, grpcError(err)
 }
 
 // GetStack implements the server-side grpc call
@@ -88,10 +159,11 @@ func (s *GRPCService) GetStack(ctx context.Context, stack *thrapb.Stack) (*thrap
 	s.handleIncomingContext(ctx, "stack."+stack.ID+".get")
 
 	stk, err := s.core.Stack(thrapb.DefaultProfile())
-	if err == nil {
-		return stk.Get(stack.ID)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	stack, err = stk.Get(stack.ID)
+	return stack, grpcError(err)
 }
 
 // IterStacks implements the server-side grpc call
@@ -102,15 +174,126 @@ func (s *GRPCService) IterStacks(opts *thrapb.IterOptions, stream thrapb.Thrap_I
 	if err != nil {
 		return err
 	}
-	return stk.Iter(opts.Prefix, func(stack *thrapb.Stack) error {
+	err = stk.Iter(opts.Prefix, func(stack *thrapb.Stack) error {
 		return stream.Send(stack)
 	})
+	return grpcError(err)
+}
+
+// IterBuilds implements the server-side grpc call
+func (s *GRPCService) IterBuilds(opts *thrapb.IterOptions, stream thrapb.Thrap_IterBuildsServer) error {
+	s.handleIncomingContext(stream.Context(), "build.list")
+
+	stk, err := s.core.Stack(thrapb.DefaultProfile())
+	if err != nil {
+		return err
+	}
+	err = stk.Builds(opts.Prefix, func(build *thrapb.StackBuild) error {
+		return stream.Send(build)
+	})
+	return grpcError(err)
+}
+
+// IterDeployments implements the server-side grpc call
+func (s *GRPCService) IterDeployments(opts *thrapb.IterOptions, stream thrapb.Thrap_IterDeploymentsServer) error {
+	s.handleIncomingContext(stream.Context(), "deployment.list")
+
+	stk, err := s.core.Stack(thrapb.DefaultProfile())
+	if err != nil {
+		return err
+	}
+	err = stk.Deployments(opts.Prefix, func(deploy *thrapb.Deployment) error {
+		return stream.Send(deploy)
+	})
+	return grpcError(err)
+}
+
+// CreateBuild implements the server-side grpc call
+func (s *GRPCService) CreateBuild(ctx context.Context, build *thrapb.StackBuild) (*thrapb.StackBuild, error) {
+	s.handleIncomingContext(ctx, "build."+build.Stack+"."+build.ID+".create")
+
+	stk, err := s.core.Stack(thrapb.DefaultProfile())
+	if err != nil {
+		return nil, err
+	}
+	// Records are attributed to the authenticated caller only, never to
+	// the identity supplied by the client
+	build.Identity = ""
+	if ident := IdentityFromContext(ctx); ident != nil {
+		build.Identity = ident.ID
+	}
+	build, err = stk.CreateBuild(build)
+	return build, grpcError(err)
+}
+
+// CreateDeployment implements the server-side grpc call
+func (s *GRPCService) CreateDeployment(ctx context.Context, deploy *thrapb.Deployment) (*thrapb.Deployment, error) {
+	s.handleIncomingContext(ctx, "deployment."+deploy.Stack+"."+deploy.ID+".create")
+
+	stk, err := s.core.Stack(thrapb.DefaultProfile())
+	if err != nil {
+		return nil, err
+	}
+	deploy.Identity = ""
+	if ident := IdentityFromContext(ctx); ident != nil {
+		deploy.Identity = ident.ID
+	}
+	deploy, err = stk.CreateDeployment(deploy)
+	return deploy, grpcError(err)
+}
+
+// grpcError returns the grpc status of errors returned by the core and
+// storage so clients and the gateway can tell them apart.  Unknown errors
+// are returned as is
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var code codes.Code
+	switch errors.Cause(err) {
+	case store.ErrKeyNotFound, store.ErrStackNotFound, store.ErrIdentityNotFound,
+		store.ErrProfileNotFound:
+		code = codes.NotFound
+
+	case store.ErrKeyExists, store.ErrStackExists, store.ErrIdentityExists,
+		store.ErrBuildExists, store.ErrDeploymentExists,
+		core.ErrStackAlreadyRegistered, core.ErrIdentityAlreadyRegistered,
+		core.ErrIdentityAlreadySigned, core.ErrKeyInUse:
+		code = codes.AlreadyExists
+
+	case core.ErrInvalidRecord, core.ErrSignatureInvalid, core.ErrTimestampSkew:
+		code = codes.InvalidArgument
+
+	case core.ErrIdentityNotConfirmed, core.ErrConfirmCodeExpired, core.ErrOIDCNotConfigured:
+		code = codes.FailedPrecondition
+
+	case core.ErrRateLimited:
+		code = codes.ResourceExhausted
+
+	case core.ErrEmailNotVerified, core.ErrGroupNotAllowed, thrapb.ErrKeyRevoked,
+		thrapb.ErrKeyExpired, thrapb.ErrKeyUnknown:
+		code = codes.PermissionDenied
+
+	case core.ErrSessionInvalid, core.ErrSessionExpired, oidc.ErrIDTokenInvalid,
+		oidc.ErrIDTokenExpired:
+		code = codes.Unauthenticated
+
+	default:
+		return err
+	}
+	return status.Error(code, err.Error())
 }
 
+// handleIncomingContext logs the call and the authenticated caller.  The raw
+// metadata is never logged as it carries session tokens and signatures
 func (s *GRPCService) handleIncomingContext(ctx context.Context, call string) {
-	// TODO: auth
-	md, ok := metadata.FromIncomingContext(ctx)
-	if ok {
-		s.log.Println(call, ":", md)
+	if ident := IdentityFromContext(ctx); ident != nil {
+		s.log.Println(call, ":", ident.ID)
+		return
 	}
+	s.log.Println(call)
 }
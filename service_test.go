@@ -0,0 +1,117 @@
+package thrap
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/manifest"
+	"github.com/euforia/thrap/store"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestService starts the grpc service on a loopback listener backed by a
+// core in a temporary data directory
+func newTestService(t *testing.T) (thrapb.ThrapClient, func()) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "service-")
+
+	opt := core.DefaultConfigureOptions()
+	opt.NoPrompt = true
+	opt.DataDir = tmpdir
+	if err := core.ConfigureGlobal(opt); err != nil {
+		t.Fatal(err)
+	}
+	// Skip fetching the default packs
+	os.MkdirAll(filepath.Join(tmpdir, consts.PacksDir), 0755)
+
+	cr, err := core.NewCore(&core.Config{
+		DataDir: tmpdir,
+		Storage: &store.DriverConfig{Name: store.DriverBolt},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	thrapb.RegisterThrapServer(srv, NewService(cr, log.New(ioutil.Discard, "", 0)))
+	go srv.Serve(lis)
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return thrapb.NewThrapClient(cc), func() {
+		cc.Close()
+		srv.Stop()
+		os.RemoveAll(tmpdir)
+	}
+}
+
+func Test_GRPCService_errors(t *testing.T) {
+	client, done := newTestService(t)
+	defer done()
+
+	ctx := context.Background()
+	_, err := client.GetStack(ctx, &thrapb.Stack{ID: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.RegisterIdentity(ctx, &thrapb.Identity{ID: "foo"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateBuild(ctx, &thrapb.StackBuild{ID: "b1", Stack: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.CreateDeployment(ctx, &thrapb.Deployment{Stack: "missing"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_Gateway_GRPCService(t *testing.T) {
+	client, done := newTestService(t)
+	defer done()
+	gw := NewGateway(client, log.New(ioutil.Discard, "", 0))
+
+	w := httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stacks/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "stack not found")
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/identities/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	stack, err := manifest.LoadManifest("./test-fixtures/thrap.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(stack)
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/stacks/"+stack.ID, strings.NewReader(string(b))))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/identities", strings.NewReader(`{"ID":"foo"}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stacks/missing/builds", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,98 @@
+package thrap
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/euforia/thrap/thrapb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Size of streamed snapshot chunks
+const snapshotChunkSize = 64 << 10
+
+var errSnapshotTLSRequired = status.Error(codes.FailedPrecondition, "snapshots require a tls connection")
+
+// chunkWriter sends written data as snapshot chunks
+type chunkWriter struct {
+	send func(*thrapb.SnapshotChunk) error
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	if err := w.send(&thrapb.SnapshotChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// chunkReader reads the data of received snapshot chunks
+type chunkReader struct {
+	recv func() (*thrapb.SnapshotChunk, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// NewSnapshotWriter returns a writer streaming data as snapshot chunks.  It
+// must be flushed when done
+func NewSnapshotWriter(send func(*thrapb.SnapshotChunk) error) *bufio.Writer {
+	return bufio.NewWriterSize(&chunkWriter{send: send}, snapshotChunkSize)
+}
+
+// NewSnapshotReader returns a reader of the data of received snapshot
+// chunks.  It returns io.EOF when recv does
+func NewSnapshotReader(recv func() (*thrapb.SnapshotChunk, error)) io.Reader {
+	return &chunkReader{recv: recv}
+}
+
+// SaveSnapshot streams an unencrypted snapshot of the agent state.  Clients
+// encrypt it if needed.  The state holds identities, sealed credentials and
+// the session signing key so it is only sent over tls
+func (s *GRPCService) SaveSnapshot(_ *thrapb.SnapshotHeader, stream thrapb.Thrap_SaveSnapshotServer) error {
+	if err := requireTLS(stream.Context()); err != nil {
+		return err
+	}
+	w := NewSnapshotWriter(stream.Send)
+	if _, err := s.core.Snapshot(w, nil); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// RestoreSnapshot loads a streamed unencrypted snapshot received over tls
+func (s *GRPCService) RestoreSnapshot(stream thrapb.Thrap_RestoreSnapshotServer) error {
+	if err := requireTLS(stream.Context()); err != nil {
+		return err
+	}
+	hdr, err := s.core.Restore(NewSnapshotReader(stream.Recv), nil)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(hdr)
+}
+
+// requireTLS returns an error if the call was not received over tls
+func requireTLS(ctx context.Context) error {
+	if p, ok := peer.FromContext(ctx); ok {
+		if _, ok = p.AuthInfo.(credentials.TLSInfo); ok {
+			return nil
+		}
+	}
+	return errSnapshotTLSRequired
+}
@@ -0,0 +1,128 @@
+package snapshot
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// FileExt is the extension of scheduled snapshot files
+	FileExt = ".snap"
+
+	filePrefix = "thrap-"
+	// Sortable file name timestamp
+	fileTimeFormat = "20060102T150405.000000000Z"
+)
+
+// SaveFunc writes a snapshot to w encrypting it with the passphrase if any
+type SaveFunc func(w io.Writer, passphrase []byte) error
+
+// Scheduler writes snapshots to a directory at an interval keeping the
+// newest Retain ones
+type Scheduler struct {
+	// Directory snapshots are written to
+	Dir string
+	// Time between snapshots
+	Interval time.Duration
+	// Number of snapshots kept.  Zero keeps all
+	Retain int
+	// Optional passphrase to encrypt snapshots
+	Passphrase []byte
+
+	Save SaveFunc
+	Log  *log.Logger
+}
+
+// Run takes a snapshot every interval until stop is closed.  Failures are
+// logged
+func (s *Scheduler) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			fpath, err := s.SaveNow()
+			if err != nil {
+				s.Log.Println("Snapshot failed:", err)
+				continue
+			}
+			s.Log.Println("Snapshot saved:", fpath)
+
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SaveNow writes a snapshot and removes old ones beyond retention.  It
+// returns the snapshot path
+func (s *Scheduler) SaveNow() (string, error) {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return "", err
+	}
+
+	name := filePrefix + time.Now().UTC().Format(fileTimeFormat) + FileExt
+	fpath := filepath.Join(s.Dir, name)
+
+	// Written to a temp file so partial snapshots are never listed
+	tmp := fpath + ".tmp"
+	fh, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	err = s.Save(fh, s.Passphrase)
+	if err == nil {
+		err = fh.Sync()
+	}
+	if cerr := fh.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, fpath)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	if _, err = Prune(s.Dir, s.Retain); err != nil {
+		s.Log.Println("Snapshot pruning failed:", err)
+	}
+	return fpath, nil
+}
+
+// List returns the scheduled snapshot paths in the directory oldest first
+func List(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+FileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Prune removes all but the newest retain snapshots in the directory.  It
+// returns the removed paths.  Zero retains all
+func Prune(dir string, retain int) ([]string, error) {
+	if retain <= 0 {
+		return nil, nil
+	}
+
+	files, err := List(dir)
+	if err != nil || len(files) <= retain {
+		return nil, err
+	}
+
+	removed := files[:len(files)-retain]
+	for _, fpath := range removed {
+		if err = os.Remove(fpath); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
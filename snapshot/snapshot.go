@@ -0,0 +1,270 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// magic and version starting every snapshot
+	magic   = "THRAPSNAP"
+	version = 1
+
+	// Max plaintext size of a chunk
+	chunkSize = 64 << 10
+
+	keySize  = 32
+	saltSize = 16
+)
+
+var (
+	// ErrChecksumMismatch is returned when the data does not match the
+	// snapshot checksum
+	ErrChecksumMismatch = errors.New("snapshot checksum mismatch")
+	// ErrPassphraseRequired is returned when reading an encrypted snapshot
+	// without a passphrase
+	ErrPassphraseRequired = errors.New("snapshot passphrase required")
+	// ErrDecrypt is returned when a chunk cannot be decrypted due to a wrong
+	// passphrase or corrupt data
+	ErrDecrypt = errors.New("snapshot decryption failed")
+
+	errInvalidSnapshot = errors.New("invalid snapshot")
+	errChunkTooLarge   = errors.New("snapshot chunk too large")
+)
+
+// deriveKey returns the AES-GCM cipher keyed by the passphrase and salt
+func deriveKey(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// nonce returns the nonce of the chunk.  Chunks are numbered so they cannot
+// be reordered
+func nonce(aead cipher.AEAD, seq uint64) []byte {
+	n := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(n[len(n)-8:], seq)
+	return n
+}
+
+// Writer writes a snapshot.  Close must be called to write the checksum
+type Writer struct {
+	w io.Writer
+	// marshalled header authenticated with each encrypted chunk
+	hdr []byte
+
+	aead cipher.AEAD
+	seq  uint64
+
+	h   hash.Hash
+	buf []byte
+}
+
+// NewWriter writes the header and returns a Writer for the data.  The data
+// is encrypted if a passphrase is given, setting the header Encrypted and
+// Salt fields
+func NewWriter(w io.Writer, hdr *thrapb.SnapshotHeader, passphrase []byte) (*Writer, error) {
+	sw := &Writer{w: w, h: sha256.New()}
+
+	hdr.Encrypted = len(passphrase) > 0
+	hdr.Salt = nil
+	if hdr.Encrypted {
+		hdr.Salt = make([]byte, saltSize)
+		if _, err := io.ReadFull(rand.Reader, hdr.Salt); err != nil {
+			return nil, err
+		}
+		aead, err := deriveKey(passphrase, hdr.Salt)
+		if err != nil {
+			return nil, err
+		}
+		sw.aead = aead
+	}
+
+	var err error
+	if sw.hdr, err = proto.Marshal(hdr); err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(append([]byte(magic), version)); err != nil {
+		return nil, err
+	}
+	return sw, sw.writeFrame(sw.hdr)
+}
+
+func (sw *Writer) writeFrame(b []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(b)))
+	if _, err := sw.w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(b)
+	return err
+}
+
+// writeChunk hashes and writes the plaintext as a frame
+func (sw *Writer) writeChunk(plain []byte) error {
+	sw.h.Write(plain)
+	if sw.aead != nil {
+		plain = sw.aead.Seal(nil, nonce(sw.aead, sw.seq), plain, sw.hdr)
+		sw.seq++
+	}
+	return sw.writeFrame(plain)
+}
+
+// Write buffers the data writing full chunks
+func (sw *Writer) Write(p []byte) (int, error) {
+	sw.buf = append(sw.buf, p...)
+	for len(sw.buf) >= chunkSize {
+		if err := sw.writeChunk(sw.buf[:chunkSize]); err != nil {
+			return 0, err
+		}
+		sw.buf = sw.buf[chunkSize:]
+	}
+	return len(p), nil
+}
+
+// Close writes the remaining data, the end frame and the checksum.  It
+// does not close the underlying writer
+func (sw *Writer) Close() error {
+	if len(sw.buf) > 0 {
+		if err := sw.writeChunk(sw.buf); err != nil {
+			return err
+		}
+		sw.buf = nil
+	}
+	if err := sw.writeFrame(nil); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(sw.h.Sum(nil))
+	return err
+}
+
+// Reader reads a snapshot.  Data is returned as it is read.  The checksum is
+// verified at the end, returning ErrChecksumMismatch instead of io.EOF if it
+// does not match
+type Reader struct {
+	// Header of the snapshot
+	Header *thrapb.SnapshotHeader
+
+	r   *bufio.Reader
+	hdr []byte
+
+	aead cipher.AEAD
+	seq  uint64
+
+	h   hash.Hash
+	buf []byte
+	err error
+}
+
+// NewReader reads the header and returns a Reader for the data.  The
+// passphrase is required if the snapshot is encrypted
+func NewReader(r io.Reader, passphrase []byte) (*Reader, error) {
+	sr := &Reader{r: bufio.NewReader(r), h: sha256.New()}
+
+	pre := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(sr.r, pre); err != nil {
+		return nil, errInvalidSnapshot
+	}
+	if !bytes.Equal(pre[:len(magic)], []byte(magic)) || pre[len(magic)] != version {
+		return nil, errInvalidSnapshot
+	}
+
+	var err error
+	if sr.hdr, err = sr.readFrame(); err != nil {
+		return nil, err
+	}
+	sr.Header = &thrapb.SnapshotHeader{}
+	if err = proto.Unmarshal(sr.hdr, sr.Header); err != nil {
+		return nil, errInvalidSnapshot
+	}
+
+	if sr.Header.Encrypted {
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+		if sr.aead, err = deriveKey(passphrase, sr.Header.Salt); err != nil {
+			return nil, err
+		}
+	}
+
+	return sr, nil
+}
+
+func (sr *Reader) readFrame() ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(sr.r, size[:]); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+	if n > 2*chunkSize {
+		return nil, errChunkTooLarge
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(sr.r, b); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return b, nil
+}
+
+// next reads the next chunk into the buffer
+func (sr *Reader) next() error {
+	b, err := sr.readFrame()
+	if err != nil {
+		return err
+	}
+
+	if len(b) == 0 {
+		sum := make([]byte, sha256.Size)
+		if _, err = io.ReadFull(sr.r, sum); err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		if !bytes.Equal(sum, sr.h.Sum(nil)) {
+			return ErrChecksumMismatch
+		}
+		return io.EOF
+	}
+
+	if sr.aead != nil {
+		if b, err = sr.aead.Open(nil, nonce(sr.aead, sr.seq), b, sr.hdr); err != nil {
+			return ErrDecrypt
+		}
+		sr.seq++
+	}
+	sr.h.Write(b)
+	sr.buf = b
+	return nil
+}
+
+// Read reads decrypted data
+func (sr *Reader) Read(p []byte) (int, error) {
+	for len(sr.buf) == 0 {
+		if sr.err != nil {
+			return 0, sr.err
+		}
+		sr.err = sr.next()
+	}
+
+	n := copy(p, sr.buf)
+	sr.buf = sr.buf[n:]
+	return n, nil
+}
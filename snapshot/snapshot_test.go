@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euforia/thrap/thrapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSnapshot(t *testing.T, data, passphrase []byte) []byte {
+	var buf bytes.Buffer
+	sw, err := NewWriter(&buf, &thrapb.SnapshotHeader{Format: "kv", Created: 1}, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Uneven writes spanning chunks
+	for i := 0; i < len(data); i += 1000 {
+		end := i + 1000
+		if end > len(data) {
+			end = len(data)
+		}
+		sw.Write(data[i:end])
+	}
+	assert.Nil(t, sw.Close())
+	return buf.Bytes()
+}
+
+func Test_Snapshot(t *testing.T) {
+	data := bytes.Repeat([]byte("thrap"), 3*chunkSize/5+7)
+
+	for _, pass := range [][]byte{nil, []byte("secret")} {
+		snap := writeSnapshot(t, data, pass)
+
+		sr, err := NewReader(bytes.NewReader(snap), pass)
+		assert.Nil(t, err)
+		assert.Equal(t, "kv", sr.Header.Format)
+		assert.Equal(t, len(pass) > 0, sr.Header.Encrypted)
+		out, err := ioutil.ReadAll(sr)
+		assert.Nil(t, err)
+		assert.Equal(t, data, out)
+
+		// Flip a data byte past the header
+		snap[len(snap)/2] ^= 0xff
+		sr, err = NewReader(bytes.NewReader(snap), pass)
+		assert.Nil(t, err)
+		_, err = ioutil.ReadAll(sr)
+		if len(pass) > 0 {
+			assert.Equal(t, ErrDecrypt, err)
+		} else {
+			assert.Equal(t, ErrChecksumMismatch, err)
+		}
+	}
+
+	snap := writeSnapshot(t, data, []byte("secret"))
+	_, err := NewReader(bytes.NewReader(snap), nil)
+	assert.Equal(t, ErrPassphraseRequired, err)
+	sr, _ := NewReader(bytes.NewReader(snap), []byte("wrong"))
+	_, err = ioutil.ReadAll(sr)
+	assert.Equal(t, ErrDecrypt, err)
+
+	// Truncated
+	sr, _ = NewReader(bytes.NewReader(snap[:len(snap)-10]), []byte("secret"))
+	_, err = ioutil.ReadAll(sr)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func Test_Scheduler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thrap-snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &Scheduler{
+		Dir:    dir,
+		Retain: 2,
+		Save: func(w io.Writer, passphrase []byte) error {
+			sw, err := NewWriter(w, &thrapb.SnapshotHeader{Format: "kv"}, passphrase)
+			if err == nil {
+				err = sw.Close()
+			}
+			return err
+		},
+		Log: log.New(ioutil.Discard, "", 0),
+	}
+
+	var saved []string
+	for i := 0; i < 3; i++ {
+		fpath, err := s.SaveNow()
+		assert.Nil(t, err)
+		saved = append(saved, fpath)
+	}
+
+	files, err := List(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, saved[1:], files)
+
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	assert.Equal(t, 0, len(tmps))
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+const (
+	defaultAuditPrefix = "/audit/"
+	// Key of the last appended entry.  It may lag behind when agents share
+	// a driver
+	defaultAuditHeadKey = "/audit-head"
+)
+
+// AuditStorage implements an append-only AuditStorage on a storage driver.
+// Entries are keyed by their zero padded sequence number and chained by
+// hash
+type AuditStorage struct {
+	d Driver
+	// serializes local appends
+	mu sync.Mutex
+}
+
+// NewAuditStorage returns a new audit storage backed by the driver
+func NewAuditStorage(d Driver) *AuditStorage {
+	return &AuditStorage{d: d}
+}
+
+func (store *AuditStorage) getOpaqueKey(seq uint64) string {
+	return fmt.Sprintf("%s%020d", defaultAuditPrefix, seq)
+}
+
+// Append sets the sequence number, previous hash and hash of the entry and
+// writes it.  Sequence numbers are claimed with a create so concurrent
+// appends from other agents follow one another
+func (store *AuditStorage) Append(entry *thrapb.AuditEntry) (*thrapb.AuditEntry, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	head, err := store.get(defaultAuditHeadKey)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, err
+	}
+
+	for {
+		entry.Seq, entry.Prev = 1, nil
+		if head != nil {
+			entry.Seq, entry.Prev = head.Seq+1, head.Hash
+		}
+		entry.Hash = entry.ComputeHash(sha256.New())
+
+		val, err := proto.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		key := store.getOpaqueKey(entry.Seq)
+		err = store.d.Create(key, val)
+		if err == nil {
+			return entry, store.d.Put(defaultAuditHeadKey, val)
+		} else if err != ErrKeyExists {
+			return nil, err
+		}
+
+		// Appended elsewhere. Follow the chain
+		if head, err = store.get(key); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Iter iterates over each entry in order starting at the sequence number
+func (store *AuditStorage) Iter(start uint64, callback func(*thrapb.AuditEntry) error) error {
+	return store.d.Iter(defaultAuditPrefix, store.getOpaqueKey(start), func(_ string, val []byte) error {
+		var entry thrapb.AuditEntry
+		if err := proto.Unmarshal(val, &entry); err != nil {
+			return err
+		}
+		return callback(&entry)
+	})
+}
+
+func (store *AuditStorage) get(key string) (*thrapb.AuditEntry, error) {
+	val, err := store.d.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry thrapb.AuditEntry
+	err = proto.Unmarshal(val, &entry)
+	return &entry, err
+}
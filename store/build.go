@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/euforia/thrap/thrapb"
+)
+
+const (
+	defaultBuildPrefix      = "/build/"
+	defaultDeploymentPrefix = "/deployment/"
+)
+
+var (
+	// ErrBuildExists is used when a build record already exists
+	ErrBuildExists = errors.New("build exists")
+	// ErrDeploymentExists is used when a deployment record already exists
+	ErrDeploymentExists = errors.New("deployment exists")
+)
+
+// BuildStorage implements the BuildStorage interface on a storage driver.
+// Records are keyed by stack id followed by the build id so a stack id
+// can be used as the iteration prefix
+type BuildStorage struct {
+	d Driver
+}
+
+// NewBuildStorage returns a new build storage backed by the driver
+func NewBuildStorage(d Driver) *BuildStorage {
+	return &BuildStorage{d: d}
+}
+
+func (store *BuildStorage) getOpaqueKey(k string) string {
+	return defaultBuildPrefix + k
+}
+
+// Create writes a new build record.  It returns an error if the record
+// already exists
+func (store *BuildStorage) Create(build *thrapb.StackBuild) (*thrapb.StackBuild, error) {
+	val, err := proto.Marshal(build)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.d.Create(store.getOpaqueKey(build.Stack+"/"+build.ID), val)
+	if err == ErrKeyExists {
+		err = ErrBuildExists
+	}
+	return build, err
+}
+
+// Iter iterates over each build record from the starting point
+func (store *BuildStorage) Iter(start string, callback func(*thrapb.StackBuild) error) error {
+	return store.d.Iter(store.getOpaqueKey(start), "", func(_ string, val []byte) error {
+		var build thrapb.StackBuild
+		if err := proto.Unmarshal(val, &build); err != nil {
+			return err
+		}
+		return callback(&build)
+	})
+}
+
+// DeploymentStorage implements the DeploymentStorage interface on a storage
+// driver.  Records are keyed the same way as builds
+type DeploymentStorage struct {
+	d Driver
+}
+
+// NewDeploymentStorage returns a new deployment storage backed by the driver
+func NewDeploymentStorage(d Driver) *DeploymentStorage {
+	return &DeploymentStorage{d: d}
+}
+
+func (store *DeploymentStorage) getOpaqueKey(k string) string {
+	return defaultDeploymentPrefix + k
+}
+
+// Create writes a new deployment record.  It returns an error if the record
+// already exists
+func (store *DeploymentStorage) Create(deploy *thrapb.Deployment) (*thrapb.Deployment, error) {
+	val, err := proto.Marshal(deploy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.d.Create(store.getOpaqueKey(deploy.Stack+"/"+deploy.ID), val)
+	if err == ErrKeyExists {
+		err = ErrDeploymentExists
+	}
+	return deploy, err
+}
+
+// Iter iterates over each deployment record from the starting point
+func (store *DeploymentStorage) Iter(start string, callback func(*thrapb.Deployment) error) error {
+	return store.d.Iter(store.getOpaqueKey(start), "", func(_ string, val []byte) error {
+		var deploy thrapb.Deployment
+		if err := proto.Unmarshal(val, &deploy); err != nil {
+			return err
+		}
+		return callback(&deploy)
+	})
+}
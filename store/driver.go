@@ -0,0 +1,103 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Storage driver names
+const (
+	// DriverBadger is the embedded badger driver.  It is the default
+	DriverBadger = "badger"
+	// DriverBolt is the embedded bolt driver
+	DriverBolt = "bolt"
+	// DriverConsul is the consul kv driver allowing multiple agents to share
+	// state
+	DriverConsul = "consul"
+)
+
+var (
+	// ErrKeyNotFound is returned by drivers when a key does not exist
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists is returned by drivers when creating an existing key
+	ErrKeyExists = errors.New("key exists")
+
+	errUnknownDriver = errors.New("unknown storage driver")
+)
+
+// Driver is an ordered key-value store backing all agent state.  Keys are
+// '/' separated paths.  Create and Update must be atomic so multiple agents
+// can share a driver
+type Driver interface {
+	// Get returns the value of the key or ErrKeyNotFound
+	Get(key string) ([]byte, error)
+	// Create writes the key if it does not exist otherwise it returns
+	// ErrKeyExists
+	Create(key string, val []byte) error
+	// Update writes an existing key otherwise it returns ErrKeyNotFound
+	Update(key string, val []byte) error
+	// Put writes the key whether it exists or not
+	Put(key string, val []byte) error
+	// Delete removes the key or returns ErrKeyNotFound
+	Delete(key string) error
+	// Iter calls f in key order for each key with the prefix starting at
+	// the start key.  An empty start begins at the prefix
+	Iter(prefix, start string, f func(key string, val []byte) error) error
+	// Close releases the driver resources
+	Close() error
+}
+
+// DriverConfig holds the storage driver configuration
+type DriverConfig struct {
+	// Driver name. Defaults to badger
+	Name string
+	// Data directory of the embedded drivers
+	DataDir string
+	// Consul address. Defaults to the consul client environment
+	Addr string
+	// Consul kv prefix.  Defaults to thrap
+	Prefix string
+	// Consul acl token. Defaults to the consul client environment
+	Token string
+}
+
+// OpenDriver opens the storage driver in the config
+func OpenDriver(conf *DriverConfig) (Driver, error) {
+	switch conf.Name {
+	case DriverBadger, "":
+		return openBadgerDriver(conf.DataDir)
+	case DriverBolt:
+		return openBoltDriver(conf.DataDir)
+	case DriverConsul:
+		return openConsulDriver(conf)
+	}
+	return nil, fmt.Errorf("%v: %s", errUnknownDriver, conf.Name)
+}
+
+// Copy copies all keys from the source to the destination driver
+// overwriting existing ones.  It returns the number of keys copied
+func Copy(dst, src Driver) (int, error) {
+	var n int
+	err := src.Iter("/", "", func(key string, val []byte) error {
+		if err := dst.Put(key, val); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// iterStart returns where iteration should begin
+func iterStart(prefix, start string) string {
+	if start == "" || start < prefix {
+		return prefix
+	}
+	return start
+}
+
+// validForPrefix returns true if the key is within the prefix
+func validForPrefix(key, prefix string) bool {
+	return strings.HasPrefix(key, prefix)
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger"
+)
+
+// badgerDriver implements Driver with a badger db
+type badgerDriver struct {
+	db *badger.DB
+}
+
+// openBadgerDriver opens the badger db in the db directory under the data
+// directory creating it if needed
+func openBadgerDriver(datadir string) (*badgerDriver, error) {
+	dbdir := filepath.Join(datadir, "db")
+	if err := os.MkdirAll(dbdir, 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := NewBadgerDB(dbdir)
+	if err != nil {
+		return nil, err
+	}
+	return &badgerDriver{db: db}, nil
+}
+
+func (d *badgerDriver) Get(key string) ([]byte, error) {
+	var val []byte
+	err := d.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err == badger.ErrKeyNotFound {
+			return ErrKeyNotFound
+		} else if err != nil {
+			return err
+		}
+		val, err = item.ValueCopy(nil)
+		return err
+	})
+	return val, err
+}
+
+func (d *badgerDriver) Create(key string, val []byte) error {
+	return d.db.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		if err == nil {
+			return ErrKeyExists
+		} else if err != badger.ErrKeyNotFound {
+			return err
+		}
+		return txn.Set([]byte(key), val)
+	})
+}
+
+func (d *badgerDriver) Update(key string, val []byte) error {
+	return d.db.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		if err == badger.ErrKeyNotFound {
+			return ErrKeyNotFound
+		} else if err != nil {
+			return err
+		}
+		return txn.Set([]byte(key), val)
+	})
+}
+
+func (d *badgerDriver) Put(key string, val []byte) error {
+	return d.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(key), val)
+	})
+}
+
+func (d *badgerDriver) Delete(key string) error {
+	return d.db.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		if err == badger.ErrKeyNotFound {
+			return ErrKeyNotFound
+		} else if err != nil {
+			return err
+		}
+		return txn.Delete([]byte(key))
+	})
+}
+
+func (d *badgerDriver) Iter(prefix, start string, f func(string, []byte) error) error {
+	pre := []byte(prefix)
+
+	return d.db.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+
+		for iter.Seek([]byte(iterStart(prefix, start))); iter.ValidForPrefix(pre); iter.Next() {
+			item := iter.Item()
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err = f(string(item.Key()), val); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *badgerDriver) Close() error {
+	return d.db.Close()
+}
+
+// SnapshotFormat implements Snapshotter
+func (d *badgerDriver) SnapshotFormat() string {
+	return DriverBadger
+}
+
+// Backup writes a full badger backup
+func (d *badgerDriver) Backup(w io.Writer) error {
+	_, err := d.db.Backup(w, 0)
+	return err
+}
+
+// Load loads a badger backup
+func (d *badgerDriver) Load(r io.Reader) error {
+	return d.db.Load(r)
+}
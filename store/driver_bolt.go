@@ -0,0 +1,114 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+)
+
+const (
+	// bolt db filename in the data directory
+	boltFile = "thrap.db"
+	// all keys are in a single bucket
+	boltBucket = "thrap"
+)
+
+// boltDriver implements Driver with a bolt db
+type boltDriver struct {
+	db *bolt.DB
+}
+
+// openBoltDriver opens the bolt db in the data directory creating it if
+// needed
+func openBoltDriver(datadir string) (*boltDriver, error) {
+	if err := os.MkdirAll(datadir, 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(filepath.Join(datadir, boltFile), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(boltBucket))
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &boltDriver{db: db}, nil
+}
+
+func (d *boltDriver) Get(key string) ([]byte, error) {
+	var val []byte
+	err := d.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(boltBucket)).Get([]byte(key))
+		if v == nil {
+			return ErrKeyNotFound
+		}
+		// Values are only valid within the transaction
+		val = append([]byte{}, v...)
+		return nil
+	})
+	return val, err
+}
+
+func (d *boltDriver) Create(key string, val []byte) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltBucket))
+		if b.Get([]byte(key)) != nil {
+			return ErrKeyExists
+		}
+		return b.Put([]byte(key), val)
+	})
+}
+
+func (d *boltDriver) Update(key string, val []byte) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltBucket))
+		if b.Get([]byte(key)) == nil {
+			return ErrKeyNotFound
+		}
+		return b.Put([]byte(key), val)
+	})
+}
+
+func (d *boltDriver) Put(key string, val []byte) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(boltBucket)).Put([]byte(key), val)
+	})
+}
+
+func (d *boltDriver) Delete(key string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltBucket))
+		if b.Get([]byte(key)) == nil {
+			return ErrKeyNotFound
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+func (d *boltDriver) Iter(prefix, start string, f func(string, []byte) error) error {
+	pre := []byte(prefix)
+
+	return d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(boltBucket)).Cursor()
+		for k, v := c.Seek([]byte(iterStart(prefix, start))); k != nil && bytes.HasPrefix(k, pre); k, v = c.Next() {
+			if err := f(string(k), append([]byte{}, v...)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *boltDriver) Close() error {
+	return d.db.Close()
+}
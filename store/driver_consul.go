@@ -0,0 +1,124 @@
+package store
+
+import (
+	"strings"
+
+	consul "github.com/hashicorp/consul/api"
+)
+
+// defaultConsulPrefix is the kv prefix all keys are written under
+const defaultConsulPrefix = "thrap"
+
+// consulDriver implements Driver with the consul kv store.  Create and
+// Update use check-and-set so multiple agents can share it
+type consulDriver struct {
+	kv     *consul.KV
+	prefix string
+}
+
+func openConsulDriver(conf *DriverConfig) (*consulDriver, error) {
+	cconf := consul.DefaultConfig()
+	if conf.Addr != "" {
+		cconf.Address = conf.Addr
+	}
+	if conf.Token != "" {
+		cconf.Token = conf.Token
+	}
+
+	client, err := consul.NewClient(cconf)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(conf.Prefix, "/")
+	if prefix == "" {
+		prefix = defaultConsulPrefix
+	}
+
+	return &consulDriver{kv: client.KV(), prefix: prefix}, nil
+}
+
+// Keys start with a slash so are appended to the prefix as is
+func (d *consulDriver) key(k string) string {
+	return d.prefix + k
+}
+
+func (d *consulDriver) Get(key string) ([]byte, error) {
+	pair, _, err := d.kv.Get(d.key(key), &consul.QueryOptions{RequireConsistent: true})
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, ErrKeyNotFound
+	}
+	return pair.Value, nil
+}
+
+func (d *consulDriver) Create(key string, val []byte) error {
+	// A zero index only succeeds if the key does not exist
+	ok, _, err := d.kv.CAS(&consul.KVPair{Key: d.key(key), Value: val}, nil)
+	if err == nil && !ok {
+		err = ErrKeyExists
+	}
+	return err
+}
+
+func (d *consulDriver) Update(key string, val []byte) error {
+	for {
+		pair, _, err := d.kv.Get(d.key(key), &consul.QueryOptions{RequireConsistent: true})
+		if err != nil {
+			return err
+		}
+		if pair == nil {
+			return ErrKeyNotFound
+		}
+
+		pair.Value = val
+		ok, _, err := d.kv.CAS(pair, nil)
+		if err != nil || ok {
+			return err
+		}
+		// Modified since read. Retry
+	}
+}
+
+func (d *consulDriver) Put(key string, val []byte) error {
+	_, err := d.kv.Put(&consul.KVPair{Key: d.key(key), Value: val}, nil)
+	return err
+}
+
+func (d *consulDriver) Delete(key string) error {
+	pair, _, err := d.kv.Get(d.key(key), &consul.QueryOptions{RequireConsistent: true})
+	if err != nil {
+		return err
+	}
+	if pair == nil {
+		return ErrKeyNotFound
+	}
+	_, err = d.kv.Delete(d.key(key), nil)
+	return err
+}
+
+// Iter lists all keys under the prefix.  Consul returns them sorted
+func (d *consulDriver) Iter(prefix, start string, f func(string, []byte) error) error {
+	pairs, _, err := d.kv.List(d.key(prefix), &consul.QueryOptions{RequireConsistent: true})
+	if err != nil {
+		return err
+	}
+
+	start = iterStart(prefix, start)
+	for _, pair := range pairs {
+		key := strings.TrimPrefix(pair.Key, d.prefix)
+		if key < start || !validForPrefix(key, prefix) {
+			continue
+		}
+		if err = f(key, pair.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *consulDriver) Close() error {
+	return nil
+}
@@ -0,0 +1,312 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euforia/thrap/thrapb"
+	consul "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDriverConformance checks the behavior every driver must provide
+func testDriverConformance(t *testing.T, d Driver) {
+	_, err := d.Get("/test/missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, ErrKeyNotFound, d.Update("/test/missing", []byte("v")))
+	assert.Equal(t, ErrKeyNotFound, d.Delete("/test/missing"))
+
+	assert.Nil(t, d.Create("/test/b", []byte("b")))
+	assert.Equal(t, ErrKeyExists, d.Create("/test/b", []byte("x")))
+	val, err := d.Get("/test/b")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("b"), val)
+
+	assert.Nil(t, d.Update("/test/b", []byte("b1")))
+	val, _ = d.Get("/test/b")
+	assert.Equal(t, []byte("b1"), val)
+
+	// Returned values must not alias driver memory
+	val[0] = 'x'
+	val, _ = d.Get("/test/b")
+	assert.Equal(t, []byte("b1"), val)
+
+	assert.Nil(t, d.Put("/test/a", []byte("a")))
+	assert.Nil(t, d.Put("/test/c", []byte("c")))
+	assert.Nil(t, d.Put("/tester", []byte("other")))
+
+	var keys []string
+	err = d.Iter("/test/", "", func(key string, val []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/test/a", "/test/b", "/test/c"}, keys)
+
+	keys = keys[:0]
+	err = d.Iter("/test/", "/test/b", func(key string, val []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/test/b", "/test/c"}, keys)
+
+	errStop := errors.New("stop")
+	var n int
+	err = d.Iter("/test/", "", func(key string, val []byte) error {
+		n++
+		return errStop
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, 1, n)
+
+	for _, k := range []string{"/test/a", "/test/b", "/test/c", "/tester"} {
+		assert.Nil(t, d.Delete(k))
+	}
+	_, err = d.Get("/test/a")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func testDriver(t *testing.T, name string) (Driver, func()) {
+	tmpdir, err := ioutil.TempDir("", "thrap-"+name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := OpenDriver(&DriverConfig{Name: name, DataDir: tmpdir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d, func() {
+		d.Close()
+		os.RemoveAll(tmpdir)
+	}
+}
+
+func Test_Driver_Badger(t *testing.T) {
+	d, done := testDriver(t, DriverBadger)
+	defer done()
+	testDriverConformance(t, d)
+}
+
+func Test_Driver_Bolt(t *testing.T) {
+	d, done := testDriver(t, DriverBolt)
+	defer done()
+	testDriverConformance(t, d)
+}
+
+// consulKV is a stand-in for the consul kv http api supporting the calls
+// made by the driver
+type consulKV struct {
+	mu    sync.Mutex
+	index uint64
+	pairs map[string]*consul.KVPair
+}
+
+func newConsulKV() *httptest.Server {
+	kv := &consulKV{pairs: make(map[string]*consul.KVPair)}
+	return httptest.NewServer(http.StripPrefix("/v1/kv/", kv))
+}
+
+func (kv *consulKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	key := r.URL.Path
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(kv.index, 10))
+
+	switch r.Method {
+	case http.MethodGet:
+		var pairs []*consul.KVPair
+		if _, ok := r.URL.Query()["recurse"]; ok {
+			for k, pair := range kv.pairs {
+				if strings.HasPrefix(k, key) {
+					pairs = append(pairs, pair)
+				}
+			}
+			sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+		} else if pair, ok := kv.pairs[key]; ok {
+			pairs = append(pairs, pair)
+		}
+		if len(pairs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(pairs)
+
+	case http.MethodPut:
+		val, _ := ioutil.ReadAll(r.Body)
+		pair, exists := kv.pairs[key]
+		if cas := r.URL.Query().Get("cas"); cas != "" {
+			idx, _ := strconv.ParseUint(cas, 10, 64)
+			if (idx == 0 && exists) || (idx > 0 && (!exists || pair.ModifyIndex != idx)) {
+				w.Write([]byte("false"))
+				return
+			}
+		}
+		kv.index++
+		if !exists {
+			pair = &consul.KVPair{Key: key, CreateIndex: kv.index}
+		}
+		kv.pairs[key] = &consul.KVPair{Key: key, Value: val, CreateIndex: pair.CreateIndex, ModifyIndex: kv.index}
+		w.Write([]byte("true"))
+
+	case http.MethodDelete:
+		kv.index++
+		delete(kv.pairs, key)
+		w.Write([]byte("true"))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// Test_Driver_Consul runs against the consul agent at CONSUL_HTTP_ADDR if set
+// otherwise against a local stand-in
+func Test_Driver_Consul(t *testing.T) {
+	addr := os.Getenv("CONSUL_HTTP_ADDR")
+	if addr == "" {
+		srv := newConsulKV()
+		defer srv.Close()
+		addr = srv.URL
+	}
+
+	d, err := OpenDriver(&DriverConfig{
+		Name:   DriverConsul,
+		Addr:   addr,
+		Prefix: fmt.Sprintf("thrap-test-%d", time.Now().UnixNano()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	testDriverConformance(t, d)
+}
+
+func Test_Copy(t *testing.T) {
+	src, done := testDriver(t, DriverBolt)
+	defer done()
+	dst, done2 := testDriver(t, DriverBolt)
+	defer done2()
+
+	assert.Nil(t, src.Put("/stack/a", []byte("a")))
+	assert.Nil(t, src.Put("/identity/b", []byte("b")))
+
+	n, err := Copy(dst, src)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	val, err := dst.Get("/identity/b")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("b"), val)
+}
+
+func Test_BackupLoad(t *testing.T) {
+	src, done := testDriver(t, DriverBolt)
+	defer done()
+	dst, done2 := testDriver(t, DriverBolt)
+	defer done2()
+
+	assert.Nil(t, src.Put("/stack/a", []byte("a")))
+	assert.Nil(t, src.Put("/stack/empty", []byte{}))
+	assert.Nil(t, dst.Put("/stack/b", []byte("b")))
+
+	var buf bytes.Buffer
+	assert.Equal(t, SnapshotFormatKV, SnapshotFormat(src))
+	assert.Nil(t, Backup(src, &buf))
+	assert.Nil(t, Load(dst, SnapshotFormatKV, &buf))
+
+	for _, k := range []string{"/stack/a", "/stack/b"} {
+		_, err := dst.Get(k)
+		assert.Nil(t, err)
+	}
+	val, err := dst.Get("/stack/empty")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(val))
+
+	assert.NotNil(t, Load(dst, DriverBadger, &buf))
+
+	// Restoring replaces all keys but the audit log
+	assert.Nil(t, src.Put(defaultAuditHeadKey, []byte("old")))
+	assert.Nil(t, src.Put(defaultAuditPrefix+"1", []byte("old")))
+	assert.Nil(t, dst.Put(defaultAuditHeadKey, []byte("new")))
+	buf.Reset()
+	assert.Nil(t, Backup(src, &buf))
+	assert.Nil(t, Clear(dst))
+	assert.Nil(t, Load(dst, SnapshotFormatKV, &buf))
+	_, err = dst.Get("/stack/b")
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = dst.Get("/stack/a")
+	assert.Nil(t, err)
+	val, err = dst.Get(defaultAuditHeadKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new"), val)
+	_, err = dst.Get(defaultAuditPrefix + "1")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// Record sizes are checked before allocating
+	buf.Reset()
+	assert.Nil(t, writeRecord(&buf, []byte("/stack/c")))
+	hdr := make([]byte, binary.MaxVarintLen64)
+	buf.Write(hdr[:binary.PutUvarint(hdr, 1<<40)])
+	assert.Equal(t, errRecordTooLarge, Load(dst, SnapshotFormatKV, &buf))
+}
+
+func Test_BackupLoad_native(t *testing.T) {
+	src, done := testDriver(t, DriverBadger)
+	defer done()
+	dst, done2 := testDriver(t, DriverBadger)
+	defer done2()
+
+	assert.Nil(t, src.Put("/stack/a", []byte("a")))
+	assert.Nil(t, src.Put(defaultAuditHeadKey, []byte("old")))
+	assert.Nil(t, src.Put(defaultAuditPrefix+"1", []byte("old")))
+	assert.Nil(t, dst.Put(defaultAuditHeadKey, []byte("new")))
+
+	var buf bytes.Buffer
+	assert.Equal(t, DriverBadger, SnapshotFormat(src))
+	assert.Nil(t, Backup(src, &buf))
+	assert.Nil(t, Clear(dst))
+	assert.Nil(t, Load(dst, DriverBadger, &buf))
+
+	_, err := dst.Get("/stack/a")
+	assert.Nil(t, err)
+	// The audit log is not rolled back
+	val, err := dst.Get(defaultAuditHeadKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new"), val)
+	_, err = dst.Get(defaultAuditPrefix + "1")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func Test_AuditStorage_Append(t *testing.T) {
+	d, done := testDriver(t, DriverBolt)
+	defer done()
+
+	// Two storages on one driver simulate agents sharing state
+	s1, s2 := NewAuditStorage(d), NewAuditStorage(d)
+	e1, err := s1.Append(&thrapb.AuditEntry{Method: "A"})
+	assert.Nil(t, err)
+	// Rewind the head so s2 collides with the first entry
+	assert.Nil(t, d.Delete(defaultAuditHeadKey))
+	e2, err := s2.Append(&thrapb.AuditEntry{Method: "B"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, uint64(2), e2.Seq)
+	assert.Equal(t, e1.Hash, e2.Prev)
+}
@@ -3,7 +3,6 @@ package store
 import (
 	"errors"
 
-	"github.com/dgraph-io/badger"
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/euforia/thrap/thrapb"
@@ -18,135 +17,84 @@ var (
 	ErrIdentityExists = errors.New("identity exists")
 )
 
-// BadgerIdentityStorage implements a badger backed IdentityStorage interface
-type BadgerIdentityStorage struct {
-	db *badger.DB
+// IdentityStorage implements the IdentityStorage interface on a storage
+// driver
+type IdentityStorage struct {
+	d Driver
 }
 
-// NewBadgerIdentityStorage returns a new BadgerIdentityStorage
-func NewBadgerIdentityStorage(db *badger.DB) *BadgerIdentityStorage {
-	return &BadgerIdentityStorage{db: db}
+// NewIdentityStorage returns a new identity storage backed by the driver
+func NewIdentityStorage(d Driver) *IdentityStorage {
+	return &IdentityStorage{d: d}
 }
 
-func (store *BadgerIdentityStorage) getOpaqueKey(k string) []byte {
-	return []byte(defaultIdentityPrefix + k)
+func (store *IdentityStorage) getOpaqueKey(k string) string {
+	return defaultIdentityPrefix + k
 }
 
 // Get returns an identity by the id
-func (store *BadgerIdentityStorage) Get(id string) (*thrapb.Identity, error) {
-	var (
-		key   = store.getOpaqueKey(id)
-		ident *thrapb.Identity
-	)
-
-	err := store.db.View(func(txn *badger.Txn) error {
-		var err error
-		ident, err = store.getIdentity(txn, key)
-		return err
-	})
+func (store *IdentityStorage) Get(id string) (*thrapb.Identity, error) {
+	val, err := store.d.Get(store.getOpaqueKey(id))
+	if err == ErrKeyNotFound {
+		return nil, ErrIdentityNotFound
+	} else if err != nil {
+		return nil, err
+	}
 
-	return ident, err
+	var ident thrapb.Identity
+	err = proto.Unmarshal(val, &ident)
+	return &ident, err
 }
 
 // Create creates a new identity. It returns an error if it exists
-func (store *BadgerIdentityStorage) Create(ident *thrapb.Identity) (*thrapb.Identity, error) {
-	key := store.getOpaqueKey(ident.ID)
+func (store *IdentityStorage) Create(ident *thrapb.Identity) (*thrapb.Identity, error) {
 	val, err := proto.Marshal(ident)
 	if err != nil {
 		return nil, err
 	}
 
-	err = store.db.Update(func(txn *badger.Txn) error {
-		_, err := txn.Get(key)
-		if err == nil {
-			return ErrIdentityExists
-		}
-
-		return txn.Set(key, val)
-	})
+	err = store.d.Create(store.getOpaqueKey(ident.ID), val)
+	if err == ErrKeyExists {
+		err = ErrIdentityExists
+	}
 	return ident, err
 }
 
 // Update an identity.  It returns an ErrIdentityNotFound
-func (store *BadgerIdentityStorage) Update(ident *thrapb.Identity) (*thrapb.Identity, error) {
-	key := store.getOpaqueKey(ident.ID)
+func (store *IdentityStorage) Update(ident *thrapb.Identity) (*thrapb.Identity, error) {
 	val, err := proto.Marshal(ident)
 	if err != nil {
 		return nil, err
 	}
 
-	err = store.db.Update(func(txn *badger.Txn) error {
-		_, err := txn.Get(key)
-		if err != nil {
-			return ErrIdentityNotFound
-		}
-
-		return txn.Set(key, val)
-	})
-
+	err = store.d.Update(store.getOpaqueKey(ident.ID), val)
+	if err == ErrKeyNotFound {
+		err = ErrIdentityNotFound
+	}
 	return ident, err
 }
 
 // Iter iterates over each identity from the starting point
-func (store *BadgerIdentityStorage) Iter(start string, callback func(*thrapb.Identity) error) error {
-	prefix := store.getOpaqueKey(start)
-
-	return store.db.View(func(txn *badger.Txn) error {
-		iter := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer iter.Close()
-
-		for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
-			item := iter.Item()
-			ident, err := identityFromItem(item)
-			if err != nil {
-				return err
-			}
-			if err = callback(ident); err != nil {
-				return err
-			}
+func (store *IdentityStorage) Iter(start string, callback func(*thrapb.Identity) error) error {
+	return store.d.Iter(store.getOpaqueKey(start), "", func(_ string, val []byte) error {
+		var ident thrapb.Identity
+		if err := proto.Unmarshal(val, &ident); err != nil {
+			return err
 		}
-
-		return nil
+		return callback(&ident)
 	})
 }
 
 // Delete deletes an identity by the id. It returns an ErrIdentityNotFound
-func (store *BadgerIdentityStorage) Delete(id string) (*thrapb.Identity, error) {
-	var (
-		key   = store.getOpaqueKey(id)
-		ident *thrapb.Identity
-	)
-
-	err := store.db.Update(func(txn *badger.Txn) error {
-		var err error
-		ident, err = store.getIdentity(txn, key)
-		if err != nil {
-			return ErrIdentityNotFound
-		}
-
-		return txn.Delete(key)
-	})
-
-	return ident, err
-}
-
-func (store *BadgerIdentityStorage) getIdentity(txn *badger.Txn, key []byte) (*thrapb.Identity, error) {
-	item, err := txn.Get(key)
+func (store *IdentityStorage) Delete(id string) (*thrapb.Identity, error) {
+	ident, err := store.Get(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return identityFromItem(item)
-}
-
-func identityFromItem(item *badger.Item) (*thrapb.Identity, error) {
-	val, err := item.Value()
-	if err != nil {
-		return nil, err
+	err = store.d.Delete(store.getOpaqueKey(id))
+	if err == ErrKeyNotFound {
+		err = ErrIdentityNotFound
 	}
-
-	var ident thrapb.Identity
-	err = proto.Unmarshal(val, &ident)
-
-	return &ident, err
+	return ident, err
 }
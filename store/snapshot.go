@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SnapshotFormatKV is the backup format supported by all drivers.  It is a
+// stream of length prefixed keys and values
+const SnapshotFormatKV = "kv"
+
+// maxRecordSize is the largest key or value read from a kv backup
+const maxRecordSize = 64 << 20
+
+// ClusterPrefix holds the cluster membership records.  They describe the
+// agents holding the state rather than the state itself so they are not
+// backed up, cleared or loaded
+const ClusterPrefix = "/cluster/"
+
+// preserved returns true for keys kept across restores.  Besides the cluster
+// membership the append-only audit log is kept so a restore cannot roll it
+// back
+func preserved(key string) bool {
+	return strings.HasPrefix(key, ClusterPrefix) ||
+		strings.HasPrefix(key, defaultAuditPrefix) || key == defaultAuditHeadKey
+}
+
+var errRecordTooLarge = errors.New("snapshot record too large")
+
+// Snapshotter is implemented by drivers with a native backup format
+type Snapshotter interface {
+	// SnapshotFormat returns the name of the native format
+	SnapshotFormat() string
+	// Backup writes all keys in the native format
+	Backup(w io.Writer) error
+	// Load loads a native backup
+	Load(r io.Reader) error
+}
+
+// SnapshotFormat returns the native format of the driver if it has one,
+// otherwise the kv format
+func SnapshotFormat(d Driver) string {
+	if s, ok := d.(Snapshotter); ok {
+		return s.SnapshotFormat()
+	}
+	return SnapshotFormatKV
+}
+
+// Backup writes all keys of the driver in its SnapshotFormat
+func Backup(d Driver, w io.Writer) error {
+	if s, ok := d.(Snapshotter); ok {
+		return s.Backup(w)
+	}
+
+	bw := bufio.NewWriter(w)
+	err := d.Iter("/", "", func(key string, val []byte) error {
+		if preserved(key) {
+			return nil
+		}
+		if err := writeRecord(bw, []byte(key)); err != nil {
+			return err
+		}
+		return writeRecord(bw, val)
+	})
+	if err == nil {
+		err = bw.Flush()
+	}
+	return err
+}
+
+// Clear deletes all keys of the driver except the cluster membership and the
+// audit log
+func Clear(d Driver) error {
+	var keys []string
+	err := d.Iter("/", "", func(key string, _ []byte) error {
+		if !preserved(key) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err = d.Delete(key); err != nil && err != ErrKeyNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load loads a backup in the format into the driver.  Existing keys not in
+// the backup are kept.  Clear the driver first to replace its state.  The
+// cluster membership and audit log are never loaded
+func Load(d Driver, format string, r io.Reader) error {
+	if s, ok := d.(Snapshotter); ok && s.SnapshotFormat() == format {
+		return loadNative(d, s, r)
+	}
+	if format != SnapshotFormatKV {
+		return fmt.Errorf("snapshot format not supported by driver: %s", format)
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		key, err := readRecord(br)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		val, err := readRecord(br)
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+
+		if preserved(string(key)) {
+			continue
+		}
+		if err = d.Put(string(key), val); err != nil {
+			return err
+		}
+	}
+}
+
+// loadNative loads a native backup.  Native backups hold all keys so the
+// preserved keys are saved beforehand and put back after the load
+func loadNative(d Driver, s Snapshotter, r io.Reader) error {
+	saved := make(map[string][]byte)
+	err := d.Iter("/", "", func(key string, val []byte) error {
+		if preserved(key) {
+			saved[key] = append([]byte(nil), val...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.Load(r); err != nil {
+		return err
+	}
+
+	var loaded []string
+	err = d.Iter("/", "", func(key string, _ []byte) error {
+		if _, ok := saved[key]; !ok && preserved(key) {
+			loaded = append(loaded, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range loaded {
+		if err = d.Delete(key); err != nil && err != ErrKeyNotFound {
+			return err
+		}
+	}
+	for key, val := range saved {
+		if err = d.Put(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRecord(w io.Writer, b []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(b)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readRecord(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRecordSize {
+		return nil, errRecordTooLarge
+	}
+	b := make([]byte, size)
+	if _, err = io.ReadFull(r, b); err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return b, err
+}
@@ -1,10 +1,9 @@
 package store
 
 import (
-	"bytes"
 	"errors"
+	"strings"
 
-	"github.com/dgraph-io/badger"
 	"github.com/euforia/thrap/thrapb"
 	"github.com/gogo/protobuf/proto"
 )
@@ -18,158 +17,94 @@ var (
 	ErrStackNotFound = errors.New("stack not found")
 )
 
-// BadgerStackStorage implments a badger backed StackStorage interface
-type BadgerStackStorage struct {
-	db *badger.DB
+// StackStorage implements the StackStorage interface on a storage driver
+type StackStorage struct {
+	d Driver
 }
 
-// NewBadgerStackStorage returns a new badger backed stack storage
-func NewBadgerStackStorage(db *badger.DB) *BadgerStackStorage {
-	return &BadgerStackStorage{db: db}
+// NewStackStorage returns a new stack storage backed by the driver
+func NewStackStorage(d Driver) *StackStorage {
+	return &StackStorage{d: d}
 }
 
-func (store *BadgerStackStorage) getOpaqueKey(k string) []byte {
-	return []byte(defaultStackPrefix + k)
+func (store *StackStorage) getOpaqueKey(k string) string {
+	return defaultStackPrefix + k
 }
 
-// Get returns a stack by the id and the chain header.
-func (store *BadgerStackStorage) Get(id string) (*thrapb.Stack, error) {
-	var (
-		key   = store.getOpaqueKey(id)
-		stack *thrapb.Stack
-	)
-
-	err := store.db.View(func(txn *badger.Txn) error {
-		var err error
-		stack, err = store.getStack(txn, key)
-		return err
-	})
+// Get returns a stack by the id
+func (store *StackStorage) Get(id string) (*thrapb.Stack, error) {
+	val, err := store.d.Get(store.getOpaqueKey(id))
+	if err == ErrKeyNotFound {
+		return nil, ErrStackNotFound
+	} else if err != nil {
+		return nil, err
+	}
 
-	return stack, err
+	var stack thrapb.Stack
+	err = proto.Unmarshal(val, &stack)
+	return &stack, err
 }
 
 // Create tries to write the new stack to the db.  If the stack exists if will
 // return an error and abort registration
-func (store *BadgerStackStorage) Create(stack *thrapb.Stack) (*thrapb.Stack, error) {
-	key := store.getOpaqueKey(stack.ID)
+func (store *StackStorage) Create(stack *thrapb.Stack) (*thrapb.Stack, error) {
 	val, err := proto.Marshal(stack)
 	if err != nil {
 		return nil, err
 	}
 
-	err = store.db.Update(func(txn *badger.Txn) error {
-		_, err := txn.Get(key)
-		if err == nil {
-			return ErrStackExists
-		}
-
-		return txn.Set(key, val)
-	})
+	err = store.d.Create(store.getOpaqueKey(stack.ID), val)
+	if err == ErrKeyExists {
+		err = ErrStackExists
+	}
 	return stack, err
 }
 
 // Update updates an existing stack
-func (store *BadgerStackStorage) Update(stack *thrapb.Stack) (*thrapb.Stack, error) {
-	key := store.getOpaqueKey(stack.ID)
+func (store *StackStorage) Update(stack *thrapb.Stack) (*thrapb.Stack, error) {
 	val, err := proto.Marshal(stack)
 	if err != nil {
 		return nil, err
 	}
 
-	err = store.db.Update(func(txn *badger.Txn) error {
-		_, err := txn.Get(key)
-		if err != nil {
-			return ErrStackNotFound
-		}
-
-		return txn.Set(key, val)
-	})
-
+	err = store.d.Update(store.getOpaqueKey(stack.ID), val)
+	if err == ErrKeyNotFound {
+		err = ErrStackNotFound
+	}
 	return stack, err
 }
 
 // List lists all stack ids.  It takes an option prefix to limit results to it
-func (store *BadgerStackStorage) List(prefix string) ([]string, error) {
-	pre := store.getOpaqueKey(prefix)
+func (store *StackStorage) List(prefix string) ([]string, error) {
 	out := make([]string, 0)
-
-	err := store.db.View(func(txn *badger.Txn) error {
-		opts := badger.DefaultIteratorOptions
-		opts.PrefetchValues = false
-		iter := txn.NewIterator(opts)
-		defer iter.Close()
-
-		for iter.Seek(pre); iter.ValidForPrefix(pre); iter.Next() {
-			item := iter.Item()
-			key := item.Key()
-			out = append(out, string(bytes.TrimSuffix(key, pre)))
-		}
-
+	err := store.d.Iter(store.getOpaqueKey(prefix), "", func(key string, _ []byte) error {
+		out = append(out, strings.TrimPrefix(key, defaultStackPrefix))
 		return nil
 	})
 	return out, err
 }
 
 // Iter iterates over each stack from the starting point
-func (store *BadgerStackStorage) Iter(start string, callback func(*thrapb.Stack) error) error {
-	prefix := store.getOpaqueKey(start)
-
-	return store.db.View(func(txn *badger.Txn) error {
-		iter := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer iter.Close()
-
-		for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
-			item := iter.Item()
-			stack, err := stackFromItem(item)
-			if err != nil {
-				return err
-			}
-			if err = callback(stack); err != nil {
-				return err
-			}
+func (store *StackStorage) Iter(start string, callback func(*thrapb.Stack) error) error {
+	return store.d.Iter(store.getOpaqueKey(start), "", func(_ string, val []byte) error {
+		var stack thrapb.Stack
+		if err := proto.Unmarshal(val, &stack); err != nil {
+			return err
 		}
-
-		return nil
+		return callback(&stack)
 	})
 }
 
 // Delete removes the stack given the id
-func (store *BadgerStackStorage) Delete(id string) (*thrapb.Stack, error) {
-	var (
-		key   = store.getOpaqueKey(id)
-		stack *thrapb.Stack
-	)
-
-	err := store.db.Update(func(txn *badger.Txn) error {
-		var err error
-		stack, err = store.getStack(txn, key)
-		if err != nil {
-			return ErrStackNotFound
-		}
-
-		return txn.Delete(key)
-	})
-
-	return stack, err
-}
-
-func (store *BadgerStackStorage) getStack(txn *badger.Txn, key []byte) (*thrapb.Stack, error) {
-	item, err := txn.Get(key)
+func (store *StackStorage) Delete(id string) (*thrapb.Stack, error) {
+	stack, err := store.Get(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return stackFromItem(item)
-}
-
-func stackFromItem(item *badger.Item) (*thrapb.Stack, error) {
-	val, err := item.Value()
-	if err != nil {
-		return nil, err
+	err = store.d.Delete(store.getOpaqueKey(id))
+	if err == ErrKeyNotFound {
+		err = ErrStackNotFound
 	}
-
-	var stack thrapb.Stack
-	err = proto.Unmarshal(val, &stack)
-
-	return &stack, err
+	return stack, err
 }
@@ -1,6 +1,10 @@
 package thrapb
 
-import digest "github.com/opencontainers/go-digest"
+import (
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+)
 
 // NewArtifact returns a Artifact instance with the id and tags
 func NewArtifact(id string, tags []string) *Artifact {
@@ -25,3 +29,16 @@ func (ci *Artifact) SetTags(tags []string) {
 func (ci *Artifact) IsTagged() bool {
 	return len(ci.Tags) > 0 && ci.Tags[0] != "<none>"
 }
+
+// SplitImage splits an image reference into the name and tag.  The digest if
+// any is dropped and the registry port is preserved in the name
+func SplitImage(image string) (string, string) {
+	if i := strings.Index(image, "@"); i > 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i+1:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
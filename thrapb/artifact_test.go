@@ -0,0 +1,21 @@
+package thrapb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitImage(t *testing.T) {
+	for image, want := range map[string][2]string{
+		"nginx":                       {"nginx", ""},
+		"nginx:1.15":                  {"nginx", "1.15"},
+		"localhost:5000/api":          {"localhost:5000/api", ""},
+		"localhost:5000/api:1.0.0":    {"localhost:5000/api", "1.0.0"},
+		"redis:5@sha256:0123456789ab": {"redis", "5"},
+	} {
+		name, tag := SplitImage(image)
+		assert.Equal(t, want[0], name, image)
+		assert.Equal(t, want[1], tag, image)
+	}
+}
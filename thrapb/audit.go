@@ -0,0 +1,155 @@
+package thrapb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrAuditHashMismatch is returned when an audit entry does not match its
+	// hash
+	ErrAuditHashMismatch = errors.New("audit entry hash mismatch")
+	// ErrAuditChainBroken is returned when an audit entry does not follow the
+	// previous one
+	ErrAuditChainBroken = errors.New("audit chain broken")
+	// ErrAuditAnchorMismatch is returned when the log does not hold the
+	// anchored entry
+	ErrAuditAnchorMismatch = errors.New("audit anchor mismatch")
+
+	errInvalidAuditAnchor = errors.New("audit anchor must be <seq>:<hex hash>")
+)
+
+// AuditAnchor is the hash of an entry kept outside of the log, such as the
+// head printed by an earlier verification.  A log rewritten up to the
+// anchored entry no longer matches it
+type AuditAnchor struct {
+	Seq  uint64
+	Hash []byte
+}
+
+// ParseAuditAnchor parses an anchor of the form <seq>:<hex hash>
+func ParseAuditAnchor(s string) (*AuditAnchor, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, errInvalidAuditAnchor
+	}
+	seq, err := strconv.ParseUint(s[:i], 10, 64)
+	if err != nil || seq == 0 {
+		return nil, errInvalidAuditAnchor
+	}
+	h, err := hex.DecodeString(s[i+1:])
+	if err != nil || len(h) == 0 {
+		return nil, errInvalidAuditAnchor
+	}
+	return &AuditAnchor{Seq: seq, Hash: h}, nil
+}
+
+// String returns the anchor as <seq>:<hex hash>
+func (anchor *AuditAnchor) String() string {
+	return fmt.Sprintf("%d:%x", anchor.Seq, anchor.Hash)
+}
+
+// ComputeHash returns the hash of all fields except the hash itself
+func (entry *AuditEntry) ComputeHash(h hash.Hash) []byte {
+	binary.Write(h, binary.BigEndian, entry.Seq)
+	binary.Write(h, binary.BigEndian, entry.Timestamp)
+	writeField(h, []byte(entry.Identity))
+	writeField(h, []byte(entry.Method))
+	writeField(h, []byte(entry.Resource))
+	writeField(h, entry.RequestHash)
+	binary.Write(h, binary.BigEndian, int32(entry.Outcome))
+	writeField(h, []byte(entry.Error))
+	writeField(h, entry.Prev)
+	return h.Sum(nil)
+}
+
+// writeField writes a length prefixed field so adjacent fields cannot be
+// shifted into one another
+func writeField(h hash.Hash, b []byte) {
+	binary.Write(h, binary.BigEndian, uint32(len(b)))
+	h.Write(b)
+}
+
+// Match returns true if the entry matches all set options
+func (opts *AuditOptions) Match(entry *AuditEntry) bool {
+	switch {
+	case entry.Seq < opts.Start:
+		return false
+	case opts.Identity != "" && entry.Identity != opts.Identity:
+		return false
+	case opts.Method != "" && entry.Method != opts.Method && !strings.HasSuffix(entry.Method, "/"+opts.Method):
+		return false
+	case opts.Resource != "" && !strings.HasPrefix(entry.Resource, opts.Resource):
+		return false
+	case opts.Since > 0 && entry.Timestamp < opts.Since:
+		return false
+	case opts.Until > 0 && entry.Timestamp >= opts.Until:
+		return false
+	}
+	return true
+}
+
+// AuditChain verifies a sequence of audit entries from the start of the log
+type AuditChain struct {
+	newHash func() hash.Hash
+	last    *AuditEntry
+
+	anchor   *AuditAnchor
+	anchored bool
+}
+
+// NewAuditChain returns a chain verifier using the hash function
+func NewAuditChain(newHash func() hash.Hash) *AuditChain {
+	return &AuditChain{newHash: newHash}
+}
+
+// Expect sets an anchor the chain must hold
+func (chain *AuditChain) Expect(anchor *AuditAnchor) {
+	chain.anchor = anchor
+}
+
+// Anchored returns an error if an anchor is set and no entry matched it
+func (chain *AuditChain) Anchored() error {
+	if chain.anchor != nil && !chain.anchored {
+		return fmt.Errorf("%v: seq=%d not found", ErrAuditAnchorMismatch, chain.anchor.Seq)
+	}
+	return nil
+}
+
+// Last returns the last verified entry
+func (chain *AuditChain) Last() *AuditEntry {
+	return chain.last
+}
+
+// Add verifies the hash of the entry and that it follows the last added one.
+// The first entry added must be the first of the log so removing the oldest
+// entries breaks the chain
+func (chain *AuditChain) Add(entry *AuditEntry) error {
+	if !bytes.Equal(entry.ComputeHash(chain.newHash()), entry.Hash) {
+		return fmt.Errorf("%v: seq=%d", ErrAuditHashMismatch, entry.Seq)
+	}
+
+	if chain.last == nil {
+		if entry.Seq != 1 || len(entry.Prev) > 0 {
+			return fmt.Errorf("%v: seq=%d", ErrAuditChainBroken, entry.Seq)
+		}
+	} else if entry.Seq != chain.last.Seq+1 || !bytes.Equal(entry.Prev, chain.last.Hash) {
+		return fmt.Errorf("%v: seq=%d", ErrAuditChainBroken, entry.Seq)
+	}
+
+	if chain.anchor != nil && entry.Seq == chain.anchor.Seq {
+		if !bytes.Equal(entry.Hash, chain.anchor.Hash) {
+			return fmt.Errorf("%v: seq=%d", ErrAuditAnchorMismatch, entry.Seq)
+		}
+		chain.anchored = true
+	}
+
+	chain.last = entry
+	return nil
+}
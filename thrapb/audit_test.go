@@ -0,0 +1,113 @@
+package thrapb
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testAuditLog(n int) []*AuditEntry {
+	var (
+		entries = make([]*AuditEntry, n)
+		prev    []byte
+	)
+	for i := range entries {
+		e := &AuditEntry{
+			Seq:       uint64(i + 1),
+			Timestamp: int64(i+1) * 10,
+			Identity:  "foo@bar.com",
+			Method:    "/Thrap/RegisterStack",
+			Resource:  "stack",
+			Outcome:   Outcome_SUCCEEDED,
+			Prev:      prev,
+		}
+		e.Hash = e.ComputeHash(sha256.New())
+		prev = e.Hash
+		entries[i] = e
+	}
+	return entries
+}
+
+func Test_AuditChain(t *testing.T) {
+	entries := testAuditLog(3)
+
+	chain := NewAuditChain(sha256.New)
+	for _, e := range entries {
+		assert.Nil(t, chain.Add(e))
+	}
+	assert.Equal(t, uint64(3), chain.Last().Seq)
+
+	// Tampered entry
+	entries[1].Identity = "bar@bar.com"
+	chain = NewAuditChain(sha256.New)
+	assert.Nil(t, chain.Add(entries[0]))
+	assert.Contains(t, chain.Add(entries[1]).Error(), ErrAuditHashMismatch.Error())
+
+	// Removed entry
+	entries = testAuditLog(3)
+	chain = NewAuditChain(sha256.New)
+	assert.Nil(t, chain.Add(entries[0]))
+	assert.Contains(t, chain.Add(entries[2]).Error(), ErrAuditChainBroken.Error())
+
+	// Removed oldest entries
+	chain = NewAuditChain(sha256.New)
+	assert.Contains(t, chain.Add(entries[1]).Error(), ErrAuditChainBroken.Error())
+
+	// First entry pointing to a previous one
+	first := testAuditLog(1)[0]
+	first.Prev = entries[2].Hash
+	first.Hash = first.ComputeHash(sha256.New())
+	chain = NewAuditChain(sha256.New)
+	assert.Contains(t, chain.Add(first).Error(), ErrAuditChainBroken.Error())
+}
+
+func Test_AuditChain_anchor(t *testing.T) {
+	entries := testAuditLog(3)
+	anchor, err := ParseAuditAnchor((&AuditAnchor{Seq: 2, Hash: entries[1].Hash}).String())
+	assert.Nil(t, err)
+
+	chain := NewAuditChain(sha256.New)
+	chain.Expect(anchor)
+	for _, e := range entries {
+		assert.Nil(t, chain.Add(e))
+	}
+	assert.Nil(t, chain.Anchored())
+
+	// Rewritten log
+	var (
+		rewritten = testAuditLog(3)
+		prev      []byte
+	)
+	for _, e := range rewritten {
+		e.Identity = "bar@bar.com"
+		e.Prev = prev
+		e.Hash = e.ComputeHash(sha256.New())
+		prev = e.Hash
+	}
+	chain = NewAuditChain(sha256.New)
+	chain.Expect(anchor)
+	assert.Nil(t, chain.Add(rewritten[0]))
+	assert.Contains(t, chain.Add(rewritten[1]).Error(), ErrAuditAnchorMismatch.Error())
+
+	// Truncated log
+	chain = NewAuditChain(sha256.New)
+	chain.Expect(anchor)
+	assert.Nil(t, chain.Add(entries[0]))
+	assert.Contains(t, chain.Anchored().Error(), ErrAuditAnchorMismatch.Error())
+
+	_, err = ParseAuditAnchor("2")
+	assert.NotNil(t, err)
+	_, err = ParseAuditAnchor("x:abcd")
+	assert.NotNil(t, err)
+}
+
+func Test_AuditOptions_Match(t *testing.T) {
+	e := testAuditLog(2)[1]
+	assert.True(t, (&AuditOptions{}).Match(e))
+	assert.True(t, (&AuditOptions{Method: "RegisterStack", Resource: "st", Since: 20, Until: 21}).Match(e))
+	assert.False(t, (&AuditOptions{Method: "Stack"}).Match(e))
+	assert.False(t, (&AuditOptions{Start: 3}).Match(e))
+	assert.False(t, (&AuditOptions{Identity: "bar@bar.com"}).Match(e))
+	assert.False(t, (&AuditOptions{Until: 20}).Match(e))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"hash"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/euforia/pseudo/scope"
@@ -74,6 +75,23 @@ func (comp *Component) ScopeVars(prefix string) scope.Variables {
 	return svars
 }
 
+// NamePorts returns the ports keyed by label.  A single port is labeled
+// default otherwise port<number> as HCL does not allow numbers as keys
+func NamePorts(ports []int32) map[string]int32 {
+	if len(ports) == 0 {
+		return nil
+	}
+	if len(ports) == 1 {
+		return map[string]int32{"default": ports[0]}
+	}
+
+	out := make(map[string]int32, len(ports))
+	for _, p := range ports {
+		out["port"+strconv.Itoa(int(p))] = p
+	}
+	return out
+}
+
 // HasPort returns true if the component has the given port specified
 func (comp *Component) HasPort(port int32) bool {
 	for _, p := range comp.Ports {
@@ -229,6 +247,7 @@ func (comp *Component) Hash(h hash.Hash) {
 
 	h.Write([]byte(comp.Cmd))
 	h.Write([]byte(strings.Join(comp.Args, "")))
+	h.Write([]byte(strings.Join(comp.DependsOn, "")))
 
 }
 
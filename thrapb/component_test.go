@@ -42,3 +42,9 @@ func Test_Component(t *testing.T) {
 	c.Secrets = &Secrets{Destination: "foo"}
 	assert.True(t, c.HasSecrets())
 }
+
+func Test_NamePorts(t *testing.T) {
+	assert.Nil(t, NamePorts(nil))
+	assert.Equal(t, map[string]int32{"default": 80}, NamePorts([]int32{80}))
+	assert.Equal(t, map[string]int32{"port80": 80, "port443": 443}, NamePorts([]int32{80, 443}))
+}
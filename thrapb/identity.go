@@ -1,6 +1,7 @@
 package thrapb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"hash"
@@ -9,6 +10,15 @@ import (
 	"strings"
 )
 
+var (
+	// ErrKeyRevoked is returned when a key has been revoked
+	ErrKeyRevoked = errors.New("key revoked")
+	// ErrKeyExpired is returned when a key has been rotated out
+	ErrKeyExpired = errors.New("key expired")
+	// ErrKeyUnknown is returned when a key does not belong to the identity
+	ErrKeyUnknown = errors.New("key unknown")
+)
+
 // NewIdentity returns a new Identity with given email address
 func NewIdentity(email string) *Identity {
 	return &Identity{
@@ -54,7 +64,7 @@ func (ident *Identity) hash(h hash.Hash) {
 }
 
 // Validate validates the identity fields.  If the id is not set, it defaults
-// to the email address
+// to the email address.  The id must always be the email address
 func (ident *Identity) Validate() error {
 	if len(ident.Email) == 0 {
 		return errors.New("email missing")
@@ -68,7 +78,77 @@ func (ident *Identity) Validate() error {
 
 	if ident.ID == "" {
 		ident.ID = ident.Email
+	} else if ident.ID != ident.Email {
+		return errors.New("id must be the email address")
 	}
 
 	return nil
 }
+
+// KeyNotBefore returns the time the current key became valid i.e. when the
+// last past key stopped being valid
+func (ident *Identity) KeyNotBefore() int64 {
+	if n := len(ident.History); n > 0 {
+		return ident.History[n-1].NotAfter
+	}
+	return 0
+}
+
+// CheckKey checks the key is valid for the identity at time t.  The current
+// key is always valid.  Past keys are valid within their period unless
+// revoked
+func (ident *Identity) CheckKey(pubkey []byte, t int64) error {
+	if len(ident.PublicKey) > 0 && bytes.Equal(ident.PublicKey, pubkey) {
+		return nil
+	}
+
+	for _, k := range ident.History {
+		if !bytes.Equal(k.PublicKey, pubkey) {
+			continue
+		}
+		if k.Revoked > 0 {
+			return ErrKeyRevoked
+		}
+		if t < k.NotBefore || t >= k.NotAfter {
+			return ErrKeyExpired
+		}
+		return nil
+	}
+
+	return ErrKeyUnknown
+}
+
+// RetireKey moves the current key to the history as of now.  If reason is
+// not empty the key is marked as revoked
+func (ident *Identity) RetireKey(now int64, reason string) {
+	key := &IdentityKey{
+		PublicKey: ident.PublicKey,
+		NotBefore: ident.KeyNotBefore(),
+		NotAfter:  now,
+	}
+	if reason != "" {
+		key.Revoked = now
+		key.RevokeReason = reason
+	}
+	ident.History = append(ident.History, key)
+	ident.PublicKey = nil
+	ident.Signature = nil
+}
+
+// SigHash returns the hash signed by the current key of the identity
+func (rot *KeyRotation) SigHash(h hash.Hash) []byte {
+	h.Write([]byte(rot.ID))
+	h.Write(rot.PublicKey)
+	binary.Write(h, binary.BigEndian, rot.Timestamp)
+	return h.Sum(nil)
+}
+
+// SigHash returns the hash signed by the revoking key
+func (rev *KeyRevocation) SigHash(h hash.Hash) []byte {
+	h.Write([]byte(rev.ID))
+	h.Write(rev.PublicKey)
+	h.Write([]byte(rev.Reason))
+	binary.Write(h, binary.BigEndian, rev.Timestamp)
+	h.Write(rev.Signer)
+	return h.Sum(nil)
+}
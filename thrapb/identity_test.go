@@ -0,0 +1,38 @@
+package thrapb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Identity_keys(t *testing.T) {
+	ident := &Identity{ID: "foo@bar.com", PublicKey: []byte("key1"), Signature: []byte("sig1")}
+	assert.Nil(t, ident.CheckKey([]byte("key1"), 10))
+	assert.Equal(t, ErrKeyUnknown, ident.CheckKey([]byte("key2"), 10))
+
+	ident.RetireKey(100, "")
+	assert.Nil(t, ident.PublicKey)
+	assert.Nil(t, ident.Signature)
+	assert.Equal(t, int64(100), ident.KeyNotBefore())
+
+	ident.PublicKey = []byte("key2")
+	assert.Nil(t, ident.CheckKey([]byte("key2"), 200))
+	assert.Nil(t, ident.CheckKey([]byte("key1"), 50))
+	assert.Equal(t, ErrKeyExpired, ident.CheckKey([]byte("key1"), 100))
+
+	ident.RetireKey(300, "compromised")
+	assert.Equal(t, 2, len(ident.History))
+	assert.Equal(t, int64(100), ident.History[1].NotBefore)
+	assert.Equal(t, ErrKeyRevoked, ident.CheckKey([]byte("key2"), 200))
+	assert.Equal(t, "compromised", ident.History[1].RevokeReason)
+}
+
+func Test_Identity_Validate(t *testing.T) {
+	ident := &Identity{Email: "foo@bar.com", PublicKey: []byte("key1")}
+	assert.Nil(t, ident.Validate())
+	assert.Equal(t, "foo@bar.com", ident.ID)
+
+	ident.ID = "admin@bar.com"
+	assert.NotNil(t, ident.Validate())
+}
@@ -2,6 +2,7 @@ package thrapb
 
 import (
 	"errors"
+	"regexp"
 	"strings"
 
 	version "github.com/hashicorp/go-version"
@@ -11,11 +12,16 @@ var (
 	errLangNotSpecified = errors.New("language not specified")
 )
 
+// wildcardVersion matches versions with a trailing wildcard e.g. 1.x or 1.2.*
+var wildcardVersion = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)\.[xX*]$`)
+
 // LanguageID is the programming language for a component actually being
 // developed. It contains the name and version
 type LanguageID string
 
-// Validate validates the language and its version to ensure both are supplied
+// Validate validates the language and its version to ensure both are supplied.
+// The version may be an exact version, a wildcard such as 1.x or a
+// constraint such as >= 1.10
 func (lang LanguageID) Validate() error {
 	if lang == "" {
 		return errLangNotSpecified
@@ -25,10 +31,31 @@ func (lang LanguageID) Validate() error {
 		return nil
 	}
 
-	_, err := version.NewVersion(vers)
+	if _, err := version.NewVersion(vers); err == nil {
+		return nil
+	}
+	_, err := ParseVersionConstraint(vers)
 	return err
 }
 
+// IsExactVersion returns true if the language version is a single version
+// rather than a wildcard or constraint
+func (lang LanguageID) IsExactVersion() bool {
+	_, err := version.NewVersion(lang.Version())
+	return err == nil
+}
+
+// ParseVersionConstraint parses a version constraint.  In addition to the
+// constraint syntax, a trailing wildcard is accepted where 1.x is equivalent
+// to ~> 1.0 and 1.2.x to ~> 1.2.0
+func ParseVersionConstraint(s string) (version.Constraints, error) {
+	s = strings.TrimSpace(s)
+	if m := wildcardVersion.FindStringSubmatch(s); m != nil {
+		s = "~> " + m[1] + ".0"
+	}
+	return version.NewConstraint(s)
+}
+
 // Lang returns the language name without the version.  It returns an empty
 // string if the language format is invalid
 func (lang LanguageID) Lang() string {
@@ -23,4 +23,27 @@ func Test_LanguageID(t *testing.T) {
 	lang = LanguageID("go:bs")
 	err = lang.Validate()
 	assert.NotNil(t, err)
+
+	lang = LanguageID("go:1.x")
+	assert.Nil(t, lang.Validate())
+	assert.False(t, lang.IsExactVersion())
+
+	lang = LanguageID("go:>= 1.10, < 2")
+	assert.Nil(t, lang.Validate())
+
+	lang = LanguageID("go:1.10")
+	assert.True(t, lang.IsExactVersion())
+}
+
+func Test_ParseVersionConstraint(t *testing.T) {
+	c, err := ParseVersionConstraint("1.x")
+	assert.Nil(t, err)
+	assert.Equal(t, "~> 1.0", c.String())
+
+	c, err = ParseVersionConstraint("1.2.*")
+	assert.Nil(t, err)
+	assert.Equal(t, "~> 1.2.0", c.String())
+
+	_, err = ParseVersionConstraint("x.y")
+	assert.NotNil(t, err)
 }
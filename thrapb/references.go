@@ -0,0 +1,143 @@
+package thrapb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/euforia/thrap/consts"
+	"github.com/hashicorp/hil"
+	"github.com/hashicorp/hil/ast"
+)
+
+// ValidateReferences performs cross-field validation of the stack.  It checks
+// that component variable references point to existing components and port
+// labels, that health check port labels exist and that volume targets are
+// unique within a component.  Errors are keyed by the offending field
+func (stack *Stack) ValidateReferences() map[string]error {
+	errs := make(map[string]error)
+
+	for id, comp := range stack.Components {
+		stack.validateCompRefs("component."+id, comp, errs)
+	}
+	for id, dep := range stack.Dependencies {
+		stack.validateCompRefs("dependency."+id, dep, errs)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (stack *Stack) validateCompRefs(prefix string, comp *Component, errs map[string]error) {
+	check := func(field, val string) {
+		if err := stack.checkInterpolation(val); err != nil {
+			errs[prefix+"."+field] = err
+		}
+	}
+
+	if comp.Env != nil {
+		for k, v := range comp.Env.Vars {
+			check("env.vars."+k, v)
+		}
+	}
+	for k, v := range comp.Config {
+		check("config."+k, v)
+	}
+	check("version", comp.Version)
+	check("cmd", comp.Cmd)
+	for i, arg := range comp.Args {
+		check("args."+strconv.Itoa(i), arg)
+	}
+	if comp.Build != nil {
+		check("build.context", comp.Build.Context)
+		check("build.dockerfile", comp.Build.Dockerfile)
+	}
+	if comp.Secrets != nil {
+		check("secrets.destination", comp.Secrets.Destination)
+		check("secrets.template", comp.Secrets.Template)
+	}
+
+	for i, hc := range comp.HealthChecks {
+		check("healthchecks."+strconv.Itoa(i)+".path", hc.Path)
+		if hc.PortLabel == "" {
+			continue
+		}
+		if _, ok := comp.Ports[hc.PortLabel]; !ok {
+			errs[prefix+".healthchecks."+strconv.Itoa(i)] = fmt.Errorf("port label not found: %s", hc.PortLabel)
+		}
+	}
+
+	targets := make(map[string]bool, len(comp.Volumes))
+	for i, vol := range comp.Volumes {
+		check("volumes."+strconv.Itoa(i)+".source", vol.Source)
+		check("volumes."+strconv.Itoa(i)+".target", vol.Target)
+		if targets[vol.Target] {
+			errs[prefix+".volumes."+strconv.Itoa(i)] = fmt.Errorf("duplicate volume target: %s", vol.Target)
+		}
+		targets[vol.Target] = true
+	}
+}
+
+// checkInterpolation parses the value and checks all component and
+// dependency variables it references
+func (stack *Stack) checkInterpolation(val string) error {
+	if !strings.Contains(val, "${") {
+		return nil
+	}
+
+	tree, err := hil.Parse(val)
+	if err != nil {
+		return err
+	}
+
+	var refErr error
+	tree.Accept(func(n ast.Node) ast.Node {
+		if va, ok := n.(*ast.VariableAccess); ok && refErr == nil {
+			refErr = stack.checkReference(va.Name)
+		}
+		return n
+	})
+
+	return refErr
+}
+
+// checkReference checks a variable of the form <comp|dep>.<id>.<attribute>
+func (stack *Stack) checkReference(name string) error {
+	parts := strings.SplitN(name, ".", 3)
+
+	var comps map[string]*Component
+	switch parts[0] {
+	case consts.CompVarPrefixKey:
+		comps = stack.Components
+	case consts.DepVarPrefixKey:
+		comps = stack.Dependencies
+	default:
+		return nil
+	}
+
+	if len(parts) < 3 {
+		return fmt.Errorf("invalid reference: %s", name)
+	}
+
+	comp, ok := comps[parts[1]]
+	if !ok {
+		return fmt.Errorf("unknown %s in reference: %s", parts[0], name)
+	}
+
+	attr := parts[2]
+	switch {
+	case attr == "version", attr == "container.ip":
+		return nil
+
+	case strings.HasPrefix(attr, "container.port."), strings.HasPrefix(attr, "container.addr."):
+		label := attr[strings.LastIndex(attr, ".")+1:]
+		if _, ok := comp.Ports[label]; !ok {
+			return fmt.Errorf("unknown port label in reference: %s", name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown attribute in reference: %s", name)
+}
@@ -0,0 +1,51 @@
+package thrapb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stack_ValidateReferences(t *testing.T) {
+	st := loadTestStack()
+	assert.Nil(t, st.ValidateReferences())
+
+	st = &Stack{
+		Components: map[string]*Component{
+			"api": &Component{
+				Ports: map[string]int32{"http": 8080},
+				Env: &Envionment{Vars: map[string]string{
+					"DB_ADDR":  "${comp.db.container.addr.default}",
+					"DB_PORT":  "${comp.db.container.port.sql}",
+					"CACHE_IP": "${comp.cache.container.ip}",
+					"VERSION":  "v${comp.api.version}",
+					"STACK":    "${stack.id}",
+					"BAD":      "${comp.api.foo}",
+					"SYNTAX":   "${comp.api",
+				}},
+				Args: []string{"--db", "${comp.db.container.ip}"},
+				HealthChecks: []*HealthCheck{
+					{PortLabel: "http"},
+					{PortLabel: "grpc"},
+				},
+				Volumes: []*Volume{
+					{Target: "/data"},
+					{Target: "/logs"},
+					{Target: "/data"},
+				},
+			},
+			"db": &Component{
+				Ports: map[string]int32{"default": 5432},
+			},
+		},
+	}
+
+	errs := st.ValidateReferences()
+	assert.Equal(t, 6, len(errs))
+	assert.Contains(t, errs["component.api.env.vars.DB_PORT"].Error(), "unknown port label")
+	assert.Contains(t, errs["component.api.env.vars.CACHE_IP"].Error(), "unknown comp")
+	assert.Contains(t, errs["component.api.env.vars.BAD"].Error(), "unknown attribute")
+	assert.NotNil(t, errs["component.api.env.vars.SYNTAX"])
+	assert.Contains(t, errs["component.api.healthchecks.1"].Error(), "grpc")
+	assert.Contains(t, errs["component.api.volumes.2"].Error(), "/data")
+}
@@ -2,6 +2,7 @@ package thrapb
 
 import (
 	"errors"
+	"fmt"
 	"hash"
 	"sort"
 
@@ -11,10 +12,25 @@ import (
 	"github.com/hashicorp/hil/ast"
 )
 
+const (
+	// VersioningRepo applies the repo version to all components.  This is
+	// the default
+	VersioningRepo = "repo"
+	// VersioningComponent versions each buildable component independently
+	// based on path-scoped tags and the commits touching its build context
+	VersioningComponent = "component"
+)
+
 var (
-	errDepCannotBuild = errors.New("dependencies cannot be built")
+	errDepCannotBuild    = errors.New("dependencies cannot be built")
+	errInvalidVersioning = errors.New("versioning must be repo or component")
 )
 
+// ComponentVersioning returns true if components are independently versioned
+func (stack *Stack) ComponentVersioning() bool {
+	return stack.Versioning == VersioningComponent
+}
+
 // ArtifactName retunrs the component artifact name based on whether
 // the component was built
 func (stack *Stack) ArtifactName(id string) string {
@@ -73,6 +89,9 @@ func (stack *Stack) Hash(h hash.Hash) []byte {
 	h.Write([]byte(stack.Name))
 	h.Write([]byte(stack.Version))
 	h.Write([]byte(stack.Description))
+	if stack.Versioning != "" {
+		h.Write([]byte(stack.Versioning))
+	}
 
 	keys := make([]string, 0, len(stack.Components))
 	for k := range stack.Components {
@@ -95,6 +114,16 @@ func (stack *Stack) Hash(h hash.Hash) []byte {
 
 	}
 
+	keys = make([]string, 0, len(stack.Packs))
+	for k := range stack.Packs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte(stack.Packs[k]))
+	}
+
 	return h.Sum(nil)
 }
 
@@ -108,6 +137,12 @@ func (stack *Stack) Validate() map[string]error {
 
 	errs := make(map[string]error)
 
+	switch stack.Versioning {
+	case "", VersioningRepo, VersioningComponent:
+	default:
+		errs["versioning"] = errInvalidVersioning
+	}
+
 	for k, comp := range stack.Components {
 		if err := comp.Validate(); err != nil {
 			errs["component."+k] = err
@@ -118,6 +153,12 @@ func (stack *Stack) Validate() map[string]error {
 		if comp.Version == "" {
 			comp.Version = stack.Version
 		}
+
+		for _, d := range comp.DependsOn {
+			if _, ok := stack.Components[d]; !ok {
+				errs["component."+k] = fmt.Errorf("depends on unknown component: %s", d)
+			}
+		}
 	}
 
 	for k, dep := range stack.Dependencies {
@@ -1,40 +1,22 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: thrap.proto
 
-/*
-	Package thrapb is a generated protocol buffer package.
-
-	It is generated from these files:
-		thrap.proto
-
-	It has these top-level messages:
-		Build
-		Secrets
-		Volume
-		Envionment
-		HealthCheck
-		Component
-		PackManifest
-		Language
-		Stack
-		Identity
-		Artifact
-		Profile
-		IterOptions
-*/
 package thrapb
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/gogoproto"
-
-import github_com_opencontainers_go_digest "github.com/opencontainers/go-digest"
-
-import context "golang.org/x/net/context"
-import grpc "google.golang.org/grpc"
-
-import io "io"
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	github_com_opencontainers_go_digest "github.com/opencontainers/go-digest"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -45,17 +27,101 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Outcome of a build or deployment
+type Outcome int32
+
+const (
+	Outcome_UNKNOWN   Outcome = 0
+	Outcome_SUCCEEDED Outcome = 1
+	Outcome_FAILED    Outcome = 2
+)
+
+var Outcome_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "SUCCEEDED",
+	2: "FAILED",
+}
+
+var Outcome_value = map[string]int32{
+	"UNKNOWN":   0,
+	"SUCCEEDED": 1,
+	"FAILED":    2,
+}
+
+func (x Outcome) String() string {
+	return proto.EnumName(Outcome_name, int32(x))
+}
+
+func (Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{0}
+}
+
+type ClusterOp int32
+
+const (
+	ClusterOp_PUT    ClusterOp = 0
+	ClusterOp_CREATE ClusterOp = 1
+	ClusterOp_UPDATE ClusterOp = 2
+	ClusterOp_DELETE ClusterOp = 3
+)
+
+var ClusterOp_name = map[int32]string{
+	0: "PUT",
+	1: "CREATE",
+	2: "UPDATE",
+	3: "DELETE",
+}
+
+var ClusterOp_value = map[string]int32{
+	"PUT":    0,
+	"CREATE": 1,
+	"UPDATE": 2,
+	"DELETE": 3,
+}
+
+func (x ClusterOp) String() string {
+	return proto.EnumName(ClusterOp_name, int32(x))
+}
+
+func (ClusterOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{1}
+}
 
 type Build struct {
 	Dockerfile string `protobuf:"bytes,1,opt,name=Dockerfile,proto3" json:"Dockerfile,omitempty" hcl:"dockerfile"`
 	Context    string `protobuf:"bytes,2,opt,name=Context,proto3" json:"Context,omitempty" hcl:"context" hcle:"omitempty"`
 }
 
-func (m *Build) Reset()                    { *m = Build{} }
-func (m *Build) String() string            { return proto.CompactTextString(m) }
-func (*Build) ProtoMessage()               {}
-func (*Build) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{0} }
+func (m *Build) Reset()         { *m = Build{} }
+func (m *Build) String() string { return proto.CompactTextString(m) }
+func (*Build) ProtoMessage()    {}
+func (*Build) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{0}
+}
+func (m *Build) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Build) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Build) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Build.Merge(m, src)
+}
+func (m *Build) XXX_Size() int {
+	return m.Size()
+}
+func (m *Build) XXX_DiscardUnknown() {
+	xxx_messageInfo_Build.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Build proto.InternalMessageInfo
 
 func (m *Build) GetDockerfile() string {
 	if m != nil {
@@ -78,10 +144,34 @@ type Secrets struct {
 	Template string `protobuf:"bytes,2,opt,name=Template,proto3" json:"Template,omitempty" hcl:"template"`
 }
 
-func (m *Secrets) Reset()                    { *m = Secrets{} }
-func (m *Secrets) String() string            { return proto.CompactTextString(m) }
-func (*Secrets) ProtoMessage()               {}
-func (*Secrets) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{1} }
+func (m *Secrets) Reset()         { *m = Secrets{} }
+func (m *Secrets) String() string { return proto.CompactTextString(m) }
+func (*Secrets) ProtoMessage()    {}
+func (*Secrets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{1}
+}
+func (m *Secrets) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Secrets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Secrets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Secrets.Merge(m, src)
+}
+func (m *Secrets) XXX_Size() int {
+	return m.Size()
+}
+func (m *Secrets) XXX_DiscardUnknown() {
+	xxx_messageInfo_Secrets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Secrets proto.InternalMessageInfo
 
 func (m *Secrets) GetDestination() string {
 	if m != nil {
@@ -102,10 +192,34 @@ type Volume struct {
 	Target string `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty" hcl:"target"`
 }
 
-func (m *Volume) Reset()                    { *m = Volume{} }
-func (m *Volume) String() string            { return proto.CompactTextString(m) }
-func (*Volume) ProtoMessage()               {}
-func (*Volume) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{2} }
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{2}
+}
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(m, src)
+}
+func (m *Volume) XXX_Size() int {
+	return m.Size()
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
 
 func (m *Volume) GetSource() string {
 	if m != nil {
@@ -125,13 +239,37 @@ type Envionment struct {
 	// File to read environment variables from
 	File string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty" hcl:"file"`
 	// Key-Value pair of variables
-	Vars map[string]string `protobuf:"bytes,2,rep,name=Vars" json:"Vars,omitempty" hcl:"vars" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Vars map[string]string `protobuf:"bytes,2,rep,name=Vars,proto3" json:"Vars,omitempty" hcl:"vars" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Envionment) Reset()         { *m = Envionment{} }
+func (m *Envionment) String() string { return proto.CompactTextString(m) }
+func (*Envionment) ProtoMessage()    {}
+func (*Envionment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{3}
+}
+func (m *Envionment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Envionment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Envionment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Envionment.Merge(m, src)
+}
+func (m *Envionment) XXX_Size() int {
+	return m.Size()
+}
+func (m *Envionment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Envionment.DiscardUnknown(m)
 }
 
-func (m *Envionment) Reset()                    { *m = Envionment{} }
-func (m *Envionment) String() string            { return proto.CompactTextString(m) }
-func (*Envionment) ProtoMessage()               {}
-func (*Envionment) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{3} }
+var xxx_messageInfo_Envionment proto.InternalMessageInfo
 
 func (m *Envionment) GetFile() string {
 	if m != nil {
@@ -149,18 +287,42 @@ func (m *Envionment) GetVars() map[string]string {
 
 type HealthCheck struct {
 	// udp, tcp, http, https
-	Protocol  string `protobuf:"bytes,1,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
-	Path      string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
-	Method    string `protobuf:"bytes,3,opt,name=Method,proto3" json:"Method,omitempty"`
-	Timeout   int64  `protobuf:"varint,4,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
-	Interval  int64  `protobuf:"varint,5,opt,name=Interval,proto3" json:"Interval,omitempty"`
-	PortLabel string `protobuf:"bytes,6,opt,name=PortLabel,proto3" json:"PortLabel,omitempty"`
+	Protocol  string `protobuf:"bytes,1,opt,name=Protocol,proto3" json:"Protocol,omitempty" yaml:",omitempty"`
+	Path      string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty" yaml:",omitempty"`
+	Method    string `protobuf:"bytes,3,opt,name=Method,proto3" json:"Method,omitempty" yaml:",omitempty"`
+	Timeout   int64  `protobuf:"varint,4,opt,name=Timeout,proto3" json:"Timeout,omitempty" yaml:",omitempty"`
+	Interval  int64  `protobuf:"varint,5,opt,name=Interval,proto3" json:"Interval,omitempty" yaml:",omitempty"`
+	PortLabel string `protobuf:"bytes,6,opt,name=PortLabel,proto3" json:"PortLabel,omitempty" yaml:",omitempty"`
+}
+
+func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
+func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()    {}
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{4}
+}
+func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HealthCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *HealthCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheck.Merge(m, src)
+}
+func (m *HealthCheck) XXX_Size() int {
+	return m.Size()
+}
+func (m *HealthCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthCheck.DiscardUnknown(m)
 }
 
-func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
-func (m *HealthCheck) String() string            { return proto.CompactTextString(m) }
-func (*HealthCheck) ProtoMessage()               {}
-func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{4} }
+var xxx_messageInfo_HealthCheck proto.InternalMessageInfo
 
 func (m *HealthCheck) GetProtocol() string {
 	if m != nil {
@@ -211,34 +373,60 @@ type Component struct {
 	Type     CompType   `protobuf:"bytes,4,opt,name=Type,proto3,casttype=CompType" json:"Type,omitempty" hcl:"type"`
 	Language LanguageID `protobuf:"bytes,5,opt,name=Language,proto3,casttype=LanguageID" json:"Language,omitempty" hcl:"language" hcle:"omitempty" yaml:",omitempty"`
 	// Build info if this is a buildable component
-	Build *Build `protobuf:"bytes,6,opt,name=Build" json:"Build,omitempty" hcl:"build" yaml:",omitempty"`
+	Build *Build `protobuf:"bytes,6,opt,name=Build,proto3" json:"Build,omitempty" hcl:"build" yaml:",omitempty"`
 	// Secrets for this component
-	Secrets *Secrets `protobuf:"bytes,7,opt,name=Secrets" json:"Secrets,omitempty" hcl:"secrets" yaml:",omitempty"`
+	Secrets *Secrets `protobuf:"bytes,7,opt,name=Secrets,proto3" json:"Secrets,omitempty" hcl:"secrets" yaml:",omitempty"`
 	// Port map so ports can be referenced by variables
-	Ports map[string]int32 `protobuf:"bytes,8,rep,name=Ports" json:"Ports,omitempty" hcl:"ports" hcle:"omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Ports map[string]int32 `protobuf:"bytes,8,rep,name=Ports,proto3" json:"Ports,omitempty" hcl:"ports" hcle:"omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	// If dep then true is an external dep. If stack item then true exposes
 	// to the internet
 	External bool `protobuf:"varint,9,opt,name=External,proto3" json:"External,omitempty" hcl:"external" hcle:"omitempty" yaml:",omitempty"`
 	// True if this is the head of the stack i.e. consumable api or ui
 	Head bool `protobuf:"varint,10,opt,name=Head,proto3" json:"Head,omitempty" hcl:"head" hcle:"omitempty" yaml:",omitempty"`
 	// Envionment variables
-	Env *Envionment `protobuf:"bytes,11,opt,name=Env" json:"Env,omitempty" hcl:"env" hcle:"omitempty" yaml:",omitempty"`
+	Env *Envionment `protobuf:"bytes,11,opt,name=Env,proto3" json:"Env,omitempty" hcl:"env" hcle:"omitempty" yaml:",omitempty"`
 	// Arbitrary KV config map
-	Config map[string]string `protobuf:"bytes,12,rep,name=Config" json:"Config,omitempty" hcl:"config" hcle:"omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Config map[string]string `protobuf:"bytes,12,rep,name=Config,proto3" json:"Config,omitempty" hcl:"config" hcle:"omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// List of volumes needed
-	Volumes []*Volume `protobuf:"bytes,13,rep,name=Volumes" json:"Volumes,omitempty" hcl:"volumes" hcle:"omitempty" yaml:",omitempty"`
+	Volumes []*Volume `protobuf:"bytes,13,rep,name=Volumes,proto3" json:"Volumes,omitempty" hcl:"volumes" hcle:"omitempty" yaml:",omitempty"`
 	// Command to run the component
 	Cmd string `protobuf:"bytes,14,opt,name=Cmd,proto3" json:"Cmd,omitempty" hcl:"cmd" hcle:"omitempty" yaml:",omitempty"`
 	// Args to command
-	Args []string `protobuf:"bytes,15,rep,name=Args" json:"Args,omitempty" hcl:"args" hcle:"omitempty" yaml:",omitempty"`
+	Args []string `protobuf:"bytes,15,rep,name=Args,proto3" json:"Args,omitempty" hcl:"args" hcle:"omitempty" yaml:",omitempty"`
 	// All healthchecks
-	HealthChecks []*HealthCheck `protobuf:"bytes,16,rep,name=HealthChecks" json:"HealthChecks,omitempty"`
+	HealthChecks []*HealthCheck `protobuf:"bytes,16,rep,name=HealthChecks,proto3" json:"HealthChecks,omitempty" yaml:",omitempty"`
+	// IDs of components that must be started before this one
+	DependsOn []string `protobuf:"bytes,17,rep,name=DependsOn,proto3" json:"DependsOn,omitempty" hcl:"depends_on" hcle:"omitempty" yaml:"depends_on,omitempty"`
+}
+
+func (m *Component) Reset()         { *m = Component{} }
+func (m *Component) String() string { return proto.CompactTextString(m) }
+func (*Component) ProtoMessage()    {}
+func (*Component) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{5}
+}
+func (m *Component) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Component) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Component) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Component.Merge(m, src)
+}
+func (m *Component) XXX_Size() int {
+	return m.Size()
+}
+func (m *Component) XXX_DiscardUnknown() {
+	xxx_messageInfo_Component.DiscardUnknown(m)
 }
 
-func (m *Component) Reset()                    { *m = Component{} }
-func (m *Component) String() string            { return proto.CompactTextString(m) }
-func (*Component) ProtoMessage()               {}
-func (*Component) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{5} }
+var xxx_messageInfo_Component proto.InternalMessageInfo
 
 func (m *Component) GetID() string {
 	if m != nil {
@@ -352,23 +540,54 @@ func (m *Component) GetHealthChecks() []*HealthCheck {
 	return nil
 }
 
+func (m *Component) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
 type PackManifest struct {
 	// Pack name
 	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
 	// User supplied constrained versions
-	Versions []string `protobuf:"bytes,2,rep,name=Versions" json:"Versions,omitempty"`
+	Versions []string `protobuf:"bytes,2,rep,name=Versions,proto3" json:"Versions,omitempty"`
 	// Default version
 	DefaultVersion string `protobuf:"bytes,3,opt,name=DefaultVersion,proto3" json:"DefaultVersion,omitempty"`
 	// Base image
 	Image string `protobuf:"bytes,4,opt,name=Image,proto3" json:"Image,omitempty"`
 	// Files
-	Files []string `protobuf:"bytes,5,rep,name=Files" json:"Files,omitempty"`
+	Files []string `protobuf:"bytes,5,rep,name=Files,proto3" json:"Files,omitempty"`
+}
+
+func (m *PackManifest) Reset()         { *m = PackManifest{} }
+func (m *PackManifest) String() string { return proto.CompactTextString(m) }
+func (*PackManifest) ProtoMessage()    {}
+func (*PackManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{6}
+}
+func (m *PackManifest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PackManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *PackManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PackManifest.Merge(m, src)
+}
+func (m *PackManifest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PackManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PackManifest.DiscardUnknown(m)
 }
 
-func (m *PackManifest) Reset()                    { *m = PackManifest{} }
-func (m *PackManifest) String() string            { return proto.CompactTextString(m) }
-func (*PackManifest) ProtoMessage()               {}
-func (*PackManifest) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{6} }
+var xxx_messageInfo_PackManifest proto.InternalMessageInfo
 
 func (m *PackManifest) GetName() string {
 	if m != nil {
@@ -409,25 +628,49 @@ type Language struct {
 	// Language name
 	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
 	// User supplied constrained versions
-	Versions       []string `protobuf:"bytes,2,rep,name=Versions" json:"Versions,omitempty"`
+	Versions       []string `protobuf:"bytes,2,rep,name=Versions,proto3" json:"Versions,omitempty"`
 	DefaultVersion string   `protobuf:"bytes,3,opt,name=DefaultVersion,proto3" json:"DefaultVersion,omitempty"`
 	// Source code file extensions of programming language. First if the primary
 	// followed by any additionals such as header files etc.
-	FileExts []string `protobuf:"bytes,4,rep,name=FileExts" json:"FileExts,omitempty"`
+	FileExts []string `protobuf:"bytes,4,rep,name=FileExts,proto3" json:"FileExts,omitempty"`
 	// Extension of binaries generated by this language to ignore
-	IgnoreFiles []string `protobuf:"bytes,5,rep,name=IgnoreFiles" json:"IgnoreFiles,omitempty"`
+	IgnoreFiles []string `protobuf:"bytes,5,rep,name=IgnoreFiles,proto3" json:"IgnoreFiles,omitempty"`
 	// Base images usable for developed
-	DevImages []string `protobuf:"bytes,6,rep,name=DevImages" json:"DevImages,omitempty"`
+	DevImages []string `protobuf:"bytes,6,rep,name=DevImages,proto3" json:"DevImages,omitempty"`
 	// Base images usable to publish
-	PubImages []string `protobuf:"bytes,7,rep,name=PubImages" json:"PubImages,omitempty"`
+	PubImages []string `protobuf:"bytes,7,rep,name=PubImages,proto3" json:"PubImages,omitempty"`
 	// Additional files part of the language pack
-	ScaffoldFiles []string `protobuf:"bytes,8,rep,name=ScaffoldFiles" json:"ScaffoldFiles,omitempty"`
+	ScaffoldFiles []string `protobuf:"bytes,8,rep,name=ScaffoldFiles,proto3" json:"ScaffoldFiles,omitempty"`
+}
+
+func (m *Language) Reset()         { *m = Language{} }
+func (m *Language) String() string { return proto.CompactTextString(m) }
+func (*Language) ProtoMessage()    {}
+func (*Language) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{7}
+}
+func (m *Language) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Language) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Language) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Language.Merge(m, src)
+}
+func (m *Language) XXX_Size() int {
+	return m.Size()
+}
+func (m *Language) XXX_DiscardUnknown() {
+	xxx_messageInfo_Language.DiscardUnknown(m)
 }
 
-func (m *Language) Reset()                    { *m = Language{} }
-func (m *Language) String() string            { return proto.CompactTextString(m) }
-func (*Language) ProtoMessage()               {}
-func (*Language) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{7} }
+var xxx_messageInfo_Language proto.InternalMessageInfo
 
 func (m *Language) GetName() string {
 	if m != nil {
@@ -489,15 +732,45 @@ type Stack struct {
 	ID           string                `protobuf:"bytes,2,opt,name=ID,proto3" json:"ID,omitempty" hcle:"omit" yaml:"-"`
 	Name         string                `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty" hcl:"name"`
 	Version      string                `protobuf:"bytes,4,opt,name=Version,proto3" json:"Version,omitempty" hcl:"version" hcle:"omitempty" yaml:",omitempty"`
-	Components   map[string]*Component `protobuf:"bytes,5,rep,name=Components" json:"Components,omitempty" hcl:"components" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
-	Dependencies map[string]*Component `protobuf:"bytes,6,rep,name=Dependencies" json:"Dependencies,omitempty" hcl:"dependencies" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Components   map[string]*Component `protobuf:"bytes,5,rep,name=Components,proto3" json:"Components,omitempty" hcl:"components" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Dependencies map[string]*Component `protobuf:"bytes,6,rep,name=Dependencies,proto3" json:"Dependencies,omitempty" hcl:"dependencies" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Description  string                `protobuf:"bytes,7,opt,name=Description,proto3" json:"Description,omitempty" hcl:"description" yaml:",omitempty" hcle:"omit"`
+	// How component versions are determined i.e. repo or component
+	Versioning string `protobuf:"bytes,8,opt,name=Versioning,proto3" json:"Versioning,omitempty" hcl:"versioning" hcle:"omitempty" yaml:",omitempty"`
+	// Pinned pack versions keyed by <type>/<id>
+	Packs map[string]string `protobuf:"bytes,9,rep,name=Packs,proto3" json:"Packs,omitempty" hcl:"packs" hcle:"omitempty" yaml:",omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Identity that registered the stack.  Set by the agent
+	Owner string `protobuf:"bytes,10,opt,name=Owner,proto3" json:"Owner,omitempty" hcle:"omit" yaml:"-"`
+}
+
+func (m *Stack) Reset()         { *m = Stack{} }
+func (m *Stack) String() string { return proto.CompactTextString(m) }
+func (*Stack) ProtoMessage()    {}
+func (*Stack) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{8}
+}
+func (m *Stack) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Stack) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Stack) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stack.Merge(m, src)
+}
+func (m *Stack) XXX_Size() int {
+	return m.Size()
+}
+func (m *Stack) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stack.DiscardUnknown(m)
 }
 
-func (m *Stack) Reset()                    { *m = Stack{} }
-func (m *Stack) String() string            { return proto.CompactTextString(m) }
-func (*Stack) ProtoMessage()               {}
-func (*Stack) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{8} }
+var xxx_messageInfo_Stack proto.InternalMessageInfo
 
 func (m *Stack) GetID() string {
 	if m != nil {
@@ -541,6 +814,27 @@ func (m *Stack) GetDescription() string {
 	return ""
 }
 
+func (m *Stack) GetVersioning() string {
+	if m != nil {
+		return m.Versioning
+	}
+	return ""
+}
+
+func (m *Stack) GetPacks() map[string]string {
+	if m != nil {
+		return m.Packs
+	}
+	return nil
+}
+
+func (m *Stack) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
 type Identity struct {
 	ID        string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty" hcl:"id"`
 	Email     string `protobuf:"bytes,2,opt,name=Email,proto3" json:"Email,omitempty" hcl:"email"`
@@ -548,13 +842,43 @@ type Identity struct {
 	Nonce     uint64 `protobuf:"varint,4,opt,name=Nonce,proto3" json:"Nonce,omitempty" hcl:"nonce"`
 	Signature []byte `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty" hcl:"signature"hcle:"omit"`
 	// Meta identities e.g. github user, ldap user etc.
-	Meta map[string]string `protobuf:"bytes,6,rep,name=Meta" json:"Meta,omitempty" hcl:"meta" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Meta map[string]string `protobuf:"bytes,6,rep,name=Meta,proto3" json:"Meta,omitempty" hcl:"meta" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Past keys, oldest first
+	History []*IdentityKey `protobuf:"bytes,7,rep,name=History,proto3" json:"History,omitempty" hcl:"history"`
+	// Expiry of the confirmation code of a pending registration and the
+	// times codes were sent within the rate limit window.  Unix nanoseconds
+	ConfirmExpires int64   `protobuf:"varint,8,opt,name=ConfirmExpires,proto3" json:"ConfirmExpires,omitempty" hcle:"omit"`
+	ConfirmSent    []int64 `protobuf:"varint,9,rep,packed,name=ConfirmSent,proto3" json:"ConfirmSent,omitempty" hcle:"omit"`
+}
+
+func (m *Identity) Reset()         { *m = Identity{} }
+func (m *Identity) String() string { return proto.CompactTextString(m) }
+func (*Identity) ProtoMessage()    {}
+func (*Identity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{9}
+}
+func (m *Identity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Identity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Identity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Identity.Merge(m, src)
+}
+func (m *Identity) XXX_Size() int {
+	return m.Size()
+}
+func (m *Identity) XXX_DiscardUnknown() {
+	xxx_messageInfo_Identity.DiscardUnknown(m)
 }
 
-func (m *Identity) Reset()                    { *m = Identity{} }
-func (m *Identity) String() string            { return proto.CompactTextString(m) }
-func (*Identity) ProtoMessage()               {}
-func (*Identity) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{9} }
+var xxx_messageInfo_Identity proto.InternalMessageInfo
 
 func (m *Identity) GetID() string {
 	if m != nil {
@@ -598,1741 +922,9677 @@ func (m *Identity) GetMeta() map[string]string {
 	return nil
 }
 
-type Artifact struct {
-	ID       github_com_opencontainers_go_digest.Digest `protobuf:"bytes,1,opt,name=ID,proto3,casttype=github.com/opencontainers/go-digest.Digest" json:"ID,omitempty"`
-	Tags     []string                                   `protobuf:"bytes,2,rep,name=Tags" json:"Tags,omitempty"`
-	Labels   map[string]string                          `protobuf:"bytes,3,rep,name=Labels" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Created  int64                                      `protobuf:"varint,4,opt,name=Created,proto3" json:"Created,omitempty"`
-	DataSize int64                                      `protobuf:"varint,5,opt,name=DataSize,proto3" json:"DataSize,omitempty"`
+func (m *Identity) GetHistory() []*IdentityKey {
+	if m != nil {
+		return m.History
+	}
+	return nil
 }
 
-func (m *Artifact) Reset()                    { *m = Artifact{} }
-func (m *Artifact) String() string            { return proto.CompactTextString(m) }
-func (*Artifact) ProtoMessage()               {}
-func (*Artifact) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{10} }
-
-func (m *Artifact) GetID() github_com_opencontainers_go_digest.Digest {
+func (m *Identity) GetConfirmExpires() int64 {
 	if m != nil {
-		return m.ID
+		return m.ConfirmExpires
 	}
-	return ""
+	return 0
 }
 
-func (m *Artifact) GetTags() []string {
+func (m *Identity) GetConfirmSent() []int64 {
 	if m != nil {
-		return m.Tags
+		return m.ConfirmSent
 	}
 	return nil
 }
 
-func (m *Artifact) GetLabels() map[string]string {
+// IdentityKey is a past public key of an identity and the period it was
+// valid for.  Times are unix nanoseconds
+type IdentityKey struct {
+	PublicKey []byte `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	NotBefore int64  `protobuf:"varint,2,opt,name=NotBefore,proto3" json:"NotBefore,omitempty"`
+	NotAfter  int64  `protobuf:"varint,3,opt,name=NotAfter,proto3" json:"NotAfter,omitempty"`
+	// Time the key was revoked.  Revoked keys are never valid
+	Revoked      int64  `protobuf:"varint,4,opt,name=Revoked,proto3" json:"Revoked,omitempty"`
+	RevokeReason string `protobuf:"bytes,5,opt,name=RevokeReason,proto3" json:"RevokeReason,omitempty"`
+}
+
+func (m *IdentityKey) Reset()         { *m = IdentityKey{} }
+func (m *IdentityKey) String() string { return proto.CompactTextString(m) }
+func (*IdentityKey) ProtoMessage()    {}
+func (*IdentityKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{10}
+}
+func (m *IdentityKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IdentityKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *IdentityKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityKey.Merge(m, src)
+}
+func (m *IdentityKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *IdentityKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentityKey proto.InternalMessageInfo
+
+func (m *IdentityKey) GetPublicKey() []byte {
 	if m != nil {
-		return m.Labels
+		return m.PublicKey
 	}
 	return nil
 }
 
-func (m *Artifact) GetCreated() int64 {
+func (m *IdentityKey) GetNotBefore() int64 {
 	if m != nil {
-		return m.Created
+		return m.NotBefore
 	}
 	return 0
 }
 
-func (m *Artifact) GetDataSize() int64 {
+func (m *IdentityKey) GetNotAfter() int64 {
 	if m != nil {
-		return m.DataSize
+		return m.NotAfter
 	}
 	return 0
 }
 
-type Profile struct {
-	ID           string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty" hcle:"omit" yaml:"-"`
-	Orchestrator string `protobuf:"bytes,2,opt,name=Orchestrator,proto3" json:"Orchestrator,omitempty" hcl:"orchestrator"`
-	Secrets      string `protobuf:"bytes,3,opt,name=Secrets,proto3" json:"Secrets,omitempty" hcl:"secrets"`
-	Registry     string `protobuf:"bytes,4,opt,name=Registry,proto3" json:"Registry,omitempty" hcl:"registry"`
-}
-
-func (m *Profile) Reset()                    { *m = Profile{} }
-func (m *Profile) String() string            { return proto.CompactTextString(m) }
-func (*Profile) ProtoMessage()               {}
-func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{11} }
-
-func (m *Profile) GetID() string {
+func (m *IdentityKey) GetRevoked() int64 {
 	if m != nil {
-		return m.ID
+		return m.Revoked
 	}
-	return ""
+	return 0
 }
 
-func (m *Profile) GetOrchestrator() string {
+func (m *IdentityKey) GetRevokeReason() string {
 	if m != nil {
-		return m.Orchestrator
+		return m.RevokeReason
 	}
 	return ""
 }
 
-func (m *Profile) GetSecrets() string {
-	if m != nil {
-		return m.Secrets
-	}
-	return ""
+// KeyRotation replaces the current key of an identity.  It is signed by the
+// current key
+type KeyRotation struct {
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// New public key
+	PublicKey []byte `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Timestamp int64  `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Signature []byte `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
 }
 
-func (m *Profile) GetRegistry() string {
-	if m != nil {
-		return m.Registry
+func (m *KeyRotation) Reset()         { *m = KeyRotation{} }
+func (m *KeyRotation) String() string { return proto.CompactTextString(m) }
+func (*KeyRotation) ProtoMessage()    {}
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{11}
+}
+func (m *KeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return b[:n], nil
 }
-
-type IterOptions struct {
-	Prefix string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+func (m *KeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRotation.Merge(m, src)
+}
+func (m *KeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRotation.DiscardUnknown(m)
 }
 
-func (m *IterOptions) Reset()                    { *m = IterOptions{} }
-func (m *IterOptions) String() string            { return proto.CompactTextString(m) }
-func (*IterOptions) ProtoMessage()               {}
-func (*IterOptions) Descriptor() ([]byte, []int) { return fileDescriptorThrap, []int{12} }
+var xxx_messageInfo_KeyRotation proto.InternalMessageInfo
 
-func (m *IterOptions) GetPrefix() string {
+func (m *KeyRotation) GetID() string {
 	if m != nil {
-		return m.Prefix
+		return m.ID
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Build)(nil), "Build")
-	proto.RegisterType((*Secrets)(nil), "Secrets")
-	proto.RegisterType((*Volume)(nil), "Volume")
-	proto.RegisterType((*Envionment)(nil), "Envionment")
-	proto.RegisterType((*HealthCheck)(nil), "HealthCheck")
-	proto.RegisterType((*Component)(nil), "Component")
-	proto.RegisterType((*PackManifest)(nil), "PackManifest")
-	proto.RegisterType((*Language)(nil), "Language")
-	proto.RegisterType((*Stack)(nil), "Stack")
-	proto.RegisterType((*Identity)(nil), "Identity")
-	proto.RegisterType((*Artifact)(nil), "Artifact")
-	proto.RegisterType((*Profile)(nil), "Profile")
-	proto.RegisterType((*IterOptions)(nil), "IterOptions")
+func (m *KeyRotation) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Thrap service
-
-type ThrapClient interface {
-	RegisterStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
-	CommitStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
-	GetStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
-	IterStacks(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterStacksClient, error)
-	RegisterIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
-	IterIdentities(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterIdentitiesClient, error)
-	ConfirmIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
-	GetIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
+func (m *KeyRotation) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-type thrapClient struct {
-	cc *grpc.ClientConn
+func (m *KeyRotation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
 
-func NewThrapClient(cc *grpc.ClientConn) ThrapClient {
-	return &thrapClient{cc}
+// KeyRevocation revokes a current or past key of an identity.  It is signed
+// by the current key of the identity or the agent key
+type KeyRevocation struct {
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Public key to revoke
+	PublicKey []byte `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Timestamp int64  `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// Public key of the signer
+	Signer    []byte `protobuf:"bytes,5,opt,name=Signer,proto3" json:"Signer,omitempty"`
+	Signature []byte `protobuf:"bytes,6,opt,name=Signature,proto3" json:"Signature,omitempty"`
 }
 
-func (c *thrapClient) RegisterStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
-	out := new(Stack)
-	err := grpc.Invoke(ctx, "/Thrap/RegisterStack", in, out, c.cc, opts...)
+func (m *KeyRevocation) Reset()         { *m = KeyRevocation{} }
+func (m *KeyRevocation) String() string { return proto.CompactTextString(m) }
+func (*KeyRevocation) ProtoMessage()    {}
+func (*KeyRevocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{12}
+}
+func (m *KeyRevocation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyRevocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return b[:n], nil
 }
-
-func (c *thrapClient) CommitStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
-	out := new(Stack)
-	err := grpc.Invoke(ctx, "/Thrap/CommitStack", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *KeyRevocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRevocation.Merge(m, src)
+}
+func (m *KeyRevocation) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyRevocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRevocation.DiscardUnknown(m)
 }
 
-func (c *thrapClient) GetStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
-	out := new(Stack)
-	err := grpc.Invoke(ctx, "/Thrap/GetStack", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_KeyRevocation proto.InternalMessageInfo
+
+func (m *KeyRevocation) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *thrapClient) IterStacks(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterStacksClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Thrap_serviceDesc.Streams[0], c.cc, "/Thrap/IterStacks", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &thrapIterStacksClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *KeyRevocation) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
 	}
-	return x, nil
+	return nil
 }
 
-type Thrap_IterStacksClient interface {
-	Recv() (*Stack, error)
-	grpc.ClientStream
+func (m *KeyRevocation) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
-type thrapIterStacksClient struct {
-	grpc.ClientStream
+func (m *KeyRevocation) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (x *thrapIterStacksClient) Recv() (*Stack, error) {
-	m := new(Stack)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *KeyRevocation) GetSigner() []byte {
+	if m != nil {
+		return m.Signer
 	}
-	return m, nil
+	return nil
 }
 
-func (c *thrapClient) RegisterIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := grpc.Invoke(ctx, "/Thrap/RegisterIdentity", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *KeyRevocation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
 	}
-	return out, nil
+	return nil
 }
 
-func (c *thrapClient) IterIdentities(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterIdentitiesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Thrap_serviceDesc.Streams[1], c.cc, "/Thrap/IterIdentities", opts...)
+// OIDCConfig is the identity provider used for single sign-on
+type OIDCConfig struct {
+	Issuer   string   `protobuf:"bytes,1,opt,name=Issuer,proto3" json:"Issuer,omitempty"`
+	ClientID string   `protobuf:"bytes,2,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	Scopes   []string `protobuf:"bytes,3,rep,name=Scopes,proto3" json:"Scopes,omitempty"`
+}
+
+func (m *OIDCConfig) Reset()         { *m = OIDCConfig{} }
+func (m *OIDCConfig) String() string { return proto.CompactTextString(m) }
+func (*OIDCConfig) ProtoMessage()    {}
+func (*OIDCConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{13}
+}
+func (m *OIDCConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OIDCConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	x := &thrapIterIdentitiesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return b[:n], nil
 }
-
-type Thrap_IterIdentitiesClient interface {
-	Recv() (*Identity, error)
-	grpc.ClientStream
+func (m *OIDCConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OIDCConfig.Merge(m, src)
+}
+func (m *OIDCConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *OIDCConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_OIDCConfig.DiscardUnknown(m)
 }
 
-type thrapIterIdentitiesClient struct {
-	grpc.ClientStream
+var xxx_messageInfo_OIDCConfig proto.InternalMessageInfo
+
+func (m *OIDCConfig) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
 }
 
-func (x *thrapIterIdentitiesClient) Recv() (*Identity, error) {
-	m := new(Identity)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *OIDCConfig) GetClientID() string {
+	if m != nil {
+		return m.ClientID
 	}
-	return m, nil
+	return ""
 }
 
-func (c *thrapClient) ConfirmIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := grpc.Invoke(ctx, "/Thrap/ConfirmIdentity", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *OIDCConfig) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *thrapClient) GetIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
-	out := new(Identity)
-	err := grpc.Invoke(ctx, "/Thrap/GetIdentity", in, out, c.cc, opts...)
+// LoginRequest exchanges an identity provider access token for a session.
+// The id token must be issued to the agent client id for the same user
+type LoginRequest struct {
+	AccessToken string `protobuf:"bytes,1,opt,name=AccessToken,proto3" json:"AccessToken,omitempty"`
+	IDToken     string `protobuf:"bytes,2,opt,name=IDToken,proto3" json:"IDToken,omitempty"`
+}
+
+func (m *LoginRequest) Reset()         { *m = LoginRequest{} }
+func (m *LoginRequest) String() string { return proto.CompactTextString(m) }
+func (*LoginRequest) ProtoMessage()    {}
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{14}
+}
+func (m *LoginRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return b[:n], nil
+}
+func (m *LoginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoginRequest.Merge(m, src)
+}
+func (m *LoginRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoginRequest.DiscardUnknown(m)
 }
 
-// Server API for Thrap service
+var xxx_messageInfo_LoginRequest proto.InternalMessageInfo
 
-type ThrapServer interface {
-	RegisterStack(context.Context, *Stack) (*Stack, error)
-	CommitStack(context.Context, *Stack) (*Stack, error)
-	GetStack(context.Context, *Stack) (*Stack, error)
-	IterStacks(*IterOptions, Thrap_IterStacksServer) error
-	RegisterIdentity(context.Context, *Identity) (*Identity, error)
-	IterIdentities(*IterOptions, Thrap_IterIdentitiesServer) error
-	ConfirmIdentity(context.Context, *Identity) (*Identity, error)
-	GetIdentity(context.Context, *Identity) (*Identity, error)
+func (m *LoginRequest) GetAccessToken() string {
+	if m != nil {
+		return m.AccessToken
+	}
+	return ""
 }
 
-func RegisterThrapServer(s *grpc.Server, srv ThrapServer) {
-	s.RegisterService(&_Thrap_serviceDesc, srv)
+func (m *LoginRequest) GetIDToken() string {
+	if m != nil {
+		return m.IDToken
+	}
+	return ""
 }
 
-func _Thrap_RegisterStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Stack)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ThrapServer).RegisterStack(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/RegisterStack",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).RegisterStack(ctx, req.(*Stack))
-	}
-	return interceptor(ctx, in, info, handler)
+// Session is a short-lived token authenticating requests in place of key
+// signatures
+type Session struct {
+	Token string `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	// Unix nanoseconds
+	Expires  int64     `protobuf:"varint,2,opt,name=Expires,proto3" json:"Expires,omitempty"`
+	Identity *Identity `protobuf:"bytes,3,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	// Identity provider groups at login
+	Groups []string `protobuf:"bytes,4,rep,name=Groups,proto3" json:"Groups,omitempty"`
 }
 
-func _Thrap_CommitStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Stack)
-	if err := dec(in); err != nil {
+func (m *Session) Reset()         { *m = Session{} }
+func (m *Session) String() string { return proto.CompactTextString(m) }
+func (*Session) ProtoMessage()    {}
+func (*Session) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{15}
+}
+func (m *Session) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Session) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ThrapServer).CommitStack(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/CommitStack",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).CommitStack(ctx, req.(*Stack))
-	}
-	return interceptor(ctx, in, info, handler)
+	return b[:n], nil
+}
+func (m *Session) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Session.Merge(m, src)
+}
+func (m *Session) XXX_Size() int {
+	return m.Size()
+}
+func (m *Session) XXX_DiscardUnknown() {
+	xxx_messageInfo_Session.DiscardUnknown(m)
 }
 
-func _Thrap_GetStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Stack)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ThrapServer).GetStack(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/GetStack",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).GetStack(ctx, req.(*Stack))
+var xxx_messageInfo_Session proto.InternalMessageInfo
+
+func (m *Session) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Thrap_IterStacks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(IterOptions)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *Session) GetExpires() int64 {
+	if m != nil {
+		return m.Expires
 	}
-	return srv.(ThrapServer).IterStacks(m, &thrapIterStacksServer{stream})
+	return 0
 }
 
-type Thrap_IterStacksServer interface {
-	Send(*Stack) error
-	grpc.ServerStream
+func (m *Session) GetIdentity() *Identity {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
 }
 
-type thrapIterStacksServer struct {
-	grpc.ServerStream
+func (m *Session) GetGroups() []string {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
 }
 
-func (x *thrapIterStacksServer) Send(m *Stack) error {
-	return x.ServerStream.SendMsg(m)
+type Artifact struct {
+	ID       github_com_opencontainers_go_digest.Digest `protobuf:"bytes,1,opt,name=ID,proto3,casttype=github.com/opencontainers/go-digest.Digest" json:"ID,omitempty"`
+	Tags     []string                                   `protobuf:"bytes,2,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	Labels   map[string]string                          `protobuf:"bytes,3,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Created  int64                                      `protobuf:"varint,4,opt,name=Created,proto3" json:"Created,omitempty"`
+	DataSize int64                                      `protobuf:"varint,5,opt,name=DataSize,proto3" json:"DataSize,omitempty"`
 }
 
-func _Thrap_RegisterIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Identity)
-	if err := dec(in); err != nil {
+func (m *Artifact) Reset()         { *m = Artifact{} }
+func (m *Artifact) String() string { return proto.CompactTextString(m) }
+func (*Artifact) ProtoMessage()    {}
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{16}
+}
+func (m *Artifact) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Artifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ThrapServer).RegisterIdentity(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/RegisterIdentity",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).RegisterIdentity(ctx, req.(*Identity))
-	}
-	return interceptor(ctx, in, info, handler)
+	return b[:n], nil
+}
+func (m *Artifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Artifact.Merge(m, src)
+}
+func (m *Artifact) XXX_Size() int {
+	return m.Size()
+}
+func (m *Artifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_Artifact.DiscardUnknown(m)
 }
 
-func _Thrap_IterIdentities_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(IterOptions)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_Artifact proto.InternalMessageInfo
+
+func (m *Artifact) GetID() github_com_opencontainers_go_digest.Digest {
+	if m != nil {
+		return m.ID
 	}
-	return srv.(ThrapServer).IterIdentities(m, &thrapIterIdentitiesServer{stream})
+	return ""
 }
 
-type Thrap_IterIdentitiesServer interface {
-	Send(*Identity) error
-	grpc.ServerStream
+func (m *Artifact) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-type thrapIterIdentitiesServer struct {
-	grpc.ServerStream
+func (m *Artifact) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
 
-func (x *thrapIterIdentitiesServer) Send(m *Identity) error {
-	return x.ServerStream.SendMsg(m)
+func (m *Artifact) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
 }
 
-func _Thrap_ConfirmIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Identity)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ThrapServer).ConfirmIdentity(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/ConfirmIdentity",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).ConfirmIdentity(ctx, req.(*Identity))
+func (m *Artifact) GetDataSize() int64 {
+	if m != nil {
+		return m.DataSize
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Thrap_GetIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Identity)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ThrapServer).GetIdentity(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Thrap/GetIdentity",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ThrapServer).GetIdentity(ctx, req.(*Identity))
-	}
-	return interceptor(ctx, in, info, handler)
+type Profile struct {
+	ID           string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty" hcle:"omit" yaml:"-"`
+	Orchestrator string `protobuf:"bytes,2,opt,name=Orchestrator,proto3" json:"Orchestrator,omitempty" hcl:"orchestrator"`
+	Secrets      string `protobuf:"bytes,3,opt,name=Secrets,proto3" json:"Secrets,omitempty" hcl:"secrets"`
+	Registry     string `protobuf:"bytes,4,opt,name=Registry,proto3" json:"Registry,omitempty" hcl:"registry"`
 }
 
-var _Thrap_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "Thrap",
-	HandlerType: (*ThrapServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "RegisterStack",
-			Handler:    _Thrap_RegisterStack_Handler,
-		},
-		{
-			MethodName: "CommitStack",
-			Handler:    _Thrap_CommitStack_Handler,
-		},
-		{
-			MethodName: "GetStack",
-			Handler:    _Thrap_GetStack_Handler,
-		},
-		{
-			MethodName: "RegisterIdentity",
-			Handler:    _Thrap_RegisterIdentity_Handler,
-		},
-		{
-			MethodName: "ConfirmIdentity",
-			Handler:    _Thrap_ConfirmIdentity_Handler,
-		},
-		{
-			MethodName: "GetIdentity",
-			Handler:    _Thrap_GetIdentity_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "IterStacks",
-			Handler:       _Thrap_IterStacks_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "IterIdentities",
-			Handler:       _Thrap_IterIdentities_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "thrap.proto",
+func (m *Profile) Reset()         { *m = Profile{} }
+func (m *Profile) String() string { return proto.CompactTextString(m) }
+func (*Profile) ProtoMessage()    {}
+func (*Profile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{17}
 }
-
-func (m *Build) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (m *Profile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Profile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *Profile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Profile.Merge(m, src)
+}
+func (m *Profile) XXX_Size() int {
+	return m.Size()
+}
+func (m *Profile) XXX_DiscardUnknown() {
+	xxx_messageInfo_Profile.DiscardUnknown(m)
 }
 
-func (m *Build) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Dockerfile) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Dockerfile)))
-		i += copy(dAtA[i:], m.Dockerfile)
-	}
-	if len(m.Context) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Context)))
-		i += copy(dAtA[i:], m.Context)
+var xxx_messageInfo_Profile proto.InternalMessageInfo
+
+func (m *Profile) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Secrets) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Profile) GetOrchestrator() string {
+	if m != nil {
+		return m.Orchestrator
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Secrets) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Destination) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Destination)))
-		i += copy(dAtA[i:], m.Destination)
+func (m *Profile) GetSecrets() string {
+	if m != nil {
+		return m.Secrets
 	}
-	if len(m.Template) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Template)))
-		i += copy(dAtA[i:], m.Template)
+	return ""
+}
+
+func (m *Profile) GetRegistry() string {
+	if m != nil {
+		return m.Registry
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Volume) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+type IterOptions struct {
+	Prefix string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+}
+
+func (m *IterOptions) Reset()         { *m = IterOptions{} }
+func (m *IterOptions) String() string { return proto.CompactTextString(m) }
+func (*IterOptions) ProtoMessage()    {}
+func (*IterOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{18}
+}
+func (m *IterOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IterOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *IterOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IterOptions.Merge(m, src)
+}
+func (m *IterOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *IterOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_IterOptions.DiscardUnknown(m)
 }
 
-func (m *Volume) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Source) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Source)))
-		i += copy(dAtA[i:], m.Source)
-	}
-	if len(m.Target) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Target)))
-		i += copy(dAtA[i:], m.Target)
+var xxx_messageInfo_IterOptions proto.InternalMessageInfo
+
+func (m *IterOptions) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Envionment) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// ComponentRecord is the per component detail of a build or deployment
+type ComponentRecord struct {
+	// Component id
+	ID      string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Full artifact name excluding the tag
+	Artifact string `protobuf:"bytes,3,opt,name=Artifact,proto3" json:"Artifact,omitempty"`
+	// Artifact digest
+	Digest    github_com_opencontainers_go_digest.Digest `protobuf:"bytes,4,opt,name=Digest,proto3,casttype=github.com/opencontainers/go-digest.Digest" json:"Digest,omitempty"`
+	Published bool                                       `protobuf:"varint,5,opt,name=Published,proto3" json:"Published,omitempty"`
+	// Start and end times in unix nanoseconds
+	Start int64  `protobuf:"varint,6,opt,name=Start,proto3" json:"Start,omitempty"`
+	End   int64  `protobuf:"varint,7,opt,name=End,proto3" json:"End,omitempty"`
+	Error string `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Pointer to where the logs can be found
+	Log string `protobuf:"bytes,9,opt,name=Log,proto3" json:"Log,omitempty"`
+}
+
+func (m *ComponentRecord) Reset()         { *m = ComponentRecord{} }
+func (m *ComponentRecord) String() string { return proto.CompactTextString(m) }
+func (*ComponentRecord) ProtoMessage()    {}
+func (*ComponentRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{19}
+}
+func (m *ComponentRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ComponentRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *ComponentRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComponentRecord.Merge(m, src)
+}
+func (m *ComponentRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *ComponentRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComponentRecord.DiscardUnknown(m)
 }
 
-func (m *Envionment) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.File) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.File)))
-		i += copy(dAtA[i:], m.File)
+var xxx_messageInfo_ComponentRecord proto.InternalMessageInfo
+
+func (m *ComponentRecord) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.Vars) > 0 {
-		for k, _ := range m.Vars {
-			dAtA[i] = 0x12
-			i++
-			v := m.Vars[k]
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	return ""
+}
+
+func (m *ComponentRecord) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return i, nil
+	return ""
 }
 
-func (m *HealthCheck) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ComponentRecord) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *HealthCheck) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Protocol) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Protocol)))
-		i += copy(dAtA[i:], m.Protocol)
+func (m *ComponentRecord) GetDigest() github_com_opencontainers_go_digest.Digest {
+	if m != nil {
+		return m.Digest
 	}
-	if len(m.Path) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+	return ""
+}
+
+func (m *ComponentRecord) GetPublished() bool {
+	if m != nil {
+		return m.Published
 	}
-	if len(m.Method) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Method)))
-		i += copy(dAtA[i:], m.Method)
+	return false
+}
+
+func (m *ComponentRecord) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	if m.Timeout != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Timeout))
+	return 0
+}
+
+func (m *ComponentRecord) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	if m.Interval != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Interval))
+	return 0
+}
+
+func (m *ComponentRecord) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	if len(m.PortLabel) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.PortLabel)))
-		i += copy(dAtA[i:], m.PortLabel)
+	return ""
+}
+
+func (m *ComponentRecord) GetLog() string {
+	if m != nil {
+		return m.Log
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Component) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// StackBuild is the record of a single stack build
+type StackBuild struct {
+	// Unique build id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Stack id
+	Stack string `protobuf:"bytes,2,opt,name=Stack,proto3" json:"Stack,omitempty"`
+	// Stack revision i.e. version at the time of the build
+	Revision string `protobuf:"bytes,3,opt,name=Revision,proto3" json:"Revision,omitempty"`
+	// Identity that ran the build
+	Identity   string             `protobuf:"bytes,4,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Profile    string             `protobuf:"bytes,5,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	Start      int64              `protobuf:"varint,6,opt,name=Start,proto3" json:"Start,omitempty"`
+	End        int64              `protobuf:"varint,7,opt,name=End,proto3" json:"End,omitempty"`
+	Outcome    Outcome            `protobuf:"varint,8,opt,name=Outcome,proto3,enum=Outcome" json:"Outcome,omitempty"`
+	Error      string             `protobuf:"bytes,9,opt,name=Error,proto3" json:"Error,omitempty"`
+	Components []*ComponentRecord `protobuf:"bytes,10,rep,name=Components,proto3" json:"Components,omitempty"`
+}
+
+func (m *StackBuild) Reset()         { *m = StackBuild{} }
+func (m *StackBuild) String() string { return proto.CompactTextString(m) }
+func (*StackBuild) ProtoMessage()    {}
+func (*StackBuild) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{20}
+}
+func (m *StackBuild) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StackBuild) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *StackBuild) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StackBuild.Merge(m, src)
+}
+func (m *StackBuild) XXX_Size() int {
+	return m.Size()
+}
+func (m *StackBuild) XXX_DiscardUnknown() {
+	xxx_messageInfo_StackBuild.DiscardUnknown(m)
 }
 
-func (m *Component) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.Version) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
-	}
-	if len(m.Type) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Type)))
-		i += copy(dAtA[i:], m.Type)
-	}
-	if len(m.Language) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Language)))
-		i += copy(dAtA[i:], m.Language)
-	}
-	if m.Build != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Build.Size()))
-		n1, err := m.Build.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
-	}
-	if m.Secrets != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Secrets.Size()))
-		n2, err := m.Secrets.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
+var xxx_messageInfo_StackBuild proto.InternalMessageInfo
+
+func (m *StackBuild) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.Ports) > 0 {
-		for k, _ := range m.Ports {
-			dAtA[i] = 0x42
-			i++
-			v := m.Ports[k]
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + sovThrap(uint64(v))
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x10
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(v))
-		}
+	return ""
+}
+
+func (m *StackBuild) GetStack() string {
+	if m != nil {
+		return m.Stack
 	}
-	if m.External {
-		dAtA[i] = 0x48
-		i++
-		if m.External {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return ""
+}
+
+func (m *StackBuild) GetRevision() string {
+	if m != nil {
+		return m.Revision
 	}
-	if m.Head {
-		dAtA[i] = 0x50
-		i++
-		if m.Head {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return ""
+}
+
+func (m *StackBuild) GetIdentity() string {
+	if m != nil {
+		return m.Identity
 	}
-	if m.Env != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Env.Size()))
-		n3, err := m.Env.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+	return ""
+}
+
+func (m *StackBuild) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	if len(m.Config) > 0 {
-		for k, _ := range m.Config {
-			dAtA[i] = 0x62
-			i++
-			v := m.Config[k]
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	return ""
+}
+
+func (m *StackBuild) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	if len(m.Volumes) > 0 {
-		for _, msg := range m.Volumes {
-			dAtA[i] = 0x6a
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return 0
+}
+
+func (m *StackBuild) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	if len(m.Cmd) > 0 {
-		dAtA[i] = 0x72
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Cmd)))
-		i += copy(dAtA[i:], m.Cmd)
+	return 0
+}
+
+func (m *StackBuild) GetOutcome() Outcome {
+	if m != nil {
+		return m.Outcome
 	}
-	if len(m.Args) > 0 {
-		for _, s := range m.Args {
-			dAtA[i] = 0x7a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return Outcome_UNKNOWN
+}
+
+func (m *StackBuild) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	if len(m.HealthChecks) > 0 {
-		for _, msg := range m.HealthChecks {
-			dAtA[i] = 0x82
-			i++
-			dAtA[i] = 0x1
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return ""
+}
+
+func (m *StackBuild) GetComponents() []*ComponentRecord {
+	if m != nil {
+		return m.Components
 	}
-	return i, nil
+	return nil
 }
 
-func (m *PackManifest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// Deployment is the record of a single stack deployment
+type Deployment struct {
+	// Unique deployment id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Stack id
+	Stack string `protobuf:"bytes,2,opt,name=Stack,proto3" json:"Stack,omitempty"`
+	// Stack revision that was deployed
+	Revision string `protobuf:"bytes,3,opt,name=Revision,proto3" json:"Revision,omitempty"`
+	// Identity that performed the deployment
+	Identity   string             `protobuf:"bytes,4,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Profile    string             `protobuf:"bytes,5,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	Start      int64              `protobuf:"varint,6,opt,name=Start,proto3" json:"Start,omitempty"`
+	End        int64              `protobuf:"varint,7,opt,name=End,proto3" json:"End,omitempty"`
+	Outcome    Outcome            `protobuf:"varint,8,opt,name=Outcome,proto3,enum=Outcome" json:"Outcome,omitempty"`
+	Error      string             `protobuf:"bytes,9,opt,name=Error,proto3" json:"Error,omitempty"`
+	Components []*ComponentRecord `protobuf:"bytes,10,rep,name=Components,proto3" json:"Components,omitempty"`
+}
+
+func (m *Deployment) Reset()         { *m = Deployment{} }
+func (m *Deployment) String() string { return proto.CompactTextString(m) }
+func (*Deployment) ProtoMessage()    {}
+func (*Deployment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{21}
+}
+func (m *Deployment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Deployment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *Deployment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Deployment.Merge(m, src)
+}
+func (m *Deployment) XXX_Size() int {
+	return m.Size()
+}
+func (m *Deployment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Deployment.DiscardUnknown(m)
 }
 
-func (m *PackManifest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.Versions) > 0 {
-		for _, s := range m.Versions {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.DefaultVersion) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.DefaultVersion)))
-		i += copy(dAtA[i:], m.DefaultVersion)
-	}
-	if len(m.Image) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Image)))
-		i += copy(dAtA[i:], m.Image)
-	}
-	if len(m.Files) > 0 {
-		for _, s := range m.Files {
-			dAtA[i] = 0x2a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+var xxx_messageInfo_Deployment proto.InternalMessageInfo
+
+func (m *Deployment) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Language) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Deployment) GetStack() string {
+	if m != nil {
+		return m.Stack
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Language) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+func (m *Deployment) GetRevision() string {
+	if m != nil {
+		return m.Revision
 	}
-	if len(m.Versions) > 0 {
-		for _, s := range m.Versions {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return ""
+}
+
+func (m *Deployment) GetIdentity() string {
+	if m != nil {
+		return m.Identity
 	}
-	if len(m.DefaultVersion) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.DefaultVersion)))
-		i += copy(dAtA[i:], m.DefaultVersion)
+	return ""
+}
+
+func (m *Deployment) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	if len(m.FileExts) > 0 {
-		for _, s := range m.FileExts {
-			dAtA[i] = 0x22
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return ""
+}
+
+func (m *Deployment) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	if len(m.IgnoreFiles) > 0 {
-		for _, s := range m.IgnoreFiles {
-			dAtA[i] = 0x2a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return 0
+}
+
+func (m *Deployment) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	if len(m.DevImages) > 0 {
-		for _, s := range m.DevImages {
-			dAtA[i] = 0x32
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return 0
+}
+
+func (m *Deployment) GetOutcome() Outcome {
+	if m != nil {
+		return m.Outcome
 	}
-	if len(m.PubImages) > 0 {
-		for _, s := range m.PubImages {
-			dAtA[i] = 0x3a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return Outcome_UNKNOWN
+}
+
+func (m *Deployment) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	if len(m.ScaffoldFiles) > 0 {
-		for _, s := range m.ScaffoldFiles {
-			dAtA[i] = 0x42
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return ""
+}
+
+func (m *Deployment) GetComponents() []*ComponentRecord {
+	if m != nil {
+		return m.Components
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Stack) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// AuditEntry is an append-only record of an agent mutation.  Each entry
+// includes the hash of the previous one forming a tamper-evident chain
+type AuditEntry struct {
+	// Sequence number starting at 1
+	Seq uint64 `protobuf:"varint,1,opt,name=Seq,proto3" json:"Seq,omitempty"`
+	// Unix nanoseconds
+	Timestamp int64 `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// Authenticated identity.  Empty for anonymous calls
+	Identity string `protobuf:"bytes,3,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	// Full grpc method
+	Method string `protobuf:"bytes,4,opt,name=Method,proto3" json:"Method,omitempty"`
+	// Id of the resource acted upon if any
+	Resource string `protobuf:"bytes,5,opt,name=Resource,proto3" json:"Resource,omitempty"`
+	// Hash of the marshalled request
+	RequestHash []byte  `protobuf:"bytes,6,opt,name=RequestHash,proto3" json:"RequestHash,omitempty"`
+	Outcome     Outcome `protobuf:"varint,7,opt,name=Outcome,proto3,enum=Outcome" json:"Outcome,omitempty"`
+	Error       string  `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Hash of the previous entry
+	Prev []byte `protobuf:"bytes,9,opt,name=Prev,proto3" json:"Prev,omitempty"`
+	// Hash of this entry
+	Hash []byte `protobuf:"bytes,10,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{22}
+}
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
 }
 
-func (m *Stack) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.Version) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
-	}
-	if len(m.Components) > 0 {
-		for k, _ := range m.Components {
-			dAtA[i] = 0x2a
-			i++
-			v := m.Components[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.Size()
-				msgSize += 1 + sovThrap(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + msgSize
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintThrap(dAtA, i, uint64(v.Size()))
-				n4, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n4
-			}
-		}
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
 	}
-	if len(m.Dependencies) > 0 {
-		for k, _ := range m.Dependencies {
-			dAtA[i] = 0x32
-			i++
-			v := m.Dependencies[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.Size()
-				msgSize += 1 + sovThrap(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + msgSize
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintThrap(dAtA, i, uint64(v.Size()))
-				n5, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n5
-			}
-		}
+	return 0
+}
+
+func (m *AuditEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
+	return 0
+}
+
+func (m *AuditEntry) GetIdentity() string {
+	if m != nil {
+		return m.Identity
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Identity) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *AuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Identity) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+func (m *AuditEntry) GetResource() string {
+	if m != nil {
+		return m.Resource
 	}
-	if len(m.Email) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Email)))
-		i += copy(dAtA[i:], m.Email)
+	return ""
+}
+
+func (m *AuditEntry) GetRequestHash() []byte {
+	if m != nil {
+		return m.RequestHash
 	}
-	if len(m.PublicKey) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.PublicKey)))
-		i += copy(dAtA[i:], m.PublicKey)
+	return nil
+}
+
+func (m *AuditEntry) GetOutcome() Outcome {
+	if m != nil {
+		return m.Outcome
 	}
-	if m.Nonce != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Nonce))
+	return Outcome_UNKNOWN
+}
+
+func (m *AuditEntry) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	if len(m.Signature) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Signature)))
-		i += copy(dAtA[i:], m.Signature)
+	return ""
+}
+
+func (m *AuditEntry) GetPrev() []byte {
+	if m != nil {
+		return m.Prev
 	}
-	if len(m.Meta) > 0 {
-		for k, _ := range m.Meta {
-			dAtA[i] = 0x32
-			i++
-			v := m.Meta[k]
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	return nil
+}
+
+func (m *AuditEntry) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Artifact) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// AuditOptions filters audit entries.  Empty fields match all
+type AuditOptions struct {
+	// First sequence number
+	Start    uint64 `protobuf:"varint,1,opt,name=Start,proto3" json:"Start,omitempty"`
+	Identity string `protobuf:"bytes,2,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	// Method name with or without the service prefix
+	Method string `protobuf:"bytes,3,opt,name=Method,proto3" json:"Method,omitempty"`
+	// Resource id prefix
+	Resource string `protobuf:"bytes,4,opt,name=Resource,proto3" json:"Resource,omitempty"`
+	// Time range in unix nanoseconds
+	Since int64 `protobuf:"varint,5,opt,name=Since,proto3" json:"Since,omitempty"`
+	Until int64 `protobuf:"varint,6,opt,name=Until,proto3" json:"Until,omitempty"`
+}
+
+func (m *AuditOptions) Reset()         { *m = AuditOptions{} }
+func (m *AuditOptions) String() string { return proto.CompactTextString(m) }
+func (*AuditOptions) ProtoMessage()    {}
+func (*AuditOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{23}
+}
+func (m *AuditOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuditOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *AuditOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditOptions.Merge(m, src)
+}
+func (m *AuditOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuditOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditOptions.DiscardUnknown(m)
 }
 
-func (m *Artifact) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+var xxx_messageInfo_AuditOptions proto.InternalMessageInfo
+
+func (m *AuditOptions) GetStart() uint64 {
+	if m != nil {
+		return m.Start
 	}
-	if len(m.Tags) > 0 {
-		for _, s := range m.Tags {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return 0
+}
+
+func (m *AuditOptions) GetIdentity() string {
+	if m != nil {
+		return m.Identity
 	}
-	if len(m.Labels) > 0 {
-		for k, _ := range m.Labels {
-			dAtA[i] = 0x1a
-			i++
-			v := m.Labels[k]
-			mapSize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			i = encodeVarintThrap(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
+	return ""
+}
+
+func (m *AuditOptions) GetMethod() string {
+	if m != nil {
+		return m.Method
 	}
-	if m.Created != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.Created))
+	return ""
+}
+
+func (m *AuditOptions) GetResource() string {
+	if m != nil {
+		return m.Resource
 	}
-	if m.DataSize != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(m.DataSize))
+	return ""
+}
+
+func (m *AuditOptions) GetSince() int64 {
+	if m != nil {
+		return m.Since
 	}
-	return i, nil
+	return 0
 }
 
-func (m *Profile) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (m *AuditOptions) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+// SnapshotHeader describes a snapshot of the agent state
+type SnapshotHeader struct {
+	// Storage format of the data e.g. badger or kv
+	Format string `protobuf:"bytes,1,opt,name=Format,proto3" json:"Format,omitempty"`
+	// Unix nanoseconds
+	Created   int64 `protobuf:"varint,2,opt,name=Created,proto3" json:"Created,omitempty"`
+	Encrypted bool  `protobuf:"varint,3,opt,name=Encrypted,proto3" json:"Encrypted,omitempty"`
+	// Salt of the key derived from the passphrase
+	Salt []byte `protobuf:"bytes,4,opt,name=Salt,proto3" json:"Salt,omitempty"`
+}
+
+func (m *SnapshotHeader) Reset()         { *m = SnapshotHeader{} }
+func (m *SnapshotHeader) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHeader) ProtoMessage()    {}
+func (*SnapshotHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{24}
+}
+func (m *SnapshotHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *SnapshotHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHeader.Merge(m, src)
+}
+func (m *SnapshotHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHeader.DiscardUnknown(m)
 }
 
-func (m *Profile) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+var xxx_messageInfo_SnapshotHeader proto.InternalMessageInfo
+
+func (m *SnapshotHeader) GetFormat() string {
+	if m != nil {
+		return m.Format
 	}
-	if len(m.Orchestrator) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Orchestrator)))
-		i += copy(dAtA[i:], m.Orchestrator)
+	return ""
+}
+
+func (m *SnapshotHeader) GetCreated() int64 {
+	if m != nil {
+		return m.Created
 	}
-	if len(m.Secrets) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Secrets)))
-		i += copy(dAtA[i:], m.Secrets)
+	return 0
+}
+
+func (m *SnapshotHeader) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
 	}
-	if len(m.Registry) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Registry)))
-		i += copy(dAtA[i:], m.Registry)
+	return false
+}
+
+func (m *SnapshotHeader) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
 	}
-	return i, nil
+	return nil
 }
 
-func (m *IterOptions) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+// SnapshotChunk is a piece of a streamed snapshot
+type SnapshotChunk struct {
+	Data []byte `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{25}
+}
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return b[:n], nil
+}
+func (m *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(m, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
 }
 
-func (m *IterOptions) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Prefix) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintThrap(dAtA, i, uint64(len(m.Prefix)))
-		i += copy(dAtA[i:], m.Prefix)
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return i, nil
+	return nil
 }
 
-func encodeVarintThrap(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+// ClusterMember is an agent in a replicated cluster
+type ClusterMember struct {
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Raft transport address
+	RaftAddr string `protobuf:"bytes,2,opt,name=RaftAddr,proto3" json:"RaftAddr,omitempty"`
+	// Grpc address clients and followers are redirected to
+	RPCAddr string `protobuf:"bytes,3,opt,name=RPCAddr,proto3" json:"RPCAddr,omitempty"`
+	Leader  bool   `protobuf:"varint,4,opt,name=Leader,proto3" json:"Leader,omitempty"`
+}
+
+func (m *ClusterMember) Reset()         { *m = ClusterMember{} }
+func (m *ClusterMember) String() string { return proto.CompactTextString(m) }
+func (*ClusterMember) ProtoMessage()    {}
+func (*ClusterMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{26}
+}
+func (m *ClusterMember) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return b[:n], nil
 }
-func (m *Build) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Dockerfile)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+func (m *ClusterMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterMember.Merge(m, src)
+}
+func (m *ClusterMember) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterMember proto.InternalMessageInfo
+
+func (m *ClusterMember) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	l = len(m.Context)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return ""
+}
+
+func (m *ClusterMember) GetRaftAddr() string {
+	if m != nil {
+		return m.RaftAddr
 	}
-	return n
+	return ""
 }
 
-func (m *Secrets) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Destination)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Template)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+func (m *ClusterMember) GetRPCAddr() string {
+	if m != nil {
+		return m.RPCAddr
 	}
-	return n
+	return ""
 }
 
-func (m *Volume) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Source)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Target)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+func (m *ClusterMember) GetLeader() bool {
+	if m != nil {
+		return m.Leader
 	}
-	return n
+	return false
 }
 
-func (m *Envionment) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.File)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	if len(m.Vars) > 0 {
-		for k, v := range m.Vars {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
+type ClusterMembers struct {
+	Members []*ClusterMember `protobuf:"bytes,1,rep,name=Members,proto3" json:"Members,omitempty"`
+}
+
+func (m *ClusterMembers) Reset()         { *m = ClusterMembers{} }
+func (m *ClusterMembers) String() string { return proto.CompactTextString(m) }
+func (*ClusterMembers) ProtoMessage()    {}
+func (*ClusterMembers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{27}
+}
+func (m *ClusterMembers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterMembers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return b[:n], nil
+}
+func (m *ClusterMembers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterMembers.Merge(m, src)
+}
+func (m *ClusterMembers) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterMembers) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterMembers.DiscardUnknown(m)
 }
 
-func (m *HealthCheck) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Protocol)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+var xxx_messageInfo_ClusterMembers proto.InternalMessageInfo
+
+func (m *ClusterMembers) GetMembers() []*ClusterMember {
+	if m != nil {
+		return m.Members
 	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return nil
+}
+
+// JoinRequest asks an agent to join the cluster of the member at the address
+type JoinRequest struct {
+	Addr string `protobuf:"bytes,1,opt,name=Addr,proto3" json:"Addr,omitempty"`
+}
+
+func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
+func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinRequest) ProtoMessage()    {}
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{28}
+}
+func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Method)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return b[:n], nil
+}
+func (m *JoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinRequest.Merge(m, src)
+}
+func (m *JoinRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *JoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
+
+func (m *JoinRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
 	}
-	if m.Timeout != 0 {
-		n += 1 + sovThrap(uint64(m.Timeout))
+	return ""
+}
+
+// ClusterCommand is a storage write replicated through the raft log
+type ClusterCommand struct {
+	Op    ClusterOp `protobuf:"varint,1,opt,name=Op,proto3,enum=ClusterOp" json:"Op,omitempty"`
+	Key   string    `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value []byte    `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (m *ClusterCommand) Reset()         { *m = ClusterCommand{} }
+func (m *ClusterCommand) String() string { return proto.CompactTextString(m) }
+func (*ClusterCommand) ProtoMessage()    {}
+func (*ClusterCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{29}
+}
+func (m *ClusterCommand) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	if m.Interval != 0 {
-		n += 1 + sovThrap(uint64(m.Interval))
+	return b[:n], nil
+}
+func (m *ClusterCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterCommand.Merge(m, src)
+}
+func (m *ClusterCommand) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterCommand proto.InternalMessageInfo
+
+func (m *ClusterCommand) GetOp() ClusterOp {
+	if m != nil {
+		return m.Op
 	}
-	l = len(m.PortLabel)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return ClusterOp_PUT
+}
+
+func (m *ClusterCommand) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return n
+	return ""
 }
 
-func (m *Component) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+func (m *ClusterCommand) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return nil
+}
+
+// ClusterResult is the outcome of a forwarded command.  Error is empty on
+// success
+type ClusterResult struct {
+	Error string `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+}
+
+func (m *ClusterResult) Reset()         { *m = ClusterResult{} }
+func (m *ClusterResult) String() string { return proto.CompactTextString(m) }
+func (*ClusterResult) ProtoMessage()    {}
+func (*ClusterResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{30}
+}
+func (m *ClusterResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return b[:n], nil
+}
+func (m *ClusterResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterResult.Merge(m, src)
+}
+func (m *ClusterResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterResult proto.InternalMessageInfo
+
+func (m *ClusterResult) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return ""
+}
+
+// ClusterSnapshot holds every key of the replicated state
+type ClusterSnapshot struct {
+	Entries []*ClusterCommand `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+}
+
+func (m *ClusterSnapshot) Reset()         { *m = ClusterSnapshot{} }
+func (m *ClusterSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ClusterSnapshot) ProtoMessage()    {}
+func (*ClusterSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_74e67e7a27ee2382, []int{31}
+}
+func (m *ClusterSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Language)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return b[:n], nil
+}
+func (m *ClusterSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterSnapshot.Merge(m, src)
+}
+func (m *ClusterSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterSnapshot proto.InternalMessageInfo
+
+func (m *ClusterSnapshot) GetEntries() []*ClusterCommand {
+	if m != nil {
+		return m.Entries
 	}
-	if m.Build != nil {
-		l = m.Build.Size()
-		n += 1 + l + sovThrap(uint64(l))
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("Outcome", Outcome_name, Outcome_value)
+	proto.RegisterEnum("ClusterOp", ClusterOp_name, ClusterOp_value)
+	proto.RegisterType((*Build)(nil), "Build")
+	proto.RegisterType((*Secrets)(nil), "Secrets")
+	proto.RegisterType((*Volume)(nil), "Volume")
+	proto.RegisterType((*Envionment)(nil), "Envionment")
+	proto.RegisterMapType((map[string]string)(nil), "Envionment.VarsEntry")
+	proto.RegisterType((*HealthCheck)(nil), "HealthCheck")
+	proto.RegisterType((*Component)(nil), "Component")
+	proto.RegisterMapType((map[string]string)(nil), "Component.ConfigEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "Component.PortsEntry")
+	proto.RegisterType((*PackManifest)(nil), "PackManifest")
+	proto.RegisterType((*Language)(nil), "Language")
+	proto.RegisterType((*Stack)(nil), "Stack")
+	proto.RegisterMapType((map[string]*Component)(nil), "Stack.ComponentsEntry")
+	proto.RegisterMapType((map[string]*Component)(nil), "Stack.DependenciesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "Stack.PacksEntry")
+	proto.RegisterType((*Identity)(nil), "Identity")
+	proto.RegisterMapType((map[string]string)(nil), "Identity.MetaEntry")
+	proto.RegisterType((*IdentityKey)(nil), "IdentityKey")
+	proto.RegisterType((*KeyRotation)(nil), "KeyRotation")
+	proto.RegisterType((*KeyRevocation)(nil), "KeyRevocation")
+	proto.RegisterType((*OIDCConfig)(nil), "OIDCConfig")
+	proto.RegisterType((*LoginRequest)(nil), "LoginRequest")
+	proto.RegisterType((*Session)(nil), "Session")
+	proto.RegisterType((*Artifact)(nil), "Artifact")
+	proto.RegisterMapType((map[string]string)(nil), "Artifact.LabelsEntry")
+	proto.RegisterType((*Profile)(nil), "Profile")
+	proto.RegisterType((*IterOptions)(nil), "IterOptions")
+	proto.RegisterType((*ComponentRecord)(nil), "ComponentRecord")
+	proto.RegisterType((*StackBuild)(nil), "StackBuild")
+	proto.RegisterType((*Deployment)(nil), "Deployment")
+	proto.RegisterType((*AuditEntry)(nil), "AuditEntry")
+	proto.RegisterType((*AuditOptions)(nil), "AuditOptions")
+	proto.RegisterType((*SnapshotHeader)(nil), "SnapshotHeader")
+	proto.RegisterType((*SnapshotChunk)(nil), "SnapshotChunk")
+	proto.RegisterType((*ClusterMember)(nil), "ClusterMember")
+	proto.RegisterType((*ClusterMembers)(nil), "ClusterMembers")
+	proto.RegisterType((*JoinRequest)(nil), "JoinRequest")
+	proto.RegisterType((*ClusterCommand)(nil), "ClusterCommand")
+	proto.RegisterType((*ClusterResult)(nil), "ClusterResult")
+	proto.RegisterType((*ClusterSnapshot)(nil), "ClusterSnapshot")
+}
+
+func init() { proto.RegisterFile("thrap.proto", fileDescriptor_74e67e7a27ee2382) }
+
+var fileDescriptor_74e67e7a27ee2382 = []byte{
+	// 2959 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x1a, 0x4b, 0x6f, 0x1b, 0xc7,
+	0xd9, 0x7c, 0x88, 0x8f, 0x8f, 0x14, 0x25, 0x4f, 0x1c, 0x63, 0x41, 0x38, 0x5a, 0x65, 0x13, 0xb7,
+	0x72, 0x6c, 0xaf, 0x65, 0x39, 0x85, 0x13, 0x23, 0x69, 0x21, 0x3e, 0x2c, 0x33, 0x96, 0x25, 0x75,
+	0x24, 0x3b, 0x45, 0x7b, 0x30, 0x56, 0xcb, 0x21, 0xb9, 0x10, 0x77, 0x97, 0xd9, 0x1d, 0xaa, 0x66,
+	0x7b, 0x68, 0xd1, 0x7b, 0x81, 0xa0, 0xa7, 0xde, 0x5a, 0xa0, 0xbd, 0xf4, 0xdc, 0x5b, 0x81, 0xde,
+	0x7b, 0xe8, 0x21, 0xc7, 0x9c, 0x16, 0x85, 0xf3, 0x0f, 0x78, 0x2a, 0x72, 0x28, 0x8a, 0x79, 0xec,
+	0xee, 0x90, 0x92, 0x6c, 0x3a, 0x40, 0x6f, 0xbd, 0x58, 0xf3, 0x3d, 0x67, 0xe6, 0x9b, 0xef, 0xb9,
+	0x26, 0x54, 0xe8, 0x20, 0xb0, 0x46, 0xe6, 0x28, 0xf0, 0xa9, 0x5f, 0xbf, 0xdd, 0x77, 0xe8, 0x60,
+	0x7c, 0x6c, 0xda, 0xbe, 0x7b, 0xa7, 0xef, 0xf7, 0xfd, 0x3b, 0x1c, 0x7d, 0x3c, 0xee, 0x71, 0x88,
+	0x03, 0x7c, 0x25, 0xd8, 0x8d, 0x5f, 0xc2, 0x52, 0x63, 0xec, 0x0c, 0xbb, 0xe8, 0x43, 0x80, 0x96,
+	0x6f, 0x9f, 0x90, 0xa0, 0xe7, 0x0c, 0x89, 0x96, 0x59, 0xcf, 0x6c, 0x94, 0x1b, 0x57, 0xa6, 0x91,
+	0xbe, 0x3a, 0xb0, 0x87, 0x0f, 0x8c, 0x6e, 0x42, 0x32, 0xb0, 0xc2, 0x87, 0x3e, 0x81, 0x62, 0xd3,
+	0xf7, 0x28, 0x79, 0x41, 0xb5, 0x2c, 0x17, 0x31, 0xa6, 0x91, 0xbe, 0xc6, 0x45, 0x6c, 0x81, 0x37,
+	0xd6, 0x07, 0xf6, 0x90, 0x3c, 0x30, 0x7c, 0xd7, 0xa1, 0xc4, 0x1d, 0xd1, 0x89, 0x81, 0x63, 0x11,
+	0x23, 0x80, 0xe2, 0x21, 0xb1, 0x03, 0x42, 0x43, 0x74, 0x1f, 0x2a, 0x2d, 0x12, 0x52, 0xc7, 0xb3,
+	0xa8, 0xe3, 0x7b, 0x72, 0xff, 0xb7, 0xa7, 0x91, 0x7e, 0x59, 0xec, 0x9f, 0xd2, 0x0c, 0xac, 0x72,
+	0x22, 0x13, 0x4a, 0x47, 0xc4, 0x1d, 0x0d, 0x2d, 0x4a, 0xe4, 0x11, 0xd0, 0x34, 0xd2, 0x6b, 0x5c,
+	0x8a, 0x4a, 0x82, 0x81, 0x13, 0x1e, 0xe3, 0x57, 0x50, 0x78, 0xe6, 0x0f, 0xc7, 0x2e, 0x41, 0x8f,
+	0xa1, 0x70, 0xe8, 0x8f, 0x03, 0x3b, 0xbe, 0xed, 0xbd, 0x69, 0xa4, 0xdf, 0xe1, 0x72, 0x21, 0x47,
+	0x9f, 0x3d, 0xf9, 0xfa, 0xc4, 0x72, 0x87, 0x0f, 0x8c, 0x5b, 0xca, 0x5d, 0xa4, 0x0a, 0xb4, 0x01,
+	0x85, 0x23, 0x2b, 0xe8, 0x93, 0xd8, 0x0e, 0xab, 0xd3, 0x48, 0xaf, 0x8a, 0x43, 0x70, 0xb4, 0x81,
+	0x25, 0xdd, 0xf8, 0x73, 0x06, 0xa0, 0xed, 0x9d, 0x3a, 0xbe, 0xe7, 0x12, 0x8f, 0x22, 0x03, 0xf2,
+	0x0f, 0x53, 0x8b, 0xd7, 0xa6, 0x91, 0x0e, 0x5c, 0x4c, 0xd8, 0x9a, 0xd3, 0xd0, 0xc7, 0x90, 0x7f,
+	0x66, 0x05, 0xa1, 0x96, 0x5d, 0xcf, 0x6d, 0x54, 0xb6, 0xde, 0x36, 0x53, 0x71, 0x93, 0xe1, 0xdb,
+	0x1e, 0x0d, 0x26, 0x8a, 0xe8, 0xa9, 0x15, 0x84, 0x06, 0xe6, 0x22, 0xf5, 0xfb, 0x50, 0x4e, 0x58,
+	0xd0, 0x2a, 0xe4, 0x4e, 0xc8, 0x44, 0x6c, 0x85, 0xd9, 0x12, 0x5d, 0x81, 0xa5, 0x53, 0x6b, 0x38,
+	0x96, 0xa6, 0xc3, 0x02, 0x78, 0x90, 0xfd, 0x28, 0x63, 0xfc, 0x35, 0x0b, 0x95, 0x47, 0xc4, 0x1a,
+	0xd2, 0x41, 0x73, 0x40, 0xec, 0x13, 0x74, 0x17, 0x4a, 0x07, 0xcc, 0x63, 0x6c, 0x7f, 0xa8, 0xbe,
+	0xce, 0x59, 0x8b, 0x24, 0x6c, 0xe8, 0x06, 0xe4, 0x0f, 0x2c, 0x3a, 0xd0, 0xb2, 0xaf, 0x62, 0xe7,
+	0x2c, 0xe8, 0x36, 0x14, 0x9e, 0x10, 0x3a, 0xf0, 0xbb, 0x5a, 0xee, 0x55, 0xcc, 0x92, 0x09, 0xdd,
+	0x81, 0xe2, 0x91, 0xe3, 0x12, 0x7f, 0x4c, 0xb5, 0xfc, 0x7a, 0x66, 0x23, 0x77, 0x11, 0x7f, 0xcc,
+	0xc5, 0x4e, 0xdf, 0xf1, 0x28, 0x09, 0x4e, 0xad, 0xa1, 0xb6, 0xf4, 0x2a, 0x89, 0x84, 0x0d, 0xdd,
+	0x83, 0xf2, 0x81, 0x1f, 0xd0, 0x5d, 0xeb, 0x98, 0x0c, 0xb5, 0xc2, 0xab, 0x4e, 0x95, 0xf2, 0x19,
+	0xff, 0x06, 0x28, 0x37, 0x7d, 0x77, 0xe4, 0x7b, 0xec, 0x6d, 0x37, 0x20, 0xdb, 0x69, 0x49, 0x6b,
+	0x69, 0xd3, 0x48, 0xbf, 0x92, 0x3a, 0x54, 0xec, 0x4b, 0xb7, 0x0d, 0x9c, 0xed, 0xb4, 0x98, 0x17,
+	0xec, 0x59, 0x6e, 0xec, 0xc1, 0xe9, 0x53, 0x7a, 0x96, 0xcb, 0xbc, 0x80, 0xd1, 0xd0, 0x1e, 0x14,
+	0x9f, 0x91, 0x20, 0x64, 0xe1, 0x21, 0x8c, 0xf4, 0xe1, 0x34, 0xd2, 0x37, 0xc5, 0x8b, 0x0b, 0xfc,
+	0x39, 0x0e, 0x7a, 0x4e, 0xf4, 0x49, 0x25, 0xc8, 0x84, 0xfc, 0xd1, 0x64, 0x44, 0xb8, 0x05, 0xcb,
+	0x8d, 0x7a, 0xb2, 0x27, 0x9d, 0x8c, 0x88, 0xf1, 0x6d, 0xa4, 0x97, 0xd8, 0x45, 0x18, 0x07, 0xe6,
+	0x7c, 0xe8, 0x39, 0x94, 0x76, 0x2d, 0xaf, 0x3f, 0xb6, 0xfa, 0x84, 0xdb, 0xb0, 0xdc, 0x68, 0x4e,
+	0x23, 0xfd, 0x2e, 0x97, 0x19, 0x4a, 0xc2, 0x22, 0x31, 0xf3, 0x6d, 0xa4, 0x43, 0xac, 0xa8, 0xd3,
+	0xc2, 0x89, 0x52, 0xf4, 0x23, 0x99, 0x8b, 0xb8, 0xb5, 0x2b, 0x5b, 0x05, 0x93, 0x43, 0x8d, 0x77,
+	0xa7, 0x91, 0xfe, 0x0e, 0xdf, 0xe5, 0x98, 0xc1, 0xe7, 0x45, 0xa1, 0x90, 0x43, 0x3b, 0x49, 0x3e,
+	0xd1, 0x8a, 0x5c, 0x45, 0xc9, 0x94, 0x70, 0xe3, 0xbd, 0x69, 0xa4, 0xeb, 0x22, 0xb8, 0x05, 0xe6,
+	0x3c, 0x35, 0xb1, 0x34, 0x7a, 0x0e, 0x4b, 0xec, 0x4d, 0x43, 0xad, 0x24, 0x23, 0x2e, 0x79, 0x53,
+	0x93, 0xe3, 0x45, 0xc4, 0x6d, 0x4d, 0x23, 0xdd, 0xe4, 0x3a, 0x47, 0x0c, 0xb9, 0x50, 0xbe, 0x10,
+	0x7a, 0xd1, 0x8f, 0xa1, 0xd4, 0x7e, 0x41, 0x49, 0xe0, 0x59, 0x43, 0xad, 0xbc, 0x9e, 0xd9, 0x28,
+	0x35, 0x7e, 0x90, 0xd8, 0x92, 0x48, 0xc2, 0x42, 0xfa, 0x12, 0x35, 0xa8, 0x0d, 0xf9, 0x47, 0xc4,
+	0xea, 0x6a, 0xc0, 0xd5, 0xdd, 0x9d, 0x46, 0xfa, 0x6d, 0xae, 0x6e, 0x40, 0xac, 0xee, 0x42, 0xaa,
+	0xb8, 0x38, 0xda, 0x87, 0x5c, 0xdb, 0x3b, 0xd5, 0x2a, 0xdc, 0x7e, 0x15, 0x25, 0xd5, 0x34, 0x36,
+	0xa7, 0x91, 0x7e, 0x4b, 0x9c, 0xd0, 0x3b, 0x5d, 0x48, 0x23, 0xd3, 0x84, 0x6c, 0x28, 0x34, 0x7d,
+	0xaf, 0xe7, 0xf4, 0xb5, 0x2a, 0x37, 0xe6, 0x55, 0xc5, 0x98, 0x82, 0x20, 0xac, 0x99, 0xa6, 0x5f,
+	0x9b, 0x63, 0x17, 0x4b, 0xbf, 0x42, 0x03, 0xfa, 0x1c, 0x8a, 0x22, 0xab, 0x87, 0xda, 0x32, 0xdf,
+	0xa5, 0x68, 0x0a, 0x58, 0x0d, 0x12, 0xc1, 0xb0, 0x90, 0xde, 0x58, 0x1b, 0x6a, 0x40, 0xae, 0xe9,
+	0x76, 0xb5, 0x1a, 0xf7, 0xf7, 0xd4, 0x02, 0xb6, 0xbb, 0x98, 0x4d, 0x99, 0x30, 0x7b, 0x99, 0xed,
+	0xa0, 0x1f, 0x6a, 0x2b, 0xeb, 0xb9, 0x8d, 0xb2, 0xf2, 0x32, 0x56, 0xd0, 0x5f, 0xec, 0x34, 0x5c,
+	0x1c, 0xed, 0x40, 0x55, 0x49, 0xc8, 0xa1, 0xb6, 0xca, 0x2f, 0x5a, 0x35, 0x15, 0xe4, 0x45, 0x19,
+	0x6a, 0x46, 0x10, 0x3d, 0x87, 0x72, 0x8b, 0x8c, 0x88, 0xd7, 0x0d, 0xf7, 0x3d, 0xed, 0x32, 0x3f,
+	0xd4, 0xf6, 0x34, 0xd2, 0x3f, 0x95, 0x95, 0x96, 0x53, 0x9e, 0xfb, 0xde, 0x85, 0x47, 0x4b, 0x59,
+	0x66, 0xb2, 0x60, 0xa2, 0xb3, 0xfe, 0x11, 0x40, 0x1a, 0x26, 0xaf, 0xab, 0x3a, 0x4b, 0x4a, 0xd5,
+	0xa9, 0x7f, 0x0c, 0x15, 0xc5, 0x27, 0xde, 0xa8, 0x60, 0xfd, 0x2e, 0x03, 0xd5, 0x03, 0xcb, 0x3e,
+	0x79, 0x62, 0x79, 0x4e, 0x8f, 0x84, 0x14, 0x21, 0x99, 0x53, 0x85, 0x34, 0x5f, 0xa3, 0x3a, 0x94,
+	0x64, 0xfa, 0x13, 0xd5, 0xb4, 0x8c, 0x13, 0x18, 0x7d, 0x0f, 0x6a, 0x2d, 0xd2, 0xb3, 0xc6, 0x43,
+	0x3a, 0x93, 0x66, 0xf1, 0x1c, 0x96, 0x1d, 0xa1, 0xe3, 0x5a, 0x7d, 0x99, 0x38, 0xb1, 0x00, 0x18,
+	0x96, 0xd5, 0xea, 0x50, 0x5b, 0xe2, 0x6a, 0x05, 0x60, 0xfc, 0x26, 0x9b, 0x26, 0xcd, 0xff, 0xd9,
+	0x81, 0xea, 0x50, 0x62, 0xbb, 0xb5, 0x5f, 0xd0, 0x50, 0xcb, 0x0b, 0x1d, 0x31, 0x8c, 0xd6, 0xa1,
+	0xd2, 0xe9, 0x7b, 0x7e, 0x40, 0xd4, 0xc3, 0xa9, 0x28, 0x74, 0x8d, 0x79, 0xc3, 0x29, 0xbf, 0x44,
+	0xa8, 0x15, 0x38, 0x3d, 0x45, 0x30, 0xea, 0xc1, 0xf8, 0x58, 0x52, 0x8b, 0x82, 0x9a, 0x20, 0xd0,
+	0xfb, 0xb0, 0x7c, 0x68, 0x5b, 0xbd, 0x9e, 0x3f, 0xec, 0x0a, 0xfd, 0x25, 0xce, 0x31, 0x8b, 0x34,
+	0xfe, 0x56, 0x80, 0xa5, 0x43, 0x6a, 0xd9, 0x27, 0xb2, 0x20, 0x66, 0xdf, 0xa0, 0x20, 0xe6, 0x16,
+	0x2b, 0x88, 0xf9, 0x8b, 0x0a, 0xe2, 0x42, 0xb1, 0x2e, 0xed, 0xb8, 0x0b, 0x90, 0xa4, 0x26, 0x61,
+	0x2a, 0x96, 0xad, 0xf8, 0xc9, 0xd3, 0x9c, 0x25, 0x73, 0x7f, 0xda, 0x1a, 0xdb, 0x09, 0xc5, 0xc0,
+	0x8a, 0x3c, 0xea, 0x41, 0x55, 0x44, 0x04, 0xf1, 0x6c, 0x47, 0x9a, 0xb6, 0xb2, 0xa5, 0x49, 0x7d,
+	0x2a, 0x49, 0x68, 0xdc, 0x98, 0x46, 0xfa, 0xfb, 0x4a, 0x08, 0x0a, 0xda, 0x79, 0x07, 0x9e, 0xd1,
+	0x8b, 0x9e, 0xf2, 0xce, 0xd9, 0x0e, 0x9c, 0x11, 0xef, 0x9c, 0x8b, 0x73, 0xbd, 0x6c, 0x37, 0xa5,
+	0xbd, 0xba, 0x3d, 0x10, 0x7d, 0x75, 0xcc, 0x8b, 0x3e, 0x07, 0x90, 0x76, 0x71, 0xbc, 0xbe, 0x56,
+	0xe2, 0x5a, 0xef, 0x4f, 0x23, 0xfd, 0x9e, 0x6a, 0x5f, 0xc7, 0x5b, 0x2c, 0x4d, 0x2b, 0xaa, 0xd0,
+	0xcf, 0x60, 0x89, 0x85, 0x69, 0xa8, 0x95, 0xb9, 0x41, 0x2e, 0x4b, 0x83, 0x70, 0xdc, 0x99, 0xba,
+	0xca, 0x90, 0x0b, 0xd6, 0x55, 0xc6, 0x5a, 0xef, 0xc0, 0xca, 0xdc, 0x4b, 0x9d, 0x93, 0x43, 0xd6,
+	0xd5, 0x1c, 0x52, 0xd9, 0x82, 0xf4, 0x71, 0xd5, 0x54, 0xf4, 0x18, 0x2e, 0x9f, 0x79, 0xa4, 0xef,
+	0xac, 0x8c, 0x65, 0xc4, 0xe4, 0x82, 0x6f, 0x94, 0xd6, 0x7e, 0x9d, 0x87, 0x52, 0xa7, 0x4b, 0x3c,
+	0xea, 0xd0, 0x09, 0xba, 0xa6, 0x34, 0x94, 0xd5, 0x69, 0xa4, 0x97, 0xb8, 0x95, 0x9c, 0xae, 0x88,
+	0x99, 0xeb, 0xb0, 0xd4, 0x76, 0x2d, 0x67, 0x28, 0x03, 0x6c, 0x65, 0x1a, 0xe9, 0x15, 0xce, 0x40,
+	0x18, 0xd6, 0xc0, 0x82, 0x8a, 0xee, 0xf2, 0x90, 0x1e, 0x3a, 0xf6, 0x63, 0x32, 0xe1, 0xf1, 0x55,
+	0x6d, 0xbc, 0x35, 0x8d, 0xf4, 0x15, 0x61, 0x71, 0x4e, 0x39, 0x21, 0xbc, 0xad, 0x8d, 0xb9, 0x98,
+	0xe6, 0x3d, 0xdf, 0xb3, 0x45, 0xca, 0xcb, 0x2b, 0x9a, 0x3d, 0x86, 0x35, 0xb0, 0xa0, 0xa2, 0x4f,
+	0xa0, 0x7c, 0xe8, 0xf4, 0x3d, 0x8b, 0x8e, 0x03, 0xd1, 0x22, 0x56, 0x1b, 0x6b, 0xd3, 0x48, 0xaf,
+	0x73, 0xd6, 0x30, 0xa6, 0x18, 0xaa, 0xcf, 0xa5, 0x02, 0xe8, 0x3e, 0xe4, 0x9f, 0x10, 0x6a, 0xc9,
+	0x40, 0x79, 0xcb, 0x8c, 0x6f, 0x6d, 0x32, 0xec, 0xfc, 0x8c, 0xe3, 0x12, 0x6a, 0x19, 0x98, 0x0b,
+	0xa0, 0x8f, 0xa1, 0xf8, 0xc8, 0x09, 0xa9, 0x1f, 0x4c, 0xb4, 0xa2, 0xac, 0x89, 0xb1, 0xec, 0x63,
+	0x32, 0x69, 0x5c, 0x9e, 0x46, 0xfa, 0x32, 0x17, 0x1a, 0x08, 0x2e, 0x03, 0xc7, 0xfc, 0xe8, 0x3e,
+	0xd4, 0x78, 0xbd, 0x09, 0xdc, 0xf6, 0x8b, 0x91, 0x13, 0xf0, 0x0c, 0xc6, 0xa6, 0x83, 0xf8, 0x86,
+	0xc9, 0x39, 0xe7, 0xd8, 0xd0, 0x5d, 0x59, 0xa8, 0x02, 0xf7, 0x90, 0x78, 0x94, 0xfb, 0xf2, 0x39,
+	0x52, 0x2a, 0x0f, 0x1b, 0xc5, 0x92, 0x9b, 0xbc, 0x91, 0x0b, 0xfc, 0x29, 0x03, 0x15, 0xe5, 0x42,
+	0x32, 0x27, 0xcb, 0x07, 0x64, 0x1a, 0xaa, 0xea, 0x5b, 0x5d, 0x83, 0xf2, 0x9e, 0x4f, 0x1b, 0xa4,
+	0xe7, 0x07, 0x42, 0x57, 0x0e, 0xa7, 0x08, 0x56, 0x2b, 0xf6, 0x7c, 0xba, 0xdd, 0xa3, 0x24, 0xe0,
+	0x6f, 0x9f, 0xc3, 0x09, 0x8c, 0x34, 0x28, 0x62, 0x72, 0xea, 0x9f, 0x90, 0xae, 0x98, 0xaa, 0x70,
+	0x0c, 0x22, 0x03, 0xaa, 0x62, 0x89, 0x89, 0x15, 0xfa, 0x9e, 0x68, 0xff, 0xf1, 0x0c, 0xce, 0xf8,
+	0x39, 0x54, 0x1e, 0x93, 0x09, 0xf6, 0xa9, 0x98, 0xcb, 0x6b, 0xa9, 0xab, 0x72, 0xe7, 0x9c, 0x39,
+	0x74, 0xf6, 0x9c, 0x43, 0xb3, 0x51, 0x2d, 0xa4, 0x96, 0x3b, 0x92, 0xe7, 0x4a, 0x11, 0x8c, 0x9a,
+	0xfa, 0x55, 0x5e, 0xc8, 0x26, 0x08, 0xe3, 0x2f, 0x19, 0x58, 0x66, 0x3b, 0x93, 0x53, 0xdf, 0xfe,
+	0x2e, 0x7b, 0x5f, 0x85, 0x82, 0xbc, 0x96, 0x28, 0xaf, 0x12, 0x9a, 0x3d, 0x53, 0x7e, 0xfe, 0x4c,
+	0x57, 0xa1, 0xc0, 0x8e, 0x40, 0x02, 0xe1, 0xe8, 0x58, 0x42, 0xb3, 0x67, 0x2d, 0xcc, 0x9f, 0xf5,
+	0x27, 0x00, 0xfb, 0x9d, 0x56, 0x53, 0x76, 0xad, 0x57, 0xa1, 0xd0, 0x09, 0xc3, 0x31, 0x09, 0xe4,
+	0x59, 0x25, 0xc4, 0x1e, 0xa9, 0x39, 0x74, 0x88, 0x47, 0xe3, 0x62, 0x89, 0x13, 0x98, 0xef, 0x6b,
+	0xfb, 0x23, 0x12, 0x6a, 0x39, 0x5e, 0x6b, 0x25, 0x64, 0x7c, 0x06, 0xd5, 0x5d, 0xbf, 0xef, 0x78,
+	0x98, 0x7c, 0x31, 0x66, 0xdd, 0xcf, 0x3a, 0x54, 0xb6, 0x6d, 0x9b, 0x84, 0xe1, 0x91, 0x7f, 0x42,
+	0xe4, 0x07, 0x15, 0xac, 0xa2, 0xd8, 0x73, 0x77, 0x5a, 0x82, 0x2a, 0x36, 0x89, 0x41, 0xe3, 0x05,
+	0x9b, 0xa3, 0xc2, 0xb8, 0xd9, 0x51, 0x15, 0x2c, 0x25, 0xa2, 0x71, 0xbc, 0x08, 0x0f, 0x8b, 0x41,
+	0x74, 0x3d, 0xcd, 0x56, 0xdc, 0x9c, 0x95, 0xad, 0x72, 0x12, 0x8c, 0x38, 0x21, 0xb1, 0x5b, 0xec,
+	0x04, 0xfe, 0x78, 0x14, 0x37, 0x2c, 0x12, 0x32, 0xfe, 0x93, 0x81, 0xd2, 0x76, 0x40, 0x9d, 0x9e,
+	0x65, 0x53, 0xf4, 0x43, 0x25, 0xdb, 0x99, 0xdf, 0x46, 0xfa, 0x07, 0xca, 0xa7, 0x2d, 0x7f, 0x44,
+	0x3c, 0xf6, 0x85, 0xc9, 0x72, 0x3c, 0x12, 0x84, 0x77, 0xfa, 0xfe, 0xed, 0xae, 0xd3, 0x27, 0x21,
+	0x35, 0x5b, 0xfc, 0x0f, 0x7f, 0x76, 0x04, 0xf9, 0x23, 0xab, 0x1f, 0xf7, 0x55, 0x7c, 0xcd, 0x3e,
+	0x34, 0xf0, 0x49, 0x5d, 0x98, 0x8f, 0x8d, 0x76, 0xf1, 0x76, 0xa6, 0xc0, 0xf3, 0xf0, 0xc4, 0x92,
+	0x89, 0x5d, 0xb4, 0x19, 0x10, 0x8b, 0xa6, 0x21, 0x21, 0x41, 0xf6, 0x46, 0x2d, 0x8b, 0x5a, 0x87,
+	0xce, 0x2f, 0x44, 0xaa, 0xcb, 0xe1, 0x04, 0x66, 0x5d, 0xac, 0xa2, 0xec, 0x8d, 0x62, 0xfd, 0x9f,
+	0x19, 0x28, 0x1e, 0x04, 0x3e, 0xff, 0xb8, 0xb6, 0xf8, 0xe7, 0x83, 0x07, 0x50, 0xdd, 0x0f, 0xec,
+	0x01, 0x09, 0x69, 0x60, 0x51, 0x3f, 0x90, 0x05, 0xe0, 0xea, 0x34, 0xd2, 0x11, 0x4f, 0x7c, 0xbe,
+	0x42, 0x34, 0xf0, 0x0c, 0x2f, 0xba, 0x99, 0x0e, 0xcd, 0xa2, 0xd9, 0x4a, 0xf3, 0x65, 0x3c, 0x2a,
+	0xa7, 0x83, 0xb1, 0x09, 0x25, 0x4c, 0xfa, 0x4e, 0x48, 0x83, 0x89, 0x96, 0x9f, 0xfb, 0xda, 0x16,
+	0x48, 0x82, 0x81, 0x13, 0x1e, 0xe3, 0x3a, 0x54, 0x3a, 0x94, 0x04, 0xfb, 0xbc, 0xa7, 0x08, 0xd9,
+	0xb3, 0x1f, 0x04, 0xa4, 0xe7, 0xbc, 0x88, 0x1d, 0x5e, 0x40, 0xc6, 0x6f, 0xb3, 0x4a, 0xdd, 0xc6,
+	0xc4, 0xf6, 0x83, 0xee, 0x99, 0x20, 0xd6, 0xd2, 0x6e, 0x4f, 0xba, 0xab, 0xd2, 0xff, 0xc6, 0x8f,
+	0x28, 0x43, 0x38, 0x81, 0xd1, 0x43, 0x28, 0x08, 0x8f, 0xd0, 0xf2, 0xdf, 0xc9, 0x8f, 0xa4, 0x74,
+	0x92, 0x42, 0xc2, 0x01, 0xe9, 0xf2, 0xf7, 0x2e, 0xe1, 0x14, 0xc1, 0xde, 0xf3, 0x90, 0x5a, 0x01,
+	0xe5, 0x01, 0x9f, 0xc3, 0x02, 0x60, 0xef, 0xde, 0xf6, 0xba, 0xbc, 0x23, 0xcb, 0xb1, 0x59, 0x98,
+	0xf3, 0xb5, 0x83, 0xc0, 0x0f, 0x44, 0x3f, 0x85, 0x05, 0xc0, 0xf8, 0x76, 0xfd, 0x3e, 0xff, 0x0e,
+	0x50, 0xc6, 0x6c, 0x69, 0xfc, 0x3e, 0x0b, 0xc0, 0xdb, 0x22, 0xf1, 0x5d, 0x63, 0xde, 0x14, 0x57,
+	0x64, 0x3f, 0x1d, 0xbb, 0x0f, 0x07, 0x98, 0x19, 0x30, 0x39, 0x75, 0x94, 0x41, 0x21, 0x81, 0x19,
+	0x2d, 0x09, 0x4b, 0x31, 0xb6, 0x24, 0x30, 0xd2, 0x12, 0x8f, 0x93, 0x79, 0x3d, 0x06, 0x17, 0xbe,
+	0x96, 0x01, 0xc5, 0xfd, 0x31, 0xb5, 0x7d, 0x97, 0xf0, 0x8b, 0xd5, 0xb6, 0x4a, 0xa6, 0x84, 0x71,
+	0x4c, 0x48, 0xaf, 0x5e, 0x56, 0xaf, 0xbe, 0x39, 0xd3, 0x72, 0x03, 0x0f, 0xc9, 0x55, 0x73, 0xce,
+	0x15, 0xd4, 0xb6, 0x9a, 0x9b, 0xa6, 0x45, 0x46, 0x43, 0x7f, 0xc2, 0x3f, 0x9f, 0xfe, 0xdf, 0x34,
+	0xa9, 0x69, 0xbe, 0xcc, 0x02, 0x6c, 0x8f, 0xbb, 0x0e, 0x4d, 0xd2, 0xce, 0x21, 0xf9, 0x82, 0xdb,
+	0x26, 0x8f, 0xd9, 0x72, 0xb6, 0xa2, 0x65, 0xe7, 0x2b, 0x5a, 0x7d, 0x2e, 0x75, 0x97, 0x67, 0xf3,
+	0xb5, 0xfc, 0x3e, 0x2b, 0x4c, 0x24, 0x21, 0x61, 0x58, 0xf1, 0xb9, 0x5c, 0x5a, 0x28, 0x81, 0x59,
+	0x05, 0x92, 0xc5, 0xe8, 0x91, 0x15, 0x0e, 0x64, 0x2d, 0x54, 0x51, 0xaa, 0x71, 0x8a, 0xaf, 0x35,
+	0xce, 0x4c, 0xc8, 0x20, 0xc8, 0x1f, 0x04, 0xe4, 0x94, 0x5b, 0xac, 0x8a, 0xf9, 0x9a, 0xe1, 0xf8,
+	0x46, 0x20, 0x70, 0x6c, 0x6d, 0xfc, 0x31, 0x03, 0x55, 0x6e, 0x92, 0x38, 0x03, 0x25, 0xef, 0x26,
+	0xcc, 0x22, 0x80, 0x99, 0xab, 0x67, 0x2f, 0xbc, 0x7a, 0xee, 0xc2, 0xab, 0xe7, 0xe7, 0xae, 0xce,
+	0x76, 0x71, 0x3c, 0x3b, 0xae, 0x0c, 0x02, 0x60, 0xd8, 0xa7, 0x1e, 0x75, 0x86, 0xb1, 0xcf, 0x70,
+	0xc0, 0xa0, 0x50, 0x3b, 0xf4, 0xac, 0x51, 0x38, 0xf0, 0x29, 0xfb, 0x00, 0x47, 0x02, 0xb6, 0xe3,
+	0x43, 0x3f, 0x70, 0x2d, 0x1a, 0x67, 0x49, 0x01, 0xa9, 0xc5, 0x28, 0x3b, 0x5b, 0x8c, 0xae, 0x41,
+	0xb9, 0xed, 0xd9, 0xc1, 0x64, 0x44, 0x89, 0x38, 0x66, 0x09, 0xa7, 0x08, 0x66, 0x98, 0x43, 0x6b,
+	0x48, 0x65, 0xe7, 0xc4, 0xd7, 0xc6, 0x7b, 0xb0, 0x1c, 0xef, 0xda, 0x1c, 0x8c, 0xbd, 0x13, 0xc6,
+	0xc4, 0xea, 0x97, 0xec, 0x27, 0xf9, 0xda, 0x70, 0x61, 0xb9, 0x39, 0x1c, 0x87, 0x94, 0x04, 0x4f,
+	0x88, 0x7b, 0x4c, 0x82, 0x33, 0xd1, 0xc6, 0x6c, 0x60, 0xf5, 0xe8, 0x76, 0xb7, 0x1b, 0xc4, 0x76,
+	0x8b, 0x61, 0xde, 0x4d, 0x1e, 0x34, 0x39, 0x49, 0x18, 0x2e, 0x06, 0xd9, 0xfd, 0x76, 0xf9, 0x4d,
+	0xf9, 0x89, 0x4a, 0x58, 0x42, 0xc6, 0x03, 0xa8, 0xcd, 0x6c, 0x17, 0xa2, 0x0d, 0x28, 0xca, 0xa5,
+	0x96, 0xe1, 0x01, 0x50, 0x33, 0x67, 0x38, 0x70, 0x4c, 0x36, 0xde, 0x85, 0xca, 0x67, 0x7e, 0xda,
+	0xfd, 0x20, 0xc8, 0xf3, 0x9d, 0xe5, 0xa7, 0x16, 0xb6, 0x36, 0x8e, 0x12, 0xf5, 0x4d, 0xdf, 0x75,
+	0x2d, 0x8f, 0x3d, 0x61, 0x76, 0x7f, 0xc4, 0x79, 0x6a, 0x6c, 0x70, 0x13, 0xc4, 0xfd, 0x11, 0xce,
+	0xee, 0x8f, 0x58, 0xf4, 0xc4, 0xdd, 0x62, 0x19, 0xb3, 0x25, 0x7b, 0xbe, 0x67, 0xbc, 0x68, 0xf3,
+	0x99, 0x09, 0x0b, 0xc0, 0xb8, 0x9e, 0xd8, 0x08, 0x93, 0x70, 0x3c, 0xa4, 0xa9, 0xc3, 0x66, 0x14,
+	0x87, 0x35, 0x3e, 0x81, 0x15, 0xc9, 0x16, 0x9b, 0x1d, 0xdd, 0x80, 0x22, 0x0b, 0x54, 0x87, 0xc4,
+	0x97, 0x5b, 0x31, 0x67, 0xcf, 0x87, 0x63, 0xfa, 0x07, 0x77, 0x93, 0x40, 0x41, 0x15, 0x28, 0x3e,
+	0xdd, 0x7b, 0xbc, 0xb7, 0xff, 0xf9, 0xde, 0xea, 0x25, 0xb4, 0x0c, 0xe5, 0xc3, 0xa7, 0xcd, 0x66,
+	0xbb, 0xdd, 0x6a, 0xb7, 0x56, 0x33, 0x08, 0xa0, 0xf0, 0x70, 0xbb, 0xb3, 0xdb, 0x6e, 0xad, 0x66,
+	0x3f, 0xf8, 0x08, 0xca, 0xc9, 0x85, 0x50, 0x11, 0x72, 0x07, 0x4f, 0x8f, 0x56, 0x2f, 0x31, 0x8e,
+	0x26, 0x6e, 0x6f, 0x1f, 0xb5, 0x05, 0xf7, 0xd3, 0x83, 0x16, 0x5b, 0x67, 0xd9, 0xba, 0xd5, 0xde,
+	0x6d, 0x1f, 0xb5, 0x57, 0x73, 0x5b, 0x7f, 0x28, 0xc0, 0xd2, 0x11, 0xfb, 0x1f, 0x45, 0xa4, 0xc3,
+	0xb2, 0xa8, 0xe4, 0x24, 0x10, 0x79, 0xb4, 0x20, 0x86, 0xf5, 0xba, 0xfc, 0x8b, 0xde, 0x61, 0x53,
+	0x90, 0xeb, 0x3a, 0xf4, 0x7c, 0x72, 0x1d, 0x4a, 0x3b, 0xe4, 0x02, 0xda, 0xfb, 0x00, 0x9d, 0x58,
+	0x6f, 0x88, 0xaa, 0xa6, 0xd2, 0x26, 0xc4, 0x3c, 0x9b, 0x19, 0xb4, 0x01, 0xab, 0xf1, 0x09, 0x92,
+	0x80, 0x4c, 0x1b, 0xca, 0x7a, 0xba, 0x44, 0x37, 0xa1, 0xd6, 0x49, 0xb9, 0x1c, 0x32, 0xaf, 0x33,
+	0x65, 0xdd, 0xcc, 0xa0, 0xef, 0xc3, 0x8a, 0x9c, 0xcc, 0x5e, 0xa3, 0xf5, 0x3d, 0xa8, 0xec, 0x10,
+	0xfa, 0x1a, 0xa6, 0x5b, 0x70, 0x99, 0x8f, 0x3d, 0x44, 0x1d, 0xd2, 0xaa, 0xa6, 0x32, 0x0d, 0xa9,
+	0xdc, 0x26, 0x5c, 0x16, 0x73, 0x93, 0xca, 0x5d, 0x33, 0x67, 0x26, 0x18, 0x95, 0xff, 0x06, 0x2c,
+	0xef, 0x10, 0xaa, 0x4c, 0x0d, 0x15, 0x33, 0x05, 0xea, 0x2a, 0xc0, 0xbe, 0x45, 0xf0, 0x19, 0x00,
+	0x2d, 0x9b, 0xea, 0x2c, 0x50, 0x2f, 0x99, 0x71, 0x3b, 0x7f, 0x03, 0xca, 0xcc, 0x28, 0x3c, 0x25,
+	0xa2, 0x65, 0x53, 0x4d, 0x8d, 0xf5, 0x8a, 0x99, 0x16, 0x8f, 0xcd, 0x0c, 0xba, 0x21, 0x1e, 0x88,
+	0x77, 0x20, 0xf3, 0xc6, 0xac, 0x98, 0x69, 0x77, 0xb2, 0x99, 0x41, 0x26, 0xac, 0x30, 0x6a, 0x5a,
+	0x96, 0xcf, 0xf2, 0xa7, 0x34, 0x6e, 0xfe, 0x8a, 0xc8, 0x5c, 0x5c, 0x01, 0x52, 0xb5, 0xcd, 0xa8,
+	0x46, 0xb7, 0x60, 0x55, 0x30, 0xa6, 0xe2, 0x48, 0xd5, 0x35, 0xa3, 0x18, 0xdd, 0x85, 0xea, 0xa1,
+	0x75, 0x4a, 0x92, 0x00, 0x5b, 0x31, 0x67, 0x13, 0x6b, 0xbd, 0x66, 0xce, 0xe4, 0xbc, 0xcd, 0x0c,
+	0xfa, 0x10, 0x56, 0x30, 0x61, 0x9f, 0x02, 0x52, 0xa9, 0x39, 0xa6, 0xfa, 0xbc, 0x96, 0x8d, 0xcc,
+	0xd6, 0xdf, 0x33, 0x50, 0x94, 0xc1, 0x85, 0x6e, 0x8a, 0xc4, 0x13, 0x83, 0x55, 0x53, 0x49, 0x43,
+	0xf5, 0xb9, 0x74, 0x85, 0x6e, 0x42, 0x79, 0xbb, 0xdb, 0x8d, 0x93, 0xe9, 0x2c, 0xf1, 0x0c, 0xb3,
+	0x09, 0xb0, 0x43, 0x68, 0x9c, 0x0a, 0x57, 0x66, 0xa9, 0x61, 0x7d, 0x1e, 0x81, 0x36, 0x60, 0x69,
+	0x7b, 0x34, 0x1a, 0x4e, 0xd0, 0x7c, 0x1e, 0x49, 0x35, 0x8b, 0x14, 0xd5, 0xf8, 0xf4, 0x1f, 0x2f,
+	0xd7, 0x32, 0x5f, 0xbd, 0x5c, 0xcb, 0x7c, 0xfd, 0x72, 0x2d, 0xf3, 0xaf, 0x97, 0x6b, 0x99, 0x2f,
+	0xbf, 0x59, 0xbb, 0xf4, 0xd5, 0x37, 0x6b, 0x97, 0xbe, 0xfe, 0x66, 0xed, 0xd2, 0x4f, 0x75, 0xa5,
+	0x3d, 0x26, 0xe3, 0x9e, 0x1f, 0x38, 0xd6, 0x1d, 0xfe, 0x0b, 0x03, 0xf1, 0xef, 0xf1, 0x71, 0x81,
+	0xff, 0x74, 0xe0, 0xde, 0x7f, 0x07, 0x00, 0x0f, 0xb4, 0x02, 0x21, 0x78, 0x20, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ThrapClient is the client API for Thrap service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ThrapClient interface {
+	RegisterStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
+	CommitStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
+	GetStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error)
+	IterStacks(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterStacksClient, error)
+	RegisterIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
+	IterIdentities(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterIdentitiesClient, error)
+	ConfirmIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
+	GetIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error)
+	RotateIdentityKey(ctx context.Context, in *KeyRotation, opts ...grpc.CallOption) (*Identity, error)
+	RevokeIdentityKey(ctx context.Context, in *KeyRevocation, opts ...grpc.CallOption) (*Identity, error)
+	GetOIDCConfig(ctx context.Context, in *OIDCConfig, opts ...grpc.CallOption) (*OIDCConfig, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*Session, error)
+	IterAudit(ctx context.Context, in *AuditOptions, opts ...grpc.CallOption) (Thrap_IterAuditClient, error)
+	IterBuilds(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterBuildsClient, error)
+	IterDeployments(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterDeploymentsClient, error)
+	CreateBuild(ctx context.Context, in *StackBuild, opts ...grpc.CallOption) (*StackBuild, error)
+	CreateDeployment(ctx context.Context, in *Deployment, opts ...grpc.CallOption) (*Deployment, error)
+	SaveSnapshot(ctx context.Context, in *SnapshotHeader, opts ...grpc.CallOption) (Thrap_SaveSnapshotClient, error)
+	RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (Thrap_RestoreSnapshotClient, error)
+}
+
+type thrapClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewThrapClient(cc *grpc.ClientConn) ThrapClient {
+	return &thrapClient{cc}
+}
+
+func (c *thrapClient) RegisterStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
+	out := new(Stack)
+	err := c.cc.Invoke(ctx, "/Thrap/RegisterStack", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Secrets != nil {
-		l = m.Secrets.Size()
-		n += 1 + l + sovThrap(uint64(l))
+	return out, nil
+}
+
+func (c *thrapClient) CommitStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
+	out := new(Stack)
+	err := c.cc.Invoke(ctx, "/Thrap/CommitStack", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Ports) > 0 {
-		for k, v := range m.Ports {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + sovThrap(uint64(v))
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
+	return out, nil
+}
+
+func (c *thrapClient) GetStack(ctx context.Context, in *Stack, opts ...grpc.CallOption) (*Stack, error) {
+	out := new(Stack)
+	err := c.cc.Invoke(ctx, "/Thrap/GetStack", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.External {
-		n += 2
+	return out, nil
+}
+
+func (c *thrapClient) IterStacks(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterStacksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[0], "/Thrap/IterStacks", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Head {
-		n += 2
+	x := &thrapIterStacksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.Env != nil {
-		l = m.Env.Size()
-		n += 1 + l + sovThrap(uint64(l))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if len(m.Config) > 0 {
-		for k, v := range m.Config {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
+	return x, nil
+}
+
+type Thrap_IterStacksClient interface {
+	Recv() (*Stack, error)
+	grpc.ClientStream
+}
+
+type thrapIterStacksClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapIterStacksClient) Recv() (*Stack, error) {
+	m := new(Stack)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if len(m.Volumes) > 0 {
-		for _, e := range m.Volumes {
-			l = e.Size()
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return m, nil
+}
+
+func (c *thrapClient) RegisterIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/Thrap/RegisterIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Cmd)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return out, nil
+}
+
+func (c *thrapClient) IterIdentities(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterIdentitiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[1], "/Thrap/IterIdentities", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Args) > 0 {
-		for _, s := range m.Args {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	x := &thrapIterIdentitiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.HealthChecks) > 0 {
-		for _, e := range m.HealthChecks {
-			l = e.Size()
-			n += 2 + l + sovThrap(uint64(l))
-		}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return n
+	return x, nil
 }
 
-func (m *PackManifest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+type Thrap_IterIdentitiesClient interface {
+	Recv() (*Identity, error)
+	grpc.ClientStream
+}
+
+type thrapIterIdentitiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapIterIdentitiesClient) Recv() (*Identity, error) {
+	m := new(Identity)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if len(m.Versions) > 0 {
-		for _, s := range m.Versions {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return m, nil
+}
+
+func (c *thrapClient) ConfirmIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/Thrap/ConfirmIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.DefaultVersion)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return out, nil
+}
+
+func (c *thrapClient) GetIdentity(ctx context.Context, in *Identity, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/Thrap/GetIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return out, nil
+}
+
+func (c *thrapClient) RotateIdentityKey(ctx context.Context, in *KeyRotation, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/Thrap/RotateIdentityKey", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Files) > 0 {
-		for _, s := range m.Files {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return out, nil
+}
+
+func (c *thrapClient) RevokeIdentityKey(ctx context.Context, in *KeyRevocation, opts ...grpc.CallOption) (*Identity, error) {
+	out := new(Identity)
+	err := c.cc.Invoke(ctx, "/Thrap/RevokeIdentityKey", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Language) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+func (c *thrapClient) GetOIDCConfig(ctx context.Context, in *OIDCConfig, opts ...grpc.CallOption) (*OIDCConfig, error) {
+	out := new(OIDCConfig)
+	err := c.cc.Invoke(ctx, "/Thrap/GetOIDCConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Versions) > 0 {
-		for _, s := range m.Versions {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return out, nil
+}
+
+func (c *thrapClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/Thrap/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.DefaultVersion)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return out, nil
+}
+
+func (c *thrapClient) IterAudit(ctx context.Context, in *AuditOptions, opts ...grpc.CallOption) (Thrap_IterAuditClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[2], "/Thrap/IterAudit", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.FileExts) > 0 {
-		for _, s := range m.FileExts {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	x := &thrapIterAuditClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.IgnoreFiles) > 0 {
-		for _, s := range m.IgnoreFiles {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if len(m.DevImages) > 0 {
-		for _, s := range m.DevImages {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return x, nil
+}
+
+type Thrap_IterAuditClient interface {
+	Recv() (*AuditEntry, error)
+	grpc.ClientStream
+}
+
+type thrapIterAuditClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapIterAuditClient) Recv() (*AuditEntry, error) {
+	m := new(AuditEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if len(m.PubImages) > 0 {
-		for _, s := range m.PubImages {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	return m, nil
+}
+
+func (c *thrapClient) IterBuilds(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterBuildsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[3], "/Thrap/IterBuilds", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.ScaffoldFiles) > 0 {
-		for _, s := range m.ScaffoldFiles {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
+	x := &thrapIterBuildsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *Stack) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+type Thrap_IterBuildsClient interface {
+	Recv() (*StackBuild, error)
+	grpc.ClientStream
+}
+
+type thrapIterBuildsClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapIterBuildsClient) Recv() (*StackBuild, error) {
+	m := new(StackBuild)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	return m, nil
+}
+
+func (c *thrapClient) IterDeployments(ctx context.Context, in *IterOptions, opts ...grpc.CallOption) (Thrap_IterDeploymentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[4], "/Thrap/IterDeployments", opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
+	x := &thrapIterDeploymentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.Components) > 0 {
-		for k, v := range m.Components {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovThrap(uint64(l))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Thrap_IterDeploymentsClient interface {
+	Recv() (*Deployment, error)
+	grpc.ClientStream
+}
+
+type thrapIterDeploymentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapIterDeploymentsClient) Recv() (*Deployment, error) {
+	m := new(Deployment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *thrapClient) CreateBuild(ctx context.Context, in *StackBuild, opts ...grpc.CallOption) (*StackBuild, error) {
+	out := new(StackBuild)
+	err := c.cc.Invoke(ctx, "/Thrap/CreateBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thrapClient) CreateDeployment(ctx context.Context, in *Deployment, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, "/Thrap/CreateDeployment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thrapClient) SaveSnapshot(ctx context.Context, in *SnapshotHeader, opts ...grpc.CallOption) (Thrap_SaveSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[5], "/Thrap/SaveSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &thrapSaveSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Thrap_SaveSnapshotClient interface {
+	Recv() (*SnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type thrapSaveSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapSaveSnapshotClient) Recv() (*SnapshotChunk, error) {
+	m := new(SnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *thrapClient) RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (Thrap_RestoreSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Thrap_serviceDesc.Streams[6], "/Thrap/RestoreSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &thrapRestoreSnapshotClient{stream}
+	return x, nil
+}
+
+type Thrap_RestoreSnapshotClient interface {
+	Send(*SnapshotChunk) error
+	CloseAndRecv() (*SnapshotHeader, error)
+	grpc.ClientStream
+}
+
+type thrapRestoreSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *thrapRestoreSnapshotClient) Send(m *SnapshotChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *thrapRestoreSnapshotClient) CloseAndRecv() (*SnapshotHeader, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SnapshotHeader)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ThrapServer is the server API for Thrap service.
+type ThrapServer interface {
+	RegisterStack(context.Context, *Stack) (*Stack, error)
+	CommitStack(context.Context, *Stack) (*Stack, error)
+	GetStack(context.Context, *Stack) (*Stack, error)
+	IterStacks(*IterOptions, Thrap_IterStacksServer) error
+	RegisterIdentity(context.Context, *Identity) (*Identity, error)
+	IterIdentities(*IterOptions, Thrap_IterIdentitiesServer) error
+	ConfirmIdentity(context.Context, *Identity) (*Identity, error)
+	GetIdentity(context.Context, *Identity) (*Identity, error)
+	RotateIdentityKey(context.Context, *KeyRotation) (*Identity, error)
+	RevokeIdentityKey(context.Context, *KeyRevocation) (*Identity, error)
+	GetOIDCConfig(context.Context, *OIDCConfig) (*OIDCConfig, error)
+	Login(context.Context, *LoginRequest) (*Session, error)
+	IterAudit(*AuditOptions, Thrap_IterAuditServer) error
+	IterBuilds(*IterOptions, Thrap_IterBuildsServer) error
+	IterDeployments(*IterOptions, Thrap_IterDeploymentsServer) error
+	CreateBuild(context.Context, *StackBuild) (*StackBuild, error)
+	CreateDeployment(context.Context, *Deployment) (*Deployment, error)
+	SaveSnapshot(*SnapshotHeader, Thrap_SaveSnapshotServer) error
+	RestoreSnapshot(Thrap_RestoreSnapshotServer) error
+}
+
+// UnimplementedThrapServer can be embedded to have forward compatible implementations.
+type UnimplementedThrapServer struct {
+}
+
+func (*UnimplementedThrapServer) RegisterStack(ctx context.Context, req *Stack) (*Stack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterStack not implemented")
+}
+func (*UnimplementedThrapServer) CommitStack(ctx context.Context, req *Stack) (*Stack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitStack not implemented")
+}
+func (*UnimplementedThrapServer) GetStack(ctx context.Context, req *Stack) (*Stack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStack not implemented")
+}
+func (*UnimplementedThrapServer) IterStacks(req *IterOptions, srv Thrap_IterStacksServer) error {
+	return status.Errorf(codes.Unimplemented, "method IterStacks not implemented")
+}
+func (*UnimplementedThrapServer) RegisterIdentity(ctx context.Context, req *Identity) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterIdentity not implemented")
+}
+func (*UnimplementedThrapServer) IterIdentities(req *IterOptions, srv Thrap_IterIdentitiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method IterIdentities not implemented")
+}
+func (*UnimplementedThrapServer) ConfirmIdentity(ctx context.Context, req *Identity) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmIdentity not implemented")
+}
+func (*UnimplementedThrapServer) GetIdentity(ctx context.Context, req *Identity) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
+}
+func (*UnimplementedThrapServer) RotateIdentityKey(ctx context.Context, req *KeyRotation) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateIdentityKey not implemented")
+}
+func (*UnimplementedThrapServer) RevokeIdentityKey(ctx context.Context, req *KeyRevocation) (*Identity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeIdentityKey not implemented")
+}
+func (*UnimplementedThrapServer) GetOIDCConfig(ctx context.Context, req *OIDCConfig) (*OIDCConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOIDCConfig not implemented")
+}
+func (*UnimplementedThrapServer) Login(ctx context.Context, req *LoginRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedThrapServer) IterAudit(req *AuditOptions, srv Thrap_IterAuditServer) error {
+	return status.Errorf(codes.Unimplemented, "method IterAudit not implemented")
+}
+func (*UnimplementedThrapServer) IterBuilds(req *IterOptions, srv Thrap_IterBuildsServer) error {
+	return status.Errorf(codes.Unimplemented, "method IterBuilds not implemented")
+}
+func (*UnimplementedThrapServer) IterDeployments(req *IterOptions, srv Thrap_IterDeploymentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method IterDeployments not implemented")
+}
+func (*UnimplementedThrapServer) CreateBuild(ctx context.Context, req *StackBuild) (*StackBuild, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBuild not implemented")
+}
+func (*UnimplementedThrapServer) CreateDeployment(ctx context.Context, req *Deployment) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeployment not implemented")
+}
+func (*UnimplementedThrapServer) SaveSnapshot(req *SnapshotHeader, srv Thrap_SaveSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method SaveSnapshot not implemented")
+}
+func (*UnimplementedThrapServer) RestoreSnapshot(srv Thrap_RestoreSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreSnapshot not implemented")
+}
+
+func RegisterThrapServer(s *grpc.Server, srv ThrapServer) {
+	s.RegisterService(&_Thrap_serviceDesc, srv)
+}
+
+func _Thrap_RegisterStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Stack)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).RegisterStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/RegisterStack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).RegisterStack(ctx, req.(*Stack))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_CommitStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Stack)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).CommitStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/CommitStack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).CommitStack(ctx, req.(*Stack))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_GetStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Stack)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).GetStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/GetStack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).GetStack(ctx, req.(*Stack))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_IterStacks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).IterStacks(m, &thrapIterStacksServer{stream})
+}
+
+type Thrap_IterStacksServer interface {
+	Send(*Stack) error
+	grpc.ServerStream
+}
+
+type thrapIterStacksServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapIterStacksServer) Send(m *Stack) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_RegisterIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).RegisterIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/RegisterIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).RegisterIdentity(ctx, req.(*Identity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_IterIdentities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).IterIdentities(m, &thrapIterIdentitiesServer{stream})
+}
+
+type Thrap_IterIdentitiesServer interface {
+	Send(*Identity) error
+	grpc.ServerStream
+}
+
+type thrapIterIdentitiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapIterIdentitiesServer) Send(m *Identity) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_ConfirmIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).ConfirmIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/ConfirmIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).ConfirmIdentity(ctx, req.(*Identity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_GetIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).GetIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/GetIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).GetIdentity(ctx, req.(*Identity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_RotateIdentityKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRotation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).RotateIdentityKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/RotateIdentityKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).RotateIdentityKey(ctx, req.(*KeyRotation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_RevokeIdentityKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRevocation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).RevokeIdentityKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/RevokeIdentityKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).RevokeIdentityKey(ctx, req.(*KeyRevocation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_GetOIDCConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OIDCConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).GetOIDCConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/GetOIDCConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).GetOIDCConfig(ctx, req.(*OIDCConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_IterAudit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).IterAudit(m, &thrapIterAuditServer{stream})
+}
+
+type Thrap_IterAuditServer interface {
+	Send(*AuditEntry) error
+	grpc.ServerStream
+}
+
+type thrapIterAuditServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapIterAuditServer) Send(m *AuditEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_IterBuilds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).IterBuilds(m, &thrapIterBuildsServer{stream})
+}
+
+type Thrap_IterBuildsServer interface {
+	Send(*StackBuild) error
+	grpc.ServerStream
+}
+
+type thrapIterBuildsServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapIterBuildsServer) Send(m *StackBuild) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_IterDeployments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).IterDeployments(m, &thrapIterDeploymentsServer{stream})
+}
+
+type Thrap_IterDeploymentsServer interface {
+	Send(*Deployment) error
+	grpc.ServerStream
+}
+
+type thrapIterDeploymentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapIterDeploymentsServer) Send(m *Deployment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_CreateBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StackBuild)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).CreateBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/CreateBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).CreateBuild(ctx, req.(*StackBuild))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_CreateDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Deployment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThrapServer).CreateDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Thrap/CreateDeployment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThrapServer).CreateDeployment(ctx, req.(*Deployment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thrap_SaveSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotHeader)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThrapServer).SaveSnapshot(m, &thrapSaveSnapshotServer{stream})
+}
+
+type Thrap_SaveSnapshotServer interface {
+	Send(*SnapshotChunk) error
+	grpc.ServerStream
+}
+
+type thrapSaveSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapSaveSnapshotServer) Send(m *SnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Thrap_RestoreSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ThrapServer).RestoreSnapshot(&thrapRestoreSnapshotServer{stream})
+}
+
+type Thrap_RestoreSnapshotServer interface {
+	SendAndClose(*SnapshotHeader) error
+	Recv() (*SnapshotChunk, error)
+	grpc.ServerStream
+}
+
+type thrapRestoreSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *thrapRestoreSnapshotServer) SendAndClose(m *SnapshotHeader) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *thrapRestoreSnapshotServer) Recv() (*SnapshotChunk, error) {
+	m := new(SnapshotChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Thrap_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "Thrap",
+	HandlerType: (*ThrapServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterStack",
+			Handler:    _Thrap_RegisterStack_Handler,
+		},
+		{
+			MethodName: "CommitStack",
+			Handler:    _Thrap_CommitStack_Handler,
+		},
+		{
+			MethodName: "GetStack",
+			Handler:    _Thrap_GetStack_Handler,
+		},
+		{
+			MethodName: "RegisterIdentity",
+			Handler:    _Thrap_RegisterIdentity_Handler,
+		},
+		{
+			MethodName: "ConfirmIdentity",
+			Handler:    _Thrap_ConfirmIdentity_Handler,
+		},
+		{
+			MethodName: "GetIdentity",
+			Handler:    _Thrap_GetIdentity_Handler,
+		},
+		{
+			MethodName: "RotateIdentityKey",
+			Handler:    _Thrap_RotateIdentityKey_Handler,
+		},
+		{
+			MethodName: "RevokeIdentityKey",
+			Handler:    _Thrap_RevokeIdentityKey_Handler,
+		},
+		{
+			MethodName: "GetOIDCConfig",
+			Handler:    _Thrap_GetOIDCConfig_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Thrap_Login_Handler,
+		},
+		{
+			MethodName: "CreateBuild",
+			Handler:    _Thrap_CreateBuild_Handler,
+		},
+		{
+			MethodName: "CreateDeployment",
+			Handler:    _Thrap_CreateDeployment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IterStacks",
+			Handler:       _Thrap_IterStacks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IterIdentities",
+			Handler:       _Thrap_IterIdentities_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IterAudit",
+			Handler:       _Thrap_IterAudit_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IterBuilds",
+			Handler:       _Thrap_IterBuilds_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IterDeployments",
+			Handler:       _Thrap_IterDeployments_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SaveSnapshot",
+			Handler:       _Thrap_SaveSnapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreSnapshot",
+			Handler:       _Thrap_RestoreSnapshot_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "thrap.proto",
+}
+
+// ClusterClient is the client API for Cluster service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClusterClient interface {
+	JoinCluster(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*ClusterMember, error)
+	AddMember(ctx context.Context, in *ClusterMember, opts ...grpc.CallOption) (*ClusterMember, error)
+	GetMembers(ctx context.Context, in *ClusterMembers, opts ...grpc.CallOption) (*ClusterMembers, error)
+	Apply(ctx context.Context, in *ClusterCommand, opts ...grpc.CallOption) (*ClusterResult, error)
+}
+
+type clusterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClusterClient(cc *grpc.ClientConn) ClusterClient {
+	return &clusterClient{cc}
+}
+
+func (c *clusterClient) JoinCluster(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*ClusterMember, error) {
+	out := new(ClusterMember)
+	err := c.cc.Invoke(ctx, "/Cluster/JoinCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) AddMember(ctx context.Context, in *ClusterMember, opts ...grpc.CallOption) (*ClusterMember, error) {
+	out := new(ClusterMember)
+	err := c.cc.Invoke(ctx, "/Cluster/AddMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetMembers(ctx context.Context, in *ClusterMembers, opts ...grpc.CallOption) (*ClusterMembers, error) {
+	out := new(ClusterMembers)
+	err := c.cc.Invoke(ctx, "/Cluster/GetMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) Apply(ctx context.Context, in *ClusterCommand, opts ...grpc.CallOption) (*ClusterResult, error) {
+	out := new(ClusterResult)
+	err := c.cc.Invoke(ctx, "/Cluster/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServer is the server API for Cluster service.
+type ClusterServer interface {
+	JoinCluster(context.Context, *JoinRequest) (*ClusterMember, error)
+	AddMember(context.Context, *ClusterMember) (*ClusterMember, error)
+	GetMembers(context.Context, *ClusterMembers) (*ClusterMembers, error)
+	Apply(context.Context, *ClusterCommand) (*ClusterResult, error)
+}
+
+// UnimplementedClusterServer can be embedded to have forward compatible implementations.
+type UnimplementedClusterServer struct {
+}
+
+func (*UnimplementedClusterServer) JoinCluster(ctx context.Context, req *JoinRequest) (*ClusterMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinCluster not implemented")
+}
+func (*UnimplementedClusterServer) AddMember(ctx context.Context, req *ClusterMember) (*ClusterMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (*UnimplementedClusterServer) GetMembers(ctx context.Context, req *ClusterMembers) (*ClusterMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembers not implemented")
+}
+func (*UnimplementedClusterServer) Apply(ctx context.Context, req *ClusterCommand) (*ClusterResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
+
+func RegisterClusterServer(s *grpc.Server, srv ClusterServer) {
+	s.RegisterService(&_Cluster_serviceDesc, srv)
+}
+
+func _Cluster_JoinCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).JoinCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Cluster/JoinCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).JoinCluster(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Cluster/AddMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).AddMember(ctx, req.(*ClusterMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterMembers)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Cluster/GetMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetMembers(ctx, req.(*ClusterMembers))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Cluster/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).Apply(ctx, req.(*ClusterCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Cluster_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "Cluster",
+	HandlerType: (*ClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "JoinCluster",
+			Handler:    _Cluster_JoinCluster_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _Cluster_AddMember_Handler,
+		},
+		{
+			MethodName: "GetMembers",
+			Handler:    _Cluster_GetMembers_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Cluster_Apply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "thrap.proto",
+}
+
+func (m *Build) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Build) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Build) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Context) > 0 {
+		i -= len(m.Context)
+		copy(dAtA[i:], m.Context)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Context)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Dockerfile) > 0 {
+		i -= len(m.Dockerfile)
+		copy(dAtA[i:], m.Dockerfile)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Dockerfile)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Secrets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Secrets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Secrets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Template) > 0 {
+		i -= len(m.Template)
+		copy(dAtA[i:], m.Template)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Template)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Destination) > 0 {
+		i -= len(m.Destination)
+		copy(dAtA[i:], m.Destination)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Destination)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Volume) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Volume) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Volume) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Target)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Envionment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Envionment) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Envionment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Vars) > 0 {
+		keysForVars := make([]string, 0, len(m.Vars))
+		for k := range m.Vars {
+			keysForVars = append(keysForVars, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForVars)
+		for iNdEx := len(keysForVars) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Vars[string(keysForVars[iNdEx])]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(keysForVars[iNdEx])
+			copy(dAtA[i:], keysForVars[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForVars[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HealthCheck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HealthCheck) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HealthCheck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PortLabel) > 0 {
+		i -= len(m.PortLabel)
+		copy(dAtA[i:], m.PortLabel)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.PortLabel)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Interval != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Interval))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Timeout != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Protocol) > 0 {
+		i -= len(m.Protocol)
+		copy(dAtA[i:], m.Protocol)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Protocol)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Component) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Component) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Component) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DependsOn) > 0 {
+		for iNdEx := len(m.DependsOn) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DependsOn[iNdEx])
+			copy(dAtA[i:], m.DependsOn[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.DependsOn[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.HealthChecks) > 0 {
+		for iNdEx := len(m.HealthChecks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.HealthChecks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.Args) > 0 {
+		for iNdEx := len(m.Args) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Args[iNdEx])
+			copy(dAtA[i:], m.Args[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Args[iNdEx])))
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.Cmd) > 0 {
+		i -= len(m.Cmd)
+		copy(dAtA[i:], m.Cmd)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Cmd)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.Volumes) > 0 {
+		for iNdEx := len(m.Volumes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Volumes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.Config) > 0 {
+		keysForConfig := make([]string, 0, len(m.Config))
+		for k := range m.Config {
+			keysForConfig = append(keysForConfig, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForConfig)
+		for iNdEx := len(keysForConfig) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Config[string(keysForConfig[iNdEx])]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(keysForConfig[iNdEx])
+			copy(dAtA[i:], keysForConfig[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForConfig[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.Env != nil {
+		{
+			size, err := m.Env.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThrap(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Head {
+		i--
+		if m.Head {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.External {
+		i--
+		if m.External {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Ports) > 0 {
+		keysForPorts := make([]string, 0, len(m.Ports))
+		for k := range m.Ports {
+			keysForPorts = append(keysForPorts, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForPorts)
+		for iNdEx := len(keysForPorts) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Ports[string(keysForPorts[iNdEx])]
+			baseI := i
+			i = encodeVarintThrap(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(keysForPorts[iNdEx])
+			copy(dAtA[i:], keysForPorts[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForPorts[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Secrets != nil {
+		{
+			size, err := m.Secrets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThrap(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Build != nil {
+		{
+			size, err := m.Build.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThrap(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Language) > 0 {
+		i -= len(m.Language)
+		copy(dAtA[i:], m.Language)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Language)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PackManifest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PackManifest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PackManifest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Files) > 0 {
+		for iNdEx := len(m.Files) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Files[iNdEx])
+			copy(dAtA[i:], m.Files[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Files[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.DefaultVersion) > 0 {
+		i -= len(m.DefaultVersion)
+		copy(dAtA[i:], m.DefaultVersion)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.DefaultVersion)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Versions) > 0 {
+		for iNdEx := len(m.Versions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Versions[iNdEx])
+			copy(dAtA[i:], m.Versions[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Versions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Language) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Language) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Language) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ScaffoldFiles) > 0 {
+		for iNdEx := len(m.ScaffoldFiles) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ScaffoldFiles[iNdEx])
+			copy(dAtA[i:], m.ScaffoldFiles[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.ScaffoldFiles[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.PubImages) > 0 {
+		for iNdEx := len(m.PubImages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PubImages[iNdEx])
+			copy(dAtA[i:], m.PubImages[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.PubImages[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.DevImages) > 0 {
+		for iNdEx := len(m.DevImages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DevImages[iNdEx])
+			copy(dAtA[i:], m.DevImages[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.DevImages[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.IgnoreFiles) > 0 {
+		for iNdEx := len(m.IgnoreFiles) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IgnoreFiles[iNdEx])
+			copy(dAtA[i:], m.IgnoreFiles[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.IgnoreFiles[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.FileExts) > 0 {
+		for iNdEx := len(m.FileExts) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FileExts[iNdEx])
+			copy(dAtA[i:], m.FileExts[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.FileExts[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.DefaultVersion) > 0 {
+		i -= len(m.DefaultVersion)
+		copy(dAtA[i:], m.DefaultVersion)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.DefaultVersion)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Versions) > 0 {
+		for iNdEx := len(m.Versions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Versions[iNdEx])
+			copy(dAtA[i:], m.Versions[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Versions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Stack) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Stack) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Stack) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Packs) > 0 {
+		keysForPacks := make([]string, 0, len(m.Packs))
+		for k := range m.Packs {
+			keysForPacks = append(keysForPacks, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForPacks)
+		for iNdEx := len(keysForPacks) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Packs[string(keysForPacks[iNdEx])]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(keysForPacks[iNdEx])
+			copy(dAtA[i:], keysForPacks[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForPacks[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Versioning) > 0 {
+		i -= len(m.Versioning)
+		copy(dAtA[i:], m.Versioning)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Versioning)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Dependencies) > 0 {
+		keysForDependencies := make([]string, 0, len(m.Dependencies))
+		for k := range m.Dependencies {
+			keysForDependencies = append(keysForDependencies, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForDependencies)
+		for iNdEx := len(keysForDependencies) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Dependencies[string(keysForDependencies[iNdEx])]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintThrap(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(keysForDependencies[iNdEx])
+			copy(dAtA[i:], keysForDependencies[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForDependencies[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Components) > 0 {
+		keysForComponents := make([]string, 0, len(m.Components))
+		for k := range m.Components {
+			keysForComponents = append(keysForComponents, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForComponents)
+		for iNdEx := len(keysForComponents) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Components[string(keysForComponents[iNdEx])]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintThrap(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(keysForComponents[iNdEx])
+			copy(dAtA[i:], keysForComponents[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForComponents[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Identity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Identity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Identity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ConfirmSent) > 0 {
+		dAtA7 := make([]byte, len(m.ConfirmSent)*10)
+		var j6 int
+		for _, num1 := range m.ConfirmSent {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintThrap(dAtA, i, uint64(j6))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.ConfirmExpires != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.ConfirmExpires))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.History) > 0 {
+		for iNdEx := len(m.History) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.History[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Meta) > 0 {
+		keysForMeta := make([]string, 0, len(m.Meta))
+		for k := range m.Meta {
+			keysForMeta = append(keysForMeta, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForMeta)
+		for iNdEx := len(keysForMeta) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Meta[string(keysForMeta[iNdEx])]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(keysForMeta[iNdEx])
+			copy(dAtA[i:], keysForMeta[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForMeta[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Email) > 0 {
+		i -= len(m.Email)
+		copy(dAtA[i:], m.Email)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Email)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IdentityKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IdentityKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IdentityKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RevokeReason) > 0 {
+		i -= len(m.RevokeReason)
+		copy(dAtA[i:], m.RevokeReason)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.RevokeReason)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Revoked != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Revoked))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.NotAfter != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.NotAfter))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.NotBefore != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.NotBefore))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyRevocation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyRevocation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyRevocation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Signer) > 0 {
+		i -= len(m.Signer)
+		copy(dAtA[i:], m.Signer)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Signer)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OIDCConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OIDCConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OIDCConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Scopes) > 0 {
+		for iNdEx := len(m.Scopes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Scopes[iNdEx])
+			copy(dAtA[i:], m.Scopes[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Scopes[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Issuer) > 0 {
+		i -= len(m.Issuer)
+		copy(dAtA[i:], m.Issuer)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Issuer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoginRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoginRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoginRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IDToken) > 0 {
+		i -= len(m.IDToken)
+		copy(dAtA[i:], m.IDToken)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.IDToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AccessToken) > 0 {
+		i -= len(m.AccessToken)
+		copy(dAtA[i:], m.AccessToken)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.AccessToken)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Session) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Session) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Session) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Groups[iNdEx])
+			copy(dAtA[i:], m.Groups[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Groups[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Identity != nil {
+		{
+			size, err := m.Identity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThrap(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Expires != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Expires))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Artifact) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Artifact) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Artifact) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DataSize != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.DataSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Created != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Created))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Labels) > 0 {
+		keysForLabels := make([]string, 0, len(m.Labels))
+		for k := range m.Labels {
+			keysForLabels = append(keysForLabels, string(k))
+		}
+		github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+		for iNdEx := len(keysForLabels) - 1; iNdEx >= 0; iNdEx-- {
+			v := m.Labels[string(keysForLabels[iNdEx])]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintThrap(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(keysForLabels[iNdEx])
+			copy(dAtA[i:], keysForLabels[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(keysForLabels[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintThrap(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThrap(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Profile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Profile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Profile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Registry) > 0 {
+		i -= len(m.Registry)
+		copy(dAtA[i:], m.Registry)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Registry)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Secrets) > 0 {
+		i -= len(m.Secrets)
+		copy(dAtA[i:], m.Secrets)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Secrets)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Orchestrator) > 0 {
+		i -= len(m.Orchestrator)
+		copy(dAtA[i:], m.Orchestrator)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Orchestrator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IterOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IterOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IterOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ComponentRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ComponentRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ComponentRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Log) > 0 {
+		i -= len(m.Log)
+		copy(dAtA[i:], m.Log)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Log)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.End != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Start != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Published {
+		i--
+		if m.Published {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Digest) > 0 {
+		i -= len(m.Digest)
+		copy(dAtA[i:], m.Digest)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Digest)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Artifact) > 0 {
+		i -= len(m.Artifact)
+		copy(dAtA[i:], m.Artifact)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Artifact)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StackBuild) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StackBuild) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StackBuild) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Components) > 0 {
+		for iNdEx := len(m.Components) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Components[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Outcome != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Outcome))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.End != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Start != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Profile) > 0 {
+		i -= len(m.Profile)
+		copy(dAtA[i:], m.Profile)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Profile)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Revision) > 0 {
+		i -= len(m.Revision)
+		copy(dAtA[i:], m.Revision)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Revision)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Stack) > 0 {
+		i -= len(m.Stack)
+		copy(dAtA[i:], m.Stack)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Stack)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Deployment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Deployment) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Deployment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Components) > 0 {
+		for iNdEx := len(m.Components) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Components[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Outcome != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Outcome))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.End != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Start != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Profile) > 0 {
+		i -= len(m.Profile)
+		copy(dAtA[i:], m.Profile)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Profile)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Revision) > 0 {
+		i -= len(m.Revision)
+		copy(dAtA[i:], m.Revision)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Revision)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Stack) > 0 {
+		i -= len(m.Stack)
+		copy(dAtA[i:], m.Stack)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Stack)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuditEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuditEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuditEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Prev) > 0 {
+		i -= len(m.Prev)
+		copy(dAtA[i:], m.Prev)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Prev)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Outcome != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Outcome))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.RequestHash) > 0 {
+		i -= len(m.RequestHash)
+		copy(dAtA[i:], m.RequestHash)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.RequestHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Resource) > 0 {
+		i -= len(m.Resource)
+		copy(dAtA[i:], m.Resource)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Resource)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Seq != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Seq))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuditOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuditOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuditOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Until != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Until))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Since != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Since))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Resource) > 0 {
+		i -= len(m.Resource)
+		copy(dAtA[i:], m.Resource)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Resource)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Start != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Salt) > 0 {
+		i -= len(m.Salt)
+		copy(dAtA[i:], m.Salt)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Salt)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Encrypted {
+		i--
+		if m.Encrypted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Created != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Created))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Format) > 0 {
+		i -= len(m.Format)
+		copy(dAtA[i:], m.Format)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Format)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterMember) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterMember) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterMember) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Leader {
+		i--
+		if m.Leader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.RPCAddr) > 0 {
+		i -= len(m.RPCAddr)
+		copy(dAtA[i:], m.RPCAddr)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.RPCAddr)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RaftAddr) > 0 {
+		i -= len(m.RaftAddr)
+		copy(dAtA[i:], m.RaftAddr)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.RaftAddr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterMembers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterMembers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterMembers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterCommand) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterCommand) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterCommand) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Op != 0 {
+		i = encodeVarintThrap(dAtA, i, uint64(m.Op))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThrap(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThrap(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintThrap(dAtA []byte, offset int, v uint64) int {
+	offset -= sovThrap(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Build) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Dockerfile)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Secrets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Destination)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Template)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Volume) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Envionment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Vars) > 0 {
+		for k, v := range m.Vars {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *HealthCheck) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Protocol)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovThrap(uint64(m.Timeout))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovThrap(uint64(m.Interval))
+	}
+	l = len(m.PortLabel)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Component) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Language)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Build != nil {
+		l = m.Build.Size()
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Secrets != nil {
+		l = m.Secrets.Size()
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Ports) > 0 {
+		for k, v := range m.Ports {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + sovThrap(uint64(v))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	if m.External {
+		n += 2
+	}
+	if m.Head {
+		n += 2
+	}
+	if m.Env != nil {
+		l = m.Env.Size()
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Config) > 0 {
+		for k, v := range m.Config {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Volumes) > 0 {
+		for _, e := range m.Volumes {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	l = len(m.Cmd)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Args) > 0 {
+		for _, s := range m.Args {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.HealthChecks) > 0 {
+		for _, e := range m.HealthChecks {
+			l = e.Size()
+			n += 2 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.DependsOn) > 0 {
+		for _, s := range m.DependsOn {
+			l = len(s)
+			n += 2 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PackManifest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Versions) > 0 {
+		for _, s := range m.Versions {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	l = len(m.DefaultVersion)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Files) > 0 {
+		for _, s := range m.Files {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Language) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Versions) > 0 {
+		for _, s := range m.Versions {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	l = len(m.DefaultVersion)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.FileExts) > 0 {
+		for _, s := range m.FileExts {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.IgnoreFiles) > 0 {
+		for _, s := range m.IgnoreFiles {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.DevImages) > 0 {
+		for _, s := range m.DevImages {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.PubImages) > 0 {
+		for _, s := range m.PubImages {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.ScaffoldFiles) > 0 {
+		for _, s := range m.ScaffoldFiles {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Stack) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Components) > 0 {
+		for k, v := range m.Components {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovThrap(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Dependencies) > 0 {
+		for k, v := range m.Dependencies {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovThrap(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Versioning)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Packs) > 0 {
+		for k, v := range m.Packs {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Identity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovThrap(uint64(m.Nonce))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Meta) > 0 {
+		for k, v := range m.Meta {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	if len(m.History) > 0 {
+		for _, e := range m.History {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if m.ConfirmExpires != 0 {
+		n += 1 + sovThrap(uint64(m.ConfirmExpires))
+	}
+	if len(m.ConfirmSent) > 0 {
+		l = 0
+		for _, e := range m.ConfirmSent {
+			l += sovThrap(uint64(e))
+		}
+		n += 1 + sovThrap(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *IdentityKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovThrap(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovThrap(uint64(m.NotAfter))
+	}
+	if m.Revoked != 0 {
+		n += 1 + sovThrap(uint64(m.Revoked))
+	}
+	l = len(m.RevokeReason)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovThrap(uint64(m.Timestamp))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyRevocation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovThrap(uint64(m.Timestamp))
+	}
+	l = len(m.Signer)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *OIDCConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Scopes) > 0 {
+		for _, s := range m.Scopes {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LoginRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.IDToken)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *Session) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Expires != 0 {
+		n += 1 + sovThrap(uint64(m.Expires))
+	}
+	if m.Identity != nil {
+		l = m.Identity.Size()
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Groups) > 0 {
+		for _, s := range m.Groups {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Artifact) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
+			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+		}
+	}
+	if m.Created != 0 {
+		n += 1 + sovThrap(uint64(m.Created))
+	}
+	if m.DataSize != 0 {
+		n += 1 + sovThrap(uint64(m.DataSize))
+	}
+	return n
+}
+
+func (m *Profile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Orchestrator)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Secrets)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Registry)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *IterOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *ComponentRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Artifact)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Published {
+		n += 2
+	}
+	if m.Start != 0 {
+		n += 1 + sovThrap(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovThrap(uint64(m.End))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *StackBuild) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Stack)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Revision)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Start != 0 {
+		n += 1 + sovThrap(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovThrap(uint64(m.End))
+	}
+	if m.Outcome != 0 {
+		n += 1 + sovThrap(uint64(m.Outcome))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Components) > 0 {
+		for _, e := range m.Components {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Deployment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Stack)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Revision)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Start != 0 {
+		n += 1 + sovThrap(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovThrap(uint64(m.End))
+	}
+	if m.Outcome != 0 {
+		n += 1 + sovThrap(uint64(m.Outcome))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if len(m.Components) > 0 {
+		for _, e := range m.Components {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AuditEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		n += 1 + sovThrap(uint64(m.Seq))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovThrap(uint64(m.Timestamp))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Resource)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.RequestHash)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Outcome != 0 {
+		n += 1 + sovThrap(uint64(m.Outcome))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Prev)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *AuditOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Start != 0 {
+		n += 1 + sovThrap(uint64(m.Start))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Resource)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Since != 0 {
+		n += 1 + sovThrap(uint64(m.Since))
+	}
+	if m.Until != 0 {
+		n += 1 + sovThrap(uint64(m.Until))
+	}
+	return n
+}
+
+func (m *SnapshotHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Format)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Created != 0 {
+		n += 1 + sovThrap(uint64(m.Created))
+	}
+	if m.Encrypted {
+		n += 2
+	}
+	l = len(m.Salt)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *SnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *ClusterMember) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.RaftAddr)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.RPCAddr)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	if m.Leader {
+		n += 2
+	}
+	return n
+}
+
+func (m *ClusterMembers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *JoinRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *ClusterCommand) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Op != 0 {
+		n += 1 + sovThrap(uint64(m.Op))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *ClusterResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThrap(uint64(l))
+	}
+	return n
+}
+
+func (m *ClusterSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovThrap(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovThrap(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozThrap(x uint64) (n int) {
+	return sovThrap(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Build) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Build: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Build: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dockerfile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dockerfile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Context = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Secrets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Secrets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Secrets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destination = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Template = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Volume) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Volume: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Volume: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Envionment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Envionment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Envionment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.File = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vars", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vars == nil {
+				m.Vars = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Vars[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HealthCheck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HealthCheck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HealthCheck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocol = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PortLabel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PortLabel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Component) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Component: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Component: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = CompType(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Language = LanguageID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Build == nil {
+				m.Build = &Build{}
+			}
+			if err := m.Build.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secrets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Secrets == nil {
+				m.Secrets = &Secrets{}
+			}
+			if err := m.Secrets.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ports == nil {
+				m.Ports = make(map[string]int32)
+			}
+			var mapkey string
+			var mapvalue int32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Ports[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field External", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.External = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Head = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Env", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Env == nil {
+				m.Env = &Envionment{}
+			}
+			if err := m.Env.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Config == nil {
+				m.Config = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Config[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volumes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Volumes = append(m.Volumes, &Volume{})
+			if err := m.Volumes[len(m.Volumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cmd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Args = append(m.Args, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthChecks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HealthChecks = append(m.HealthChecks, &HealthCheck{})
+			if err := m.HealthChecks[len(m.HealthChecks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependsOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DependsOn = append(m.DependsOn, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PackManifest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PackManifest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PackManifest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Versions = append(m.Versions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DefaultVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Language) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Language: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Language: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Versions = append(m.Versions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DefaultVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileExts", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileExts = append(m.FileExts, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreFiles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IgnoreFiles = append(m.IgnoreFiles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevImages", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevImages = append(m.DevImages, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubImages", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PubImages = append(m.PubImages, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScaffoldFiles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScaffoldFiles = append(m.ScaffoldFiles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Stack) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Stack: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Stack: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Components", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Components == nil {
+				m.Components = make(map[string]*Component)
+			}
+			var mapkey string
+			var mapvalue *Component
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Component{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Components[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dependencies == nil {
+				m.Dependencies = make(map[string]*Component)
+			}
+			var mapkey string
+			var mapvalue *Component
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Component{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Dependencies[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versioning", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Versioning = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Packs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Packs == nil {
+				m.Packs = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Packs[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Identity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Identity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Identity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipThrap(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthThrap
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Meta[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.History = append(m.History, &IdentityKey{})
+			if err := m.History[len(m.History)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmExpires", wireType)
+			}
+			m.ConfirmExpires = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmExpires |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ConfirmSent = append(m.ConfirmSent, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowThrap
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthThrap
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthThrap
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ConfirmSent) == 0 {
+					m.ConfirmSent = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowThrap
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ConfirmSent = append(m.ConfirmSent, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmSent", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IdentityKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IdentityKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IdentityKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revoked", wireType)
+			}
+			m.Revoked = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revoked |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevokeReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevokeReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyRevocation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyRevocation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyRevocation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signer = append(m.Signer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signer == nil {
+				m.Signer = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
-	}
-	if len(m.Dependencies) > 0 {
-		for k, v := range m.Dependencies {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovThrap(uint64(l))
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
 			}
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
-	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	return n
-}
-
-func (m *Identity) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Email)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.PublicKey)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	if m.Nonce != 0 {
-		n += 1 + sovThrap(uint64(m.Nonce))
-	}
-	l = len(m.Signature)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	if len(m.Meta) > 0 {
-		for k, v := range m.Meta {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
-		}
-	}
-	return n
-}
-
-func (m *Artifact) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	if len(m.Tags) > 0 {
-		for _, s := range m.Tags {
-			l = len(s)
-			n += 1 + l + sovThrap(uint64(l))
-		}
-	}
-	if len(m.Labels) > 0 {
-		for k, v := range m.Labels {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovThrap(uint64(len(k))) + 1 + len(v) + sovThrap(uint64(len(v)))
-			n += mapEntrySize + 1 + sovThrap(uint64(mapEntrySize))
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	if m.Created != 0 {
-		n += 1 + sovThrap(uint64(m.Created))
-	}
-	if m.DataSize != 0 {
-		n += 1 + sovThrap(uint64(m.DataSize))
-	}
-	return n
-}
-
-func (m *Profile) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Orchestrator)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Secrets)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	l = len(m.Registry)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	return n
-}
-
-func (m *IterOptions) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Prefix)
-	if l > 0 {
-		n += 1 + l + sovThrap(uint64(l))
-	}
-	return n
-}
 
-func sovThrap(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozThrap(x uint64) (n int) {
-	return sovThrap(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Build) Unmarshal(dAtA []byte) error {
+func (m *OIDCConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2347,7 +10607,7 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2355,15 +10615,15 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Build: wiretype end group for non-group")
+			return fmt.Errorf("proto: OIDCConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Build: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OIDCConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dockerfile", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2375,7 +10635,7 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2385,14 +10645,17 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Dockerfile = string(dAtA[iNdEx:postIndex])
+			m.Issuer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2404,7 +10667,7 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2414,10 +10677,45 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Context = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scopes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scopes = append(m.Scopes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2428,6 +10726,9 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2440,7 +10741,7 @@ func (m *Build) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Secrets) Unmarshal(dAtA []byte) error {
+func (m *LoginRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2455,7 +10756,7 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2463,15 +10764,15 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Secrets: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoginRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Secrets: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoginRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2483,7 +10784,7 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2493,14 +10794,17 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Destination = string(dAtA[iNdEx:postIndex])
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2512,7 +10816,7 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2522,10 +10826,13 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Template = string(dAtA[iNdEx:postIndex])
+			m.IDToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2536,6 +10843,9 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2548,7 +10858,7 @@ func (m *Secrets) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Volume) Unmarshal(dAtA []byte) error {
+func (m *Session) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2563,25 +10873,76 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Volume: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Volume: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Session: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Session: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			m.Expires = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Expires |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -2591,24 +10952,31 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Source = string(dAtA[iNdEx:postIndex])
+			if m.Identity == nil {
+				m.Identity = &Identity{}
+			}
+			if err := m.Identity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2620,7 +10988,7 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2630,10 +10998,13 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Target = string(dAtA[iNdEx:postIndex])
+			m.Groups = append(m.Groups, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2644,6 +11015,9 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2656,7 +11030,7 @@ func (m *Volume) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Envionment) Unmarshal(dAtA []byte) error {
+func (m *Artifact) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2671,7 +11045,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2679,15 +11053,15 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Envionment: wiretype end group for non-group")
+			return fmt.Errorf("proto: Artifact: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Envionment: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Artifact: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2699,7 +11073,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2709,14 +11083,49 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.File = string(dAtA[iNdEx:postIndex])
+			m.ID = github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vars", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2728,7 +11137,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2737,11 +11146,14 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vars == nil {
-				m.Vars = make(map[string]string)
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
 			}
 			var mapkey string
 			var mapvalue string
@@ -2757,7 +11169,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
+					wire |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
@@ -2774,7 +11186,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						stringLenmapkey |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
@@ -2784,6 +11196,9 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 						return ErrInvalidLengthThrap
 					}
 					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthThrap
+					}
 					if postStringIndexmapkey > l {
 						return io.ErrUnexpectedEOF
 					}
@@ -2800,7 +11215,7 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						stringLenmapvalue |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
@@ -2810,6 +11225,9 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 						return ErrInvalidLengthThrap
 					}
 					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthThrap
+					}
 					if postStringIndexmapvalue > l {
 						return io.ErrUnexpectedEOF
 					}
@@ -2830,150 +11248,13 @@ func (m *Envionment) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.Vars[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipThrap(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthThrap
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HealthCheck) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowThrap
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HealthCheck: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HealthCheck: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Protocol = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Path = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Method = string(dAtA[iNdEx:postIndex])
+			m.Labels[mapkey] = mapvalue
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
 			}
-			m.Timeout = 0
+			m.Created = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -2983,35 +11264,16 @@ func (m *HealthCheck) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timeout |= (int64(b) & 0x7F) << shift
+				m.Created |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
-			}
-			m.Interval = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Interval |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PortLabel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataSize", wireType)
 			}
-			var stringLen uint64
+			m.DataSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3021,21 +11283,11 @@ func (m *HealthCheck) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.DataSize |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PortLabel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThrap(dAtA[iNdEx:])
@@ -3045,6 +11297,9 @@ func (m *HealthCheck) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3057,7 +11312,7 @@ func (m *HealthCheck) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Component) Unmarshal(dAtA []byte) error {
+func (m *Profile) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3072,7 +11327,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3080,10 +11335,10 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Component: wiretype end group for non-group")
+			return fmt.Errorf("proto: Profile: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Component: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Profile: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3100,7 +11355,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3110,6 +11365,9 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3117,7 +11375,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Orchestrator", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3129,7 +11387,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3139,14 +11397,17 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Orchestrator = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Secrets", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3158,7 +11419,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3168,14 +11429,17 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.Secrets = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Registry", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3187,7 +11451,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3197,78 +11461,72 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Type = CompType(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Language", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Language = LanguageID(dAtA[iNdEx:postIndex])
+			m.Registry = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
 			}
-			if msglen < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Build == nil {
-				m.Build = &Build{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IterOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
 			}
-			if err := m.Build.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 7:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IterOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IterOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Secrets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3278,137 +11536,82 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Secrets == nil {
-				m.Secrets = &Secrets{}
-			}
-			if err := m.Secrets.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThrap
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Ports == nil {
-				m.Ports = make(map[string]int32)
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
 			}
-			var mapkey string
-			var mapvalue int32
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= (int32(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Ports[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field External", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ComponentRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
 			}
-			var v int
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ComponentRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ComponentRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3418,17 +11621,29 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.External = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
 			}
-			var v int
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3438,17 +11653,29 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Head = bool(v != 0)
-		case 11:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Env", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Artifact", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3458,30 +11685,29 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Env == nil {
-				m.Env = &Envionment{}
-			}
-			if err := m.Env.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Artifact = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3491,115 +11717,49 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Config == nil {
-				m.Config = make(map[string]string)
+			m.Digest = github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Published", wireType)
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Config[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Volumes", wireType)
+			m.Published = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var msglen int
+			m.Start = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3609,28 +11769,16 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Start |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Volumes = append(m.Volumes, &Volume{})
-			if err := m.Volumes[len(m.Volumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 14:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			var stringLen uint64
+			m.End = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3640,24 +11788,14 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.End |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Cmd = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 15:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3669,7 +11807,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3679,16 +11817,19 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Args = append(m.Args, string(dAtA[iNdEx:postIndex]))
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 16:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HealthChecks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -3698,22 +11839,23 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HealthChecks = append(m.HealthChecks, &HealthCheck{})
-			if err := m.HealthChecks[len(m.HealthChecks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3724,6 +11866,9 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3736,7 +11881,7 @@ func (m *Component) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PackManifest) Unmarshal(dAtA []byte) error {
+func (m *StackBuild) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3751,7 +11896,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3759,15 +11904,15 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PackManifest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StackBuild: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PackManifest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StackBuild: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3779,7 +11924,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3789,14 +11934,17 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stack", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3808,7 +11956,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3818,14 +11966,17 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Versions = append(m.Versions, string(dAtA[iNdEx:postIndex]))
+			m.Stack = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3837,7 +11988,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3847,14 +11998,17 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DefaultVersion = string(dAtA[iNdEx:postIndex])
+			m.Revision = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3866,7 +12020,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3876,14 +12030,17 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
+			m.Identity = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3895,7 +12052,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3905,10 +12062,136 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
+			m.Profile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
+			}
+			m.Outcome = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Outcome |= Outcome(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Components", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Components = append(m.Components, &ComponentRecord{})
+			if err := m.Components[len(m.Components)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3919,6 +12202,9 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3931,7 +12217,7 @@ func (m *PackManifest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Language) Unmarshal(dAtA []byte) error {
+func (m *Deployment) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3946,7 +12232,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3954,15 +12240,15 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Language: wiretype end group for non-group")
+			return fmt.Errorf("proto: Deployment: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Language: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Deployment: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3974,7 +12260,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3984,14 +12270,17 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stack", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4003,7 +12292,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4013,14 +12302,17 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Versions = append(m.Versions, string(dAtA[iNdEx:postIndex]))
+			m.Stack = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4032,7 +12324,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4042,14 +12334,17 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DefaultVersion = string(dAtA[iNdEx:postIndex])
+			m.Revision = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileExts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4061,7 +12356,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4071,14 +12366,17 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FileExts = append(m.FileExts, string(dAtA[iNdEx:postIndex]))
+			m.Identity = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreFiles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4090,7 +12388,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4100,16 +12398,19 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IgnoreFiles = append(m.IgnoreFiles, string(dAtA[iNdEx:postIndex]))
+			m.Profile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevImages", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var stringLen uint64
+			m.Start = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4119,24 +12420,52 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Start |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.DevImages = append(m.DevImages, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 7:
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
+			}
+			m.Outcome = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Outcome |= Outcome(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubImages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4148,7 +12477,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4158,16 +12487,19 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PubImages = append(m.PubImages, string(dAtA[iNdEx:postIndex]))
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScaffoldFiles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Components", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4177,20 +12509,25 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ScaffoldFiles = append(m.ScaffoldFiles, string(dAtA[iNdEx:postIndex]))
+			m.Components = append(m.Components, &ComponentRecord{})
+			if err := m.Components[len(m.Components)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4201,6 +12538,9 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4213,7 +12553,7 @@ func (m *Language) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Stack) Unmarshal(dAtA []byte) error {
+func (m *AuditEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4228,7 +12568,7 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4236,15 +12576,53 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Stack: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Stack: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4256,7 +12634,7 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4266,14 +12644,17 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Identity = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4285,7 +12666,7 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4295,14 +12676,17 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Method = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4314,7 +12698,7 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4324,16 +12708,19 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.Resource = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Components", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4343,120 +12730,50 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Components == nil {
-				m.Components = make(map[string]*Component)
+			m.RequestHash = append(m.RequestHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RequestHash == nil {
+				m.RequestHash = []byte{}
 			}
-			var mapkey string
-			var mapvalue *Component
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
+			}
+			m.Outcome = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= (int(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if mapmsglen < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &Component{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Outcome |= Outcome(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Components[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 6:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4466,120 +12783,63 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Dependencies == nil {
-				m.Dependencies = make(map[string]*Component)
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prev", wireType)
 			}
-			var mapkey string
-			var mapvalue *Component
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= (int(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if mapmsglen < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &Component{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Dependencies[mapkey] = mapvalue
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prev = append(m.Prev[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prev == nil {
+				m.Prev = []byte{}
+			}
 			iNdEx = postIndex
-		case 7:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4589,20 +12849,25 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4613,6 +12878,9 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4625,7 +12893,7 @@ func (m *Stack) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Identity) Unmarshal(dAtA []byte) error {
+func (m *AuditOptions) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4640,7 +12908,7 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4648,15 +12916,34 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Identity: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditOptions: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Identity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditOptions: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4668,7 +12955,7 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4678,14 +12965,17 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Identity = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4697,7 +12987,7 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4707,16 +12997,19 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.Method = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4726,28 +13019,152 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PublicKey == nil {
-				m.PublicKey = []byte{}
+			m.Resource = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Since |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Until", wireType)
+			}
+			m.Until = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Until |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
 			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Format = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
 			}
-			m.Nonce = 0
+			m.Created = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4757,14 +13174,34 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Nonce |= (uint64(b) & 0x7F) << shift
+				m.Created |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encrypted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Encrypted = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Salt", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4776,7 +13213,7 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4785,19 +13222,75 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signature == nil {
-				m.Signature = []byte{}
+			m.Salt = append(m.Salt[:0], dAtA[iNdEx:postIndex]...)
+			if m.Salt == nil {
+				m.Salt = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -4807,109 +13300,25 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-			m.Meta[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4920,6 +13329,9 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4932,7 +13344,7 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Artifact) Unmarshal(dAtA []byte) error {
+func (m *ClusterMember) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4947,7 +13359,7 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4955,10 +13367,10 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Artifact: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClusterMember: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Artifact: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClusterMember: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4975,7 +13387,7 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4985,14 +13397,17 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RaftAddr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5004,7 +13419,7 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5014,16 +13429,19 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			m.RaftAddr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RPCAddr", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5033,115 +13451,102 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = make(map[string]string)
+			m.RPCAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThrap
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowThrap
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthThrap
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipThrap(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthThrap
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Labels[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			m.Leader = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterMembers) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Created = 0
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterMembers: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterMembers: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5151,16 +13556,84 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Created |= (int64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataSize", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
 			}
-			m.DataSize = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &ClusterMember{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5170,11 +13643,24 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataSize |= (int64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThrap(dAtA[iNdEx:])
@@ -5184,6 +13670,9 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5196,7 +13685,7 @@ func (m *Artifact) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Profile) Unmarshal(dAtA []byte) error {
+func (m *ClusterCommand) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5211,7 +13700,7 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5219,17 +13708,17 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Profile: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClusterCommand: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Profile: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClusterCommand: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			var stringLen uint64
+			m.Op = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5239,24 +13728,14 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Op |= ClusterOp(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthThrap
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Orchestrator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5268,7 +13747,7 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5278,16 +13757,19 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Orchestrator = string(dAtA[iNdEx:postIndex])
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Secrets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5297,24 +13779,82 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Secrets = string(dAtA[iNdEx:postIndex])
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThrap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThrap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Registry", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5326,7 +13866,7 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5336,10 +13876,13 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthThrap
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Registry = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5350,6 +13893,9 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5362,7 +13908,7 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IterOptions) Unmarshal(dAtA []byte) error {
+func (m *ClusterSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5377,7 +13923,7 @@ func (m *IterOptions) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5385,17 +13931,17 @@ func (m *IterOptions) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IterOptions: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClusterSnapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IterOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClusterSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThrap
@@ -5405,20 +13951,25 @@ func (m *IterOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthThrap
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthThrap
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Prefix = string(dAtA[iNdEx:postIndex])
+			m.Entries = append(m.Entries, &ClusterCommand{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5429,6 +13980,9 @@ func (m *IterOptions) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthThrap
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthThrap
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5444,6 +13998,7 @@ func (m *IterOptions) Unmarshal(dAtA []byte) error {
 func skipThrap(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -5475,10 +14030,8 @@ func skipThrap(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -5495,163 +14048,34 @@ func skipThrap(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthThrap
 			}
-			return iNdEx, nil
+			iNdEx += length
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowThrap
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipThrap(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupThrap
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthThrap
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthThrap = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowThrap   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthThrap        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowThrap          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupThrap = fmt.Errorf("proto: unexpected end of group")
 )
-
-func init() { proto.RegisterFile("thrap.proto", fileDescriptorThrap) }
-
-var fileDescriptorThrap = []byte{
-	// 1660 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x49, 0x6f, 0x23, 0xc7,
-	0x15, 0x0e, 0xf7, 0xe6, 0x23, 0x47, 0xd2, 0x94, 0xc7, 0x83, 0x06, 0x61, 0xab, 0x99, 0xf2, 0x38,
-	0x21, 0x62, 0xab, 0xb5, 0xd8, 0xc1, 0xd8, 0x82, 0x91, 0xc0, 0x14, 0x95, 0x31, 0x31, 0x9b, 0xd2,
-	0x52, 0x26, 0x40, 0x2e, 0x46, 0xa9, 0x59, 0x24, 0x1b, 0xea, 0x85, 0xa8, 0x2e, 0x0a, 0x62, 0x72,
-	0x48, 0x90, 0x5f, 0x10, 0xe4, 0x37, 0xe4, 0x92, 0x4b, 0x7e, 0x47, 0x10, 0xe4, 0x90, 0x6b, 0x2e,
-	0x8d, 0x60, 0xf2, 0x0f, 0xfa, 0x38, 0x87, 0x20, 0xa8, 0xa5, 0x17, 0xc9, 0xb2, 0x87, 0x0a, 0x90,
-	0x8b, 0xd4, 0x6f, 0xfb, 0xaa, 0xea, 0xd5, 0xab, 0xaf, 0x5e, 0x11, 0x3a, 0x7c, 0xce, 0xc8, 0xc2,
-	0x5e, 0xb0, 0x88, 0x47, 0xbd, 0x9d, 0x99, 0xc7, 0xe7, 0xcb, 0x73, 0xdb, 0x8d, 0x82, 0xdd, 0x59,
-	0x34, 0x8b, 0x76, 0xa5, 0xfa, 0x7c, 0x39, 0x95, 0x92, 0x14, 0xe4, 0x97, 0x72, 0xc7, 0xbf, 0x81,
-	0xc6, 0x70, 0xe9, 0xf9, 0x13, 0xf4, 0x29, 0xc0, 0x28, 0x72, 0x2f, 0x28, 0x9b, 0x7a, 0x3e, 0x35,
-	0x2b, 0xfd, 0xca, 0xa0, 0x3d, 0x7c, 0x90, 0x26, 0xd6, 0xd6, 0xdc, 0xf5, 0x0f, 0xf1, 0x24, 0x37,
-	0x61, 0xa7, 0xe4, 0x87, 0xbe, 0x80, 0xd6, 0x51, 0x14, 0x72, 0x7a, 0xc5, 0xcd, 0xaa, 0x0c, 0xc1,
-	0x69, 0x62, 0x6d, 0xcb, 0x10, 0x57, 0xe9, 0x71, 0x7f, 0xee, 0xfa, 0xf4, 0x10, 0x47, 0x81, 0xc7,
-	0x69, 0xb0, 0xe0, 0x2b, 0xec, 0x64, 0x21, 0x98, 0x41, 0xeb, 0x94, 0xba, 0x8c, 0xf2, 0x18, 0x3d,
-	0x86, 0xce, 0x88, 0xc6, 0xdc, 0x0b, 0x09, 0xf7, 0xa2, 0x50, 0x8f, 0xff, 0x6e, 0x9a, 0x58, 0xf7,
-	0xd5, 0xf8, 0x85, 0x0d, 0x3b, 0x65, 0x4f, 0x64, 0x83, 0x71, 0x46, 0x83, 0x85, 0x4f, 0x38, 0xd5,
-	0x53, 0x40, 0x69, 0x62, 0x6d, 0xc8, 0x28, 0xae, 0x0d, 0xd8, 0xc9, 0x7d, 0xf0, 0x6f, 0xa1, 0xf9,
-	0x2a, 0xf2, 0x97, 0x01, 0x45, 0x4f, 0xa1, 0x79, 0x1a, 0x2d, 0x99, 0x9b, 0xad, 0xf6, 0x93, 0x34,
-	0xb1, 0x76, 0x65, 0x5c, 0x2c, 0xd5, 0xdf, 0x9c, 0x79, 0x7f, 0x45, 0x02, 0xff, 0x10, 0x7f, 0x5c,
-	0x5a, 0x8b, 0x86, 0x40, 0x03, 0x68, 0x9e, 0x11, 0x36, 0xa3, 0x59, 0x1e, 0xb6, 0xd2, 0xc4, 0xea,
-	0xaa, 0x49, 0x48, 0x35, 0x76, 0xb4, 0x1d, 0xff, 0xa9, 0x02, 0x70, 0x1c, 0x5e, 0x7a, 0x51, 0x18,
-	0xd0, 0x90, 0x23, 0x0c, 0xf5, 0x9f, 0x15, 0x19, 0xdf, 0x48, 0x13, 0x0b, 0x64, 0x98, 0xca, 0xb5,
-	0xb4, 0xa1, 0xcf, 0xa1, 0xfe, 0x8a, 0xb0, 0xd8, 0xac, 0xf6, 0x6b, 0x83, 0xce, 0xc1, 0xbb, 0x76,
-	0x11, 0x6e, 0x0b, 0xfd, 0x71, 0xc8, 0xd9, 0xaa, 0x14, 0x7a, 0x49, 0x58, 0x8c, 0x1d, 0x19, 0xd2,
-	0x7b, 0x0c, 0xed, 0xdc, 0x05, 0x6d, 0x41, 0xed, 0x82, 0xae, 0xd4, 0x50, 0x8e, 0xf8, 0x44, 0x0f,
-	0xa0, 0x71, 0x49, 0xfc, 0xa5, 0x4e, 0x9d, 0xa3, 0x84, 0xc3, 0xea, 0x67, 0x15, 0xfc, 0xe7, 0x0a,
-	0x74, 0xbe, 0xa2, 0xc4, 0xe7, 0xf3, 0xa3, 0x39, 0x75, 0x2f, 0x50, 0x0f, 0x8c, 0x13, 0x51, 0x31,
-	0x6e, 0xe4, 0x6b, 0x80, 0x5c, 0x46, 0x08, 0xea, 0x27, 0x84, 0xcf, 0x35, 0x88, 0xfc, 0x46, 0x0f,
-	0xa1, 0xf9, 0x9c, 0xf2, 0x79, 0x34, 0x31, 0x6b, 0x52, 0xab, 0x25, 0x64, 0x42, 0xeb, 0xcc, 0x0b,
-	0x68, 0xb4, 0xe4, 0x66, 0xbd, 0x5f, 0x19, 0xd4, 0x9c, 0x4c, 0x14, 0x23, 0x8c, 0x43, 0x4e, 0xd9,
-	0x25, 0xf1, 0xcd, 0x86, 0x34, 0xe5, 0x32, 0x7a, 0x0f, 0xda, 0x27, 0x11, 0xe3, 0xcf, 0xc8, 0x39,
-	0xf5, 0xcd, 0xa6, 0x04, 0x2c, 0x14, 0xf8, 0x77, 0x00, 0xed, 0xa3, 0x28, 0x58, 0x44, 0xa1, 0xc8,
-	0xe8, 0x00, 0xaa, 0xe3, 0x91, 0xce, 0xa7, 0x99, 0x26, 0xd6, 0x83, 0x62, 0x1b, 0xb3, 0x1d, 0xdc,
-	0xc1, 0x4e, 0x75, 0x3c, 0x12, 0xb9, 0x7f, 0x41, 0x82, 0xac, 0x6e, 0x8a, 0x04, 0x86, 0x24, 0x10,
-	0xb9, 0x17, 0x36, 0xf4, 0x02, 0x5a, 0xaf, 0x28, 0x8b, 0x45, 0x51, 0xca, 0x85, 0x0c, 0x3f, 0x4d,
-	0x13, 0x6b, 0x4f, 0xe5, 0x59, 0xe9, 0x6f, 0x29, 0x8b, 0x5b, 0x6a, 0x5e, 0x83, 0x20, 0x1b, 0xea,
-	0x67, 0xab, 0x05, 0x95, 0x8b, 0x6f, 0x0f, 0x7b, 0xf9, 0x98, 0x7c, 0xb5, 0xa0, 0xf8, 0x4d, 0x62,
-	0x19, 0x62, 0x21, 0xc2, 0xc3, 0x91, 0x7e, 0xe8, 0x6b, 0x30, 0x9e, 0x91, 0x70, 0xb6, 0x24, 0x33,
-	0x2a, 0xb3, 0xd2, 0x1e, 0x1e, 0xa5, 0x89, 0xb5, 0x2f, 0x63, 0x7c, 0x6d, 0x58, 0xa7, 0x52, 0xdf,
-	0x24, 0x16, 0x64, 0x40, 0xe3, 0x91, 0x93, 0x83, 0xa2, 0x9f, 0x6a, 0x06, 0x90, 0x69, 0xed, 0x1c,
-	0x34, 0x6d, 0x29, 0x0d, 0xbf, 0x9f, 0x26, 0xd6, 0xfb, 0x72, 0x94, 0x73, 0x21, 0xdf, 0x56, 0xfb,
-	0x9a, 0x39, 0x9e, 0xe4, 0xa7, 0xd8, 0x6c, 0x49, 0x08, 0xc3, 0xd6, 0xf2, 0xf0, 0x83, 0x34, 0xb1,
-	0x2c, 0x75, 0xa4, 0x94, 0xe6, 0x36, 0x98, 0x9c, 0x03, 0xbe, 0x86, 0x86, 0xd8, 0xd3, 0xd8, 0x34,
-	0x74, 0x9d, 0xe7, 0x7b, 0x6a, 0x4b, 0xbd, 0xaa, 0xf3, 0x83, 0x34, 0xb1, 0x6c, 0x89, 0xb9, 0x10,
-	0xca, 0xb5, 0x4e, 0xa9, 0xc2, 0x45, 0x3f, 0x07, 0xe3, 0xf8, 0x8a, 0x53, 0x16, 0x12, 0xdf, 0x6c,
-	0xf7, 0x2b, 0x03, 0x63, 0xf8, 0xe3, 0x3c, 0x97, 0x54, 0x1b, 0xd6, 0xc2, 0xcb, 0x61, 0xd0, 0x31,
-	0xd4, 0xbf, 0xa2, 0x64, 0x62, 0x82, 0x84, 0xdb, 0x4f, 0x13, 0x6b, 0x47, 0xc2, 0xcd, 0x29, 0x99,
-	0xac, 0x05, 0x25, 0xc3, 0xd1, 0x4b, 0xa8, 0x1d, 0x87, 0x97, 0x66, 0x47, 0xe6, 0xaf, 0x53, 0x3a,
-	0xe0, 0xc3, 0xbd, 0x34, 0xb1, 0x3e, 0x56, 0x33, 0x0c, 0x2f, 0xd7, 0x42, 0x14, 0x48, 0xc8, 0x85,
-	0xe6, 0x51, 0x14, 0x4e, 0xbd, 0x99, 0xd9, 0x95, 0xc9, 0x7c, 0x58, 0x4a, 0xa6, 0x32, 0xa8, 0x6c,
-	0x16, 0xa4, 0xe7, 0x4a, 0xed, 0x7a, 0xa4, 0xa7, 0x10, 0xd0, 0x2f, 0xa1, 0xa5, 0xb8, 0x34, 0x36,
-	0xef, 0xc9, 0x51, 0x5a, 0xb6, 0x92, 0xcb, 0x87, 0x44, 0x39, 0xac, 0x85, 0x9b, 0xa1, 0xa1, 0x21,
-	0xd4, 0x8e, 0x82, 0x89, 0xb9, 0x21, 0xeb, 0xbd, 0xc8, 0x80, 0x1b, 0xac, 0x97, 0x53, 0x11, 0x2c,
-	0x76, 0xe6, 0x4b, 0x36, 0x8b, 0xcd, 0xcd, 0x7e, 0x6d, 0xd0, 0x2e, 0xed, 0x0c, 0x61, 0xb3, 0xf5,
-	0x66, 0x23, 0xc3, 0xd1, 0x1e, 0x74, 0x4b, 0x34, 0x18, 0x9b, 0x5b, 0x72, 0xa1, 0x5d, 0xbb, 0xa4,
-	0x74, 0xae, 0x79, 0xf4, 0x3e, 0x03, 0x28, 0xca, 0xf5, 0x6d, 0x9c, 0xdb, 0x28, 0x71, 0x6e, 0xef,
-	0x73, 0xe8, 0x94, 0xf6, 0xe6, 0x4e, 0x74, 0xfd, 0xc7, 0x0a, 0x74, 0x4f, 0x88, 0x7b, 0xf1, 0x9c,
-	0x84, 0xde, 0x94, 0xc6, 0x5c, 0x70, 0xb2, 0xe4, 0x36, 0x15, 0xad, 0xb8, 0xac, 0x07, 0x86, 0xa6,
-	0x21, 0x75, 0x97, 0xb4, 0x9d, 0x5c, 0x46, 0x3f, 0x80, 0x8d, 0x11, 0x9d, 0x92, 0xa5, 0xcf, 0xaf,
-	0xd1, 0x9d, 0x73, 0x43, 0x2b, 0xa6, 0x30, 0x0e, 0x04, 0x19, 0xd5, 0xd5, 0x14, 0xa4, 0x20, 0xb4,
-	0xe2, 0xa6, 0x8a, 0xcd, 0x86, 0x84, 0x55, 0x02, 0xfe, 0x7d, 0xb5, 0x20, 0xaf, 0xff, 0xdb, 0x84,
-	0x7a, 0x60, 0x88, 0xd1, 0x8e, 0xaf, 0x78, 0x6c, 0xd6, 0x15, 0x46, 0x26, 0xa3, 0x3e, 0x74, 0xc6,
-	0xb3, 0x30, 0x62, 0xb4, 0x3c, 0xb9, 0xb2, 0x4a, 0x5c, 0x2c, 0x23, 0x7a, 0x29, 0x17, 0x11, 0x9b,
-	0x4d, 0x69, 0x2f, 0x14, 0xf2, 0xda, 0x59, 0x9e, 0x6b, 0x6b, 0x4b, 0x59, 0x73, 0x05, 0x7a, 0x04,
-	0xf7, 0x4e, 0x5d, 0x32, 0x9d, 0x46, 0xfe, 0x44, 0xe1, 0x1b, 0xd2, 0xe3, 0xba, 0x12, 0xff, 0xad,
-	0x0e, 0x8d, 0x53, 0x4e, 0xdc, 0x0b, 0x7d, 0x31, 0x55, 0xef, 0x70, 0x31, 0xd5, 0xd6, 0xbb, 0x98,
-	0xea, 0xdf, 0x76, 0x31, 0xad, 0x75, 0xe6, 0x74, 0x1e, 0x9f, 0x01, 0xe4, 0x14, 0xa1, 0x52, 0x25,
-	0x58, 0x43, 0xce, 0xbc, 0xe0, 0x0e, 0xcd, 0xc1, 0x45, 0x63, 0xe8, 0xe6, 0x16, 0xec, 0x94, 0xe2,
-	0xd1, 0x14, 0xba, 0x23, 0xba, 0xa0, 0xe1, 0x84, 0x86, 0xae, 0xa7, 0x53, 0xdb, 0x39, 0x30, 0x35,
-	0x5e, 0xd9, 0xa4, 0x10, 0x07, 0x69, 0x62, 0x3d, 0xd2, 0xad, 0x5e, 0x61, 0xbb, 0x6d, 0xc2, 0xd7,
-	0x70, 0xd1, 0x2f, 0x64, 0xdf, 0xe8, 0x32, 0x6f, 0x21, 0xfb, 0xc6, 0xd6, 0x8d, 0x4e, 0x6e, 0x52,
-	0xd8, 0xbe, 0xfb, 0x9a, 0x56, 0x5d, 0x65, 0xe6, 0xdb, 0x1b, 0xc3, 0xe6, 0x8d, 0x35, 0xdf, 0x72,
-	0x1a, 0xfb, 0xe5, 0xd3, 0xd8, 0x39, 0x80, 0x22, 0x4d, 0xe5, 0x43, 0xfd, 0x14, 0xee, 0x7f, 0x63,
-	0xb9, 0xff, 0x2b, 0x18, 0xfe, 0x67, 0x15, 0x8c, 0xf1, 0x84, 0x86, 0xdc, 0xe3, 0x2b, 0xf4, 0x5e,
-	0xa9, 0xd1, 0xe9, 0xa6, 0x89, 0x65, 0xc8, 0x25, 0x7b, 0x13, 0x55, 0x43, 0x1f, 0x42, 0xe3, 0x38,
-	0x20, 0x9e, 0xaf, 0x0b, 0x6e, 0x33, 0x4d, 0xac, 0x8e, 0xba, 0x47, 0x84, 0x16, 0x3b, 0xca, 0x8a,
-	0xf6, 0x65, 0x89, 0xfb, 0x9e, 0xfb, 0x94, 0xae, 0x64, 0xbd, 0x75, 0x87, 0xef, 0xa4, 0x89, 0xb5,
-	0xa9, 0x6e, 0x58, 0x69, 0xb9, 0xa0, 0x2b, 0xec, 0x14, 0x5e, 0x02, 0xf9, 0x45, 0x14, 0xba, 0x8a,
-	0x02, 0xea, 0x25, 0xe4, 0x50, 0x68, 0xb1, 0xa3, 0xac, 0xe8, 0x0b, 0x68, 0x9f, 0x7a, 0xb3, 0x90,
-	0xf0, 0x25, 0x53, 0xad, 0x4b, 0x77, 0xb8, 0x9d, 0x26, 0x56, 0x4f, 0xf5, 0x03, 0x99, 0x05, 0x97,
-	0xf7, 0xa0, 0x08, 0x40, 0x8f, 0xa1, 0xfe, 0x9c, 0x72, 0xa2, 0x0b, 0xe7, 0x1d, 0x3b, 0x5b, 0xb5,
-	0x2d, 0xb4, 0x37, 0x3b, 0xde, 0x80, 0x72, 0x82, 0x1d, 0x19, 0x20, 0x3a, 0xde, 0xdc, 0xe5, 0x4e,
-	0x14, 0xfa, 0x9f, 0x0a, 0x18, 0x5f, 0x32, 0xee, 0x4d, 0x89, 0xcb, 0xd1, 0x4f, 0x4a, 0xb9, 0xb5,
-	0xdf, 0x24, 0xd6, 0x8f, 0x4a, 0xcf, 0xaa, 0x68, 0x41, 0x43, 0xf1, 0xba, 0x21, 0x5e, 0x48, 0x59,
-	0xbc, 0x3b, 0x8b, 0x76, 0x26, 0xde, 0x8c, 0xc6, 0xdc, 0x1e, 0xc9, 0x7f, 0x32, 0xfb, 0x08, 0xea,
-	0x67, 0x64, 0x96, 0xb1, 0x9a, 0xfc, 0x46, 0x3b, 0xd0, 0x94, 0xfd, 0x6a, 0x6c, 0xd6, 0x74, 0x83,
-	0x93, 0x0d, 0x67, 0x2b, 0xbd, 0x9c, 0xb3, 0xa3, 0x9d, 0x44, 0xa7, 0x7c, 0xc4, 0x28, 0xe1, 0x74,
-	0x92, 0x75, 0xca, 0x5a, 0x14, 0x94, 0x37, 0x22, 0x9c, 0x9c, 0x7a, 0xbf, 0xa6, 0x59, 0xa7, 0x9c,
-	0xc9, 0xe2, 0x0e, 0x29, 0x81, 0xdd, 0x29, 0x01, 0x7f, 0xaf, 0x40, 0xeb, 0x84, 0x45, 0xf2, 0x61,
-	0xb7, 0x7e, 0x13, 0x7d, 0x08, 0xdd, 0x97, 0xcc, 0x9d, 0xd3, 0x98, 0x33, 0xc2, 0x23, 0xa6, 0xcb,
-	0xed, 0x61, 0x9a, 0x58, 0x48, 0xee, 0x4d, 0x54, 0x32, 0x62, 0xe7, 0x9a, 0x2f, 0xfa, 0xa8, 0x68,
-	0x1d, 0x15, 0xd5, 0xdd, 0x4f, 0x13, 0xeb, 0xde, 0xb5, 0x86, 0xb1, 0x68, 0x0f, 0x6d, 0x30, 0x1c,
-	0x3a, 0xf3, 0x62, 0xce, 0x56, 0x9a, 0xf1, 0x8a, 0x97, 0x1e, 0xd3, 0x06, 0xec, 0xe4, 0x3e, 0xf8,
-	0x43, 0xe8, 0x8c, 0x39, 0x65, 0x2f, 0xe5, 0x89, 0x8e, 0xc5, 0x83, 0xe4, 0x84, 0xd1, 0xa9, 0x77,
-	0xa5, 0x93, 0xa1, 0xa5, 0x83, 0xbf, 0x54, 0xa1, 0x71, 0x26, 0x1e, 0xd0, 0xc8, 0x82, 0x7b, 0x2a,
-	0x98, 0x32, 0x45, 0xd8, 0x4d, 0x45, 0x57, 0x3d, 0xfd, 0x1f, 0xbd, 0x2f, 0xee, 0xe7, 0x20, 0xf0,
-	0xf8, 0xed, 0xe6, 0x1e, 0x18, 0x4f, 0xe8, 0xb7, 0xd8, 0x1e, 0x01, 0x8c, 0x33, 0xdc, 0x18, 0x75,
-	0xed, 0xd2, 0xcc, 0x32, 0x9f, 0xbd, 0x0a, 0x1a, 0xc0, 0x56, 0x36, 0x83, 0xfc, 0x94, 0xb7, 0xf3,
-	0xd2, 0xef, 0x15, 0x9f, 0xe8, 0x23, 0xd8, 0x18, 0x17, 0x5e, 0x82, 0x09, 0xaf, 0x63, 0x16, 0xae,
-	0x7b, 0x15, 0xf4, 0x43, 0xc1, 0x66, 0xe1, 0xd4, 0x63, 0xc1, 0x5b, 0x50, 0x3f, 0x80, 0xce, 0x13,
-	0xca, 0xbf, 0xdb, 0x69, 0xb8, 0xff, 0xd7, 0xd7, 0xdb, 0x95, 0x7f, 0xbc, 0xde, 0xae, 0xfc, 0xeb,
-	0xf5, 0x76, 0xe5, 0x0f, 0xff, 0xde, 0xfe, 0xde, 0xaf, 0xac, 0xd2, 0xe1, 0xa0, 0xcb, 0x69, 0xc4,
-	0x3c, 0xb2, 0x2b, 0x7f, 0x93, 0x50, 0x7f, 0xcf, 0xcf, 0x9b, 0xf2, 0xc7, 0x86, 0x4f, 0xfe, 0x1b,
-	0x00, 0x00, 0xff, 0xff, 0x84, 0xd4, 0x84, 0xa1, 0xaa, 0x10, 0x00, 0x00,
-}
@@ -12,6 +12,37 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 )
 
+// ecdsa256ParamLen is the byte length of each P256 signature and key
+// parameter
+const ecdsa256ParamLen = 32
+
+// SignData signs data with the key returning the signature as r and s
+// concatenated.  Each is left padded so the halves can always be split
+func SignData(kp *ecdsa.PrivateKey, data []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, kp, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(padParam(r), padParam(s)...), nil
+}
+
+// PublicKeyBytes returns the public key as x and y concatenated, usable
+// with VerifySignature
+func PublicKeyBytes(pub *ecdsa.PublicKey) []byte {
+	return append(padParam(pub.X), padParam(pub.Y)...)
+}
+
+func padParam(i *big.Int) []byte {
+	b := i.Bytes()
+	if len(b) >= ecdsa256ParamLen {
+		return b
+	}
+	out := make([]byte, ecdsa256ParamLen)
+	copy(out[ecdsa256ParamLen-len(b):], b)
+	return out
+}
+
+// VerifySignature verifies the signature of data with the public key
 func VerifySignature(pubkey, data, signature []byte) bool {
 	r := big.Int{}
 	s := big.Int{}
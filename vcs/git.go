@@ -30,6 +30,11 @@ func DefaultGitRemoteURL(addr, owner, name string) string {
 	return "ssh://git@" + addr + "/" + owner + "/" + name
 }
 
+// HTTPSGitRemoteURL returns the https url scheme for remote access
+func HTTPSGitRemoteURL(addr, owner, name string) string {
+	return "https://" + addr + "/" + owner + "/" + name + ".git"
+}
+
 // SetupLocalGitRepo initializes a new git repo.  It returns an error
 // if it already has been initialized or fails
 func SetupLocalGitRepo(projName, repoOwner, projPath, remoteAddr string) (VCS, *git.Repository, error) {
@@ -0,0 +1,270 @@
+package vcs
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+var errAddrRequired = errors.New("addr required")
+
+// giteaRepo is the subset of the gitea repository api object used
+type giteaRepo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	SSHURL      string `json:"ssh_url"`
+	CloneURL    string `json:"clone_url"`
+	HTMLURL     string `json:"html_url"`
+}
+
+type giteaUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type giteaHook struct {
+	ID     int64             `json:"id,omitempty"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// gitea repo extending git. Repo owners map to a user or organization
+type giteaVCS struct {
+	git *GitVCS
+	// Host used to generate remote urls
+	host   string
+	client *restClient
+}
+
+// takes an optional underlying git vcs
+func newGiteaVCS(g *GitVCS) *giteaVCS {
+	gt := &giteaVCS{git: g}
+	if gt.git == nil {
+		gt.git = NewGitVCS()
+	}
+	return gt
+}
+
+func (gt *giteaVCS) ID() string {
+	return "gitea"
+}
+
+// Init initializes the underlying git vcs and the api client.  gitea is
+// always self-hosted so addr is required.  The token may also be supplied
+// via GITEA_ACCESS_TOKEN
+func (gt *giteaVCS) Init(conf map[string]interface{}) error {
+	err := gt.git.Init(conf)
+	if err != nil {
+		return err
+	}
+
+	addr, err := getConfString(conf, "addr")
+	if err != nil {
+		return err
+	}
+	if addr == "" {
+		return errAddrRequired
+	}
+
+	token, err := getConfString(conf, "token")
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		token = os.Getenv("GITEA_ACCESS_TOKEN")
+	}
+
+	base, host, err := parseProviderAddr(addr)
+	if err != nil {
+		return err
+	}
+
+	gt.host = host
+	var auth string
+	if token != "" {
+		auth = "token " + token
+	}
+	gt.client = newRESTClient(base+"/api/v1", "Authorization", auth)
+
+	return nil
+}
+
+func (gt *giteaVCS) GlobalUser() string {
+	return gt.git.globalUser
+}
+
+func (gt *giteaVCS) GlobalEmail() string {
+	return gt.git.globalEmail
+}
+
+// RemoteURL returns the ssh or https remote url for the repo
+func (gt *giteaVCS) RemoteURL(repo *Repository, ssh bool) string {
+	if ssh {
+		return DefaultGitRemoteURL(gt.host, repo.Owner, repo.Name)
+	}
+	return HTTPSGitRemoteURL(gt.host, repo.Owner, repo.Name)
+}
+
+// currentUser returns the user the token belongs to
+func (gt *giteaVCS) currentUser() (*giteaUser, error) {
+	var user giteaUser
+	err := gt.client.do(http.MethodGet, "/user", nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// repoPath returns the api path to the repo defaulting the owner to the
+// token user
+func (gt *giteaVCS) repoPath(repo *Repository) (string, error) {
+	owner := repo.Owner
+	if owner == "" {
+		user, err := gt.currentUser()
+		if err != nil {
+			return "", err
+		}
+		owner = user.Login
+	}
+
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo.Name), nil
+}
+
+// Get returns info on a gitea repository
+func (gt *giteaVCS) Get(repo *Repository, opt Option) (interface{}, error) {
+	path, err := gt.repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var grepo giteaRepo
+	if err = gt.client.do(http.MethodGet, path, nil, &grepo); err != nil {
+		return nil, err
+	}
+	return &grepo, nil
+}
+
+// Create creates a new repository under the owner which may be a user or
+// organization.  If no owner is given it is created under the token user. It
+// is a no-op if the repository exists
+func (gt *giteaVCS) Create(repo *Repository, opt Option) (interface{}, bool, error) {
+	user, err := gt.currentUser()
+	if err != nil {
+		return nil, false, err
+	}
+
+	owner := repo.Owner
+	if owner == "" {
+		owner = user.Login
+	}
+
+	var grepo giteaRepo
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo.Name)
+	err = gt.client.do(http.MethodGet, path, nil, &grepo)
+	if err == nil {
+		return &grepo, false, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	req := map[string]interface{}{
+		"name":        repo.Name,
+		"description": repo.Description,
+		"private":     repo.Private,
+	}
+
+	path = "/user/repos"
+	if owner != user.Login {
+		path = "/orgs/" + url.PathEscape(owner) + "/repos"
+	}
+
+	err = gt.client.do(http.MethodPost, path, req, &grepo)
+	if err != nil {
+		return nil, false, err
+	}
+	return &grepo, true, nil
+}
+
+// Delete deletes the repository from gitea
+func (gt *giteaVCS) Delete(repo *Repository, opt Option) error {
+	path, err := gt.repoPath(repo)
+	if err == nil {
+		err = gt.client.do(http.MethodDelete, path, nil, nil)
+	}
+	return err
+}
+
+// AddHook adds a repository webhook.  Supported events are push, tag and
+// pull_request
+func (gt *giteaVCS) AddHook(repo *Repository, hook *Webhook) (*Webhook, error) {
+	path, err := gt.repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &giteaHook{
+		Type: "gitea",
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+			"secret":       hook.Secret,
+		},
+		Active: true,
+	}
+
+	if len(hook.Events) == 0 {
+		req.Events = []string{"push"}
+	}
+	for _, e := range hook.Events {
+		switch e {
+		case "push":
+			req.Events = append(req.Events, "push")
+		case "tag":
+			req.Events = append(req.Events, "create")
+		case "merge_request", "pull_request":
+			req.Events = append(req.Events, "pull_request")
+		default:
+			return nil, errors.New("unsupported event: " + e)
+		}
+	}
+
+	var resp giteaHook
+	if err = gt.client.do(http.MethodPost, path+"/hooks", req, &resp); err != nil {
+		return nil, err
+	}
+
+	out := *hook
+	out.ID = strconv.FormatInt(resp.ID, 10)
+	return &out, nil
+}
+
+// RemoveHook removes a repository webhook by id
+func (gt *giteaVCS) RemoveHook(repo *Repository, hook *Webhook) error {
+	path, err := gt.repoPath(repo)
+	if err == nil {
+		err = gt.client.do(http.MethodDelete, path+"/hooks/"+hook.ID, nil, nil)
+	}
+	return err
+}
+
+func (gt *giteaVCS) Open(repo *Repository, opt Option) (interface{}, error) {
+	return gt.git.Open(repo, opt)
+}
+
+func (gt *giteaVCS) Status(opt Option) (git.Status, error) {
+	return gt.git.Status(opt)
+}
+
+func (gt *giteaVCS) IgnoresFile() string {
+	return gt.git.IgnoresFile()
+}
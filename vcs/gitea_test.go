@@ -0,0 +1,109 @@
+package vcs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gitea api stand-in where the token user is 'me' and 'platform' is an org
+func newTestGiteaServer(t *testing.T) *httptest.Server {
+	repos := make(map[string]*giteaRepo)
+
+	create := func(owner string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+
+			repo := &giteaRepo{ID: 1, Name: req["name"].(string), FullName: owner + "/" + req["name"].(string)}
+			repos[repo.FullName] = repo
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(repo)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(&giteaUser{ID: 1, Login: "me"})
+	})
+	mux.HandleFunc("/api/v1/user/repos", create("me"))
+	mux.HandleFunc("/api/v1/orgs/platform/repos", create("platform"))
+	mux.HandleFunc("/api/v1/repos/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/platform/api/hooks":
+			var hook giteaHook
+			json.NewDecoder(r.Body).Decode(&hook)
+			assert.Equal(t, []string{"push", "create"}, hook.Events)
+			hook.ID = 9
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(&hook)
+			return
+
+		case "/api/v1/repos/platform/api/hooks/9":
+			w.WriteHeader(204)
+			return
+		}
+
+		name := r.URL.Path[len("/api/v1/repos/"):]
+		repo, ok := repos[name]
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(repos, name)
+			w.WriteHeader(204)
+			return
+		}
+		json.NewEncoder(w).Encode(repo)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func Test_Gitea(t *testing.T) {
+	srv := newTestGiteaServer(t)
+	defer srv.Close()
+
+	gt := newGiteaVCS(nil)
+	assert.Equal(t, errAddrRequired, gt.Init(map[string]interface{}{}))
+
+	err := gt.Init(map[string]interface{}{"addr": srv.URL, "token": "secret"})
+	assert.Nil(t, err)
+	assert.Equal(t, "gitea", gt.ID())
+
+	var opt Option
+
+	// User owned
+	urepo := &Repository{Name: "tool"}
+	_, created, err := gt.Create(urepo, opt)
+	assert.Nil(t, err)
+	assert.True(t, created)
+	_, err = gt.Get(urepo, opt)
+	assert.Nil(t, err)
+
+	// Org owned
+	repo := &Repository{Name: "api", Owner: "platform"}
+	_, created, err = gt.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.True(t, created)
+
+	_, created, err = gt.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.False(t, created)
+
+	hook, err := gt.AddHook(repo, &Webhook{URL: "http://thrap/hook", Events: []string{"push", "tag"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "9", hook.ID)
+	assert.Nil(t, gt.RemoveHook(repo, hook))
+
+	assert.Nil(t, gt.Delete(repo, opt))
+	_, err = gt.Get(repo, opt)
+	assert.True(t, isNotFound(err))
+
+	assert.Equal(t, "ssh://git@"+srv.Listener.Addr().String()+"/platform/api", gt.RemoteURL(repo, true))
+}
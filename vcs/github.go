@@ -5,14 +5,13 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
 	git "gopkg.in/src-d/go-git.v4"
 )
 
-const defaultHookName = "thrap"
-
 // github repo extending git
 type githubVCS struct {
 	git    *GitVCS
@@ -73,22 +72,71 @@ func (gh *githubVCS) Get(repo *Repository, opt Option) (interface{}, error) {
 	return ghRepo, err
 }
 
-func (gh *githubVCS) AddHook(repo *Repository) (interface{}, error) {
-	rs := gh.client.Repositories
+// AddHook adds a repository webhook.  Supported events are push, tag and
+// pull_request
+func (gh *githubVCS) AddHook(repo *Repository, hook *Webhook) (*Webhook, error) {
+	var (
+		hookName = "web"
+		active   = true
+		events   = make([]string, 0, len(hook.Events))
+	)
+
+	if len(hook.Events) == 0 {
+		events = append(events, "push")
+	}
+	for _, e := range hook.Events {
+		switch e {
+		case "push":
+			events = append(events, "push")
+		case "tag":
+			events = append(events, "create")
+		case "merge_request", "pull_request":
+			events = append(events, "pull_request")
+		default:
+			return nil, errors.New("unsupported event: " + e)
+		}
+	}
+
+	ghook := &github.Hook{
+		Name:   &hookName,
+		Active: &active,
+		Events: events,
+		Config: map[string]interface{}{
+			"url":          hook.URL,
+			"content_type": "json",
+			"secret":       hook.Secret,
+		},
+	}
 
 	ctx := context.Background()
+	rhook, _, err := gh.client.Repositories.CreateHook(ctx, repo.Owner, repo.Name, ghook)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *hook
+	out.ID = strconv.FormatInt(rhook.GetID(), 10)
+	return &out, nil
+}
 
-	hookName := defaultHookName
-	hook := &github.Hook{
-		Name: &hookName,
+// RemoveHook removes a repository webhook by id
+func (gh *githubVCS) RemoveHook(repo *Repository, hook *Webhook) error {
+	id, err := strconv.ParseInt(hook.ID, 10, 64)
+	if err != nil {
+		return err
 	}
 
-	rhook, _, err := rs.CreateHook(ctx, repo.Owner, repo.Name, hook)
-	return rhook, err
+	ctx := context.Background()
+	_, err = gh.client.Repositories.DeleteHook(ctx, repo.Owner, repo.Name, id)
+	return err
 }
 
-func (gh *githubVCS) RemoveHook(repo *Repository) (interface{}, error) {
-	return nil, errors.New("to be implemented")
+// RemoteURL returns the ssh or https remote url for the repo
+func (gh *githubVCS) RemoteURL(repo *Repository, ssh bool) string {
+	if ssh {
+		return DefaultGitRemoteURL("github.com", repo.Owner, repo.Name)
+	}
+	return HTTPSGitRemoteURL("github.com", repo.Owner, repo.Name)
 }
 
 // Create creates a new repo. Each call only fills in missing pieces so multiple
@@ -0,0 +1,239 @@
+package vcs
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+const defaultGitlabAddr = "gitlab.com"
+
+// gitlabProject is the subset of the gitlab project api object used
+type gitlabProject struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	Description       string `json:"description"`
+	Visibility        string `json:"visibility"`
+	SSHURLToRepo      string `json:"ssh_url_to_repo"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	WebURL            string `json:"web_url"`
+}
+
+type gitlabNamespace struct {
+	ID       int    `json:"id"`
+	FullPath string `json:"full_path"`
+	Kind     string `json:"kind"`
+}
+
+type gitlabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+type gitlabHook struct {
+	ID                  int    `json:"id,omitempty"`
+	URL                 string `json:"url"`
+	Token               string `json:"token,omitempty"`
+	PushEvents          bool   `json:"push_events"`
+	TagPushEvents       bool   `json:"tag_push_events"`
+	MergeRequestsEvents bool   `json:"merge_requests_events"`
+}
+
+// gitlab repo extending git. Repo owners map to a user or group namespace
+type gitlabVCS struct {
+	git *GitVCS
+	// Host used to generate remote urls
+	host   string
+	client *restClient
+}
+
+// takes an optional underlying git vcs
+func newGitlabVCS(g *GitVCS) *gitlabVCS {
+	gl := &gitlabVCS{git: g}
+	if gl.git == nil {
+		gl.git = NewGitVCS()
+	}
+	return gl
+}
+
+func (gl *gitlabVCS) ID() string {
+	return "gitlab"
+}
+
+// Init initializes the underlying git vcs and the api client.  addr defaults
+// to gitlab.com and the token may also be supplied via GITLAB_ACCESS_TOKEN
+func (gl *gitlabVCS) Init(conf map[string]interface{}) error {
+	err := gl.git.Init(conf)
+	if err != nil {
+		return err
+	}
+
+	addr, err := getConfString(conf, "addr")
+	if err != nil {
+		return err
+	}
+	if addr == "" {
+		addr = defaultGitlabAddr
+	}
+
+	token, err := getConfString(conf, "token")
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		token = os.Getenv("GITLAB_ACCESS_TOKEN")
+	}
+
+	base, host, err := parseProviderAddr(addr)
+	if err != nil {
+		return err
+	}
+
+	gl.host = host
+	gl.client = newRESTClient(base+"/api/v4", "PRIVATE-TOKEN", token)
+
+	return nil
+}
+
+func (gl *gitlabVCS) GlobalUser() string {
+	return gl.git.globalUser
+}
+
+func (gl *gitlabVCS) GlobalEmail() string {
+	return gl.git.globalEmail
+}
+
+// RemoteURL returns the ssh or https remote url for the repo
+func (gl *gitlabVCS) RemoteURL(repo *Repository, ssh bool) string {
+	if ssh {
+		return DefaultGitRemoteURL(gl.host, repo.Owner, repo.Name)
+	}
+	return HTTPSGitRemoteURL(gl.host, repo.Owner, repo.Name)
+}
+
+// projectID returns the url encoded project path used as the id in api
+// calls
+func (gl *gitlabVCS) projectID(repo *Repository) string {
+	if repo.Owner == "" {
+		return url.PathEscape(repo.Name)
+	}
+	return url.PathEscape(repo.Owner + "/" + repo.Name)
+}
+
+// Get returns info on a gitlab project
+func (gl *gitlabVCS) Get(repo *Repository, opt Option) (interface{}, error) {
+	var proj gitlabProject
+	err := gl.client.do(http.MethodGet, "/projects/"+gl.projectID(repo), nil, &proj)
+	if err != nil {
+		return nil, err
+	}
+	return &proj, nil
+}
+
+// Create creates a new project under the owner namespace which may be a user
+// or group.  If no owner is given it is created under the token user.  It is
+// a no-op if the project exists
+func (gl *gitlabVCS) Create(repo *Repository, opt Option) (interface{}, bool, error) {
+	// Projects without an owner live under the token user namespace
+	existing := repo
+	if repo.Owner == "" {
+		var user gitlabUser
+		err := gl.client.do(http.MethodGet, "/user", nil, &user)
+		if err != nil {
+			return nil, false, err
+		}
+		existing = &Repository{Name: repo.Name, Owner: user.Username}
+	}
+
+	proj, err := gl.Get(existing, opt)
+	if err == nil {
+		return proj, false, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	// Projects are always created private.  Visibility can be widened on
+	// gitlab once the project has been reviewed
+	req := map[string]interface{}{
+		"name":        repo.Name,
+		"path":        repo.Name,
+		"description": repo.Description,
+		"visibility":  "private",
+	}
+
+	if repo.Owner != "" {
+		var ns gitlabNamespace
+		err = gl.client.do(http.MethodGet, "/namespaces/"+url.PathEscape(repo.Owner), nil, &ns)
+		if err != nil {
+			return nil, false, err
+		}
+		req["namespace_id"] = ns.ID
+	}
+
+	var created gitlabProject
+	err = gl.client.do(http.MethodPost, "/projects", req, &created)
+	if err != nil {
+		return nil, false, err
+	}
+	return &created, true, nil
+}
+
+// Delete deletes the project from gitlab
+func (gl *gitlabVCS) Delete(repo *Repository, opt Option) error {
+	return gl.client.do(http.MethodDelete, "/projects/"+gl.projectID(repo), nil, nil)
+}
+
+// AddHook adds a project webhook.  Supported events are push, tag and
+// merge_request
+func (gl *gitlabVCS) AddHook(repo *Repository, hook *Webhook) (*Webhook, error) {
+	req := &gitlabHook{URL: hook.URL, Token: hook.Secret}
+	if len(hook.Events) == 0 {
+		req.PushEvents = true
+	}
+	for _, e := range hook.Events {
+		switch e {
+		case "push":
+			req.PushEvents = true
+		case "tag":
+			req.TagPushEvents = true
+		case "merge_request", "pull_request":
+			req.MergeRequestsEvents = true
+		default:
+			return nil, errors.New("unsupported event: " + e)
+		}
+	}
+
+	var resp gitlabHook
+	err := gl.client.do(http.MethodPost, "/projects/"+gl.projectID(repo)+"/hooks", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *hook
+	out.ID = strconv.Itoa(resp.ID)
+	return &out, nil
+}
+
+// RemoveHook removes a project webhook by id
+func (gl *gitlabVCS) RemoveHook(repo *Repository, hook *Webhook) error {
+	return gl.client.do(http.MethodDelete, "/projects/"+gl.projectID(repo)+"/hooks/"+hook.ID, nil, nil)
+}
+
+func (gl *gitlabVCS) Open(repo *Repository, opt Option) (interface{}, error) {
+	return gl.git.Open(repo, opt)
+}
+
+func (gl *gitlabVCS) Status(opt Option) (git.Status, error) {
+	return gl.git.Status(opt)
+}
+
+func (gl *gitlabVCS) IgnoresFile() string {
+	return gl.git.IgnoresFile()
+}
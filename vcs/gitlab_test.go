@@ -0,0 +1,152 @@
+package vcs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gitlab api stand-in with a single group namespace and the token user dev
+func newTestGitlabServer(t *testing.T) *httptest.Server {
+	projects := make(map[string]*gitlabProject)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&gitlabUser{ID: 3, Username: "dev"})
+	})
+	mux.HandleFunc("/api/v4/namespaces/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/namespaces/platform" {
+			w.WriteHeader(404)
+			return
+		}
+		json.NewEncoder(w).Encode(&gitlabNamespace{ID: 7, FullPath: "platform", Kind: "group"})
+	})
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "private", req["visibility"])
+
+		ns := "dev"
+		if req["namespace_id"] != nil {
+			assert.Equal(t, float64(7), req["namespace_id"])
+			ns = "platform"
+		}
+
+		path := ns + "/" + req["name"].(string)
+		if _, ok := projects[path]; ok {
+			w.WriteHeader(400)
+			json.NewEncoder(w).Encode(map[string]interface{}{"message": map[string][]string{"name": {"has already been taken"}}})
+			return
+		}
+		proj := &gitlabProject{ID: len(projects) + 1, Name: req["name"].(string), PathWithNamespace: path}
+		projects[path] = proj
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(proj)
+	})
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/platform%2Fapi/hooks":
+			var hook gitlabHook
+			json.NewDecoder(r.Body).Decode(&hook)
+			assert.True(t, hook.MergeRequestsEvents)
+			hook.ID = 42
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(&hook)
+
+		case "/api/v4/projects/platform%2Fapi/hooks/42":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(204)
+
+		default:
+			path, _ := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4/projects/"))
+			proj, ok := projects[path]
+			if !ok {
+				w.WriteHeader(404)
+				json.NewEncoder(w).Encode(map[string]string{"message": "404 Project Not Found"})
+				return
+			}
+			if r.Method == http.MethodDelete {
+				delete(projects, path)
+				w.WriteHeader(202)
+				return
+			}
+			json.NewEncoder(w).Encode(proj)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func Test_Gitlab(t *testing.T) {
+	srv := newTestGitlabServer(t)
+	defer srv.Close()
+
+	gl := newGitlabVCS(nil)
+	err := gl.Init(map[string]interface{}{"addr": srv.URL, "token": "secret"})
+	assert.Nil(t, err)
+	assert.Equal(t, "gitlab", gl.ID())
+
+	var opt Option
+	repo := &Repository{Name: "api", Owner: "platform"}
+
+	_, err = gl.Get(repo, opt)
+	assert.True(t, isNotFound(err))
+
+	_, created, err := gl.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.True(t, created)
+
+	_, created, err = gl.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.False(t, created)
+
+	hook, err := gl.AddHook(repo, &Webhook{URL: "http://thrap/hook", Events: []string{"merge_request"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "42", hook.ID)
+	assert.Nil(t, gl.RemoveHook(repo, hook))
+
+	_, err = gl.AddHook(repo, &Webhook{URL: "http://thrap/hook", Events: []string{"foo"}})
+	assert.NotNil(t, err)
+
+	assert.Nil(t, gl.Delete(repo, opt))
+	_, err = gl.Get(repo, opt)
+	assert.True(t, isNotFound(err))
+
+	// Unknown group
+	_, _, err = gl.Create(&Repository{Name: "api", Owner: "other"}, opt)
+	assert.NotNil(t, err)
+
+	// Without an owner the project is looked up under the token user
+	repo = &Repository{Name: "web"}
+	_, created, err = gl.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.True(t, created)
+
+	proj, created, err := gl.Create(repo, opt)
+	assert.Nil(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "dev/web", proj.(*gitlabProject).PathWithNamespace)
+}
+
+func Test_Gitlab_RemoteURL(t *testing.T) {
+	gl := newGitlabVCS(nil)
+	err := gl.Init(map[string]interface{}{"addr": "https://git.example.com"})
+	assert.Nil(t, err)
+
+	repo := &Repository{Name: "api", Owner: "platform"}
+	assert.Equal(t, "ssh://git@git.example.com/platform/api", gl.RemoteURL(repo, true))
+	assert.Equal(t, "https://git.example.com/platform/api.git", gl.RemoteURL(repo, false))
+
+	// Default address
+	gl = newGitlabVCS(nil)
+	gl.Init(map[string]interface{}{})
+	assert.Equal(t, "https://gitlab.com/platform/api.git", gl.RemoteURL(repo, false))
+}
@@ -0,0 +1,206 @@
+package vcs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// FindRepoRoot returns the first directory from path upwards containing a
+// .git directory.  It returns an error if none is found
+func FindRepoRoot(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if fi, err := os.Stat(filepath.Join(dir, ".git")); err == nil && fi.IsDir() {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", git.ErrRepositoryNotExists
+		}
+		dir = parent
+	}
+}
+
+// GetPathVersion returns the version of a subtree of the repo at repoPath.
+// The tag is the highest semver tag reachable from HEAD named
+// prefix/<version> e.g. api/v1.4.0, with the prefix stripped.  The count is the number of commits touching the
+// subtree since the tag and the hash is that of the last commit touching the
+// subtree, so commits elsewhere in the repo do not change the version
+func GetPathVersion(repoPath, prefix, subpath string) (RepoVersion, error) {
+	rv := RepoVersion{Tag: defaultVersionTag}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return rv, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return rv, err
+	}
+
+	reachable, err := reachableCommits(repo, head.Hash())
+	if err != nil {
+		return rv, err
+	}
+
+	var released map[plumbing.Hash]bool
+	if tag, _ := latestPrefixedTag(repo, prefix, reachable); tag != nil {
+		rv.Tag = strings.TrimPrefix(tag.Name, prefix+"/")
+		if released, err = reachableCommits(repo, tag.Commit); err != nil {
+			return rv, err
+		}
+	}
+
+	subpath = filepath.ToSlash(filepath.Clean(subpath))
+	if subpath == "." {
+		subpath = ""
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return rv, err
+	}
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		if released[c.Hash] {
+			return nil
+		}
+
+		touched, err := commitTouchesPath(c, subpath)
+		if err != nil {
+			return err
+		}
+		if touched {
+			if rv.Count == 0 {
+				rv.Hash = c.Hash
+			}
+			rv.Count++
+		}
+		return nil
+	})
+
+	return rv, err
+}
+
+// latestPrefixedTag returns the highest semver tag of the form
+// prefix/<version> in the set of reachable commits
+func latestPrefixedTag(repo *git.Repository, prefix string, reachable map[plumbing.Hash]bool) (*SemverTag, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *SemverTag
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !strings.HasPrefix(name, prefix+"/") {
+			return nil
+		}
+
+		ver := parseSemverTag(strings.TrimPrefix(name, prefix+"/"))
+		if ver == nil {
+			return nil
+		}
+
+		commit := resolveTagCommit(repo, ref)
+		if !reachable[commit] {
+			return nil
+		}
+		if latest == nil || latest.Version.LessThan(ver) {
+			latest = &SemverTag{Name: name, Version: ver, Commit: commit}
+		}
+		return nil
+	})
+
+	return latest, err
+}
+
+// commitTouchesPath returns true if the commit changed anything under the path
+// compared to its first parent
+func commitTouchesPath(c *object.Commit, path string) (bool, error) {
+	curr, err := pathHash(c, path)
+	if err != nil {
+		return false, err
+	}
+
+	if c.NumParents() == 0 {
+		return !curr.IsZero(), nil
+	}
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return false, err
+	}
+
+	prev, err := pathHash(parent, path)
+	if err != nil {
+		return false, err
+	}
+
+	return curr != prev, nil
+}
+
+// pathHash returns the object hash of the path in the commit tree.  It
+// returns a zero hash if the path does not exist
+func pathHash(c *object.Commit, path string) (plumbing.Hash, error) {
+	if path == "" {
+		return c.TreeHash, nil
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		if err == object.ErrDirectoryNotFound || err == object.ErrEntryNotFound {
+			return plumbing.ZeroHash, nil
+		}
+		return plumbing.ZeroHash, err
+	}
+
+	return entry.Hash, nil
+}
+
+// PathHasChanges returns true if the worktree has uncommitted changes under
+// the subpath
+func PathHasChanges(repoPath, subpath string) (bool, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return false, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+
+	subpath = filepath.ToSlash(filepath.Clean(subpath))
+	for fpath, fs := range status {
+		if fs.Staging == git.Unmodified && fs.Worktree == git.Unmodified {
+			continue
+		}
+		if subpath == "." || fpath == subpath || strings.HasPrefix(fpath, subpath+"/") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,94 @@
+package vcs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func Test_GetPathVersion(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "pv-")
+	defer os.RemoveAll(tmpdir)
+
+	_, repo, _ := SetupLocalGitRepo("test", "me", tmpdir, "foo.com")
+	wt, _ := repo.Worktree()
+
+	commit := func(fname string) plumbing.Hash {
+		fpath := filepath.Join(tmpdir, fname)
+		os.MkdirAll(filepath.Dir(fpath), 0755)
+		ioutil.WriteFile(fpath, []byte(fname+plumbing.ZeroHash.String()), 0644)
+		wt.Add(fname)
+		h, err := wt.Commit("update "+fname, committer())
+		fatal(t, err)
+		return h
+	}
+
+	commit("api/main.go")
+	apiHash := commit("api/util.go")
+	commit("web/index.js")
+
+	ver, err := GetPathVersion(tmpdir, "api", "api")
+	fatal(t, err)
+	assert.Equal(t, "v0.0.0", ver.Tag)
+	assert.Equal(t, 2, ver.Count)
+	assert.Equal(t, apiHash, ver.Hash)
+
+	// Tag the api
+	ref := plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/api/v1.4.0"), apiHash)
+	repo.Storer.SetReference(ref)
+	ref = plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/api/v1.10.0-rc.1"), apiHash)
+	repo.Storer.SetReference(ref)
+
+	// Commits outside the path do not change the version
+	commit("web/app.js")
+	ver, err = GetPathVersion(tmpdir, "api", "api")
+	fatal(t, err)
+	assert.Equal(t, "v1.10.0-rc.1", ver.String())
+
+	h := commit("api/handler.go")
+	ver, _ = GetPathVersion(tmpdir, "api", "api")
+	assert.Equal(t, 1, ver.Count)
+	assert.Equal(t, h, ver.Hash)
+
+	// Tags on unmerged branches are ignored
+	hc, _ := repo.CommitObject(h)
+	branch := &object.Commit{
+		Author:       *committer().Author,
+		Committer:    *committer().Author,
+		Message:      "unmerged",
+		TreeHash:     hc.TreeHash,
+		ParentHashes: []plumbing.Hash{h},
+	}
+	obj := repo.Storer.NewEncodedObject()
+	fatal(t, branch.Encode(obj))
+	bh, err := repo.Storer.SetEncodedObject(obj)
+	fatal(t, err)
+	ref = plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/api/v2.0.0"), bh)
+	repo.Storer.SetReference(ref)
+
+	ver, _ = GetPathVersion(tmpdir, "api", "api")
+	assert.Equal(t, "v1.10.0-rc.1", ver.Tag)
+	assert.Equal(t, 1, ver.Count)
+
+	ver, _ = GetPathVersion(tmpdir, "web", "web")
+	assert.Equal(t, 2, ver.Count)
+
+	changed, err := PathHasChanges(tmpdir, "web")
+	fatal(t, err)
+	assert.False(t, changed)
+
+	ioutil.WriteFile(filepath.Join(tmpdir, "web", "index.js"), []byte("changed"), 0644)
+	changed, _ = PathHasChanges(tmpdir, "web")
+	assert.True(t, changed)
+	changed, _ = PathHasChanges(tmpdir, "api")
+	assert.False(t, changed)
+
+	root, err := FindRepoRoot(filepath.Join(tmpdir, "api"))
+	fatal(t, err)
+	assert.Equal(t, tmpdir, root)
+}
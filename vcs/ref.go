@@ -0,0 +1,64 @@
+package vcs
+
+import (
+	"errors"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+var (
+	errDetachedHead = errors.New("head is not a branch")
+)
+
+// CurrentBranch returns the short name of the branch checked out in the repo
+// at repoPath.  It returns an error if the head is detached
+func CurrentBranch(repoPath string) (string, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	if !head.Name().IsBranch() {
+		return "", errDetachedHead
+	}
+
+	return head.Name().Short(), nil
+}
+
+// ResolveRef returns the commit hash the given branch, tag or revision points
+// to in the repo at repoPath
+func ResolveRef(repoPath, ref string) (plumbing.Hash, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	h, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return *h, nil
+}
+
+// HeadCommit returns the commit hash the head of the repo at repoPath
+// points to
+func HeadCommit(repoPath string) (plumbing.Hash, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return head.Hash(), nil
+}
@@ -0,0 +1,463 @@
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	version "github.com/hashicorp/go-version"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const (
+	// DefaultChangelogFile is the changelog filename written on release
+	DefaultChangelogFile = "CHANGELOG.md"
+
+	changelogHeader = "# Changelog\n"
+)
+
+var (
+	errNothingToRelease = errors.New("no commits since last release")
+	errTagExists        = errors.New("tag exists")
+	errWorktreeDirty    = errors.New("worktree has uncommitted changes")
+)
+
+// Bump is a semantic version increment
+type Bump int
+
+const (
+	// BumpNone signifies no increment
+	BumpNone Bump = iota
+	// BumpPatch increments the patch version
+	BumpPatch
+	// BumpMinor increments the minor version resetting the patch
+	BumpMinor
+	// BumpMajor increments the major version resetting minor and patch
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// conventionalRegexp matches the header of a conventional commit i.e.
+// type(scope)!: subject
+var conventionalRegexp = regexp.MustCompile(`^([a-zA-Z]+)(\(([^)]*)\))?(!)?:\s+(.+)$`)
+
+// ConventionalCommit is a commit parsed per the conventional commits spec.
+// Commits not following the spec have an empty type
+type ConventionalCommit struct {
+	Hash     plumbing.Hash
+	Type     string
+	Scope    string
+	Subject  string
+	Breaking bool
+}
+
+// ParseConventionalCommit parses a commit message
+func ParseConventionalCommit(hash plumbing.Hash, message string) *ConventionalCommit {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	cc := &ConventionalCommit{Hash: hash, Subject: strings.TrimSpace(lines[0])}
+
+	m := conventionalRegexp.FindStringSubmatch(cc.Subject)
+	if m == nil {
+		return cc
+	}
+
+	cc.Type = strings.ToLower(m[1])
+	cc.Scope = m[3]
+	cc.Breaking = m[4] == "!"
+	cc.Subject = m[5]
+
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			cc.Breaking = true
+			break
+		}
+	}
+
+	return cc
+}
+
+// Bump returns the version increment the commit calls for
+func (cc *ConventionalCommit) Bump() Bump {
+	switch {
+	case cc.Breaking:
+		return BumpMajor
+	case cc.Type == "feat":
+		return BumpMinor
+	case cc.Type == "fix", cc.Type == "perf":
+		return BumpPatch
+	}
+	return BumpNone
+}
+
+// ReleaseOptions are options used to compute the next release
+type ReleaseOptions struct {
+	// Bump overrides the increment computed from the commits
+	Bump Bump
+	// Prerelease identifier e.g. rc.  If set the next version is a numbered
+	// prerelease of the computed version
+	Prerelease string
+}
+
+// Release is the next release computed from the commit history
+type Release struct {
+	// Last release. nil if there is none
+	Previous *SemverTag
+	// Version being released
+	Version *version.Version
+	// Increment applied to the previous release
+	Bump Bump
+	// Commits since the previous release, newest first
+	Commits []*ConventionalCommit
+	// Commit being released
+	Head plumbing.Hash
+}
+
+// Tag returns the tag name for the release
+func (rel *Release) Tag() string {
+	return "v" + rel.Version.String()
+}
+
+// NextRelease computes the next release from the commits since the last
+// non-prerelease semver tag reachable from HEAD
+func NextRelease(repo *git.Repository, opts ReleaseOptions) (*Release, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	reachable, err := reachableCommits(repo, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := latestReachableTag(repo, reachable)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := &Release{Previous: prev, Head: head.Hash()}
+	rel.Commits, err = commitsSince(repo, rel.Head, prev)
+	if err != nil {
+		return nil, err
+	}
+	if len(rel.Commits) == 0 {
+		return nil, errNothingToRelease
+	}
+
+	rel.Bump = opts.Bump
+	if rel.Bump == BumpNone {
+		for _, c := range rel.Commits {
+			if b := c.Bump(); b > rel.Bump {
+				rel.Bump = b
+			}
+		}
+		if rel.Bump == BumpNone {
+			rel.Bump = BumpPatch
+		}
+	}
+
+	base := "0.0.0"
+	if prev != nil {
+		base = prev.Version.String()
+	}
+	next, err := bumpVersion(base, rel.Bump)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Prerelease != "" {
+		tags, err := SemverTags(repo)
+		if err != nil {
+			return nil, err
+		}
+		next += "-" + opts.Prerelease + "." + strconv.Itoa(nextPrereleaseNum(tags, next, opts.Prerelease))
+	}
+
+	rel.Version, err = version.NewVersion(next)
+	return rel, err
+}
+
+// latestReachableTag returns the highest non-prerelease semver tag whose
+// commit is in the reachable set.  It returns nil if there is none
+func latestReachableTag(repo *git.Repository, reachable map[plumbing.Hash]bool) (*SemverTag, error) {
+	tags, err := SemverTags(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		if !tags[i].IsPrerelease() && reachable[tags[i].Commit] {
+			return tags[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// reachableCommits returns the set of commits reachable from the given commit
+// including itself
+func reachableCommits(repo *git.Repository, from plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	iter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[plumbing.Hash]bool)
+	err = iter.ForEach(func(c *object.Commit) error {
+		out[c.Hash] = true
+		return nil
+	})
+
+	return out, err
+}
+
+// commitsSince returns commits reachable from head that are not reachable
+// from the tagged commit.  The tag is expected to be an ancestor of head
+func commitsSince(repo *git.Repository, head plumbing.Hash, tag *SemverTag) ([]*ConventionalCommit, error) {
+	released := make(map[plumbing.Hash]bool)
+	if tag != nil {
+		var err error
+		if released, err = reachableCommits(repo, tag.Commit); err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*ConventionalCommit, 0)
+	err = iter.ForEach(func(c *object.Commit) error {
+		if !released[c.Hash] {
+			out = append(out, ParseConventionalCommit(c.Hash, c.Message))
+		}
+		return nil
+	})
+
+	return out, err
+}
+
+// bumpVersion increments the core version
+func bumpVersion(base string, bump Bump) (string, error) {
+	ver, err := version.NewVersion(base)
+	if err != nil {
+		return "", err
+	}
+
+	segs := ver.Segments()
+	for len(segs) < 3 {
+		segs = append(segs, 0)
+	}
+
+	switch bump {
+	case BumpMajor:
+		segs[0], segs[1], segs[2] = segs[0]+1, 0, 0
+	case BumpMinor:
+		segs[1], segs[2] = segs[1]+1, 0
+	case BumpPatch:
+		segs[2]++
+	}
+
+	return fmt.Sprintf("%d.%d.%d", segs[0], segs[1], segs[2]), nil
+}
+
+// nextPrereleaseNum returns the next number for the prerelease identifier of
+// the given core version starting at 1
+func nextPrereleaseNum(tags []*SemverTag, core, pre string) int {
+	n := 0
+	prefix := pre + "."
+	for _, t := range tags {
+		segs := t.Version.Segments()
+		if len(segs) < 3 || fmt.Sprintf("%d.%d.%d", segs[0], segs[1], segs[2]) != core {
+			continue
+		}
+
+		p := t.Version.Prerelease()
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		if i, err := strconv.Atoi(strings.TrimPrefix(p, prefix)); err == nil && i > n {
+			n = i
+		}
+	}
+	return n + 1
+}
+
+// changelog section titles in the order they are written
+var changelogSections = []struct {
+	title string
+	match func(*ConventionalCommit) bool
+}{
+	{"Breaking Changes", func(c *ConventionalCommit) bool { return c.Breaking }},
+	{"Features", func(c *ConventionalCommit) bool { return c.Type == "feat" }},
+	{"Bug Fixes", func(c *ConventionalCommit) bool { return c.Type == "fix" }},
+	{"Performance", func(c *ConventionalCommit) bool { return c.Type == "perf" }},
+}
+
+// Changelog returns the markdown changelog section for the release.  Only
+// breaking changes, features, fixes and performance commits are listed
+func (rel *Release) Changelog(date time.Time) string {
+	buf := bytes.NewBufferString(fmt.Sprintf("## %s (%s)\n", rel.Tag(), date.Format("2006-01-02")))
+
+	for _, sec := range changelogSections {
+		var items []string
+		for _, c := range rel.Commits {
+			if !sec.match(c) {
+				continue
+			}
+			item := "- "
+			if c.Scope != "" {
+				item += "**" + c.Scope + ":** "
+			}
+			items = append(items, item+c.Subject+" ("+c.Hash.String()[:8]+")")
+		}
+
+		if len(items) > 0 {
+			fmt.Fprintf(buf, "\n### %s\n\n%s\n", sec.title, strings.Join(items, "\n"))
+		}
+	}
+
+	return buf.String()
+}
+
+// WriteChangelog adds the section to the top of the changelog file creating
+// it if needed
+func WriteChangelog(fpath, section string) error {
+	existing, err := ioutil.ReadFile(fpath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	body := strings.TrimPrefix(string(existing), changelogHeader)
+	out := changelogHeader + "\n" + section + "\n" + strings.TrimLeft(body, "\n")
+
+	return ioutil.WriteFile(fpath, []byte(out), 0644)
+}
+
+// ApplyOptions are options used to apply a release to the repo
+type ApplyOptions struct {
+	// Changelog file relative to the repo root. If empty no changelog is
+	// written
+	Changelog string
+	// Tagger and committer signature
+	Signature object.Signature
+	// Push the release commit and tag to the remote
+	Push bool
+	// Remote to push to. Defaults to origin
+	Remote string
+}
+
+// Apply writes and commits the changelog if requested, creates the annotated
+// release tag and optionally pushes both.  The worktree must be clean
+func (rel *Release) Apply(repo *git.Repository, path string, opts ApplyOptions) error {
+	if _, err := repo.Reference(plumbing.ReferenceName("refs/tags/"+rel.Tag()), false); err == nil {
+		return errTagExists
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	if !status.IsClean() {
+		return errWorktreeDirty
+	}
+
+	now := time.Now()
+	section := rel.Changelog(now)
+	opts.Signature.When = now
+
+	target := rel.Head
+	if opts.Changelog != "" {
+		if err = WriteChangelog(filepath.Join(path, opts.Changelog), section); err != nil {
+			return err
+		}
+		if _, err = wt.Add(opts.Changelog); err != nil {
+			return err
+		}
+
+		target, err = wt.Commit("chore(release): "+rel.Tag(), &git.CommitOptions{
+			Author: &opts.Signature,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = createAnnotatedTag(repo, rel.Tag(), section, opts.Signature, target); err != nil {
+		return err
+	}
+
+	if !opts.Push {
+		return nil
+	}
+
+	remote := opts.Remote
+	if remote == "" {
+		remote = defaultRemoteName
+	}
+
+	tagRef := "refs/tags/" + rel.Tag()
+	refSpecs := []config.RefSpec{config.RefSpec(tagRef + ":" + tagRef)}
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		branch := head.Name().String()
+		refSpecs = append(refSpecs, config.RefSpec(branch+":"+branch))
+	}
+
+	err = repo.Push(&git.PushOptions{RemoteName: remote, RefSpecs: refSpecs})
+	if err == git.NoErrAlreadyUpToDate {
+		err = nil
+	}
+	return err
+}
+
+// createAnnotatedTag writes an annotated tag object for the commit and the
+// tag reference pointing to it
+func createAnnotatedTag(repo *git.Repository, name, message string, tagger object.Signature, target plumbing.Hash) error {
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     tagger,
+		Message:    message,
+		TargetType: plumbing.CommitObject,
+		Target:     target,
+	}
+
+	obj := repo.Storer.NewEncodedObject()
+	if err := tag.Encode(obj); err != nil {
+		return err
+	}
+
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return err
+	}
+
+	ref := plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/"+name), hash)
+	return repo.Storer.SetReference(ref)
+}
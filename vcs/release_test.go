@@ -0,0 +1,156 @@
+package vcs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func fatal(t *testing.T, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_ParseConventionalCommit(t *testing.T) {
+	cc := ParseConventionalCommit(plumbing.ZeroHash, "feat(api): add builds endpoint\n\nbody")
+	assert.Equal(t, "feat", cc.Type)
+	assert.Equal(t, "api", cc.Scope)
+	assert.Equal(t, "add builds endpoint", cc.Subject)
+	assert.Equal(t, BumpMinor, cc.Bump())
+
+	cc = ParseConventionalCommit(plumbing.ZeroHash, "fix!: drop v1 api")
+	assert.True(t, cc.Breaking)
+	assert.Equal(t, BumpMajor, cc.Bump())
+
+	cc = ParseConventionalCommit(plumbing.ZeroHash, "refactor: x\n\nBREAKING CHANGE: removed y")
+	assert.Equal(t, BumpMajor, cc.Bump())
+
+	cc = ParseConventionalCommit(plumbing.ZeroHash, "Update readme")
+	assert.Equal(t, "", cc.Type)
+	assert.Equal(t, BumpNone, cc.Bump())
+}
+
+func Test_bumpVersion(t *testing.T) {
+	v, _ := bumpVersion("1.2.3", BumpPatch)
+	assert.Equal(t, "1.2.4", v)
+	v, _ = bumpVersion("1.2.3", BumpMinor)
+	assert.Equal(t, "1.3.0", v)
+	v, _ = bumpVersion("1.2.3", BumpMajor)
+	assert.Equal(t, "2.0.0", v)
+}
+
+func Test_Release(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("/tmp", "rel-")
+	defer os.RemoveAll(tmpdir)
+
+	_, repo, _ := SetupLocalGitRepo("test", "me", tmpdir, "foo.com")
+	wt, _ := repo.Worktree()
+
+	commit := func(fname, msg string) plumbing.Hash {
+		ioutil.WriteFile(filepath.Join(tmpdir, fname), []byte(msg), 0644)
+		wt.Add(fname)
+		h, err := wt.Commit(msg, committer())
+		fatal(t, err)
+		return h
+	}
+	tag := func(name string, h plumbing.Hash) {
+		ref := plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/"+name), h)
+		repo.Storer.SetReference(ref)
+	}
+
+	_, err := NextRelease(repo, ReleaseOptions{})
+	assert.NotNil(t, err)
+
+	h := commit("a", "feat: initial")
+	tag("v1.10.0", h)
+	tag("v1.9.0", commit("b", "fix: b"))
+	tag("v1.10.1-rc.1", commit("c", "fix: c"))
+	tag("notsemver", commit("d", "chore: d"))
+
+	// Sorted by semver not iteration or lexical order
+	tags, err := SemverTags(repo)
+	fatal(t, err)
+	assert.Equal(t, 3, len(tags))
+	assert.Equal(t, "v1.10.1-rc.1", tags[2].Name)
+
+	latest, _ := LatestSemverTag(repo, false)
+	assert.Equal(t, "v1.10.0", latest.Name)
+	head, _ := repo.Head()
+	reachable, _ := reachableCommits(repo, head.Hash())
+	// Prereleases are not used as the version base
+	assert.Equal(t, "v1.10.0", getLatestTag(repo, reachable).Name().Short())
+
+	// Commits since v1.10.0 are fix, fix, chore
+	rel, err := NextRelease(repo, ReleaseOptions{})
+	fatal(t, err)
+	assert.Equal(t, "v1.10.1", rel.Tag())
+	assert.Equal(t, 3, len(rel.Commits))
+
+	rel, _ = NextRelease(repo, ReleaseOptions{Prerelease: "rc"})
+	assert.Equal(t, "v1.10.1-rc.2", rel.Tag())
+
+	commit("e", "feat(ui): dashboard")
+	rel, _ = NextRelease(repo, ReleaseOptions{})
+	assert.Equal(t, "v1.11.0", rel.Tag())
+
+	rel, _ = NextRelease(repo, ReleaseOptions{Bump: BumpMajor})
+	assert.Equal(t, "v2.0.0", rel.Tag())
+
+	err = rel.Apply(repo, tmpdir, ApplyOptions{
+		Changelog: DefaultChangelogFile,
+		Signature: object.Signature{Name: "thrap", Email: "thrap"},
+	})
+	fatal(t, err)
+
+	b, _ := ioutil.ReadFile(filepath.Join(tmpdir, DefaultChangelogFile))
+	log := string(b)
+	assert.True(t, strings.HasPrefix(log, "# Changelog\n\n## v2.0.0"))
+	assert.Contains(t, log, "**ui:** dashboard")
+	assert.NotContains(t, log, "chore")
+
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/tags/v2.0.0"), false)
+	fatal(t, err)
+	to, err := repo.TagObject(ref.Hash())
+	fatal(t, err)
+	head, _ = repo.Head()
+	assert.Equal(t, head.Hash(), to.Target)
+
+	ver, _ := getRepoVersion(repo)
+	assert.Equal(t, "v2.0.0", ver.String())
+
+	// Tag exists
+	assert.Equal(t, errTagExists, rel.Apply(repo, tmpdir, ApplyOptions{}))
+
+	// A higher tag on an unmerged branch is ignored
+	hc, _ := repo.CommitObject(head.Hash())
+	branch := &object.Commit{
+		Author:       *committer().Author,
+		Committer:    *committer().Author,
+		Message:      "feat: unmerged",
+		TreeHash:     hc.TreeHash,
+		ParentHashes: []plumbing.Hash{head.Hash()},
+	}
+	obj := repo.Storer.NewEncodedObject()
+	fatal(t, branch.Encode(obj))
+	bh, err := repo.Storer.SetEncodedObject(obj)
+	fatal(t, err)
+	tag("v5.0.0", bh)
+
+	commit("f", "fix: f")
+	rel, err = NextRelease(repo, ReleaseOptions{})
+	fatal(t, err)
+	assert.Equal(t, "v2.0.0", rel.Previous.Name)
+	assert.Equal(t, "v2.0.1", rel.Tag())
+	assert.Equal(t, 1, len(rel.Commits))
+
+	ver, _ = getRepoVersion(repo)
+	assert.Equal(t, "v2.0.0", ver.Tag)
+	assert.Equal(t, 1, ver.Count)
+}
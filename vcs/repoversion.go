@@ -1,13 +1,10 @@
 package vcs
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
-	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 const (
@@ -31,19 +28,29 @@ func (rver RepoVersion) String() string {
 	return fmt.Sprintf("%s-%d-%s", rver.Tag, rver.Count, rver.Hash.String()[:8])
 }
 
-// returns nil if tags cannot be found
-func getLatestTag(repo *git.Repository) (lastTag *plumbing.Reference) {
-	tags, _ := repo.Tags()
-
-	for {
-		t, err := tags.Next()
-		if err != nil {
-			break
+// getLatestTag returns the highest non-prerelease semver tag in the set of
+// reachable commits.  If there is none the last reachable tag by iteration
+// order is returned.  It returns nil if no tag is reachable
+func getLatestTag(repo *git.Repository, reachable map[plumbing.Hash]bool) (lastTag *plumbing.Reference) {
+	if st, _ := latestReachableTag(repo, reachable); st != nil {
+		ref, err := repo.Reference(plumbing.ReferenceName("refs/tags/"+st.Name), false)
+		if err == nil {
+			return ref
 		}
+	}
 
-		lastTag = t
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil
 	}
 
+	tags.ForEach(func(t *plumbing.Reference) error {
+		if reachable[resolveTagCommit(repo, t)] {
+			lastTag = t
+		}
+		return nil
+	})
+
 	return
 }
 
@@ -58,6 +65,8 @@ func GetRepoVersion(path string) RepoVersion {
 	return ver
 }
 
+// getRepoVersion returns the version from the latest tag reachable from HEAD
+// counting the commits not reachable from the tag
 func getRepoVersion(repo *git.Repository) (RepoVersion, error) {
 	rv := RepoVersion{Tag: defaultVersionTag}
 
@@ -67,37 +76,25 @@ func getRepoVersion(repo *git.Repository) (RepoVersion, error) {
 	}
 	rv.Hash = head.Hash()
 
-	iter, err := repo.Log(&git.LogOptions{From: rv.Hash})
+	reachable, err := reachableCommits(repo, rv.Hash)
 	if err != nil {
 		return rv, err
 	}
 
-	var (
-		cmtHash   plumbing.Hash
-		latestTag = getLatestTag(repo)
-	)
-
-	if latestTag != nil {
+	var released map[plumbing.Hash]bool
+	if latestTag := getLatestTag(repo, reachable); latestTag != nil {
 		rv.Tag = latestTag.Name().Short()
-
-		to, _ := repo.TagObject(latestTag.Hash())
-		if to != nil {
-			// Annotated tag
-			cmtHash = to.Target
-		} else {
-			// Tag with no annotation
-			cmtHash = latestTag.Hash()
+		released, err = reachableCommits(repo, resolveTagCommit(repo, latestTag))
+		if err != nil {
+			return rv, err
 		}
-
 	}
 
-	iter.ForEach(func(c *object.Commit) error {
-		if bytes.Compare(cmtHash[:], c.Hash[:]) == 0 {
-			return io.EOF
+	for h := range reachable {
+		if !released[h] {
+			rv.Count++
 		}
-		rv.Count++
-		return nil
-	})
+	}
 
 	return rv, nil
 }
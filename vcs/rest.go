@@ -0,0 +1,138 @@
+package vcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// restError is returned when a provider api call returns a non-2xx status
+type restError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *restError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+// isNotFound returns true if the error is a 404 from the provider api
+func isNotFound(err error) bool {
+	rerr, ok := err.(*restError)
+	return ok && rerr.StatusCode == http.StatusNotFound
+}
+
+// restClient is a minimal json api client shared by the self-hostable
+// providers
+type restClient struct {
+	// Base api url including the version path
+	base string
+	// Header name and value used to authenticate
+	authHeader string
+	authValue  string
+
+	client *http.Client
+}
+
+func newRESTClient(base, authHeader, authValue string) *restClient {
+	return &restClient{
+		base:       strings.TrimSuffix(base, "/"),
+		authHeader: authHeader,
+		authValue:  authValue,
+		client:     http.DefaultClient,
+	}
+}
+
+// do performs the request json encoding in if not nil and decoding the
+// response into out if not nil
+func (rc *restClient) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, rc.base+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if rc.authValue != "" {
+		req.Header.Set(rc.authHeader, rc.authValue)
+	}
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		rerr := &restError{StatusCode: resp.StatusCode}
+		var msg struct {
+			Message interface{} `json:"message"`
+		}
+		if json.Unmarshal(b, &msg) == nil && msg.Message != nil {
+			rerr.Message = fmt.Sprint(msg.Message)
+		}
+		return rerr
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// parseProviderAddr returns the api scheme+host and the bare host from a
+// configured address.  The address may or may not contain a scheme
+func parseProviderAddr(addr string) (string, string, error) {
+	if !strings.Contains(addr, "://") {
+		addr = "https://" + addr
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Host == "" {
+		return "", "", fmt.Errorf("invalid address: '%s'", addr)
+	}
+
+	return u.Scheme + "://" + u.Host, u.Host, nil
+}
+
+// getConfString returns the string value for the key from the config. It
+// returns an error if the value is not a string
+func getConfString(conf map[string]interface{}, key string) (string, error) {
+	iface, ok := conf[key]
+	if !ok {
+		return "", nil
+	}
+
+	str, ok := iface.(string)
+	if !ok {
+		return "", fmt.Errorf("'%s' must be a string", key)
+	}
+	return str, nil
+}
@@ -0,0 +1,107 @@
+package vcs
+
+import (
+	"regexp"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+// SemverTag is a repo tag that parses as a semantic version
+type SemverTag struct {
+	// Tag name as it appears in the repo e.g. v1.2.0
+	Name string
+	// Parsed version
+	Version *version.Version
+	// Commit the tag points to.  Annotated tags are resolved to their target
+	Commit plumbing.Hash
+}
+
+// IsPrerelease returns true if the tag is a prerelease
+func (tag *SemverTag) IsPrerelease() bool {
+	return tag.Version.Prerelease() != ""
+}
+
+// semverTags sorts tags by semantic version
+type semverTags []*SemverTag
+
+func (t semverTags) Len() int           { return len(t) }
+func (t semverTags) Less(i, j int) bool { return t[i].Version.LessThan(t[j].Version) }
+func (t semverTags) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
+// semverTagRegexp matches [v]MAJOR.MINOR.PATCH with an optional prerelease
+// and build metadata
+var semverTagRegexp = regexp.MustCompile(`^[vV]?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// parseSemverTag parses a tag name as a semantic version. It returns nil if
+// the name is not a semantic version
+func parseSemverTag(name string) *version.Version {
+	if !semverTagRegexp.MatchString(name) {
+		return nil
+	}
+
+	ver, err := version.NewVersion(name)
+	if err != nil {
+		return nil
+	}
+	return ver
+}
+
+// resolveTagCommit returns the commit the tag reference points to
+func resolveTagCommit(repo *git.Repository, ref *plumbing.Reference) plumbing.Hash {
+	to, err := repo.TagObject(ref.Hash())
+	if err == nil && to != nil {
+		// Annotated tag
+		return to.Target
+	}
+	// Tag with no annotation
+	return ref.Hash()
+}
+
+// SemverTags returns all tags in the repo that are semantic versions sorted
+// in ascending order. Tags that are not semantic versions are skipped
+func SemverTags(repo *git.Repository) ([]*SemverTag, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(semverTags, 0)
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		ver := parseSemverTag(name)
+		if ver == nil {
+			return nil
+		}
+
+		out = append(out, &SemverTag{
+			Name:    name,
+			Version: ver,
+			Commit:  resolveTagCommit(repo, ref),
+		})
+		return nil
+	})
+
+	sort.Stable(out)
+	return out, err
+}
+
+// LatestSemverTag returns the highest semver tag.  If includePre is false,
+// prereleases are skipped.  It returns nil if no tag is found
+func LatestSemverTag(repo *git.Repository, includePre bool) (*SemverTag, error) {
+	tags, err := SemverTags(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		if includePre || !tags[i].IsPrerelease() {
+			return tags[i], nil
+		}
+	}
+
+	return nil, nil
+}
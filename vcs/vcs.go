@@ -34,6 +34,28 @@ type Option struct {
 	Remote string
 }
 
+// Webhook is a repository webhook registered with a remote provider
+type Webhook struct {
+	// Provider assigned id. Populated once the hook has been added
+	ID string
+	// URL to deliver events to
+	URL string
+	// Secret used by the provider to sign or authenticate deliveries
+	Secret string
+	// Events to subscribe to e.g. push, tag, pull_request.  Defaults to push
+	// if empty
+	Events []string
+}
+
+// WebhookManager is implemented by remote providers that support
+// repository webhooks
+type WebhookManager interface {
+	// Add a webhook to the repository returning the hook with its id set
+	AddHook(*Repository, *Webhook) (*Webhook, error)
+	// Remove a webhook by its id from the repository
+	RemoveHook(*Repository, *Webhook) error
+}
+
 // VCS implements a version control system interface such as git, svn etc.
 type VCS interface {
 	// Initialize the VCS interface
@@ -70,6 +92,12 @@ func New(conf *Config) (VCS, error) {
 	case "github":
 		v = newGithubVCS(nil)
 
+	case "gitlab":
+		v = newGitlabVCS(nil)
+
+	case "gitea":
+		v = newGiteaVCS(nil)
+
 	default:
 		err = fmt.Errorf("unsupported vcs: '%s'", conf.Provider)
 
@@ -0,0 +1,153 @@
+package vcs
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedEvent is returned when a webhook delivery is not a pull
+	// or merge request event
+	ErrUnsupportedEvent = errors.New("unsupported webhook event")
+	// ErrInvalidSignature is returned when a webhook delivery fails signature
+	// or token verification
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// PullRequestEvent is a provider neutral pull or merge request webhook event
+type PullRequestEvent struct {
+	// Provider the event originated from
+	Provider string
+	// Repository full name i.e. owner/name
+	Repo string
+	// Source branch of the request
+	Branch string
+	// Whether the request was closed, either by merging or being declined
+	Closed bool
+	// Whether the request was merged
+	Merged bool
+}
+
+// ParsePullRequestEvent parses a github, gitlab or gitea pull request webhook
+// delivery.  If secret is not empty the delivery is verified against it using
+// the mechanism of the originating provider
+func ParsePullRequestEvent(r *http.Request, secret string) (*PullRequestEvent, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitea also sends github headers so it must be checked first
+	switch {
+	case r.Header.Get("X-Gitea-Event") != "":
+		if r.Header.Get("X-Gitea-Event") != "pull_request" {
+			return nil, ErrUnsupportedEvent
+		}
+		if secret != "" && !validHMAC(sha256.New, secret, body, r.Header.Get("X-Gitea-Signature")) {
+			return nil, ErrInvalidSignature
+		}
+		return parseGithubPullRequest("gitea", body)
+
+	case r.Header.Get("X-GitHub-Event") != "":
+		if r.Header.Get("X-GitHub-Event") != "pull_request" {
+			return nil, ErrUnsupportedEvent
+		}
+		if secret != "" {
+			sig := strings.TrimPrefix(r.Header.Get("X-Hub-Signature"), "sha1=")
+			if !validHMAC(sha1.New, secret, body, sig) {
+				return nil, ErrInvalidSignature
+			}
+		}
+		return parseGithubPullRequest("github", body)
+
+	case r.Header.Get("X-Gitlab-Event") != "":
+		if r.Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+			return nil, ErrUnsupportedEvent
+		}
+		if secret != "" {
+			token := r.Header.Get("X-Gitlab-Token")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+				return nil, ErrInvalidSignature
+			}
+		}
+		return parseGitlabMergeRequest(body)
+
+	}
+
+	return nil, ErrUnsupportedEvent
+}
+
+func validHMAC(h func() hash.Hash, secret string, body []byte, sig string) bool {
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// github and gitea share the same payload structure
+type githubPullRequestPayload struct {
+	Action      string
+	PullRequest struct {
+		Merged bool
+		Head   struct {
+			Ref string
+		}
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	}
+}
+
+func parseGithubPullRequest(provider string, body []byte) (*PullRequestEvent, error) {
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	return &PullRequestEvent{
+		Provider: provider,
+		Repo:     payload.Repository.FullName,
+		Branch:   payload.PullRequest.Head.Ref,
+		Closed:   payload.Action == "closed",
+		Merged:   payload.PullRequest.Merged,
+	}, nil
+}
+
+type gitlabMergeRequestPayload struct {
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	}
+	ObjectAttributes struct {
+		SourceBranch string `json:"source_branch"`
+		State        string
+	} `json:"object_attributes"`
+}
+
+func parseGitlabMergeRequest(body []byte) (*PullRequestEvent, error) {
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	state := payload.ObjectAttributes.State
+	return &PullRequestEvent{
+		Provider: "gitlab",
+		Repo:     payload.Project.PathWithNamespace,
+		Branch:   payload.ObjectAttributes.SourceBranch,
+		Closed:   state == "merged" || state == "closed",
+		Merged:   state == "merged",
+	}, nil
+}
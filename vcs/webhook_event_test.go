@@ -0,0 +1,87 @@
+package vcs
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(secret string, body []byte, sha256sum bool) string {
+	h := sha1.New
+	if sha256sum {
+		h = sha256.New
+	}
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_ParsePullRequestEvent_github(t *testing.T) {
+	body := []byte(`{"action":"closed","pull_request":{"merged":true,"head":{"ref":"feature/x"}},"repository":{"full_name":"euforia/thrap"}}`)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-Hub-Signature", "sha1="+sign("secret", body, false))
+
+	ev, err := ParsePullRequestEvent(req, "secret")
+	fatal(t, err)
+	assert.Equal(t, "github", ev.Provider)
+	assert.Equal(t, "euforia/thrap", ev.Repo)
+	assert.Equal(t, "feature/x", ev.Branch)
+	assert.True(t, ev.Closed)
+	assert.True(t, ev.Merged)
+
+	req = httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-Hub-Signature", "sha1="+sign("other", body, false))
+	_, err = ParsePullRequestEvent(req, "secret")
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	req = httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "push")
+	_, err = ParsePullRequestEvent(req, "")
+	assert.Equal(t, ErrUnsupportedEvent, err)
+}
+
+func Test_ParsePullRequestEvent_gitea(t *testing.T) {
+	body := []byte(`{"action":"opened","pull_request":{"merged":false,"head":{"ref":"fix"}},"repository":{"full_name":"org/app"}}`)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-Gitea-Event", "pull_request")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-Gitea-Signature", sign("secret", body, true))
+
+	ev, err := ParsePullRequestEvent(req, "secret")
+	fatal(t, err)
+	assert.Equal(t, "gitea", ev.Provider)
+	assert.Equal(t, "fix", ev.Branch)
+	assert.False(t, ev.Closed)
+	assert.False(t, ev.Merged)
+}
+
+func Test_ParsePullRequestEvent_gitlab(t *testing.T) {
+	body := []byte(`{"object_kind":"merge_request","project":{"path_with_namespace":"group/app"},"object_attributes":{"source_branch":"feat","state":"merged"}}`)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "secret")
+
+	ev, err := ParsePullRequestEvent(req, "secret")
+	fatal(t, err)
+	assert.Equal(t, "gitlab", ev.Provider)
+	assert.Equal(t, "group/app", ev.Repo)
+	assert.Equal(t, "feat", ev.Branch)
+	assert.True(t, ev.Closed)
+	assert.True(t, ev.Merged)
+
+	req = httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	_, err = ParsePullRequestEvent(req, "secret")
+	assert.Equal(t, ErrInvalidSignature, err)
+}
@@ -0,0 +1,127 @@
+package thrap
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/euforia/thrap/core"
+	"github.com/euforia/thrap/thrapb"
+	"github.com/euforia/thrap/vcs"
+)
+
+// PreviewWebhookPath is the path prefix the preview webhook is served on.
+// The stack id follows the prefix e.g. /v1/preview/<stack>.  The profile
+// the preview was deployed with is selected with the profile query parameter
+const PreviewWebhookPath = "/v1/preview/"
+
+// maxWebhookBodySize is the largest webhook delivery read
+const maxWebhookBodySize = 5 << 20
+
+// ProfileGetter returns a profile by id or nil if it does not exist
+type ProfileGetter interface {
+	Get(id string) *thrapb.Profile
+}
+
+// PreviewWebhook is an http handler receiving pull request webhook deliveries
+// from github, gitlab or gitea.  It tears down the preview environment of the
+// source branch once the request of the stack repository is merged or closed
+type PreviewWebhook struct {
+	core   *core.Core
+	profs  ProfileGetter
+	secret string
+	log    *log.Logger
+}
+
+// NewPreviewWebhook returns a new preview webhook handler.  Deliveries are
+// verified against the secret.  All deliveries are rejected if it is empty
+func NewPreviewWebhook(core *core.Core, profs ProfileGetter, secret string, logger *log.Logger) *PreviewWebhook {
+	return &PreviewWebhook{
+		core:   core,
+		profs:  profs,
+		secret: secret,
+		log:    logger,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (wh *PreviewWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if wh.secret == "" {
+		http.Error(w, "webhook secret not configured", http.StatusForbidden)
+		return
+	}
+
+	stackID := strings.Trim(strings.TrimPrefix(r.URL.Path, PreviewWebhookPath), "/")
+	if stackID == "" {
+		http.Error(w, "stack id required", http.StatusNotFound)
+		return
+	}
+
+	profName := r.URL.Query().Get("profile")
+	if profName == "" {
+		profName = thrapb.DefaultProfile().ID
+	}
+	prof := wh.profs.Get(profName)
+	if prof == nil {
+		http.Error(w, "profile not found: "+profName, http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookBodySize)
+	ev, err := vcs.ParsePullRequestEvent(r, wh.secret)
+	switch err {
+	case nil:
+	case vcs.ErrUnsupportedEvent:
+		// Acknowledge pings and other events so providers do not retry
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case vcs.ErrInvalidSignature:
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !ev.Closed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	stm, err := wh.core.Stack(prof)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	stack, err := stm.Get(stackID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !stm.RepoMatches(stack, ev.Repo) {
+		http.Error(w, "repository does not match the stack: "+ev.Repo, http.StatusForbidden)
+		return
+	}
+
+	id := core.PreviewID(stack.ID, ev.Branch)
+	wh.log.Printf("preview.%s.down: provider=%s repo=%s merged=%v", id, ev.Provider, ev.Repo, ev.Merged)
+
+	results, err := stm.PreviewDown(context.Background(), stack, ev.Branch)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			wh.log.Printf("preview.%s.down: %s %v", id, r.Resource, r.Error)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}